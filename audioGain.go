@@ -0,0 +1,81 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Largest values of the audio gain fields of SetStateData accepted by the
+// controller. Larger volumes are not used by the PS5 and SpeakerCompPreGain
+// has three bits in the output report.
+const (
+	MAX_VOLUME_HEADPHONES     = 0x7F
+	MAX_VOLUME_SPEAKER        = 0x64
+	MAX_VOLUME_MIC            = 0x40
+	MAX_SPEAKER_COMP_PRE_GAIN = 0x07
+)
+
+// ErrOutOfRange is wrapped by the errors returned for output values outside
+// their valid range.
+var ErrOutOfRange = errors.New("value out of range")
+
+// AudioGain is the valid range of one audio gain field of SetStateData.
+type AudioGain struct {
+	Field string
+	Max   uint8
+}
+
+var (
+	HeadphoneVolume    = AudioGain{Field: "VolumeHeadphones", Max: MAX_VOLUME_HEADPHONES}
+	SpeakerVolume      = AudioGain{Field: "VolumeSpeaker", Max: MAX_VOLUME_SPEAKER}
+	MicVolume          = AudioGain{Field: "VolumeMic", Max: MAX_VOLUME_MIC}
+	SpeakerCompPreGain = AudioGain{Field: "SpeakerCompPreGain", Max: MAX_SPEAKER_COMP_PRE_GAIN}
+)
+
+// FromPercent scales percent, clamped to [0, 100], onto the range of g.
+func (g AudioGain) FromPercent(percent float64) uint8 {
+	return uint8(math.Round(min(max(percent, 0), 100) / 100 * float64(g.Max)))
+}
+
+// Percent is the inverse of FromPercent.
+func (g AudioGain) Percent(value uint8) float64 {
+	return float64(min(value, g.Max)) / float64(g.Max) * 100
+}
+
+func (g AudioGain) Clamp(value uint8) uint8 {
+	return min(value, g.Max)
+}
+
+// Validate returns an error wrapping ErrOutOfRange if value exceeds g.Max.
+func (g AudioGain) Validate(value uint8) error {
+	if value > g.Max {
+		return fmt.Errorf("%w: %s %d exceeds %d", ErrOutOfRange, g.Field, value, g.Max)
+	}
+	return nil
+}
+
+// checkAudioGains validates the audio gains of setStateData, or clamps them
+// when the DualSense was created WithAudioGainClamping.
+func (d *DualSense) checkAudioGains(setStateData *SetStateData) error {
+	gains := []struct {
+		gain  AudioGain
+		value *uint8
+	}{
+		{HeadphoneVolume, &setStateData.VolumeHeadphones},
+		{SpeakerVolume, &setStateData.VolumeSpeaker},
+		{MicVolume, &setStateData.VolumeMic},
+		{SpeakerCompPreGain, &setStateData.SpeakerCompPreGain},
+	}
+	for _, gain := range gains {
+		if d.clampAudioGains {
+			*gain.value = gain.gain.Clamp(*gain.value)
+			continue
+		}
+		err := gain.gain.Validate(*gain.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
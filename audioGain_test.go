@@ -0,0 +1,55 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAudioGainValidation(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := d.SetVolumeHeadphones(MAX_VOLUME_HEADPHONES); err != nil {
+		t.Fatal(err)
+	}
+	err := d.SetVolumeSpeaker(MAX_VOLUME_SPEAKER + 1)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if err := d.SetSpeakerCompPreGain(8); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if got := d.GetOutStateData(); got.VolumeSpeaker != 0 || got.SpeakerCompPreGain != 0 {
+		t.Fatalf("out of range values were applied: %+v", got)
+	}
+}
+
+func TestAudioGainClamping(t *testing.T) {
+	d := NewMockDualSense(WithAudioGainClamping())
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := d.SetVolumeMic(0xFF); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.GetOutStateData().VolumeMic; got != MAX_VOLUME_MIC {
+		t.Fatalf("got VolumeMic %d, want %d", got, MAX_VOLUME_MIC)
+	}
+}
+
+func TestAudioGainPercent(t *testing.T) {
+	if got := SpeakerVolume.FromPercent(50); got != 50 {
+		t.Errorf("got %d, want 50", got)
+	}
+	if got := HeadphoneVolume.FromPercent(150); got != MAX_VOLUME_HEADPHONES {
+		t.Errorf("got %d, want %d", got, MAX_VOLUME_HEADPHONES)
+	}
+	if got := MicVolume.Percent(MAX_VOLUME_MIC); got != 100 {
+		t.Errorf("got %v, want 100", got)
+	}
+}
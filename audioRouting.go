@@ -0,0 +1,79 @@
+package dualsense
+
+import "sync"
+
+// OutputPathSelect values used by audio routing.
+const (
+	OUTPUT_PATH_HEADPHONES = 0 // L_R_X
+	OUTPUT_PATH_SPEAKER    = 3 // X_X_R
+)
+
+// AudioRouting holds the initial volumes used by WithAudioAutoRouting.
+type AudioRouting struct {
+	HeadphoneVolume uint8
+	SpeakerVolume   uint8
+}
+
+type audioRouter struct {
+	mu          sync.Mutex
+	enabled     bool
+	initialized bool
+	headphones  bool
+	volumes     AudioRouting
+}
+
+// WithAudioAutoRouting makes audio follow the headphone jack: whenever
+// PluggedHeadphones changes, OutputPathSelect switches between headphones and
+// speaker, the unused output is muted and the volume last used on the new
+// output is restored, starting from routing.
+func WithAudioAutoRouting(routing AudioRouting) Option {
+	return func(d *DualSense) {
+		d.audioRouter.enabled = true
+		d.audioRouter.volumes = routing
+	}
+}
+
+// route applies the routing for headphones to setStateData, remembering the
+// volume of the output being switched away from.
+func (a *audioRouter) route(setStateData *SetStateData, headphones bool) {
+	if a.initialized {
+		if a.headphones {
+			a.volumes.HeadphoneVolume = setStateData.VolumeHeadphones
+		} else {
+			a.volumes.SpeakerVolume = setStateData.VolumeSpeaker
+		}
+	}
+	a.initialized = true
+	a.headphones = headphones
+	setStateData.AllowAudioControl = true
+	setStateData.AllowAudioMute = true
+	setStateData.HeadphoneMute = !headphones
+	setStateData.SpeakerMute = headphones
+	if headphones {
+		setStateData.AllowHeadphoneVolume = true
+		setStateData.OutputPathSelect = OUTPUT_PATH_HEADPHONES
+		setStateData.VolumeHeadphones = a.volumes.HeadphoneVolume
+	} else {
+		setStateData.AllowSpeakerVolume = true
+		setStateData.OutputPathSelect = OUTPUT_PATH_SPEAKER
+		setStateData.VolumeSpeaker = a.volumes.SpeakerVolume
+	}
+}
+
+// processAudioRouting reroutes audio when the headphone jack changes.
+func (d *DualSense) processAudioRouting(state *USBGetStateData) {
+	d.audioRouter.mu.Lock()
+	rerouted := d.audioRouter.enabled && (!d.audioRouter.initialized || d.audioRouter.headphones != state.PluggedHeadphones)
+	d.audioRouter.mu.Unlock()
+	if !rerouted {
+		return
+	}
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		d.audioRouter.mu.Lock()
+		defer d.audioRouter.mu.Unlock()
+		d.audioRouter.route(setStateData, state.PluggedHeadphones)
+	})
+	if err != nil {
+		d.logger.Debug("could not reroute DualSense audio", "error", err)
+	}
+}
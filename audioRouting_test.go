@@ -0,0 +1,27 @@
+package dualsense
+
+import "testing"
+
+func TestAudioAutoRouting(t *testing.T) {
+	mock := NewMockDualSense(WithAudioAutoRouting(AudioRouting{HeadphoneVolume: 60, SpeakerVolume: 80}))
+
+	mock.UpdateInState(func(state *USBGetStateData) {})
+	out := mock.GetOutStateData()
+	if out.OutputPathSelect != OUTPUT_PATH_SPEAKER || out.VolumeSpeaker != 80 || out.SpeakerMute || !out.HeadphoneMute {
+		t.Fatalf("got %+v without headphones", out)
+	}
+
+	mock.SetVolumeSpeaker(50)
+	mock.UpdateInState(func(state *USBGetStateData) { state.PluggedHeadphones = true })
+	out = mock.GetOutStateData()
+	if out.OutputPathSelect != OUTPUT_PATH_HEADPHONES || out.VolumeHeadphones != 60 || !out.SpeakerMute || out.HeadphoneMute {
+		t.Fatalf("got %+v with headphones", out)
+	}
+
+	mock.UpdateInState(func(state *USBGetStateData) { state.PluggedHeadphones = false })
+	out = mock.GetOutStateData()
+	// The speaker volume set before plugging in is restored.
+	if out.OutputPathSelect != OUTPUT_PATH_SPEAKER || out.VolumeSpeaker != 50 {
+		t.Fatalf("got %+v after unplugging", out)
+	}
+}
@@ -0,0 +1,56 @@
+package dualsense
+
+import "sync"
+
+// AxisInversion selects the axes to flip, e.g. for flight-sim style inverted
+// Y. An inverted stick axis reports 255 - value, so an inverted Y grows
+// upwards; an inverted trigger rests at 255 and reads 0 when fully pressed.
+// The digital L2 and R2 buttons are not affected.
+type AxisInversion struct {
+	LeftStickX   bool
+	LeftStickY   bool
+	RightStickX  bool
+	RightStickY  bool
+	TriggerLeft  bool
+	TriggerRight bool
+}
+
+type axisInverter struct {
+	mu        sync.Mutex
+	inversion AxisInversion
+}
+
+// SetAxisInversion flips the selected axes as input reports arrive, so state,
+// FieldChange callbacks, events and GamepadState all see the inverted values.
+func (d *DualSense) SetAxisInversion(inversion AxisInversion) {
+	d.axisInverter.mu.Lock()
+	defer d.axisInverter.mu.Unlock()
+	d.axisInverter.inversion = inversion
+}
+
+// AxisInversion returns the inversion set with SetAxisInversion.
+func (d *DualSense) AxisInversion() AxisInversion {
+	d.axisInverter.mu.Lock()
+	defer d.axisInverter.mu.Unlock()
+	return d.axisInverter.inversion
+}
+
+func (d *DualSense) processAxisInversion(state *USBGetStateData) {
+	inversion := d.AxisInversion()
+	axes := []struct {
+		inverted bool
+		value    *uint8
+	}{
+		{inversion.LeftStickX, &state.LeftStickX},
+		{inversion.LeftStickY, &state.LeftStickY},
+		{inversion.RightStickX, &state.RightStickX},
+		{inversion.RightStickY, &state.RightStickY},
+		{inversion.TriggerLeft, &state.TriggerLeft},
+		{inversion.TriggerRight, &state.TriggerRight},
+	}
+	for _, axis := range axes {
+		if axis.inverted {
+			*axis.value = 255 - *axis.value
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package dualsense
+
+import "testing"
+
+func TestAxisInversion(t *testing.T) {
+	d := NewMockDualSense()
+	d.SetAxisInversion(AxisInversion{LeftStickY: true, TriggerRight: true})
+	var got []uint8
+	d.OnFieldChange(FieldLeftStickY, func(change FieldChange) {
+		got = append(got, change.New.(uint8))
+	})
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.LeftStickX = 10
+		state.LeftStickY = 0
+		state.TriggerRight = 255
+	})
+
+	state := d.GetInStateData()
+	if state.LeftStickX != 10 || state.LeftStickY != 255 || state.TriggerRight != 0 {
+		t.Errorf("got stick (%d, %d) and right trigger %d, want (10, 255) and 0", state.LeftStickX, state.LeftStickY, state.TriggerRight)
+	}
+	if len(got) != 1 || got[0] != 255 {
+		t.Errorf("FieldLeftStickY changes: got %v, want [255]", got)
+	}
+	if y := d.GamepadState().Axes[StandardAxisLeftStickY]; y != 1 {
+		t.Errorf("standard left stick Y: got %v, want 1", y)
+	}
+}
@@ -0,0 +1,148 @@
+package dualsense
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// NOMINAL_BATTERY_LIFE is the runtime of a full charge assumed until a
+	// discharge rate has been measured.
+	NOMINAL_BATTERY_LIFE     = 6 * time.Hour
+	BATTERY_RATE_STEPS       = 4 // Measured steps needed for full confidence
+	batteryNominalConfidence = 0.1
+)
+
+// BatteryEstimate is the battery level and remaining runtime extrapolated
+// from the discharge rate observed so far. PowerPercent only changes in 10%
+// steps, so the rate is measured between step changes and Confidence grows
+// with the number of steps observed, up to 1 after BATTERY_RATE_STEPS.
+// Before the first two steps the estimate assumes NOMINAL_BATTERY_LIFE.
+type BatteryEstimate struct {
+	Level       float64 // 0-1
+	Remaining   time.Duration
+	Confidence  float64 // 0-1
+	Discharging bool
+}
+
+type batteryTracker struct {
+	mu             sync.Mutex
+	initialized    bool
+	powerPercent   uint8
+	powerState     PowerState
+	firstStep      time.Time
+	firstStepLevel uint8
+	lastStep       time.Time
+	lastStepLevel  uint8
+	steps          int
+}
+
+// batteryLevel converts PowerPercent to the middle of its 10% step.
+func batteryLevel(powerPercent uint8) float64 {
+	return min(float64(powerPercent)*0.1+0.05, 1)
+}
+
+// batteryPercent converts PowerPercent to a percentage as batteryLevel does,
+// 100 once charging completed.
+func batteryPercent(powerPercent uint8, powerState PowerState) uint8 {
+	if powerState == PowerStateComplete {
+		return 100
+	}
+	return uint8(math.Round(batteryLevel(powerPercent) * 100))
+}
+
+// dischargingBelow reports whether state is discharging with the battery
+// below percent. PowerPercent counts as the bottom of its 10% step, so the
+// battery is below percent as soon as the step reported reaches below it.
+func dischargingBelow(state *USBGetStateData, percent uint8) bool {
+	return state.PowerState == PowerStateDischarging && uint16(state.PowerPercent)*10 < uint16(percent)
+}
+
+// record tracks a report's power fields and reports whether charging just
+// completed.
+func (b *batteryTracker) record(powerPercent uint8, powerState PowerState, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.initialized {
+		b.initialized = true
+		b.powerPercent = powerPercent
+		b.powerState = powerState
+		return powerState == PowerStateComplete
+	}
+	completed := powerState == PowerStateComplete && b.powerState != PowerStateComplete
+	if powerState != PowerStateDischarging || b.powerState != PowerStateDischarging {
+		// Any charging invalidates the measured rate.
+		b.steps = 0
+		b.firstStep = time.Time{}
+		b.lastStep = time.Time{}
+	} else if powerPercent < b.powerPercent && (b.firstStep.IsZero() || powerPercent < b.lastStepLevel) {
+		// Only new minimum levels count, so a reading jittering across a
+		// step boundary is not measured as repeated steps.
+		if b.firstStep.IsZero() {
+			b.firstStep, b.firstStepLevel = now, powerPercent
+		} else {
+			b.steps++
+		}
+		b.lastStep, b.lastStepLevel = now, powerPercent
+	}
+	b.powerPercent = powerPercent
+	b.powerState = powerState
+	return completed
+}
+
+func (b *batteryTracker) estimate(now time.Time) BatteryEstimate {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.initialized {
+		return BatteryEstimate{}
+	}
+	level := batteryLevel(b.powerPercent)
+	if b.powerState == PowerStateComplete {
+		level = 1
+	}
+	estimate := BatteryEstimate{Level: level, Discharging: b.powerState == PowerStateDischarging}
+	if !estimate.Discharging {
+		return estimate
+	}
+	// Level drain per second.
+	rate := 1 / NOMINAL_BATTERY_LIFE.Seconds()
+	estimate.Confidence = batteryNominalConfidence
+	if elapsed := b.lastStep.Sub(b.firstStep); b.steps > 0 && elapsed > 0 {
+		measured := float64(b.firstStepLevel-b.lastStepLevel) * 0.1 / elapsed.Seconds()
+		// Falls back to the nominal rate rather than dividing by a rate of 0.
+		if measured > 0 {
+			rate = measured
+			estimate.Confidence = min(float64(b.steps)/BATTERY_RATE_STEPS, 1)
+		}
+	}
+	// Right after a step the level is at the top of the new step.
+	if !b.lastStep.IsZero() {
+		estimate.Level = max(float64(b.lastStepLevel)*0.1+0.1-now.Sub(b.lastStep).Seconds()*rate, float64(b.lastStepLevel)*0.1)
+	}
+	estimate.Remaining = time.Duration(estimate.Level / rate * float64(time.Second))
+	return estimate
+}
+
+func (b *batteryTracker) chargeComplete() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.powerState == PowerStateComplete
+}
+
+// EstimatedTimeRemaining extrapolates the remaining battery runtime, see
+// BatteryEstimate. Remaining is 0 unless the controller is discharging.
+func (d *DualSense) EstimatedTimeRemaining() BatteryEstimate {
+	return d.battery.estimate(time.Now())
+}
+
+// ChargeComplete reports whether the controller reports a full charge.
+func (d *DualSense) ChargeComplete() bool {
+	return d.battery.chargeComplete()
+}
+
+// OnChargeComplete is called when the controller reports its battery fully
+// charged.
+func (d *DualSense) OnChargeComplete(callback func()) {
+	d.callbacks.OnChargeComplete = append(d.callbacks.OnChargeComplete, callback)
+}
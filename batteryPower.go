@@ -0,0 +1,65 @@
+package dualsense
+
+import (
+	"slices"
+	"sync"
+)
+
+// BatteryPowerThreshold applies Reduction once the battery drops below
+// BelowPercent.
+type BatteryPowerThreshold struct {
+	BelowPercent uint8
+	Reduction    PowerReduction
+}
+
+// BatteryPowerReductionPolicy raises TriggerMotorPowerReduction and
+// RumbleMotorPowerReduction while discharging, to extend play time on a
+// draining battery. The highest Reduction among the thresholds the battery
+// has dropped below applies; levels set by the application are restored once
+// the controller charges or the policy is cleared. ThermalPolicy throttling
+// combines with it, the higher reduction winning.
+type BatteryPowerReductionPolicy struct {
+	Thresholds []BatteryPowerThreshold
+}
+
+type batteryPowerReducer struct {
+	mu        sync.Mutex
+	policy    BatteryPowerReductionPolicy
+	reduction PowerReduction
+}
+
+func (p BatteryPowerReductionPolicy) reductionFor(state *USBGetStateData) PowerReduction {
+	var reduction PowerReduction
+	for _, threshold := range p.Thresholds {
+		if dischargingBelow(state, threshold.BelowPercent) {
+			reduction = max(reduction, min(threshold.Reduction, PowerReductionMax))
+		}
+	}
+	return reduction
+}
+
+// SetBatteryPowerReductionPolicy configures battery linked motor power
+// reduction, see BatteryPowerReductionPolicy. It takes effect with the next
+// input report.
+func (d *DualSense) SetBatteryPowerReductionPolicy(policy BatteryPowerReductionPolicy) {
+	d.batteryPower.mu.Lock()
+	defer d.batteryPower.mu.Unlock()
+	policy.Thresholds = slices.Clone(policy.Thresholds)
+	d.batteryPower.policy = policy
+}
+
+// processBatteryPowerReduction applies the battery power reduction policy for
+// the latest report.
+func (d *DualSense) processBatteryPowerReduction(state *USBGetStateData) {
+	d.batteryPower.mu.Lock()
+	previous := d.batteryPower.reduction
+	reduction := d.batteryPower.policy.reductionFor(state)
+	d.batteryPower.reduction = reduction
+	d.batteryPower.mu.Unlock()
+	if reduction == previous {
+		return
+	}
+	d.limitMotorPower(func(l *motorPowerLimiter) {
+		l.battery = reduction
+	})
+}
@@ -0,0 +1,36 @@
+package dualsense
+
+import "testing"
+
+func TestBatteryPowerReduction(t *testing.T) {
+	mock := NewMockDualSense()
+	mock.SetTriggerMotorPowerReduction(1)
+	mock.SetBatteryPowerReductionPolicy(BatteryPowerReductionPolicy{Thresholds: []BatteryPowerThreshold{
+		{BelowPercent: 50, Reduction: 2},
+		{BelowPercent: 20, Reduction: 5},
+	}})
+	mock.SetThermalPolicy(ThermalPolicy{Threshold: 40, Critical: 46})
+
+	setBattery := func(percent uint8, powerState PowerState, temperature int8) SetStateData {
+		mock.UpdateInState(func(state *USBGetStateData) {
+			state.PowerPercent = percent / 10
+			state.PowerState = powerState
+			state.Temperature = temperature
+		})
+		return mock.GetOutStateData()
+	}
+	check := func(got SetStateData, trigger, rumble PowerReduction) {
+		t.Helper()
+		if got.TriggerMotorPowerReduction != trigger || got.RumbleMotorPowerReduction != rumble {
+			t.Fatalf("got trigger %d and rumble %d, want %d and %d", got.TriggerMotorPowerReduction, got.RumbleMotorPowerReduction, trigger, rumble)
+		}
+	}
+
+	check(setBattery(60, PowerStateDischarging, 30), 1, 0)
+	check(setBattery(40, PowerStateDischarging, 30), 2, 2)
+	check(setBattery(10, PowerStateDischarging, 30), 5, 5)
+	// Thermal throttling raises the rumble motors above the battery level.
+	check(setBattery(10, PowerStateDischarging, 46), 5, MAX_MOTOR_POWER_REDUCTION)
+	check(setBattery(10, PowerStateDischarging, 30), 5, 5)
+	check(setBattery(10, PowerStateCharging, 30), 1, 0)
+}
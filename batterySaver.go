@@ -0,0 +1,161 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// BatterySaverPolicy is a battery saver mode that, while discharging below
+// BelowPercent, dims the lightbar, reduces rumble power and blinks the player
+// indicators as a low battery warning. Everything it changes is put back once
+// the controller charges, the battery reports above BelowPercent again or the
+// policy is cleared. The zero value disables it.
+type BatterySaverPolicy struct {
+	// BelowPercent engages the battery saver while discharging below this
+	// percentage; 0 disables it.
+	BelowPercent uint8
+	// LightbarBrightness caps the lightbar brightness in percent, combining
+	// with SetLightbarBrightness the dimmer winning.
+	LightbarBrightness float64
+	// RumbleReduction raises RumbleMotorPowerReduction, combining with the
+	// thermal and battery power reduction policies the higher level winning.
+	RumbleReduction PowerReduction
+	// BlinkInterval switches the player indicators on and off every interval,
+	// showing the center light if none are set; 0 leaves them alone. The
+	// blink follows the input reports, so it is no finer than the polling
+	// rate.
+	BlinkInterval time.Duration
+}
+
+// DefaultBatterySaverPolicy engages below 20%, dimming the lightbar to a
+// quarter, halving rumble power and blinking the player indicators once a
+// second.
+var DefaultBatterySaverPolicy = BatterySaverPolicy{
+	BelowPercent:       20,
+	LightbarBrightness: 25,
+	RumbleReduction:    4,
+	BlinkInterval:      time.Second,
+}
+
+func (p BatterySaverPolicy) validate() error {
+	if math.IsNaN(p.LightbarBrightness) || p.LightbarBrightness < 0 || p.LightbarBrightness > 100 {
+		return fmt.Errorf("%w: battery saver lightbar brightness %v%% outside [0, 100]", ErrOutOfRange, p.LightbarBrightness)
+	}
+	err := p.RumbleReduction.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid battery saver RumbleReduction: %w", err)
+	}
+	if p.BlinkInterval < 0 {
+		return fmt.Errorf("%w: negative battery saver blink interval %v", ErrOutOfRange, p.BlinkInterval)
+	}
+	return nil
+}
+
+type batterySaver struct {
+	mu        sync.Mutex
+	policy    BatterySaverPolicy
+	engaged   bool
+	since     time.Time
+	reduction PowerReduction
+	// layer carries the player indicator blink over the indicators as set.
+	layer *OutputLayer
+}
+
+// batterySaverLights is the lightbar dimming of the battery saver applied to
+// outgoing output reports, guarded by setStateDataMu.
+type batterySaverLights struct {
+	engaged    bool
+	brightness float64
+}
+
+// SetBatterySaverPolicy configures the battery saver mode, see
+// BatterySaverPolicy. It takes effect with the next input report.
+func (d *DualSense) SetBatterySaverPolicy(policy BatterySaverPolicy) error {
+	err := policy.validate()
+	if err != nil {
+		return err
+	}
+	d.saver.mu.Lock()
+	defer d.saver.mu.Unlock()
+	d.saver.policy = policy
+	return nil
+}
+
+// BatterySaver reports whether the battery saver mode is engaged.
+func (d *DualSense) BatterySaver() bool {
+	d.saver.mu.Lock()
+	defer d.saver.mu.Unlock()
+	return d.saver.engaged
+}
+
+// OnBatterySaverChange registers a callback for when the battery saver mode
+// engages or releases.
+func (d *DualSense) OnBatterySaverChange(callback func(engaged bool)) {
+	d.callbacks.OnBatterySaverChange = append(d.callbacks.OnBatterySaverChange, callback)
+}
+
+// processBatterySaver applies the battery saver policy for the latest report.
+func (d *DualSense) processBatterySaver(state *USBGetStateData, now time.Time) {
+	d.saver.mu.Lock()
+	policy := d.saver.policy
+	engaged := policy.BelowPercent > 0 && dischargingBelow(state, policy.BelowPercent)
+	changed := engaged != d.saver.engaged
+	if changed {
+		d.saver.engaged = engaged
+		d.saver.since = now
+	}
+	var lights batterySaverLights
+	var reduction PowerReduction
+	blink := engaged && policy.BlinkInterval > 0
+	var blinkOff bool
+	if engaged {
+		lights.engaged = true
+		lights.brightness = policy.LightbarBrightness
+		blinkOff = blink && now.Sub(d.saver.since)/policy.BlinkInterval%2 == 1
+		reduction = policy.RumbleReduction
+	}
+	previousReduction := d.saver.reduction
+	d.saver.reduction = reduction
+	d.saver.mu.Unlock()
+
+	if reduction != previousReduction {
+		d.limitMotorPower(func(l *motorPowerLimiter) {
+			l.saver = reduction
+		})
+	}
+	d.setStateDataMu.Lock()
+	anyLight := playerLightsOf(&d.setStateData) != [5]bool{}
+	if lights != d.saverLights {
+		d.saverLights = lights
+		// Written even if unchanged, as the dimmed lightbar is not part of
+		// the state.
+		err := d.applySetStateData(d.setStateData)
+		if err != nil {
+			d.logger.Debug("could not dim DualSense lightbar for battery saver", "error", err)
+		}
+	}
+	d.setStateDataMu.Unlock()
+	layer := d.saver.layer
+	// The blink switches the indicators as set off, or shows the center one
+	// if none are set.
+	err := layer.update(func() {
+		switch {
+		case blinkOff:
+			layer.playerLights = &[5]bool{}
+		case blink && !anyLight:
+			layer.playerLights = &[5]bool{false, false, true, false, false}
+		default:
+			layer.playerLights = nil
+		}
+	})
+	if err != nil {
+		d.logger.Debug("could not blink DualSense player indicators", "error", err)
+	}
+	if changed {
+		for _, callback := range d.callbacks.OnBatterySaverChange {
+			callback(engaged)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBatterySaver(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetLedColor(LedColor{Red: 200}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetBatterySaverPolicy(BatterySaverPolicy{LightbarBrightness: 101}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if err := d.SetBatterySaverPolicy(DefaultBatterySaverPolicy); err != nil {
+		t.Fatal(err)
+	}
+	var changes []bool
+	d.OnBatterySaverChange(func(engaged bool) {
+		changes = append(changes, engaged)
+	})
+
+	start := time.Now()
+	state := USBGetStateData{PowerPercent: 1, PowerState: PowerStateDischarging}
+	d.processBatterySaver(&state, start)
+	if !d.BatterySaver() {
+		t.Fatal("battery saver not engaged at 10%")
+	}
+	out := d.OutStates()[len(d.OutStates())-1]
+	if out.LedRed != 50 {
+		t.Fatalf("got LedRed %d, want 50", out.LedRed)
+	}
+	if out.RumbleMotorPowerReduction != DefaultBatterySaverPolicy.RumbleReduction {
+		t.Fatalf("got rumble reduction %d, want %d", out.RumbleMotorPowerReduction, DefaultBatterySaverPolicy.RumbleReduction)
+	}
+	if !out.PlayerLight3 {
+		t.Fatal("center player light off while blinking on")
+	}
+	if got := d.GetOutStateData(); got.LedRed != 200 || got.PlayerLight3 {
+		t.Fatalf("GetOutStateData returned the battery saver lights %+v", got)
+	}
+
+	d.processBatterySaver(&state, start.Add(DefaultBatterySaverPolicy.BlinkInterval))
+	if out := d.OutStates()[len(d.OutStates())-1]; out.PlayerLight3 || !out.AllowPlayerIndicators {
+		t.Fatalf("got player light %v, allowed %v, want off, allowed", out.PlayerLight3, out.AllowPlayerIndicators)
+	}
+
+	state.PowerState = PowerStateCharging
+	d.processBatterySaver(&state, start.Add(2*DefaultBatterySaverPolicy.BlinkInterval))
+	out = d.OutStates()[len(d.OutStates())-1]
+	if out.LedRed != 200 || out.RumbleMotorPowerReduction != 0 || out.PlayerLight3 {
+		t.Fatalf("battery saver not released: LedRed %d, rumble reduction %d, player light %v", out.LedRed, out.RumbleMotorPowerReduction, out.PlayerLight3)
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Fatalf("got changes %v, want [true false]", changes)
+	}
+}
+
+func TestBatterySaverThreshold(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetBatterySaverPolicy(BatterySaverPolicy{BelowPercent: 30, LightbarBrightness: 100}); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		percent uint8
+		want    bool
+	}{{50, false}, {30, false}, {20, true}, {40, false}} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.PowerPercent = test.percent / 10
+			state.PowerState = PowerStateDischarging
+		})
+		if got := d.BatterySaver(); got != test.want {
+			t.Fatalf("at %d%% got engaged %v, want %v", test.percent, got, test.want)
+		}
+	}
+}
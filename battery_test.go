@@ -0,0 +1,82 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBatteryTrackerEstimate(t *testing.T) {
+	var b batteryTracker
+	start := time.Now()
+	b.record(8, PowerStateDischarging, start)
+	nominal := b.estimate(start)
+	if nominal.Confidence != batteryNominalConfidence || nominal.Level != batteryLevel(8) {
+		t.Fatalf("got %+v before any step", nominal)
+	}
+	// 85% of NOMINAL_BATTERY_LIFE.
+	if want := 306 * time.Minute; nominal.Remaining < want-time.Second || nominal.Remaining > want+time.Second {
+		t.Fatalf("got remaining %v, want %v", nominal.Remaining, want)
+	}
+
+	// 10% per 30 minutes, measured over two steps.
+	b.record(7, PowerStateDischarging, start.Add(10*time.Minute))
+	b.record(6, PowerStateDischarging, start.Add(40*time.Minute))
+	b.record(5, PowerStateDischarging, start.Add(70*time.Minute))
+	estimate := b.estimate(start.Add(70 * time.Minute))
+	if estimate.Confidence != 2.0/BATTERY_RATE_STEPS {
+		t.Fatalf("got confidence %v, want %v", estimate.Confidence, 2.0/BATTERY_RATE_STEPS)
+	}
+	if want := 180 * time.Minute; estimate.Remaining < want-time.Second || estimate.Remaining > want+time.Second {
+		t.Fatalf("got remaining %v, want %v", estimate.Remaining, want)
+	}
+
+	if b.record(10, PowerStateCharging, start.Add(80*time.Minute)) {
+		t.Fatal("charging reported as complete")
+	}
+	if estimate := b.estimate(start.Add(80 * time.Minute)); estimate.Discharging || estimate.Remaining != 0 {
+		t.Fatalf("got %+v while charging", estimate)
+	}
+	if !b.record(10, PowerStateComplete, start.Add(90*time.Minute)) || !b.chargeComplete() {
+		t.Fatal("charge completion not detected")
+	}
+	if b.record(10, PowerStateComplete, start.Add(91*time.Minute)) {
+		t.Fatal("charge completion reported twice")
+	}
+}
+
+func TestBatteryTrackerJitter(t *testing.T) {
+	var b batteryTracker
+	start := time.Now()
+	b.record(5, PowerStateDischarging, start)
+	// PowerPercent jittering across the 40-50% boundary is one step.
+	for i, powerPercent := range []uint8{4, 5, 4, 5, 4} {
+		b.record(powerPercent, PowerStateDischarging, start.Add(time.Duration(i+1)*time.Minute))
+	}
+	estimate := b.estimate(start.Add(10 * time.Minute))
+	if estimate.Confidence != batteryNominalConfidence {
+		t.Fatalf("got confidence %v, want the nominal %v", estimate.Confidence, batteryNominalConfidence)
+	}
+	if estimate.Remaining <= 0 || estimate.Remaining > NOMINAL_BATTERY_LIFE {
+		t.Fatalf("got remaining %v", estimate.Remaining)
+	}
+}
+
+func TestDischargingBelow(t *testing.T) {
+	for _, test := range []struct {
+		powerPercent uint8
+		powerState   PowerState
+		percent      uint8
+		want         bool
+	}{
+		{1, PowerStateDischarging, 20, true},
+		{2, PowerStateDischarging, 20, false},
+		{2, PowerStateDischarging, 25, true},
+		{1, PowerStateCharging, 20, false},
+		{0, PowerStateDischarging, 0, false},
+	} {
+		state := USBGetStateData{PowerPercent: test.powerPercent, PowerState: test.powerState}
+		if got := dischargingBelow(&state, test.percent); got != test.want {
+			t.Errorf("PowerPercent %d, %v below %d%%: got %v, want %v", test.powerPercent, test.powerState, test.percent, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+const (
+	BT_INPUT_REPORT_ID   = 0x31
+	BT_INPUT_REPORT_SIZE = 78
+	// BT_INPUT_CRC_SEED is the HID transaction header byte the CRC32 at the
+	// end of every Bluetooth report is computed over before the report.
+	BT_INPUT_CRC_SEED = 0xA1
+
+	BT_OUTPUT_REPORT_ID   = 0x31
+	BT_OUTPUT_REPORT_SIZE = 78
+	// BT_OUTPUT_CRC_SEED is the transaction header byte of output reports,
+	// see BT_INPUT_CRC_SEED.
+	BT_OUTPUT_CRC_SEED = 0xA2
+
+	// BT_CALIBRATION_REPORT_ID is the feature report whose read switches a
+	// Bluetooth controller from its reduced 0x01 input reports to the full
+	// BT_INPUT_REPORT_ID ones.
+	BT_CALIBRATION_REPORT_ID   = 0x05
+	BT_CALIBRATION_REPORT_SIZE = 41
+)
+
+// btOutputTag follows the sequence byte of every Bluetooth output report.
+const btOutputTag = 0x10
+
+// defaultBluetoothReportLayout is the layout assumed over Bluetooth when the
+// transport provides no report descriptor.
+var defaultBluetoothReportLayout = ReportLayout{
+	Input:  map[uint8]int{BT_INPUT_REPORT_ID: BT_INPUT_REPORT_SIZE},
+	Output: map[uint8]int{BT_OUTPUT_REPORT_ID: BT_OUTPUT_REPORT_SIZE},
+}
+
+func bluetoothCRC(seed byte, data []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE([]byte{seed}), crc32.IEEETable, data)
+}
+
+// bluetoothInputToUSB checks the CRC32 of a Bluetooth input report and copies
+// its fields into usb, a USB input report of USB_PACKET_SIZE bytes.
+func bluetoothInputToUSB(data, usb []byte) error {
+	if len(data) != BT_INPUT_REPORT_SIZE {
+		return fmt.Errorf("invalid length of data: %d", len(data))
+	}
+	if data[0] != BT_INPUT_REPORT_ID {
+		return fmt.Errorf("invalid Bluetooth input report ID 0x%02X", data[0])
+	}
+	crc := bluetoothCRC(BT_INPUT_CRC_SEED, data[:BT_INPUT_REPORT_SIZE-4])
+	if want := binary.LittleEndian.Uint32(data[BT_INPUT_REPORT_SIZE-4:]); crc != want {
+		return fmt.Errorf("invalid Bluetooth input report CRC32 0x%08X, computed 0x%08X", want, crc)
+	}
+	usb[0] = 0x01
+	copy(usb[1:USB_PACKET_SIZE], data[2:])
+	return nil
+}
+
+// UnmarshalBluetoothInputReport parses a 78 byte Bluetooth input report,
+// including its report ID, after checking its CRC32. Past a sequence byte, the
+// Bluetooth report carries the fields of the USB report at the same offsets,
+// so the result is what UnmarshalInputReport returns for the USB report, with
+// the Bluetooth report ID.
+func UnmarshalBluetoothInputReport(data []byte) (USBReportIn, error) {
+	var usb [USB_PACKET_SIZE]byte
+	err := bluetoothInputToUSB(data, usb[:])
+	if err != nil {
+		return USBReportIn{}, err
+	}
+	reportIn, err := UnmarshalInputReport(usb[:])
+	if err != nil {
+		return USBReportIn{}, err
+	}
+	reportIn.ReportID = data[0]
+	return reportIn, nil
+}
+
+// MarshalBluetoothOutputReport packs setStateData into a 78 byte Bluetooth
+// output report. It carries the fields of the USB report after a sequence
+// number, seq modulo 16, and a tag byte, and ends in a CRC32.
+func MarshalBluetoothOutputReport(setStateData SetStateData, seq uint8) ([]byte, error) {
+	usb, err := MarshalOutputReport(setStateData)
+	if err != nil {
+		return nil, err
+	}
+	report := make([]byte, BT_OUTPUT_REPORT_SIZE)
+	report[0] = BT_OUTPUT_REPORT_ID
+	report[1] = (seq % 16) << 4
+	report[2] = btOutputTag
+	copy(report[3:BT_OUTPUT_REPORT_SIZE-4], usb[1:])
+	binary.LittleEndian.PutUint32(report[BT_OUTPUT_REPORT_SIZE-4:], bluetoothCRC(BT_OUTPUT_CRC_SEED, report[:BT_OUTPUT_REPORT_SIZE-4]))
+	return report, nil
+}
+
+// wireless reports whether the controller is connected over Bluetooth, as
+// told by a transport implementing WirelessTransport.
+func (d *DualSense) wireless() bool {
+	wireless, ok := d.currentTransport().(WirelessTransport)
+	return ok && wireless.Wireless()
+}
+
+// enableBluetoothReports reads the calibration feature report of a Bluetooth
+// controller, which makes it send full input reports. Over USB it does
+// nothing.
+func (d *DualSense) enableBluetoothReports() {
+	if !d.wireless() {
+		return
+	}
+	calibration := make([]byte, BT_CALIBRATION_REPORT_SIZE)
+	calibration[0] = BT_CALIBRATION_REPORT_ID
+	_, err := d.GetFeatureReport(calibration)
+	if err != nil {
+		d.logger.Debug("could not enable full DualSense Bluetooth input reports", "error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// HCI commands, from bluetooth/hci.h.
+const (
+	hciCommandPkt = 0x01
+	hciDisconnect = 0x0406
+	// hciUserEndedConnection is the reason given to the controller, as when
+	// the PS button is held.
+	hciUserEndedConnection = 0x13
+)
+
+// disconnectHIDRaw drops the Bluetooth link of the controller behind the
+// hidraw node, e.g. /dev/hidraw0, by sending HCI_Disconnect to its adapter.
+// Raw HCI sockets need CAP_NET_RAW.
+func disconnectHIDRaw(node string) error {
+	devicePath, err := filepath.EvalSymlinks(filepath.Join("/sys/class/hidraw", filepath.Base(node), "device"))
+	if err != nil {
+		return fmt.Errorf("filepath.EvalSymlinks: error trying to find Bluetooth connection of %s: %w", node, err)
+	}
+	dev, handle, err := hciConnection(devicePath)
+	if err != nil {
+		return err
+	}
+	return sendHCIDisconnect(dev, handle)
+}
+
+// hciConnection finds the adapter and connection handle in the sysfs path of
+// a Bluetooth HID device, which is below a directory named hciX:HANDLE.
+func hciConnection(devicePath string) (dev, handle uint16, err error) {
+	for _, element := range strings.Split(devicePath, "/") {
+		name, handleString, ok := strings.Cut(element, ":")
+		devString, isHCI := strings.CutPrefix(name, "hci")
+		if !ok || !isHCI {
+			continue
+		}
+		devNumber, err := strconv.ParseUint(devString, 10, 16)
+		if err != nil {
+			continue
+		}
+		handleNumber, err := strconv.ParseUint(handleString, 10, 16)
+		if err != nil {
+			continue
+		}
+		return uint16(devNumber), uint16(handleNumber), nil
+	}
+	return 0, 0, fmt.Errorf("%s is not a Bluetooth connection: %w", devicePath, errors.ErrUnsupported)
+}
+
+// sendHCIDisconnect asks adapter hci<dev> to drop connection handle.
+func sendHCIDisconnect(dev, handle uint16) error {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.BTPROTO_HCI)
+	if err != nil {
+		return fmt.Errorf("unix.Socket: error trying to open HCI socket: %w", err)
+	}
+	defer unix.Close(fd)
+	err = unix.Bind(fd, &unix.SockaddrHCI{Dev: dev, Channel: unix.HCI_CHANNEL_RAW})
+	if err != nil {
+		return fmt.Errorf("unix.Bind: error trying to bind HCI socket to hci%d: %w", dev, err)
+	}
+	command := []byte{hciCommandPkt, hciDisconnect & 0xFF, hciDisconnect >> 8, 3, byte(handle), byte(handle >> 8), hciUserEndedConnection}
+	_, err = unix.Write(fd, command)
+	if err != nil {
+		return fmt.Errorf("error trying to send HCI_Disconnect to hci%d: %w", dev, err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHCIConnection(t *testing.T) {
+	dev, handle, err := hciConnection("/sys/devices/pci0000:00/0000:00:14.0/usb1/1-10/1-10:1.0/bluetooth/hci1/hci1:3585/0005:054C:0CE6.0007")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev != 1 || handle != 3585 {
+		t.Errorf("hciConnection() = %d, %d, want 1, 3585", dev, handle)
+	}
+	_, _, err = hciConnection("/sys/devices/pci0000:00/0000:00:14.0/usb3/3-2/3-2:1.3/0003:054C:0CE6.0008")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("hciConnection() over USB: got %v, want errors.ErrUnsupported", err)
+	}
+}
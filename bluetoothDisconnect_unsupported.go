@@ -0,0 +1,13 @@
+//go:build !linux
+
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+)
+
+// disconnectHIDRaw needs Linux raw HCI sockets.
+func disconnectHIDRaw(node string) error {
+	return fmt.Errorf("error trying to disconnect %s: %w", node, errors.ErrUnsupported)
+}
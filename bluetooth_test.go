@@ -0,0 +1,86 @@
+package dualsense
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// bluetoothInputReport wraps a USB input report into a Bluetooth one.
+func bluetoothInputReport(usb []byte) []byte {
+	report := make([]byte, BT_INPUT_REPORT_SIZE)
+	report[0] = BT_INPUT_REPORT_ID
+	copy(report[2:], usb[1:])
+	binary.LittleEndian.PutUint32(report[BT_INPUT_REPORT_SIZE-4:], bluetoothCRC(BT_INPUT_CRC_SEED, report[:BT_INPUT_REPORT_SIZE-4]))
+	return report
+}
+
+func TestMarshalBluetoothOutputReport(t *testing.T) {
+	setStateData := defaultSetStateData
+	setStateData.LedRed = 0x12
+	setStateData.RumbleEmulationLeft = 0x34
+	usb, err := MarshalOutputReport(setStateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := MarshalBluetoothOutputReport(setStateData, 17)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != BT_OUTPUT_REPORT_SIZE || report[0] != BT_OUTPUT_REPORT_ID || report[1] != 0x10 || report[2] != btOutputTag {
+		t.Fatalf("got header % x, want 31 10 10", report[:3])
+	}
+	if !bytes.Equal(report[3:3+len(usb)-1], usb[1:]) {
+		t.Fatal("Bluetooth report does not carry the USB report fields")
+	}
+	if crc := binary.LittleEndian.Uint32(report[BT_OUTPUT_REPORT_SIZE-4:]); crc != bluetoothCRC(BT_OUTPUT_CRC_SEED, report[:BT_OUTPUT_REPORT_SIZE-4]) {
+		t.Fatalf("bad CRC32 0x%08X", crc)
+	}
+}
+
+func TestBluetoothReports(t *testing.T) {
+	transport := &wirelessMockTransport{wireless: true}
+	d := newDualSense(transport, []Option{WithManualPump()})
+	if err := d.detectReportLayout(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.outputReports) < 2 {
+		t.Fatalf("got %d output reports, want at least 2", len(transport.outputReports))
+	}
+	for i, report := range transport.outputReports {
+		if len(report) != BT_OUTPUT_REPORT_SIZE || report[0] != BT_OUTPUT_REPORT_ID || report[1] != uint8(i)<<4 {
+			t.Fatalf("output report %d: got header % x", i, report[:3])
+		}
+	}
+
+	usb := make([]byte, USB_PACKET_SIZE)
+	usb[0] = 0x01
+	usb[8] = 0x20 // Cross
+	transport.inputReports = append(transport.inputReports, bluetoothInputReport(usb))
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if !d.GetInStateData().ButtonCross {
+		t.Fatal("Bluetooth input report not processed")
+	}
+
+	corrupt := bluetoothInputReport(usb)
+	corrupt[10] ^= 0xFF
+	transport.inputReports = append(transport.inputReports, corrupt)
+	if err := d.Poll(); err == nil {
+		t.Fatal("Poll accepted a Bluetooth input report with a bad CRC32")
+	}
+	if got := d.Diagnostics().MalformedReports; got != 1 {
+		t.Fatalf("got %d malformed reports, want 1", got)
+	}
+}
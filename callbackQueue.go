@@ -0,0 +1,94 @@
+package dualsense
+
+import (
+	"slices"
+	"sync"
+)
+
+// callbackQueue holds the input states waiting for one subscription created
+// WithAsyncCallbacks, which its own goroutine delivers, so a slow callback
+// only delays itself.
+type callbackQueue struct {
+	mu    sync.Mutex
+	field Field
+	size  int
+	// delivered is the state the next change is diffed against, starting
+	// from the state before the first queued change.
+	delivered USBGetStateData
+	started   bool
+	states    []USBGetStateData
+	wake      chan struct{}
+}
+
+// queueCall wraps call so changes are queued for a goroutine that runs until
+// stop or the DualSense is closed.
+func (d *DualSense) queueCall(field Field, call func(current, previous *USBGetStateData), stop <-chan struct{}) func(current, previous *USBGetStateData) {
+	q := &callbackQueue{
+		field: field,
+		size:  d.callbackQueueSize,
+		wake:  make(chan struct{}, 1),
+	}
+	go q.dispatch(call, stop, d.closed)
+	return func(current, previous *USBGetStateData) {
+		if q.queue(current, previous) {
+			d.stats.recordCoalescedState()
+		}
+	}
+}
+
+// queue adds current without blocking, discarding the oldest queued state
+// when the queue is full, which it reports.
+func (q *callbackQueue) queue(current, previous *USBGetStateData) bool {
+	q.mu.Lock()
+	if !q.started {
+		q.started = true
+		q.delivered = *previous
+	}
+	discarded := len(q.states) >= q.size
+	if discarded {
+		q.states = slices.Delete(q.states, 0, 1)
+	}
+	q.states = append(q.states, *current)
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return discarded
+}
+
+// next pops the oldest queued state and the state it is diffed against.
+func (q *callbackQueue) next() (current, previous USBGetStateData, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.states) == 0 {
+		return USBGetStateData{}, USBGetStateData{}, false
+	}
+	current = q.states[0]
+	q.states = slices.Delete(q.states, 0, 1)
+	previous = q.delivered
+	q.delivered = current
+	return current, previous, true
+}
+
+func (q *callbackQueue) dispatch(call func(current, previous *USBGetStateData), stop, closed <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-closed:
+			return
+		case <-q.wake:
+		}
+		for {
+			current, previous, ok := q.next()
+			if !ok {
+				break
+			}
+			// A discarded state may have undone the change.
+			if inputFields[q.field].changed(&current, &previous) {
+				call(&current, &previous)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+// Command dualsense-desktop uses a DualSense controller as a keyboard and
+// mouse through Linux uinput.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"github.com/nikashan02/dualsense-go/emulation"
+)
+
+func main() {
+	profilePath := flag.String("profile", "", "emulation profile JSON file, the built-in desktop profile if empty")
+	calibrationTicks := flag.Int("calibrate", 125, "ticks to measure gyro bias over at startup, keep the controller still meanwhile")
+	flag.Parse()
+
+	logger := slog.Default()
+	profile := emulation.DefaultProfile()
+	if *profilePath != "" {
+		var err error
+		profile, err = emulation.LoadProfile(*profilePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	injector, err := emulation.NewUinputInjector("DualSense Desktop")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer injector.Close()
+	controller, err := dualsense.NewDualSense(dualsense.WithLogger(logger))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer controller.Close()
+	engine, err := emulation.NewEngine(controller, injector, profile, emulation.WithErrorHandler(func(err error) {
+		logger.Error("emulation error", "error", err)
+	}))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer engine.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	engine.CalibrateGyro(*calibrationTicks)
+	engine.Start()
+	logger.Info("emulating keyboard and mouse", "profile", profile.Name)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
@@ -0,0 +1,48 @@
+// Command dualsense-http serves a DualSense controller over HTTP, see
+// httpapi.NewHandler for the endpoints.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"github.com/nikashan02/dualsense-go/httpapi"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	debug := flag.Bool("debug", false, "also serve DebugSnapshot at /debug")
+	flag.Parse()
+
+	logger := slog.Default()
+	controller, err := dualsense.NewDualSense(dualsense.WithLogger(logger))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer controller.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	controller.OnError(func(err error) {
+		logger.Error("DualSense error", "error", err)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/", httpapi.NewHandler(controller))
+	if *debug {
+		mux.Handle("GET /debug", controller.DebugHandler())
+	}
+	logger.Info("listening", "addr", *addr)
+	err = http.ListenAndServe(*addr, mux)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,203 @@
+// Command dualsense-monitor shows the live input state of a DualSense
+// controller in the terminal, with a touchpad view and basic output controls.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"github.com/rivo/tview"
+)
+
+const (
+	TOUCHPAD_COLS   = 48
+	TOUCHPAD_ROWS   = 12
+	RUMBLE_DURATION = 500 * time.Millisecond
+)
+
+func displayStructAsTable(data dualsense.USBGetStateData, table *tview.Table) {
+	val := reflect.ValueOf(data)
+	typeOfS := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		fieldName := typeOfS.Field(i).Name
+		fieldValue := val.Field(i).Interface()
+
+		// handle bools
+		if b, ok := fieldValue.(bool); ok {
+			fieldValue = strconv.FormatBool(b)
+		}
+
+		table.SetCell(i, 0, tview.NewTableCell(fieldName).SetAlign(tview.AlignRight))
+		table.SetCell(i, 1, tview.NewTableCell(fmt.Sprintf("%v", fieldValue)).SetAlign(tview.AlignLeft))
+	}
+}
+
+func displayBattery(data dualsense.USBGetStateData, stats dualsense.Stats, view *tview.TextView) {
+	// PowerPercent counts in steps of 10%, capped at 100% while charging.
+	percent := min(int(data.PowerPercent)*10+5, 100)
+	if data.PowerState == dualsense.PowerStateComplete {
+		percent = 100
+	}
+	bar := strings.Repeat("█", percent/10) + strings.Repeat("░", 10-percent/10)
+	view.SetText(fmt.Sprintf("%s %d%% %s\nReports: %d received, %d dropped, %.0f Hz ±%v",
+		bar, percent, data.PowerState, stats.ReportsReceived, stats.ReportsDropped, stats.ReportRate, stats.ReportJitter.Round(10*time.Microsecond)))
+}
+
+func displayTouchpad(touchData dualsense.TouchData, view *tview.TextView) {
+	grid := make([][]rune, TOUCHPAD_ROWS)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat("·", TOUCHPAD_COLS))
+	}
+	for i, finger := range []dualsense.TouchFinger{touchData.TouchFinger1, touchData.TouchFinger2} {
+		if finger.NotTouching {
+			continue
+		}
+		col := min(int(finger.FingerX)*TOUCHPAD_COLS/dualsense.TOUCHPAD_WIDTH, TOUCHPAD_COLS-1)
+		row := min(int(finger.FingerY)*TOUCHPAD_ROWS/dualsense.TOUCHPAD_HEIGHT, TOUCHPAD_ROWS-1)
+		grid[row][col] = rune('1' + i)
+	}
+	lines := make([]string, len(grid))
+	for row := range grid {
+		lines[row] = string(grid[row])
+	}
+	view.SetText(strings.Join(lines, "\n"))
+}
+
+func newControls(app *tview.Application, controller *dualsense.DualSense, setStatus func(error)) *tview.Form {
+	form := tview.NewForm()
+	colorField := func(label string) {
+		form.AddInputField(label, "0", 4, tview.InputFieldInteger, nil)
+	}
+	colorField("Red")
+	colorField("Green")
+	colorField("Blue")
+	colorValue := func(label string) uint8 {
+		value, _ := strconv.ParseUint(form.GetFormItemByLabel(label).(*tview.InputField).GetText(), 10, 8)
+		return uint8(value)
+	}
+	form.AddButton("Set LED", func() {
+		setStatus(controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+			setStateData.AllowLedColor = true
+			setStateData.LedRed = colorValue("Red")
+			setStateData.LedGreen = colorValue("Green")
+			setStateData.LedBlue = colorValue("Blue")
+		}))
+	})
+	form.AddButton("Rumble test", func() {
+		rumble := func(value uint8) error {
+			return controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+				setStateData.EnableRumbleEmulation = true
+				setStateData.UseRumbleNotHaptics = true
+				setStateData.RumbleEmulationLeft = value
+				setStateData.RumbleEmulationRight = value
+			})
+		}
+		setStatus(rumble(0xFF))
+		time.AfterFunc(RUMBLE_DURATION, func() {
+			setStatus(rumble(0))
+		})
+	})
+	form.AddButton("Quit", app.Stop)
+	form.SetBorder(true).SetTitle("Output").SetTitleAlign(tview.AlignLeft)
+	return form
+}
+
+func main() {
+	refreshRate := flag.Int("refresh", 30, "display refresh rate in Hz")
+	powerLog := flag.String("power-log", "", "append battery, temperature and connection samples to this JSON Lines `file`")
+	powerLogInterval := flag.Duration("power-log-interval", dualsense.DEFAULT_POWER_LOG_INTERVAL, "how often to sample for -power-log")
+	flag.Parse()
+
+	var options []dualsense.Option
+	if path, err := dualsense.DefaultLabelsPath(); err == nil {
+		if labels, err := dualsense.LoadLabels(path); err == nil {
+			options = append(options, dualsense.WithLabels(labels))
+		}
+	}
+	controller, err := dualsense.NewDualSense(options...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer controller.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *powerLog != "" {
+		logger, err := dualsense.OpenPowerLog(*powerLog)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer logger.Close()
+		err = logger.Attach(controller, *powerLogInterval)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	app := tview.NewApplication()
+	table := tview.NewTable()
+	title := "USB Get State Data"
+	if label := controller.Label(); label != "" {
+		title = label + ": " + title
+	}
+	table.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft)
+	battery := tview.NewTextView()
+	battery.SetBorder(true).SetTitle("Battery").SetTitleAlign(tview.AlignLeft)
+	touchpad := tview.NewTextView()
+	touchpad.SetBorder(true).SetTitle("Touchpad").SetTitleAlign(tview.AlignLeft)
+	status := tview.NewTextView().SetDynamicColors(true)
+	setStatus := func(err error) {
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				status.SetText("[red]" + tview.Escape(err.Error()))
+			} else {
+				status.SetText("")
+			}
+		})
+	}
+	controls := newControls(app, controller, setStatus)
+
+	side := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(battery, 4, 0, false).
+		AddItem(touchpad, TOUCHPAD_ROWS+2, 0, false).
+		AddItem(controls, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	root := tview.NewFlex().
+		AddItem(table, 0, 1, false).
+		AddItem(side, TOUCHPAD_COLS+2, 0, true)
+
+	controller.OnError(func(err error) {
+		setStatus(err)
+	})
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(max(*refreshRate, 1)))
+		defer ticker.Stop()
+		for range ticker.C {
+			data := controller.GetInStateData()
+			stats := controller.Stats()
+			app.QueueUpdateDraw(func() {
+				displayStructAsTable(data, table)
+				displayBattery(data, stats, battery)
+				displayTouchpad(data.TouchData, touchpad)
+			})
+		}
+	}()
+
+	err = app.SetRoot(root, true).EnableMouse(true).Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,519 @@
+// Command dualsensectl controls a DualSense controller from the shell.
+//
+// Usage:
+//
+//	dualsensectl led <red> <green> <blue>
+//	dualsensectl player <mask>
+//	dualsensectl trigger <left|right> <off|feedback|weapon|vibration> [start end strength]
+//	dualsensectl mic-mute <on|off>
+//	dualsensectl preset <name>
+//	dualsensectl battery
+//	dualsensectl firmware
+//	dualsensectl support-info
+//	dualsensectl label [name]
+//	dualsensectl white-point [red green blue]
+//	dualsensectl self-test
+//	dualsensectl rumble-wav <file>
+//	dualsensectl effect <file>
+//	dualsensectl capture <description>
+//
+// Output commands start from the default output state, so every invocation
+// replaces the previous one.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+var timeout = flag.Duration("timeout", time.Second, "how long to wait for an input report")
+
+type command struct {
+	args  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"led":          {"<red> <green> <blue>", "set the lightbar color", runLed},
+	"player":       {"<mask>", "set the player LEDs from a 5-bit mask, e.g. 0b00100", runPlayer},
+	"trigger":      {"<left|right> <effect> [start end strength]", "set a trigger effect: off, feedback, weapon or vibration", runTrigger},
+	"mic-mute":     {"<on|off>", "mute or unmute the microphone and set the mute light", runMicMute},
+	"preset":       {"<name>", "apply a built-in preset: " + strings.Join(dualsense.PresetNames(), ", "), runPreset},
+	"battery":      {"", "print the battery level and charging state", runBattery},
+	"firmware":     {"", "print the hardware and firmware versions", runFirmware},
+	"support-info": {"", "print controller and library details to attach to bug reports", runSupportInfo},
+	"label":        {"[name]", "print the controller's label, or set it; an empty name removes it", runLabel},
+	"white-point":  {"[red green blue]", "print the controller's lightbar white point, or set it from scales in [0, 1]", runWhitePoint},
+	"self-test":    {"", "exercise the motors, triggers and lights and check the controller confirms each", runSelfTest},
+	"rumble-wav":   {"<file>", "play the amplitude envelope of a WAV file on the rumble motors", runRumbleWAV},
+	"effect":       {"<file>", "play a JSON effect script of lightbar, rumble and trigger cues", runEffect},
+	"capture":      {"<description>", "print the next input report as a conformance test case", runCapture},
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <command> [args]\n\nCommands:\n", os.Args[0])
+	for _, name := range []string{"led", "player", "trigger", "mic-mute", "preset", "battery", "firmware", "support-info", "label", "white-point", "self-test", "rumble-wav", "effect", "capture"} {
+		c := commands[name]
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s %s\n    \t%s\n", name, c.args, c.usage)
+	}
+	fmt.Fprintln(flag.CommandLine.Output(), "\nFlags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	c, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+	err := c.run(flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func parseUint8(arg string) (uint8, error) {
+	value, err := strconv.ParseUint(arg, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q: %w", arg, err)
+	}
+	return uint8(value), nil
+}
+
+// applyOutput opens the controller and sends the default output state with
+// update applied as its initial state.
+func applyOutput(update func(*dualsense.SetStateData)) error {
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump(), withSavedWhitePoints())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	setStateData := controller.GetOutStateData()
+	update(&setStateData)
+	return controller.Start(&setStateData)
+}
+
+func runLed(args []string) error {
+	if len(args) != 3 {
+		return errors.New("led: expected <red> <green> <blue>")
+	}
+	var rgb [3]uint8
+	for i, arg := range args {
+		value, err := parseUint8(arg)
+		if err != nil {
+			return fmt.Errorf("led: %w", err)
+		}
+		rgb[i] = value
+	}
+	return applyOutput(func(setStateData *dualsense.SetStateData) {
+		setStateData.AllowLedColor = true
+		setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = rgb[0], rgb[1], rgb[2]
+	})
+}
+
+func runPlayer(args []string) error {
+	if len(args) != 1 {
+		return errors.New("player: expected <mask>")
+	}
+	mask, err := parseUint8(args[0])
+	if err != nil || mask > 0b11111 {
+		return fmt.Errorf("player: invalid mask %q", args[0])
+	}
+	return applyOutput(func(setStateData *dualsense.SetStateData) {
+		setStateData.AllowPlayerIndicators = true
+		setStateData.PlayerLight1 = mask&0b00001 != 0
+		setStateData.PlayerLight2 = mask&0b00010 != 0
+		setStateData.PlayerLight3 = mask&0b00100 != 0
+		setStateData.PlayerLight4 = mask&0b01000 != 0
+		setStateData.PlayerLight5 = mask&0b10000 != 0
+	})
+}
+
+var effectTypes = map[string]dualsense.EffectType{
+	"off":       dualsense.EffectTypeOff,
+	"feedback":  dualsense.EffectTypeFeedback,
+	"weapon":    dualsense.EffectTypeWeapon,
+	"vibration": dualsense.EffectTypeVibration,
+}
+
+func runTrigger(args []string) error {
+	if len(args) != 2 && len(args) != 5 {
+		return errors.New("trigger: expected <left|right> <effect> [start end strength]")
+	}
+	effectType, ok := effectTypes[args[1]]
+	if !ok {
+		return fmt.Errorf("trigger: unknown effect %q", args[1])
+	}
+	var params [3]uint8
+	for i, arg := range args[2:] {
+		value, err := parseUint8(arg)
+		if err != nil {
+			return fmt.Errorf("trigger: %w", err)
+		}
+		params[i] = value
+	}
+	ffbParams := dualsense.GenerateTriggerFFBParams(effectType, params[0], params[1], params[2])
+	switch args[0] {
+	case "left":
+		return applyOutput(func(setStateData *dualsense.SetStateData) {
+			setStateData.AllowLeftTriggerFFB = true
+			setStateData.LeftTriggerFFB = ffbParams
+		})
+	case "right":
+		return applyOutput(func(setStateData *dualsense.SetStateData) {
+			setStateData.AllowRightTriggerFFB = true
+			setStateData.RightTriggerFFB = ffbParams
+		})
+	default:
+		return fmt.Errorf("trigger: unknown trigger %q", args[0])
+	}
+}
+
+func runMicMute(args []string) error {
+	if len(args) != 1 || args[0] != "on" && args[0] != "off" {
+		return errors.New("mic-mute: expected <on|off>")
+	}
+	mute := args[0] == "on"
+	return applyOutput(func(setStateData *dualsense.SetStateData) {
+		setStateData.AllowAudioMute = true
+		setStateData.MicMute = mute
+		setStateData.AllowMuteLight = true
+		setStateData.MuteLight = dualsense.MuteLightModeOff
+		if mute {
+			setStateData.MuteLight = dualsense.MuteLightModeOn
+		}
+	})
+}
+
+func runPreset(args []string) error {
+	if len(args) != 1 {
+		return errors.New("preset: expected <name>")
+	}
+	profile, err := dualsense.Preset(args[0])
+	if err != nil {
+		return fmt.Errorf("preset: %w", err)
+	}
+	return applyOutput(func(setStateData *dualsense.SetStateData) {
+		*setStateData = profile.SetStateData
+	})
+}
+
+func runBattery(args []string) error {
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	deadline := time.Now().Add(*timeout)
+	for controller.Stats().ReportsReceived == 0 {
+		if time.Now().After(deadline) {
+			return errors.New("battery: no input report received")
+		}
+		err := controller.Poll()
+		if err != nil {
+			return fmt.Errorf("battery: %w", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	state := controller.GetInStateData()
+	// PowerPercent counts in steps of 10%.
+	percent := min(int(state.PowerPercent)*10+5, 100)
+	if state.PowerState == dualsense.PowerStateComplete {
+		percent = 100
+	}
+	fmt.Printf("%d%% %s\n", percent, state.PowerState)
+	return nil
+}
+
+func runFirmware(args []string) error {
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	info, err := controller.HardwareInfo()
+	if err != nil {
+		return fmt.Errorf("firmware: %w", err)
+	}
+	fmt.Printf("build:    %s\n", info.BuildTime.Format(time.DateTime))
+	fmt.Printf("hardware: %#08x\n", info.HardwareVersion)
+	fmt.Printf("firmware: %#08x\n", info.FirmwareVersion)
+	if info.MacAddress != "" {
+		fmt.Printf("address:  %s\n", info.MacAddress)
+	}
+	return nil
+}
+
+func runSupportInfo(args []string) error {
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump(), withSavedLabels())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(controller.DumpSupportInfo())
+}
+
+// withSavedLabels labels the controller from the labels saved by the label
+// command, leaving it unlabeled if they cannot be read.
+func withSavedLabels() dualsense.Option {
+	path, err := dualsense.DefaultLabelsPath()
+	if err != nil {
+		return dualsense.WithLabels(nil)
+	}
+	labels, err := dualsense.LoadLabels(path)
+	if err != nil {
+		return dualsense.WithLabels(nil)
+	}
+	return dualsense.WithLabels(labels)
+}
+
+// withSavedWhitePoints calibrates the lightbar from the white points saved by
+// the white-point command, leaving it uncalibrated if they cannot be read.
+func withSavedWhitePoints() dualsense.Option {
+	path, err := dualsense.DefaultWhitePointsPath()
+	if err != nil {
+		return dualsense.WithWhitePoints(nil)
+	}
+	whitePoints, err := dualsense.LoadWhitePoints(path)
+	if err != nil {
+		return dualsense.WithWhitePoints(nil)
+	}
+	return dualsense.WithWhitePoints(whitePoints)
+}
+
+func runLabel(args []string) error {
+	if len(args) > 1 {
+		return errors.New("label: expected [name]")
+	}
+	path, err := dualsense.DefaultLabelsPath()
+	if err != nil {
+		return fmt.Errorf("label: %w", err)
+	}
+	labels, err := dualsense.LoadLabels(path)
+	if err != nil {
+		return fmt.Errorf("label: %w", err)
+	}
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	serial, err := controller.SerialNumber()
+	if err != nil {
+		return fmt.Errorf("label: %w", err)
+	}
+	if len(args) == 0 {
+		fmt.Printf("%s %s\n", serial, labels[serial])
+		return nil
+	}
+	if args[0] == "" {
+		delete(labels, serial)
+	} else {
+		labels[serial] = args[0]
+	}
+	err = dualsense.SaveLabels(path, labels)
+	if err != nil {
+		return fmt.Errorf("label: %w", err)
+	}
+	return nil
+}
+
+func runWhitePoint(args []string) error {
+	if len(args) != 0 && len(args) != 3 {
+		return errors.New("white-point: expected [red green blue]")
+	}
+	var scales [3]float64
+	for i, arg := range args {
+		scale, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("white-point: invalid scale %q: %w", arg, err)
+		}
+		scales[i] = scale
+	}
+	path, err := dualsense.DefaultWhitePointsPath()
+	if err != nil {
+		return fmt.Errorf("white-point: %w", err)
+	}
+	whitePoints, err := dualsense.LoadWhitePoints(path)
+	if err != nil {
+		return fmt.Errorf("white-point: %w", err)
+	}
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	serial, err := controller.SerialNumber()
+	if err != nil {
+		return fmt.Errorf("white-point: %w", err)
+	}
+	if len(args) == 0 {
+		whitePoint, ok := whitePoints[serial]
+		if !ok {
+			whitePoint = dualsense.WhitePoint{Red: 1, Green: 1, Blue: 1}
+		}
+		fmt.Printf("%s %g %g %g\n", serial, whitePoint.Red, whitePoint.Green, whitePoint.Blue)
+		return nil
+	}
+	whitePoint := dualsense.WhitePoint{Red: scales[0], Green: scales[1], Blue: scales[2]}
+	err = whitePoint.Validate()
+	if err != nil {
+		return fmt.Errorf("white-point: %w", err)
+	}
+	whitePoints[serial] = whitePoint
+	err = dualsense.SaveWhitePoints(path, whitePoints)
+	if err != nil {
+		return fmt.Errorf("white-point: %w", err)
+	}
+	return nil
+}
+
+func runSelfTest(args []string) error {
+	controller, err := dualsense.NewDualSense()
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		return fmt.Errorf("self-test: %w", err)
+	}
+	result, err := controller.RunSelfTest()
+	if err != nil {
+		return fmt.Errorf("self-test: %w", err)
+	}
+	for _, step := range result.Steps {
+		if step.Passed {
+			fmt.Printf("ok   %-22s %s\n", step.Name, step.Latency)
+		} else {
+			fmt.Printf("FAIL %-22s %s\n", step.Name, step.Error)
+		}
+	}
+	if !result.Passed {
+		return errors.New("self-test: failed")
+	}
+	return nil
+}
+
+func runRumbleWAV(args []string) error {
+	if len(args) != 1 {
+		return errors.New("rumble-wav: expected <file>")
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("rumble-wav: %w", err)
+	}
+	defer file.Close()
+	pattern, err := dualsense.RumblePatternFromWAV(file, 20*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("rumble-wav: %w", err)
+	}
+	controller, err := dualsense.NewDualSense()
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		return fmt.Errorf("rumble-wav: %w", err)
+	}
+	err = controller.PlayRumblePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("rumble-wav: %w", err)
+	}
+	return nil
+}
+
+func runEffect(args []string) error {
+	if len(args) != 1 {
+		return errors.New("effect: expected <file>")
+	}
+	script, err := dualsense.LoadEffectScript(args[0])
+	if err != nil {
+		return fmt.Errorf("effect: %w", err)
+	}
+	controller, err := dualsense.NewDualSense(withSavedWhitePoints())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	err = controller.Start(nil)
+	if err != nil {
+		return fmt.Errorf("effect: %w", err)
+	}
+	err = controller.PlayTimeline(script.Timeline, nil)
+	if err != nil {
+		return fmt.Errorf("effect: %w", err)
+	}
+	return nil
+}
+
+// conformanceCase is the format of the library's testdata/conformance corpus.
+type conformanceCase struct {
+	Source      string         `json:"source"`
+	Description string         `json:"description"`
+	Report      string         `json:"report"`
+	Expect      map[string]any `json:"expect"`
+}
+
+// runCapture prints the next input report as read from the transport, with
+// every field it decodes to as expectations to prune by hand.
+func runCapture(args []string) error {
+	if len(args) != 1 {
+		return errors.New("capture: expected <description>")
+	}
+	controller, err := dualsense.NewDualSense(dualsense.WithManualPump())
+	if err != nil {
+		return err
+	}
+	defer controller.Close()
+	// The state decoded last is that of the last report read.
+	var report []byte
+	controller.OnRawReport(func(reportID uint8, data []byte) {
+		report = append(append(report[:0], reportID), data...)
+	})
+	deadline := time.Now().Add(*timeout)
+	for controller.Stats().ReportsReceived == 0 {
+		if time.Now().After(deadline) {
+			return errors.New("capture: no input report received")
+		}
+		err := controller.Poll()
+		if err != nil {
+			return fmt.Errorf("capture: %w", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	state := controller.GetInStateData()
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("capture: %w", err)
+	}
+	c := conformanceCase{Source: "captured", Description: args[0], Report: hex.EncodeToString(report)}
+	err = json.Unmarshal(encoded, &c.Expect)
+	if err != nil {
+		return fmt.Errorf("capture: %w", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(c)
+}
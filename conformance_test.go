@@ -0,0 +1,154 @@
+package dualsense
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// conformanceCase is an input report from testdata/conformance and the fields
+// it must decode to. Source is "captured" for reports read from a controller,
+// as printed by dualsensectl capture, or "synthesized" for reports assembled
+// by hand from the documented layout, which only check the parser against
+// that documentation. Expect holds a subset of the JSON encoding of
+// USBGetStateData; nested objects such as touchData are matched partially too.
+type conformanceCase struct {
+	Source      string         `json:"source"`
+	Description string         `json:"description"`
+	Report      string         `json:"report"`
+	Expect      map[string]any `json:"expect"`
+}
+
+// conformanceParsers decodes the reports of each directory of
+// testdata/conformance.
+var conformanceParsers = map[string]func([]byte) (USBReportIn, error){
+	"usb":       UnmarshalInputReport,
+	"bluetooth": UnmarshalBluetoothInputReport,
+	"edge":      UnmarshalInputReport,
+}
+
+// TestConformance replays the report corpus in testdata/conformance through
+// the parsers. Add a case by running dualsensectl capture with the controller
+// held in the state to test, pruning the printed expectations to the fields
+// that state is about, and dropping the JSON file into the directory of its
+// transport or controller variant. A variant without any captured case is
+// reported as skipped, as its synthesized cases only check the parsers
+// against the documented layout, not against a real controller.
+func TestConformance(t *testing.T) {
+	for variant, parse := range conformanceParsers {
+		paths, err := filepath.Glob(filepath.Join("testdata", "conformance", variant, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(paths) == 0 {
+			t.Errorf("no %s conformance cases found", variant)
+		}
+		captured := 0
+		for _, path := range paths {
+			name := variant + "/" + strings.TrimSuffix(filepath.Base(path), ".json")
+			t.Run(name, func(t *testing.T) {
+				if runConformanceCase(t, path, parse) == "captured" {
+					captured++
+				}
+			})
+		}
+		t.Run(variant+"/captured", func(t *testing.T) {
+			if captured == 0 {
+				t.Skipf("no captured %s conformance cases: the %d cases of testdata/conformance/%s are all synthesized and were never checked against a real controller", variant, len(paths), variant)
+			}
+		})
+	}
+}
+
+// runConformanceCase checks the case at path and returns its source.
+func runConformanceCase(t *testing.T, path string, parse func([]byte) (USBReportIn, error)) string {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c conformanceCase
+	err = json.Unmarshal(encoded, &c)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	if c.Source != "captured" && c.Source != "synthesized" {
+		t.Fatalf("%s: source %q is neither captured nor synthesized", path, c.Source)
+	}
+	data, err := hex.DecodeString(c.Report)
+	if err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	reportIn, err := parse(data)
+	if err != nil {
+		t.Fatalf("%s (%s): %v", path, c.Description, err)
+	}
+	decoded, err := json.Marshal(reportIn.USBGetStateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	err = json.Unmarshal(decoded, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mismatch := range conformanceMismatches("", c.Expect, got) {
+		t.Errorf("%s (%s): %s", path, c.Description, mismatch)
+	}
+	return c.Source
+}
+
+// conformanceMismatches compares the fields of want to those of got, as
+// decoded from JSON, ignoring the fields want does not mention.
+func conformanceMismatches(prefix string, want, got map[string]any) []string {
+	var mismatches []string
+	names := make([]string, 0, len(want))
+	for name := range want {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		gotValue, ok := got[name]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("unknown field %s%s", prefix, name))
+			continue
+		}
+		wantObject, wantIsObject := want[name].(map[string]any)
+		gotObject, gotIsObject := gotValue.(map[string]any)
+		if wantIsObject && gotIsObject {
+			mismatches = append(mismatches, conformanceMismatches(prefix+name+".", wantObject, gotObject)...)
+			continue
+		}
+		if !reflect.DeepEqual(want[name], gotValue) {
+			mismatches = append(mismatches, fmt.Sprintf("%s%s: got %v, want %v", prefix, name, gotValue, want[name]))
+		}
+	}
+	return mismatches
+}
+
+func TestUnmarshalBluetoothInputReportCRC(t *testing.T) {
+	encoded, err := os.ReadFile(filepath.Join("testdata", "conformance", "bluetooth", "neutral.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c conformanceCase
+	if err := json.Unmarshal(encoded, &c); err != nil {
+		t.Fatal(err)
+	}
+	data, err := hex.DecodeString(c.Report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[10] ^= 0x01
+	if _, err := UnmarshalBluetoothInputReport(data); err == nil {
+		t.Error("corrupted report: expected CRC error")
+	}
+	if _, err := UnmarshalBluetoothInputReport(data[:USB_PACKET_SIZE]); err == nil {
+		t.Error("short report: expected error")
+	}
+}
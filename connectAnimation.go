@@ -0,0 +1,87 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+// ConnectAnimationFrame is one step of a ConnectAnimation, shown for Duration.
+type ConnectAnimationFrame struct {
+	Color        LedColor
+	PlayerLights [5]bool
+	Duration     time.Duration
+}
+
+// ConnectAnimation is played on the lightbar and player indicators when the
+// controller is started or reconnected, see WithConnectAnimation.
+type ConnectAnimation []ConnectAnimationFrame
+
+// DefaultConnectAnimation sweeps the player indicators across the pad under a
+// blue lightbar, in under half a second.
+var DefaultConnectAnimation = ConnectAnimation{
+	{Color: LedColor{Blue: 0xFF}, PlayerLights: [5]bool{true, false, false, false, false}, Duration: 60 * time.Millisecond},
+	{Color: LedColor{Blue: 0xFF}, PlayerLights: [5]bool{false, true, false, false, false}, Duration: 60 * time.Millisecond},
+	{Color: LedColor{Blue: 0xFF}, PlayerLights: [5]bool{false, false, true, false, false}, Duration: 60 * time.Millisecond},
+	{Color: LedColor{Blue: 0xFF}, PlayerLights: [5]bool{false, false, false, true, false}, Duration: 60 * time.Millisecond},
+	{Color: LedColor{Blue: 0xFF}, PlayerLights: [5]bool{false, false, false, false, true}, Duration: 60 * time.Millisecond},
+	{Color: LedColor{Green: 0xFF}, PlayerLights: [5]bool{true, true, true, true, true}, Duration: 150 * time.Millisecond},
+}
+
+type connectAnimator struct {
+	mu         sync.Mutex
+	generation int
+	// layer carries the animation over the lightbar and player indicators
+	// as set.
+	layer *OutputLayer
+}
+
+// playConnectAnimation plays the animation set WithConnectAnimation, if any, on
+// its own goroutine, so it may be called from the I/O goroutine. The animation
+// is shown on its own OutputLayer at CONNECT_ANIMATION_LAYER_PRIORITY, so the
+// lightbar and player indicators as set reappear once it ends. A later call
+// replaces an animation still playing.
+func (d *DualSense) playConnectAnimation() {
+	if len(d.connectAnimation) == 0 {
+		return
+	}
+	go d.runConnectAnimation(d.connectAnimation)
+}
+
+func (d *DualSense) runConnectAnimation(animation ConnectAnimation) {
+	d.connectAnim.mu.Lock()
+	d.connectAnim.generation++
+	generation := d.connectAnim.generation
+	d.connectAnim.mu.Unlock()
+
+	current := func() bool {
+		d.connectAnim.mu.Lock()
+		defer d.connectAnim.mu.Unlock()
+		return d.connectAnim.generation == generation
+	}
+	layer := d.connectAnim.layer
+	for _, frame := range animation {
+		if !current() {
+			return
+		}
+		// A closed controller fails every frame.
+		err := layer.update(func() {
+			layer.lightbar = &frame.Color
+			layer.playerLights = &frame.PlayerLights
+		})
+		if err != nil {
+			d.logger.Debug("stopped DualSense connect animation", "error", err)
+			return
+		}
+		time.Sleep(frame.Duration)
+	}
+
+	d.connectAnim.mu.Lock()
+	defer d.connectAnim.mu.Unlock()
+	if d.connectAnim.generation != generation {
+		return
+	}
+	err := layer.Clear()
+	if err != nil {
+		d.logger.Debug("could not end DualSense connect animation", "error", err)
+	}
+}
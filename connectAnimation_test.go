@@ -0,0 +1,89 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func waitOutStates(t *testing.T, d *MockDualSense, count int) []SetStateData {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		outStates := d.OutStates()
+		if len(outStates) >= count {
+			return outStates
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d output reports, want %d", len(outStates), count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConnectAnimation(t *testing.T) {
+	animation := ConnectAnimation{
+		{Color: LedColor{Red: 0xFF}, PlayerLights: [5]bool{true, false, false, false, false}, Duration: time.Millisecond},
+		{Color: LedColor{Green: 0xFF}, PlayerLights: [5]bool{false, false, false, false, true}, Duration: time.Millisecond},
+	}
+	d := NewMockDualSense(WithConnectAnimation(animation))
+	initial := defaultSetStateData
+	LedColor{Blue: 0x40}.apply(&initial)
+	if err := d.Start(&initial); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	outStates := waitOutStates(t, d, 4)
+	for i, frame := range animation {
+		if color, lights := ledColorOf(&outStates[1+i]), playerLightsOf(&outStates[1+i]); color != frame.Color || lights != frame.PlayerLights {
+			t.Errorf("frame %d: got %+v %v, want %+v %v", i, color, lights, frame.Color, frame.PlayerLights)
+		}
+	}
+	if got, want := ledColorOf(&outStates[3]), ledColorOf(&initial); got != want || playerLightsOf(&outStates[3]) != playerLightsOf(&initial) {
+		t.Errorf("lights not restored: got %+v, want %+v", got, want)
+	}
+}
+
+func TestConnectAnimationKeepsLaterChanges(t *testing.T) {
+	animation := ConnectAnimation{
+		{Color: LedColor{Red: 0xFF}, Duration: 20 * time.Millisecond},
+	}
+	d := NewMockDualSense(WithConnectAnimation(animation))
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	waitOutStates(t, d, 2)
+	color := LedColor{Red: 0x10, Green: 0x20, Blue: 0x30}
+	if err := d.SetLedColor(color); err != nil {
+		t.Fatal(err)
+	}
+	// The animation ends by writing the color set meanwhile.
+	deadline := time.Now().Add(time.Second)
+	for {
+		outStates := d.OutStates()
+		if ledColorOf(&outStates[len(outStates)-1]) == color {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got LED color %+v after the animation, want %+v", ledColorOf(&outStates[len(outStates)-1]), color)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := d.LedColor(); got != color {
+		t.Errorf("got LED color %+v, want %+v", got, color)
+	}
+}
+
+func TestNoConnectAnimationByDefault(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	// Without an animation Start spawns nothing that could write later.
+	if outStates := d.OutStates(); len(outStates) != 1 {
+		t.Errorf("got %d output reports, want only the initial one", len(outStates))
+	}
+}
@@ -0,0 +1,136 @@
+package dualsense
+
+// InputSubscriber registers callbacks for input changes.
+type InputSubscriber interface {
+	OnFieldChange(field Field, callback func(FieldChange), options ...SubscriptionOption) (func(), error)
+	OnLeftStickXChange(callback func(uint8), options ...SubscriptionOption)
+	OnLeftStickYChange(callback func(uint8), options ...SubscriptionOption)
+	OnRightStickXChange(callback func(uint8), options ...SubscriptionOption)
+	OnRightStickYChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerLeftChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerRightChange(callback func(uint8), options ...SubscriptionOption)
+	OnDPadChange(callback func(Direction), options ...SubscriptionOption)
+	OnButtonSquareChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonCrossChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonCircleChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonTriangleChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonL1Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonR1Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonL2Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonR2Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonCreateChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonOptionsChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonL3Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonR3Change(callback func(bool), options ...SubscriptionOption)
+	OnButtonHomeChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonPadChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonMuteChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonLeftFunctionChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonRightFunctionChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonLeftPaddleChange(callback func(bool), options ...SubscriptionOption)
+	OnButtonRightPaddleChange(callback func(bool), options ...SubscriptionOption)
+	OnAngularVelocityXChange(callback func(int16), options ...SubscriptionOption)
+	OnAngularVelocityZChange(callback func(int16), options ...SubscriptionOption)
+	OnAngularVelocityYChange(callback func(int16), options ...SubscriptionOption)
+	OnAccelerometerXChange(callback func(int16), options ...SubscriptionOption)
+	OnAccelerometerYChange(callback func(int16), options ...SubscriptionOption)
+	OnAccelerometerZChange(callback func(int16), options ...SubscriptionOption)
+	OnTemperatureChange(callback func(int8), options ...SubscriptionOption)
+	OnTouchFinger1Change(callback func(TouchFinger), options ...SubscriptionOption)
+	OnTouchFinger2Change(callback func(TouchFinger), options ...SubscriptionOption)
+	OnTriggerRightStopLocationChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerRightStatusChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerLeftStopLocationChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerLeftStatusChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerRightEffectChange(callback func(uint8), options ...SubscriptionOption)
+	OnTriggerLeftEffectChange(callback func(uint8), options ...SubscriptionOption)
+	OnPowerPercentChange(callback func(uint8), options ...SubscriptionOption)
+	OnPowerStateChange(callback func(PowerState), options ...SubscriptionOption)
+	OnPluggedHeadphonesChange(callback func(bool), options ...SubscriptionOption)
+	OnPluggedMicChange(callback func(bool), options ...SubscriptionOption)
+	OnMicMutedChange(callback func(bool), options ...SubscriptionOption)
+	OnPluggedUsbDataChange(callback func(bool), options ...SubscriptionOption)
+	OnPluggedExternalMicChange(callback func(bool), options ...SubscriptionOption)
+	OnHapticLowPassFilterChange(callback func(bool), options ...SubscriptionOption)
+}
+
+// OutputWriter reads and updates the output state of a controller.
+type OutputWriter interface {
+	GetOutStateData() SetStateData
+	UpdateState(update func(*SetStateData)) error
+	SetStateData(setStateData SetStateData) error
+	SetEnableRunbleEmulation(enable bool) error
+	SetUseRumbleNotHaptics(useRumbleNotHaptics bool) error
+	SetAllowRightTriggerFFB(allow bool) error
+	SetAllowLeftTriggerFFB(allow bool) error
+	SetAllowHeadphoneVolume(allow bool) error
+	SetAllowSpeakerVolume(allow bool) error
+	SetAllowMicVolume(allow bool) error
+	SetAllowAudioControl(allow bool) error
+	SetAllowMuteLight(allow bool) error
+	SetAllowAudioMute(allow bool) error
+	SetAllowLedColor(allow bool) error
+	SetResetLights(reset bool) error
+	SetAllowPlayerIndicators(allow bool) error
+	SetAllowHapticLowPassFilter(allow bool) error
+	SetAllowMotorPowerLevel(allow bool) error
+	SetAllowAudioControl2(allow bool) error
+	SetRumbleEmulationRight(value uint8) error
+	SetRumbleEmulationLeft(value uint8) error
+	SetVolumeHeadphones(value uint8) error
+	SetVolumeSpeaker(value uint8) error
+	SetVolumeMic(value uint8) error
+	SetMicSelect(value MicSelectType) error
+	SetEchoCancelEnable(enable bool) error
+	SetNoiseCancelEnable(enable bool) error
+	SetOutputPathSelect(value uint8) error
+	SetInputPathSelect(value uint8) error
+	SetMuteLight(value MuteLightMode) error
+	SetTouchPowerSave(enable bool) error
+	SetMotionPowerSave(enable bool) error
+	SetHapticPowerSave(enable bool) error
+	SetAudioPowerSave(enable bool) error
+	SetPowerSave(powerSave PowerSave) error
+	SetMicMute(enable bool) error
+	SetSpeakerMute(enable bool) error
+	SetHeadphoneMute(enable bool) error
+	SetHapticMute(enable bool) error
+	SetRightTriggerFFB(params [11]uint8) error
+	SetLeftTriggerFFB(params [11]uint8) error
+	SetTriggerMotorPowerReduction(level PowerReduction) error
+	SetRumbleMotorPowerReduction(level PowerReduction) error
+	SetSpeakerCompPreGain(gain uint8) error
+	SetBeamformingEnable(enable bool) error
+	SetAllowLightBrightnessChange(allow bool) error
+	SetAllowColorLightFadeAnimation(allow bool) error
+	SetEnableImprovedRumbleEmulation(enable bool) error
+	SetLightFadeAnimation(animation LightFadeAnimation) error
+	SetLightBrightness(brightness LightBrightness) error
+	SetPlayerLight1(enable bool) error
+	SetPlayerLight2(enable bool) error
+	SetPlayerLight3(enable bool) error
+	SetPlayerLight4(enable bool) error
+	SetPlayerLight5(enable bool) error
+	SetPlayerLightFade(enable bool) error
+	SetLedRed(value uint8) error
+	SetLedGreen(value uint8) error
+	SetLedBlue(value uint8) error
+}
+
+// Controller is the public surface shared by the hardware backed DualSense and
+// other implementations such as MockDualSense, so applications can depend on it
+// instead of a concrete type.
+type Controller interface {
+	Start(initialSetStateData *SetStateData) error
+	Close()
+	GetInStateData() USBGetStateData
+	Stats() Stats
+	OnError(callback func(error))
+	InputSubscriber
+	OutputWriter
+}
+
+var (
+	_ Controller = (*DualSense)(nil)
+	_ Controller = (*MockDualSense)(nil)
+)
@@ -0,0 +1,51 @@
+package dualsense
+
+import "testing"
+
+// lightOnCross lights the lightbar red while Cross is held, knowing only the
+// Controller interface.
+func lightOnCross(t *testing.T, c Controller) {
+	t.Helper()
+	c.OnButtonCrossChange(func(pressed bool) {
+		var red uint8
+		if pressed {
+			red = 0xFF
+		}
+		if err := c.UpdateState(func(setStateData *SetStateData) {
+			setStateData.AllowLedColor = true
+			setStateData.LedRed = red
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestController(t *testing.T) {
+	mock := NewMockDualSense()
+	var c Controller = mock
+	if err := c.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	var errs []error
+	c.OnError(func(err error) { errs = append(errs, err) })
+	lightOnCross(t, c)
+
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	if !c.GetInStateData().ButtonCross {
+		t.Fatal("input state not visible through Controller")
+	}
+	if got := c.GetOutStateData().LedRed; got != 0xFF {
+		t.Fatalf("got LedRed %d while Cross is held, want 0xFF", got)
+	}
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = false })
+	if got := c.GetOutStateData().LedRed; got != 0 {
+		t.Fatalf("got LedRed %d after release, want 0", got)
+	}
+	if got := c.Stats().ReportsReceived; got != 2 {
+		t.Fatalf("got %d reports received, want 2", got)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("got errors %v", errs)
+	}
+}
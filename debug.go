@@ -0,0 +1,51 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+)
+
+// DebugSnapshot is the view of a running controller published through expvar
+// and DebugHandler.
+type DebugSnapshot struct {
+	Stats        Stats
+	Latency      LatencyStats
+	InStateData  USBGetStateData
+	OutStateData SetStateData
+}
+
+// DebugSnapshot is safe to call while the controller is running, from any
+// goroutine.
+func (d *DualSense) DebugSnapshot() DebugSnapshot {
+	return DebugSnapshot{
+		Stats:        d.Stats(),
+		Latency:      d.Latency(),
+		InStateData:  d.GetInStateData(),
+		OutStateData: d.GetOutStateData(),
+	}
+}
+
+// PublishExpvar publishes DebugSnapshot under name, making it visible at
+// /debug/vars when the expvar handler is served.
+func (d *DualSense) PublishExpvar(name string) error {
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar.Publish: error trying to publish DualSense debug snapshot: %q is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any {
+		return d.DebugSnapshot()
+	}))
+	return nil
+}
+
+// DebugHandler returns an opt-in http.Handler serving DebugSnapshot as JSON.
+func (d *DualSense) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(d.DebugSnapshot())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error encoding DualSense debug snapshot: %v", err), http.StatusInternalServerError)
+		}
+	})
+}
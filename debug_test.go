@@ -0,0 +1,64 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// expvarRuns makes the names published by tests unique, as expvar cannot
+// unpublish them and -count runs tests again in the same process.
+var expvarRuns atomic.Int64
+
+func TestDebugSnapshot(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+
+	// Snapshots are taken while reports are processed, for the race
+	// detector.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for x := range uint8(100) {
+			d.UpdateInState(func(state *USBGetStateData) {
+				state.LeftStickX = x
+				state.ButtonCross = true
+			})
+		}
+	}()
+	for range 100 {
+		d.DebugSnapshot()
+	}
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	d.DebugHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+	var snapshot DebugSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if !snapshot.InStateData.ButtonCross || snapshot.InStateData.LeftStickX != 99 || snapshot.OutStateData.LedRed != 0x40 {
+		t.Fatalf("got snapshot %+v", snapshot)
+	}
+	if snapshot.Stats.ReportsReceived != 100 {
+		t.Fatalf("got %d reports received, want 100", snapshot.Stats.ReportsReceived)
+	}
+
+	name := fmt.Sprintf("%s-%d", t.Name(), expvarRuns.Add(1))
+	if err := d.PublishExpvar(name); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.PublishExpvar(name); err == nil {
+		t.Fatal("published the same expvar twice")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build cgo
+
+package dualsense
+
+func openDefaultTransport() (Transport, error) {
+	return OpenHIDTransport()
+}
+
+func openDefaultDualShock4Transport() (Transport, error) {
+	return OpenDualShock4HIDTransport()
+}
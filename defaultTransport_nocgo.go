@@ -0,0 +1,11 @@
+//go:build !cgo && linux
+
+package dualsense
+
+func openDefaultTransport() (Transport, error) {
+	return OpenUSBTransport()
+}
+
+func openDefaultDualShock4Transport() (Transport, error) {
+	return OpenDualShock4USBTransport()
+}
@@ -0,0 +1,13 @@
+//go:build !cgo && !linux
+
+package dualsense
+
+import "errors"
+
+func openDefaultTransport() (Transport, error) {
+	return nil, errors.New("error trying to open DualSense controller: no transport available without cgo on this platform, use WithTransport")
+}
+
+func openDefaultDualShock4Transport() (Transport, error) {
+	return nil, errors.New("error trying to open DualShock 4 controller: no transport available without cgo on this platform, use WithTransport")
+}
@@ -0,0 +1,112 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// Diagnostics exposes the parts of the input report this package does not
+// interpret, named as in the reference structures, along with anything out of
+// the ordinary found while parsing. It is meant for protocol exploration.
+type Diagnostics struct {
+	UNK1        bool   // Bit 3 of byte 10, between ButtonMute and ButtonLeftFunction
+	UNK2        uint8  // Byte 11
+	UNK_COUNTER uint32 // Bytes 12 to 15, a counter the Linux driver treats as reserved
+	PluggedUnk1 uint8  // Bits 5 to 7 of byte 54
+	PluggedUnk3 uint8  // Bits 2 to 7 of byte 55
+	// Anomalies describes the values of the report outside their documented
+	// range, such as an unknown report ID or a touch point off the touchpad.
+	Anomalies []string
+	// AnomalousReports counts the input reports with anomalies since the
+	// controller was opened, and MalformedReports the reads discarded
+	// because their length did not match the report layout.
+	AnomalousReports uint64
+	MalformedReports uint64
+}
+
+var knownPowerStates = map[PowerState]bool{
+	PowerStateDischarging:         true,
+	PowerStateCharging:            true,
+	PowerStateComplete:            true,
+	PowerStateAbnormalVoltage:     true,
+	PowerStateAbnormalTemperature: true,
+	PowerStateChargingError:       true,
+}
+
+// UnmarshalDiagnostics extracts the uninterpreted fields and anomalies of a 64
+// byte USB input report, including its report ID. The counters are left zero.
+// Bluetooth reports are diagnosed once converted to the USB layout, keeping
+// their BT_INPUT_REPORT_ID.
+func UnmarshalDiagnostics(data []byte) (Diagnostics, error) {
+	reportIn, err := UnmarshalInputReport(data)
+	if err != nil {
+		return Diagnostics{}, err
+	}
+	return diagnose(data, &reportIn), nil
+}
+
+// diagnose is UnmarshalDiagnostics for a report already parsed into reportIn.
+func diagnose(data []byte, reportIn *USBReportIn) Diagnostics {
+	diagnostics := Diagnostics{
+		UNK1:        getNthLittleEndianBitUint8(data[10], 3) == 1,
+		UNK2:        data[11],
+		UNK_COUNTER: binary.LittleEndian.Uint32(data[12:]),
+		PluggedUnk1: data[54] >> 5,
+		PluggedUnk3: data[55] >> 2,
+	}
+	state := &reportIn.USBGetStateData
+	if reportIn.ReportID != 0x01 && reportIn.ReportID != BT_INPUT_REPORT_ID {
+		diagnostics.Anomalies = append(diagnostics.Anomalies, fmt.Sprintf("unexpected report ID 0x%02X", reportIn.ReportID))
+	}
+	if state.DPad > DirectionNone {
+		diagnostics.Anomalies = append(diagnostics.Anomalies, fmt.Sprintf("DPad %d out of range", state.DPad))
+	}
+	if state.PowerPercent > 10 {
+		diagnostics.Anomalies = append(diagnostics.Anomalies, fmt.Sprintf("PowerPercent %d out of range", state.PowerPercent))
+	}
+	if !knownPowerStates[state.PowerState] {
+		diagnostics.Anomalies = append(diagnostics.Anomalies, fmt.Sprintf("unknown PowerState 0x%X", uint8(state.PowerState)))
+	}
+	for i, finger := range []TouchFinger{state.TouchData.TouchFinger1, state.TouchData.TouchFinger2} {
+		if !finger.NotTouching && (finger.FingerX >= TOUCHPAD_WIDTH || finger.FingerY >= TOUCHPAD_HEIGHT) {
+			diagnostics.Anomalies = append(diagnostics.Anomalies, fmt.Sprintf("TouchFinger%d at (%d, %d) outside the touchpad", i+1, finger.FingerX, finger.FingerY))
+		}
+	}
+	return diagnostics
+}
+
+type diagnosticsTracker struct {
+	mu          sync.Mutex
+	diagnostics Diagnostics
+}
+
+// record keeps the diagnostics of the last input report read.
+func (t *diagnosticsTracker) record(data []byte, reportIn *USBReportIn) {
+	diagnostics := diagnose(data, reportIn)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	diagnostics.AnomalousReports = t.diagnostics.AnomalousReports
+	diagnostics.MalformedReports = t.diagnostics.MalformedReports
+	if len(diagnostics.Anomalies) > 0 {
+		diagnostics.AnomalousReports++
+	}
+	t.diagnostics = diagnostics
+}
+
+func (t *diagnosticsTracker) recordMalformed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.diagnostics.MalformedReports++
+}
+
+// Diagnostics returns the uninterpreted fields and anomalies of the last input
+// report read from the transport, with counters covering every report since
+// the controller was opened.
+func (d *DualSense) Diagnostics() Diagnostics {
+	d.diagnostics.mu.Lock()
+	defer d.diagnostics.mu.Unlock()
+	diagnostics := d.diagnostics.diagnostics
+	diagnostics.Anomalies = append([]string(nil), diagnostics.Anomalies...)
+	return diagnostics
+}
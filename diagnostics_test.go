@@ -0,0 +1,82 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	report := make([]byte, USB_PACKET_SIZE)
+	report[0] = 0x01
+	report[8] = uint8(DirectionNone)
+	report[10] = 0x08 // UNK1
+	report[11] = 0x5A
+	binary.LittleEndian.PutUint32(report[12:], 0xDEADBEEF)
+	report[33] = 0x80 // TouchFinger1 not touching
+	report[37] = 0x80 // TouchFinger2 not touching
+	report[54] = 0xA0
+	report[55] = 0xFC
+	d.QueueRawReport(report)
+
+	anomalous := append([]byte(nil), report...)
+	anomalous[53] = 0x3F // PowerPercent 15, PowerState 3
+	d.QueueRawReport(anomalous)
+	d.QueueRawReport(report[:10])
+	d.Poll()
+
+	got := d.Diagnostics()
+	if !got.UNK1 || got.UNK2 != 0x5A || got.UNK_COUNTER != 0xDEADBEEF || got.PluggedUnk1 != 0x05 || got.PluggedUnk3 != 0x3F {
+		t.Errorf("got %+v", got)
+	}
+	if len(got.Anomalies) != 2 {
+		t.Errorf("got anomalies %q, want PowerPercent and PowerState", got.Anomalies)
+	}
+	if got.AnomalousReports != 1 || got.MalformedReports != 1 {
+		t.Errorf("got %d anomalous and %d malformed reports, want 1 and 1", got.AnomalousReports, got.MalformedReports)
+	}
+
+	clean, err := UnmarshalDiagnostics(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clean.Anomalies) != 0 {
+		t.Errorf("got anomalies %q in a valid report", clean.Anomalies)
+	}
+}
+
+func TestDiagnosticsBluetooth(t *testing.T) {
+	transport := &wirelessMockTransport{wireless: true}
+	d := newDualSense(transport, []Option{WithManualPump()})
+	if err := d.detectReportLayout(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	usb := make([]byte, USB_PACKET_SIZE)
+	usb[0] = 0x01
+	usb[8] = uint8(DirectionNone)
+	usb[11] = 0x5A
+	usb[33] = 0x80 // TouchFinger1 not touching
+	usb[37] = 0x80 // TouchFinger2 not touching
+	transport.inputReports = append(transport.inputReports, bluetoothInputReport(usb))
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := d.Diagnostics()
+	if got.UNK2 != 0x5A {
+		t.Errorf("got UNK2 0x%02X, want 0x5A", got.UNK2)
+	}
+	if len(got.Anomalies) != 0 || got.AnomalousReports != 0 {
+		t.Errorf("got anomalies %q in %d reports, want none in a valid Bluetooth report", got.Anomalies, got.AnomalousReports)
+	}
+}
@@ -0,0 +1,33 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DisconnectTransport is implemented by transports that can drop the
+// Bluetooth link of the controller, as holding the PS button does. The
+// controller is then free to connect to another host.
+type DisconnectTransport interface {
+	Disconnect() error
+}
+
+// Disconnect drops the Bluetooth link of the controller, e.g. to hand it over
+// to another machine. The DualSense has no output report for it, so it relies
+// on the transport implementing DisconnectTransport, as HIDTransport and
+// HIDRawTransport do on Linux given CAP_NET_RAW, and otherwise returns an
+// error wrapping errors.ErrUnsupported. USB connections cannot be dropped.
+func (d *DualSense) Disconnect() error {
+	disconnecter, ok := d.currentTransport().(DisconnectTransport)
+	if !ok {
+		return fmt.Errorf("error trying to disconnect DualSense controller: %w", errors.ErrUnsupported)
+	}
+	var err error
+	d.doIO(func(Transport) {
+		err = disconnecter.Disconnect()
+	})
+	if err != nil {
+		return fmt.Errorf("transport.Disconnect: error trying to disconnect DualSense controller: %w", err)
+	}
+	return nil
+}
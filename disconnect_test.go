@@ -0,0 +1,30 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+type disconnectTransport struct {
+	mockTransport
+	disconnected bool
+}
+
+func (t *disconnectTransport) Disconnect() error {
+	t.disconnected = true
+	return nil
+}
+
+func TestDisconnect(t *testing.T) {
+	if err := NewMockDualSense().Disconnect(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("got %v, want errors.ErrUnsupported", err)
+	}
+	transport := &disconnectTransport{}
+	d := newDualSense(transport, []Option{WithManualPump()})
+	if err := d.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if !transport.disconnected {
+		t.Fatal("transport was not disconnected")
+	}
+}
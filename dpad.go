@@ -0,0 +1,126 @@
+package dualsense
+
+import "sync"
+
+// SOCDMode resolves simultaneous opposing D-pad directions, which arise when
+// the physical D-pad is combined with a synthetic one such as the touchpad's
+// or the left stick's.
+type SOCDMode uint8
+
+const (
+	// SOCDLastInput keeps the direction pressed last, and neither when both
+	// were pressed at once.
+	SOCDLastInput SOCDMode = iota
+	// SOCDNeutral cancels opposing directions out.
+	SOCDNeutral
+	// SOCDUpPriority keeps Up over Down and cancels Left and Right out, as
+	// on leverless arcade controllers.
+	SOCDUpPriority
+)
+
+// DiagonalMode selects how diagonal D-pad directions are reported.
+type DiagonalMode uint8
+
+const (
+	DiagonalsKeep DiagonalMode = iota
+	// DiagonalsHorizontal reports diagonals as their horizontal component.
+	DiagonalsHorizontal
+	// DiagonalsVertical reports diagonals as their vertical component.
+	DiagonalsVertical
+	// DiagonalsNeutral reports diagonals as no direction.
+	DiagonalsNeutral
+)
+
+// DPadPolicy cleans the D-pad before FieldDPad callbacks fire, as fighting
+// game tools expect. The zero value keeps diagonals and resolves opposing
+// directions by last input.
+type DPadPolicy struct {
+	SOCD      SOCDMode
+	Diagonals DiagonalMode
+}
+
+// dPadCleaner applies the DPadPolicy, remembering which directions were held
+// to tell the last input.
+type dPadCleaner struct {
+	mu     sync.Mutex
+	policy DPadPolicy
+	held   uint8
+	// lastVertical and lastHorizontal are the hat bits pressed last on each
+	// axis, both bits of the axis if they were pressed together.
+	lastVertical   uint8
+	lastHorizontal uint8
+}
+
+// SetDPadPolicy sets how the D-pad is cleaned, see DPadPolicy.
+func (d *DualSense) SetDPadPolicy(policy DPadPolicy) {
+	d.dPad.mu.Lock()
+	defer d.dPad.mu.Unlock()
+	d.dPad.policy = policy
+}
+
+// DPadPolicy returns the policy set with SetDPadPolicy.
+func (d *DualSense) DPadPolicy() DPadPolicy {
+	d.dPad.mu.Lock()
+	defer d.dPad.mu.Unlock()
+	return d.dPad.policy
+}
+
+// processDPad combines the physical D-pad with the synthetic ones and cleans
+// the result.
+func (d *DualSense) processDPad(state *USBGetStateData) {
+	hat := dPadHats[state.DPad] | d.touchpadDPadHat(state) | d.stickDPadHat(state)
+	state.DPad = hatDirection(d.dPad.clean(hat))
+}
+
+func (c *dPadCleaner) clean(hat uint8) uint8 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pressed := hat &^ c.held
+	c.held = hat
+	if vertical := pressed & (HatUp | HatDown); vertical != 0 {
+		c.lastVertical = vertical
+	}
+	if horizontal := pressed & (HatLeft | HatRight); horizontal != 0 {
+		c.lastHorizontal = horizontal
+	}
+
+	if hat&(HatUp|HatDown) == HatUp|HatDown {
+		hat &^= HatUp | HatDown
+		switch c.policy.SOCD {
+		case SOCDLastInput:
+			if c.lastVertical != HatUp|HatDown {
+				hat |= c.lastVertical
+			}
+		case SOCDUpPriority:
+			hat |= HatUp
+		}
+	}
+	if hat&(HatLeft|HatRight) == HatLeft|HatRight {
+		hat &^= HatLeft | HatRight
+		if c.policy.SOCD == SOCDLastInput && c.lastHorizontal != HatLeft|HatRight {
+			hat |= c.lastHorizontal
+		}
+	}
+
+	if hat&(HatUp|HatDown) != 0 && hat&(HatLeft|HatRight) != 0 {
+		switch c.policy.Diagonals {
+		case DiagonalsHorizontal:
+			hat &^= HatUp | HatDown
+		case DiagonalsVertical:
+			hat &^= HatLeft | HatRight
+		case DiagonalsNeutral:
+			hat = 0
+		}
+	}
+	return hat
+}
+
+// hatDirection is the Direction of hat bits without opposing directions.
+func hatDirection(hat uint8) Direction {
+	for direction, bits := range dPadHats {
+		if bits == hat {
+			return direction
+		}
+	}
+	return DirectionNone
+}
@@ -0,0 +1,82 @@
+package dualsense
+
+import "testing"
+
+func TestDPadCleaner(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy DPadPolicy
+		hats   []uint8
+		want   []Direction
+	}{
+		{
+			"last input",
+			DPadPolicy{},
+			[]uint8{HatLeft, HatLeft | HatRight, HatRight, HatLeft | HatRight, HatUp | HatDown},
+			[]Direction{DirectionWest, DirectionEast, DirectionEast, DirectionWest, DirectionNone},
+		},
+		{
+			"neutral",
+			DPadPolicy{SOCD: SOCDNeutral},
+			[]uint8{HatLeft, HatLeft | HatRight, HatUp | HatDown | HatRight},
+			[]Direction{DirectionWest, DirectionNone, DirectionEast},
+		},
+		{
+			"up priority",
+			DPadPolicy{SOCD: SOCDUpPriority},
+			[]uint8{HatUp, HatUp | HatDown, HatDown, HatUp | HatDown | HatLeft | HatRight},
+			[]Direction{DirectionNorth, DirectionNorth, DirectionSouth, DirectionNorth},
+		},
+		{
+			"diagonals horizontal",
+			DPadPolicy{Diagonals: DiagonalsHorizontal},
+			[]uint8{HatUp | HatRight, HatDown | HatLeft, HatDown},
+			[]Direction{DirectionEast, DirectionWest, DirectionSouth},
+		},
+		{
+			"diagonals vertical",
+			DPadPolicy{Diagonals: DiagonalsVertical},
+			[]uint8{HatUp | HatRight, HatDown | HatLeft, HatLeft},
+			[]Direction{DirectionNorth, DirectionSouth, DirectionWest},
+		},
+		{
+			"diagonals neutral",
+			DPadPolicy{Diagonals: DiagonalsNeutral},
+			[]uint8{HatUp | HatRight, HatRight},
+			[]Direction{DirectionNone, DirectionEast},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := dPadCleaner{policy: test.policy}
+			for i, hat := range test.hats {
+				if got := hatDirection(c.clean(hat)); got != test.want[i] {
+					t.Errorf("hat %04b: got %s, want %s", hat, got, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDPadPolicy(t *testing.T) {
+	d := NewMockDualSense()
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionNone
+		state.TouchData.TouchFinger1 = TouchFinger{NotTouching: true}
+		state.TouchData.TouchFinger2 = TouchFinger{NotTouching: true}
+	})
+	d.SetTouchpadDPadMode(TouchpadDPad4Way)
+	d.SetDPadPolicy(DPadPolicy{SOCD: SOCDNeutral})
+	if got := d.DPadPolicy(); got.SOCD != SOCDNeutral {
+		t.Fatalf("DPadPolicy() = %+v", got)
+	}
+
+	// The physical D-pad held West while the touchpad points East.
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionWest
+		state.TouchData.TouchFinger1 = TouchFinger{FingerX: 1800, FingerY: 540}
+	})
+	if got := d.GetInStateData().DPad; got != DirectionNone {
+		t.Errorf("got %s, want None", got)
+	}
+}
@@ -1,11 +1,12 @@
 package dualsense
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
-
-	hid "github.com/sstallion/go-hid"
 )
 
 const (
@@ -17,96 +18,216 @@ const (
 )
 
 type callbacks struct {
-	OnLeftStickXChange               []func(uint8)
-	OnLeftStickYChange               []func(uint8)
-	OnRightStickXChange              []func(uint8)
-	OnRightStickYChange              []func(uint8)
-	OnTriggerLeftChange              []func(uint8)
-	OnTriggerRightChange             []func(uint8)
-	OnDPadChange                     []func(Direction)
-	OnButtonSquareChange             []func(bool)
-	OnButtonCrossChange              []func(bool)
-	OnButtonCircleChange             []func(bool)
-	OnButtonTriangleChange           []func(bool)
-	OnButtonL1Change                 []func(bool)
-	OnButtonR1Change                 []func(bool)
-	OnButtonL2Change                 []func(bool)
-	OnButtonR2Change                 []func(bool)
-	OnButtonCreateChange             []func(bool)
-	OnButtonOptionsChange            []func(bool)
-	OnButtonL3Change                 []func(bool)
-	OnButtonR3Change                 []func(bool)
-	OnButtonHomeChange               []func(bool)
-	OnButtonPadChange                []func(bool)
-	OnButtonMuteChange               []func(bool)
-	OnButtonLeftFunctionChange       []func(bool)
-	OnButtonRightFunctionChange      []func(bool)
-	OnButtonLeftPaddleChange         []func(bool)
-	OnButtonRightPaddleChange        []func(bool)
-	OnAngularVelocityXChange         []func(int16)
-	OnAngularVelocityZChange         []func(int16)
-	OnAngularVelocityYChange         []func(int16)
-	OnAccelerometerXChange           []func(int16)
-	OnAccelerometerYChange           []func(int16)
-	OnAccelerometerZChange           []func(int16)
-	OnTemperatureChange              []func(int8)
-	OnTouchFinger1Change             []func(TouchFinger)
-	OnTouchFinger2Change             []func(TouchFinger)
-	OnTriggerRightStopLocationChange []func(uint8)
-	OnTriggerRightStatusChange       []func(uint8)
-	OnTriggerLeftStopLocationChange  []func(uint8)
-	OnTriggerLeftStatusChange        []func(uint8)
-	OnTriggerRightEffectChange       []func(uint8)
-	OnTriggerLeftEffectChange        []func(uint8)
-	OnPowerPercentChange             []func(uint8)
-	OnPowerStateChange               []func(PowerState)
-	OnPluggedHeadphonesChange        []func(bool)
-	OnPluggedMicChange               []func(bool)
-	OnMicMutedChange                 []func(bool)
-	OnPluggedUsbDataChange           []func(bool)
-	OnPluggedExternalMicChange       []func(bool)
-	OnHapticLowPassFilterChange      []func(bool)
+	// subscriptionsMu guards replacing subscriptions, see subscribe.
+	subscriptionsMu          sync.Mutex
+	subscriptions            []*subscription
+	suppressed               [fieldCount]bool
+	OnError                  []func(error)
+	OnReportGap              []func(ReportGap)
+	OnRawReport              []func(uint8, []byte)
+	OnChargeComplete         []func()
+	OnPowerSourceChange      []func(PowerSourceChange)
+	OnIdle                   []func()
+	OnActive                 []func()
+	OnThermalThrottle        []func(ThermalThrottle)
+	OnBatterySaverChange     []func(bool)
+	OnProfileSwitchRequested []func(ProfileSwitchRequest)
+	OnShapeGesture           []func(ShapeGesture)
+	OnTouchpadScroll         []func(TouchpadScroll)
+	OnStickFlick             []func(StickFlick)
+	OnStickClick             []func(StickClick)
+	OnOutputApplied          []func(OutputApplied)
+	OnLifecycleChange        []func(LifecycleChange)
+}
+
+// WriteRetryPolicy controls how failed output report writes are retried. The
+// backoff doubles after every failed attempt, capped at MaxBackoff when set.
+// Retries are written like rate limited output, see SetMaxOutputReportRate:
+// the failed state stays pending, merged with later updates, so a setter whose
+// write failed returns once the retry is scheduled, and only the error of the
+// last attempt is returned or reaches OnError.
+type WriteRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
 type DualSense struct {
-	device           *hid.Device
-	getStateData     USBGetStateData
-	usbReportInClose chan bool
-	setStateData     SetStateData
-	setStateDataMu   sync.Mutex
-	callbacks        callbacks
-	pollingRate      time.Duration
-}
-
-func NewDualSense() (*DualSense, error) {
-	device, err := hid.OpenFirst(DUALSENSE_VENDOR_ID, DUALSENSE_PRODUCT_ID)
-	if err != nil {
-		return nil, fmt.Errorf("error trying to open DualSense controller: %w", err)
+	// transportMu guards transport, readBuffer and reportLayout, which
+	// reconnect swaps on the I/O goroutine.
+	transportMu sync.RWMutex
+	transport   Transport
+	// getStateDataMu guards replacing getStateData, which only the goroutine
+	// processing reports does, so it reads getStateData without locking.
+	getStateDataMu sync.RWMutex
+	getStateData   USBGetStateData
+	setStateData   SetStateData
+	setStateDataMu sync.Mutex
+	callbacks      callbacks
+	pollingRate    time.Duration
+
+	io        atomic.Pointer[ioChannels]
+	lifecycle lifecycle
+
+	setStateDataDirty   bool
+	setStateDataPending chan struct{}
+	outputFlushInterval time.Duration
+	asyncOutput         bool
+	minOutputInterval   time.Duration
+	lastOutputWrite     time.Time
+	keepAliveInterval   time.Duration
+	writeRetryPolicy    WriteRetryPolicy
+	// writeAttempts counts the failed writes of the pending output state,
+	// retried from writeRetryAt on.
+	writeAttempts        int
+	writeRetryAt         time.Time
+	reconnectPolicy      ReconnectPolicy
+	openTransport        func() (Transport, error)
+	label                atomic.Value
+	labels               Labels
+	whitePoints          WhitePoints
+	whitePoint           WhitePoint
+	clampAudioGains      bool
+	lightbarBrightness   float64
+	lightbarReactive     LightbarReactive
+	lightbarAmplitude    float64
+	saverLights          batterySaverLights
+	outputLayers         []*OutputLayer
+	lightbarFadeDuration time.Duration
+	selfTestStepDuration time.Duration
+	selfTestEchoTimeout  time.Duration
+	connectAnimation     ConnectAnimation
+
+	stats        reportStats
+	latency      latencyTracker
+	diagnostics  diagnosticsTracker
+	ioErrors     errorCounter
+	outputAck    outputAckTracker
+	muteLight    muteLightTimer
+	connectAnim  connectAnimator
+	battery      batteryTracker
+	powerSource  powerDebouncer
+	idle         idleTracker
+	powerSave    powerSaveManager
+	thermal      thermalThrottler
+	batteryPower batteryPowerReducer
+	saver        batterySaver
+	motorPower   motorPowerLimiter
+	audioRouter  audioRouter
+	eventHistory eventHistory
+	stateHistory stateHistory
+	shapeStroke  shapeStroke
+	touchpadDPad touchpadDPad
+	stickFlicks  [2]stickFlickTracker
+	dPad         dPadCleaner
+	axisInverter axisInverter
+	stickDPad    stickDPad
+	actuation    triggerActuation
+	hapticTick   hapticTicker
+	logger       *slog.Logger
+
+	manualPump   bool
+	readBuffer   []byte
+	reportLayout ReportLayout
+	// bluetoothBuffer holds a Bluetooth input report converted to the USB
+	// layout, and outputSeq numbers Bluetooth output reports under
+	// setStateDataMu.
+	bluetoothBuffer [USB_PACKET_SIZE]byte
+	outputSeq       uint8
+
+	// callbackQueueSize is set WithAsyncCallbacks.
+	callbackQueueSize int
+	// closed is closed by Close, ending the goroutines that outlive Stop.
+	closed chan struct{}
+}
+
+// NewDualSense opens the first DualSense controller found, through hidapi when
+// built with cgo and through Linux usbfs otherwise, unless a transport is
+// provided WithTransport.
+func NewDualSense(options ...Option) (*DualSense, error) {
+	dualsense := newDualSense(nil, options)
+	openedTransport := dualsense.transport == nil
+	if openedTransport {
+		transport, err := openDefaultTransport()
+		if err != nil {
+			return nil, err
+		}
+		dualsense.transport = transport
+		dualsense.openTransport = openDefaultTransport
 	}
-	err = device.SetNonblock(false)
+	err := dualsense.detectReportLayout()
 	if err != nil {
-		return nil, fmt.Errorf("error trying to set DualSense controller to blocking mode: %w", err)
-	}
-	usbReportInClose := make(chan bool)
-	dualsense := &DualSense{
-		device:           device,
-		usbReportInClose: usbReportInClose,
-		pollingRate:      DEFAULT_POLLING_RATE,
+		if openedTransport {
+			dualsense.transport.Close()
+		}
+		return nil, err
 	}
+	dualsense.enableBluetoothReports()
+	dualsense.applyLabels()
+	dualsense.applyWhitePoints()
 	return dualsense, nil
 }
 
+func newDualSense(transport Transport, options []Option) *DualSense {
+	dualsense := &DualSense{
+		transport:            transport,
+		pollingRate:          DEFAULT_POLLING_RATE,
+		setStateDataPending:  make(chan struct{}, 1),
+		powerSource:          powerDebouncer{settleTime: DEFAULT_POWER_SETTLE_TIME},
+		keepAliveInterval:    DEFAULT_KEEP_ALIVE_INTERVAL,
+		readBuffer:           make([]byte, USB_PACKET_SIZE),
+		reportLayout:         defaultReportLayout,
+		logger:               discardLogger,
+		lightbarBrightness:   100,
+		whitePoint:           neutralWhitePoint,
+		lightbarFadeDuration: LIGHTBAR_FADE_DURATION,
+		selfTestStepDuration: SELF_TEST_STEP_DURATION,
+		selfTestEchoTimeout:  SELF_TEST_ECHO_TIMEOUT,
+		closed:               make(chan struct{}),
+	}
+	for _, option := range options {
+		option(dualsense)
+	}
+	dualsense.saver.layer = dualsense.addOutputLayer(BATTERY_SAVER_LAYER_PRIORITY, MixPreempt)
+	dualsense.hapticTick.layer = dualsense.addOutputLayer(HAPTIC_TICK_LAYER_PRIORITY, MixMax)
+	dualsense.muteLight.layer = dualsense.addOutputLayer(MUTE_LIGHT_LAYER_PRIORITY, MixPreempt)
+	dualsense.connectAnim.layer = dualsense.addOutputLayer(CONNECT_ANIMATION_LAYER_PRIORITY, MixPreempt)
+	dualsense.logger = slog.New(labelHandler{Handler: dualsense.logger.Handler(), label: dualsense.Label})
+	return dualsense
+}
+
+// Start spawns the I/O goroutine, unless created WithManualPump, and writes
+// initialSetStateData, or the default output state when nil. It is accepted
+// once after NewDualSense and again after Stop.
 func (d *DualSense) Start(initialSetStateData *SetStateData) error {
-	go d.listenReportIn()
-	var err error
-	if initialSetStateData == nil {
-		err = d.writeSetStateData(defaultSetStateData)
-	} else {
-		err = d.writeSetStateData(*initialSetStateData)
+	setStateData := defaultSetStateData
+	if initialSetStateData != nil {
+		setStateData = *initialSetStateData
+		err := d.checkOutputRanges(&setStateData)
+		if err != nil {
+			return fmt.Errorf("setStateData: error trying to set initial state data for DualSense controller: %w", err)
+		}
+	}
+	old, err := d.transition("Start", LifecycleStarted, LifecycleCreated, LifecycleStopped)
+	if err != nil {
+		return err
+	}
+	// Written before the I/O goroutine is spawned, so the write cannot race
+	// with it.
+	d.setStateDataMu.Lock()
+	err = d.writeSetStateData(setStateData)
+	d.setStateDataMu.Unlock()
+	if !d.manualPump {
+		channels := newIOChannels()
+		d.io.Store(channels)
+		go d.ioLoop(channels)
 	}
 	if err != nil {
 		return fmt.Errorf("setStateData: error trying to set initial state data for DualSense controller: %w", err)
 	}
+	if old == LifecycleCreated {
+		d.playConnectAnimation()
+	}
 	return nil
 }
 
@@ -114,515 +235,689 @@ func (d *DualSense) SetPollingRate(pollingRateHz int) {
 	d.pollingRate = time.Duration(1000/pollingRateHz) * time.Millisecond
 }
 
-func (d *DualSense) Close() {
-	d.usbReportInClose <- true
-	d.device.Close()
+// SetOutputFlushInterval makes setters only mark the output state dirty, with
+// at most one combined output report sent per interval. An interval of 0
+// restores immediate writes on every setter call.
+func (d *DualSense) SetOutputFlushInterval(interval time.Duration) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.outputFlushInterval = interval
+	if interval == 0 && !d.asyncOutput && d.setStateDataDirty {
+		err := d.writeSetStateData(d.setStateData)
+		if err != nil {
+			return fmt.Errorf("error flushing pending setStateData: %w", err)
+		}
+	}
+	d.signalSetStateDataPending()
+	return nil
 }
 
-func (d *DualSense) readReportIn() (USBReportIn, error) {
-	buffer := make([]byte, USB_PACKET_SIZE)
-	bytesRead, err := d.device.ReadWithTimeout(buffer, DEFAULT_READ_TIMEOUT)
-	if err != nil {
-		return USBReportIn{}, fmt.Errorf("device.ReadWithTimeout: error trying to read DualSense controller input report: %w", err)
-	}
-	if bytesRead != USB_PACKET_SIZE {
-		return USBReportIn{}, fmt.Errorf("device.ReadWithTimeout: error trying to read DualSense controller input report: expected %d bytes, got %d bytes", USB_PACKET_SIZE, bytesRead)
+// SetAsyncOutput moves output report writes off the caller's goroutine. Setters
+// only queue the new state and the I/O goroutine sends the latest one, so
+// setter calls never block on HID I/O and write errors are not returned.
+func (d *DualSense) SetAsyncOutput(async bool) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.asyncOutput = async
+	d.signalSetStateDataPending()
+}
+
+// SetMaxOutputReportRate limits how many output reports are sent per second.
+// Updates arriving faster than the limit are merged into the next report
+// rather than dropped. A rate of 0 removes the limit.
+func (d *DualSense) SetMaxOutputReportRate(reportRateHz int) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	if reportRateHz <= 0 {
+		d.minOutputInterval = 0
+	} else {
+		d.minOutputInterval = time.Second / time.Duration(reportRateHz)
 	}
-	reportIn, err := unpackUSBReportIn(buffer)
+	d.signalSetStateDataPending()
+}
+
+func (d *DualSense) SetWriteRetryPolicy(policy WriteRetryPolicy) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.writeRetryPolicy = policy
+}
+
+// Stop stops the I/O goroutine, leaving the transport open until Start is
+// called again.
+func (d *DualSense) Stop() error {
+	_, err := d.transition("Stop", LifecycleStopped, LifecycleStarted)
 	if err != nil {
-		return USBReportIn{}, fmt.Errorf("unpackUSBReportIn: error trying to unpack DualSense controller input report: %w", err)
+		return err
 	}
-	return reportIn, err
+	d.stopGoroutines()
+	return nil
 }
 
-func (d *DualSense) triggerCallbacks(previousGetStateData USBGetStateData) {
-	if d.getStateData.LeftStickX != previousGetStateData.LeftStickX {
-		for _, callback := range d.callbacks.OnLeftStickXChange {
-			callback(d.getStateData.LeftStickX)
-		}
-	}
-	if d.getStateData.LeftStickY != previousGetStateData.LeftStickY {
-		for _, callback := range d.callbacks.OnLeftStickYChange {
-			callback(d.getStateData.LeftStickY)
-		}
-	}
-	if d.getStateData.RightStickX != previousGetStateData.RightStickX {
-		for _, callback := range d.callbacks.OnRightStickXChange {
-			callback(d.getStateData.RightStickX)
-		}
-	}
-	if d.getStateData.RightStickY != previousGetStateData.RightStickY {
-		for _, callback := range d.callbacks.OnRightStickYChange {
-			callback(d.getStateData.RightStickY)
-		}
-	}
-	if d.getStateData.TriggerLeft != previousGetStateData.TriggerLeft {
-		for _, callback := range d.callbacks.OnTriggerLeftChange {
-			callback(d.getStateData.TriggerLeft)
-		}
-	}
-	if d.getStateData.TriggerRight != previousGetStateData.TriggerRight {
-		for _, callback := range d.callbacks.OnTriggerRightChange {
-			callback(d.getStateData.TriggerRight)
-		}
-	}
-	if d.getStateData.DPad != previousGetStateData.DPad {
-		for _, callback := range d.callbacks.OnDPadChange {
-			callback(d.getStateData.DPad)
-		}
-	}
-	if d.getStateData.ButtonSquare != previousGetStateData.ButtonSquare {
-		for _, callback := range d.callbacks.OnButtonSquareChange {
-			callback(d.getStateData.ButtonSquare)
-		}
-	}
-	if d.getStateData.ButtonCross != previousGetStateData.ButtonCross {
-		for _, callback := range d.callbacks.OnButtonCrossChange {
-			callback(d.getStateData.ButtonCross)
-		}
-	}
-	if d.getStateData.ButtonCircle != previousGetStateData.ButtonCircle {
-		for _, callback := range d.callbacks.OnButtonCircleChange {
-			callback(d.getStateData.ButtonCircle)
-		}
-	}
-	if d.getStateData.ButtonTriangle != previousGetStateData.ButtonTriangle {
-		for _, callback := range d.callbacks.OnButtonTriangleChange {
-			callback(d.getStateData.ButtonTriangle)
-		}
-	}
-	if d.getStateData.ButtonL1 != previousGetStateData.ButtonL1 {
-		for _, callback := range d.callbacks.OnButtonL1Change {
-			callback(d.getStateData.ButtonL1)
-		}
-	}
-	if d.getStateData.ButtonR1 != previousGetStateData.ButtonR1 {
-		for _, callback := range d.callbacks.OnButtonR1Change {
-			callback(d.getStateData.ButtonR1)
-		}
-	}
-	if d.getStateData.ButtonL2 != previousGetStateData.ButtonL2 {
-		for _, callback := range d.callbacks.OnButtonL2Change {
-			callback(d.getStateData.ButtonL2)
-		}
-	}
-	if d.getStateData.ButtonR2 != previousGetStateData.ButtonR2 {
-		for _, callback := range d.callbacks.OnButtonR2Change {
-			callback(d.getStateData.ButtonR2)
-		}
-	}
-	if d.getStateData.ButtonCreate != previousGetStateData.ButtonCreate {
-		for _, callback := range d.callbacks.OnButtonCreateChange {
-			callback(d.getStateData.ButtonCreate)
-		}
+// stopGoroutines stops the goroutines spawned by Start.
+func (d *DualSense) stopGoroutines() {
+	if channels := d.io.Swap(nil); channels != nil {
+		channels.stop()
+		<-channels.done
 	}
-	if d.getStateData.ButtonOptions != previousGetStateData.ButtonOptions {
-		for _, callback := range d.callbacks.OnButtonOptionsChange {
-			callback(d.getStateData.ButtonOptions)
-		}
-	}
-	if d.getStateData.ButtonL3 != previousGetStateData.ButtonL3 {
-		for _, callback := range d.callbacks.OnButtonL3Change {
-			callback(d.getStateData.ButtonL3)
-		}
-	}
-	if d.getStateData.ButtonR3 != previousGetStateData.ButtonR3 {
-		for _, callback := range d.callbacks.OnButtonR3Change {
-			callback(d.getStateData.ButtonR3)
-		}
-	}
-	if d.getStateData.ButtonHome != previousGetStateData.ButtonHome {
-		for _, callback := range d.callbacks.OnButtonHomeChange {
-			callback(d.getStateData.ButtonHome)
-		}
-	}
-	if d.getStateData.ButtonPad != previousGetStateData.ButtonPad {
-		for _, callback := range d.callbacks.OnButtonPadChange {
-			callback(d.getStateData.ButtonPad)
-		}
-	}
-	if d.getStateData.ButtonMute != previousGetStateData.ButtonMute {
-		for _, callback := range d.callbacks.OnButtonMuteChange {
-			callback(d.getStateData.ButtonMute)
-		}
-	}
-	if d.getStateData.ButtonLeftFunction != previousGetStateData.ButtonLeftFunction {
-		for _, callback := range d.callbacks.OnButtonLeftFunctionChange {
-			callback(d.getStateData.ButtonLeftFunction)
-		}
-	}
-	if d.getStateData.ButtonRightFunction != previousGetStateData.ButtonRightFunction {
-		for _, callback := range d.callbacks.OnButtonRightFunctionChange {
-			callback(d.getStateData.ButtonRightFunction)
-		}
-	}
-	if d.getStateData.ButtonLeftPaddle != previousGetStateData.ButtonLeftPaddle {
-		for _, callback := range d.callbacks.OnButtonLeftPaddleChange {
-			callback(d.getStateData.ButtonLeftPaddle)
-		}
+}
+
+// Close stops the controller and closes its transport. Later calls do nothing.
+func (d *DualSense) Close() {
+	old, err := d.transition("Close", LifecycleClosed, LifecycleCreated, LifecycleStarted, LifecycleStopped, LifecycleDisconnected)
+	if err != nil {
+		return
 	}
-	if d.getStateData.ButtonRightPaddle != previousGetStateData.ButtonRightPaddle {
-		for _, callback := range d.callbacks.OnButtonRightPaddleChange {
-			callback(d.getStateData.ButtonRightPaddle)
-		}
+	if old == LifecycleStarted || old == LifecycleDisconnected {
+		d.stopGoroutines()
 	}
-	if d.getStateData.AngularVelocityX != previousGetStateData.AngularVelocityX {
-		for _, callback := range d.callbacks.OnAngularVelocityXChange {
-			callback(d.getStateData.AngularVelocityX)
-		}
+	d.currentTransport().Close()
+	d.eventHistory.closeStreams()
+	d.stopThrottles()
+	d.muteLight.stop()
+	close(d.closed)
+}
+
+// currentTransport returns the transport, which reconnect may swap while
+// other goroutines use it.
+func (d *DualSense) currentTransport() Transport {
+	d.transportMu.RLock()
+	defer d.transportMu.RUnlock()
+	return d.transport
+}
+
+func (d *DualSense) readReportIn(timeout time.Duration) (USBReportIn, error) {
+	bytesRead, err := d.transport.Read(d.readBuffer, timeout)
+	return d.parseReportIn(bytesRead, err)
+}
+
+// parseReportIn parses the result of a read into readBuffer.
+func (d *DualSense) parseReportIn(bytesRead int, err error) (USBReportIn, error) {
+	buffer := d.readBuffer
+	if err != nil {
+		return USBReportIn{}, fmt.Errorf("transport.Read: error trying to read DualSense controller input report: %w", err)
 	}
-	if d.getStateData.AngularVelocityZ != previousGetStateData.AngularVelocityZ {
-		for _, callback := range d.callbacks.OnAngularVelocityZChange {
-			callback(d.getStateData.AngularVelocityZ)
+	if bytesRead > 0 {
+		for _, callback := range d.callbacks.OnRawReport {
+			callback(buffer[0], buffer[1:bytesRead])
 		}
 	}
-	if d.getStateData.AngularVelocityY != previousGetStateData.AngularVelocityY {
-		for _, callback := range d.callbacks.OnAngularVelocityYChange {
-			callback(d.getStateData.AngularVelocityY)
-		}
+	if bytesRead != len(buffer) {
+		d.diagnostics.recordMalformed()
+		return USBReportIn{}, fmt.Errorf("transport.Read: error trying to read DualSense controller input report: expected %d bytes, got %d bytes", len(buffer), bytesRead)
 	}
-	if d.getStateData.AccelerometerX != previousGetStateData.AccelerometerX {
-		for _, callback := range d.callbacks.OnAccelerometerXChange {
-			callback(d.getStateData.AccelerometerX)
+	report := buffer[:USB_PACKET_SIZE]
+	if buffer[0] == BT_INPUT_REPORT_ID {
+		// Bluetooth reports are parsed and diagnosed in the USB layout.
+		err = bluetoothInputToUSB(buffer[:BT_INPUT_REPORT_SIZE], d.bluetoothBuffer[:])
+		if err != nil {
+			d.diagnostics.recordMalformed()
+			return USBReportIn{}, fmt.Errorf("error trying to unpack DualSense controller Bluetooth input report: %w", err)
 		}
+		report = d.bluetoothBuffer[:]
 	}
-	if d.getStateData.AccelerometerY != previousGetStateData.AccelerometerY {
-		for _, callback := range d.callbacks.OnAccelerometerYChange {
-			callback(d.getStateData.AccelerometerY)
-		}
+	reportIn, err := UnmarshalInputReport(report)
+	if err != nil {
+		return USBReportIn{}, fmt.Errorf("UnmarshalInputReport: error trying to unpack DualSense controller input report: %w", err)
 	}
-	if d.getStateData.AccelerometerZ != previousGetStateData.AccelerometerZ {
-		for _, callback := range d.callbacks.OnAccelerometerZChange {
-			callback(d.getStateData.AccelerometerZ)
-		}
+	reportIn.ReportID = buffer[0]
+	d.diagnostics.record(report, &reportIn)
+	return reportIn, err
+}
+
+func (d *DualSense) triggerErrorCallbacks(err error) {
+	for _, callback := range d.callbacks.OnError {
+		callback(err)
+	}
+}
+
+func (d *DualSense) processReportIn(reportIn USBReportIn) {
+	d.latency.recordEcho(reportIn.USBGetStateData.HostTimestamp, time.Now())
+	d.processOutputAck(&reportIn.USBGetStateData, time.Now())
+	d.stats.recordArrival(time.Now())
+	if gap, ok := d.stats.recordSeqNo(reportIn.USBGetStateData.SeqNo); ok {
+		d.logger.Debug("gap in DualSense input report sequence", "expected", gap.ExpectedSeqNo, "received", gap.ReceivedSeqNo, "dropped", gap.Dropped, "outOfOrder", gap.OutOfOrder)
+		for _, callback := range d.callbacks.OnReportGap {
+			callback(gap)
+		}
+	}
+	if d.battery.record(reportIn.USBGetStateData.PowerPercent, reportIn.USBGetStateData.PowerState, time.Now()) {
+		for _, callback := range d.callbacks.OnChargeComplete {
+			callback()
+		}
+	}
+	powerSource := PowerSource{UsbPower: reportIn.USBGetStateData.PluggedUsbPower, PowerState: reportIn.USBGetStateData.PowerState}
+	if change, ok := d.powerSource.record(powerSource, time.Now()); ok {
+		for _, callback := range d.callbacks.OnPowerSourceChange {
+			callback(change)
+		}
+	}
+	d.processIdle(&reportIn.USBGetStateData)
+	d.processPowerSave(&reportIn.USBGetStateData)
+	d.processThermal(&reportIn.USBGetStateData)
+	d.processBatteryPowerReduction(&reportIn.USBGetStateData)
+	d.processBatterySaver(&reportIn.USBGetStateData, time.Now())
+	d.processAudioRouting(&reportIn.USBGetStateData)
+	d.processTriggerActuation(&reportIn.USBGetStateData)
+	d.processAxisInversion(&reportIn.USBGetStateData)
+	d.processDPad(&reportIn.USBGetStateData)
+	previousGetStateData := d.getStateData
+	d.getStateDataMu.Lock()
+	d.getStateData = reportIn.USBGetStateData
+	d.getStateDataMu.Unlock()
+	now := time.Now()
+	d.recordEvents(&d.getStateData, &previousGetStateData, now)
+	d.recordState(&d.getStateData, now)
+	d.processEdgeCombos(&d.getStateData, &previousGetStateData)
+	d.processShapeGestures(&d.getStateData, now)
+	d.processTouchpadScroll(&d.getStateData, &previousGetStateData)
+	d.processStickGestures(&d.getStateData, &previousGetStateData, now)
+	d.triggerCallbacks(&d.getStateData, &previousGetStateData)
+}
+
+// Poll processes every input report already received and flushes pending
+// output state and keep-alive writes that are due, running callbacks on the
+// caller's goroutine. It is meant for DualSense instances created
+// WithManualPump and never blocks waiting for new reports.
+func (d *DualSense) Poll() error {
+	err := d.checkOpen("Poll")
+	if err != nil {
+		return err
 	}
-	if d.getStateData.Temperature != previousGetStateData.Temperature {
-		for _, callback := range d.callbacks.OnTemperatureChange {
-			callback(d.getStateData.Temperature)
+	for {
+		reportIn, err := d.readReportIn(0)
+		if errors.Is(err, ErrTimeout) {
+			break
 		}
-	}
-	if d.getStateData.TouchData.TouchFinger1 != previousGetStateData.TouchData.TouchFinger1 {
-		for _, callback := range d.callbacks.OnTouchFinger1Change {
-			callback(d.getStateData.TouchData.TouchFinger1)
+		if err != nil {
+			d.ioErrors.record(err, false)
+			if errors.Is(err, ErrDisconnected) {
+				d.markDisconnected()
+			}
+			return fmt.Errorf("readReportIn: error trying to poll DualSense controller: %w", err)
 		}
+		d.processReportIn(reportIn)
 	}
-	if d.getStateData.TouchData.TouchFinger2 != previousGetStateData.TouchData.TouchFinger2 {
-		for _, callback := range d.callbacks.OnTouchFinger2Change {
-			callback(d.getStateData.TouchData.TouchFinger2)
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	if d.setStateDataDirty && d.outputReportDelay() == 0 && time.Since(d.lastOutputWrite) >= d.outputFlushInterval {
+		err := d.writeSetStateData(d.setStateData)
+		if err != nil {
+			return fmt.Errorf("error flushing pending setStateData: %w", err)
 		}
-	}
-	if d.getStateData.TriggerRightStopLocation != previousGetStateData.TriggerRightStopLocation {
-		for _, callback := range d.callbacks.OnTriggerRightStopLocationChange {
-			callback(d.getStateData.TriggerRightStopLocation)
+	} else if d.keepAliveDelay() == 0 {
+		err := d.writeSetStateData(d.setStateData)
+		if err != nil {
+			return fmt.Errorf("error sending keep-alive setStateData: %w", err)
 		}
 	}
-	if d.getStateData.TriggerRightStatus != previousGetStateData.TriggerRightStatus {
-		for _, callback := range d.callbacks.OnTriggerRightStatusChange {
-			callback(d.getStateData.TriggerRightStatus)
-		}
+	return nil
+}
+
+// transformOutput turns the output state as set into the one written: output
+// layers mixed in, then the reactive lightbar, white point and brightness
+// applied. It must be called with setStateDataMu held.
+func (d *DualSense) transformOutput(setStateData *SetStateData) {
+	d.mixOutputLayers(setStateData)
+	d.applyLightbarReactive(setStateData)
+	d.calibrateLightbar(setStateData)
+	d.scaleLightbar(setStateData)
+}
+
+func (d *DualSense) writeSetStateData(setStateData SetStateData) error {
+	outgoingSetStateData := setStateData
+	stamped := d.latency.isEnabled() || d.outputAck.isEnabled()
+	if stamped {
+		outgoingSetStateData.HostTimestamp = d.latency.nextHostTimestamp()
 	}
-	if d.getStateData.TriggerLeftStopLocation != previousGetStateData.TriggerLeftStopLocation {
-		for _, callback := range d.callbacks.OnTriggerLeftStopLocationChange {
-			callback(d.getStateData.TriggerLeftStopLocation)
-		}
+	d.transformOutput(&outgoingSetStateData)
+	var packedReportOut []byte
+	var err error
+	if d.wireless() {
+		packedReportOut, err = MarshalBluetoothOutputReport(outgoingSetStateData, d.outputSeq)
+		d.outputSeq++
+	} else {
+		packedReportOut, err = MarshalOutputReport(outgoingSetStateData)
 	}
-	if d.getStateData.TriggerLeftStatus != previousGetStateData.TriggerLeftStatus {
-		for _, callback := range d.callbacks.OnTriggerLeftStatusChange {
-			callback(d.getStateData.TriggerLeftStatus)
-		}
+	if err != nil {
+		return fmt.Errorf("MarshalOutputReport: error trying to pack DualSense controller output report: %w", err)
 	}
-	if d.getStateData.TriggerRightEffect != previousGetStateData.TriggerRightEffect {
-		for _, callback := range d.callbacks.OnTriggerRightEffectChange {
-			callback(d.getStateData.TriggerRightEffect)
-		}
+	// Bluetooth reports end in their CRC32, so only USB ones are padded.
+	if outputSize := d.ReportLayout().Output[0x02]; outputSize > len(packedReportOut) && packedReportOut[0] == 0x02 {
+		packedReportOut = append(packedReportOut, make([]byte, outputSize-len(packedReportOut))...)
 	}
-	if d.getStateData.TriggerLeftEffect != previousGetStateData.TriggerLeftEffect {
-		for _, callback := range d.callbacks.OnTriggerLeftEffectChange {
-			callback(d.getStateData.TriggerLeftEffect)
+	d.doIO(func(transport Transport) {
+		_, err = transport.Write(packedReportOut)
+	})
+	d.lastOutputWrite = time.Now()
+	if err != nil {
+		d.writeAttempts++
+		if d.writeAttempts < d.writeRetryPolicy.MaxAttempts {
+			backoff := d.writeRetryBackoff()
+			d.logger.Debug("retrying DualSense output report write", "attempt", d.writeAttempts, "backoff", backoff, "error", err)
+			d.writeRetryAt = d.lastOutputWrite.Add(backoff)
+			d.setStateData = setStateData
+			d.setStateDataDirty = true
+			d.signalSetStateDataPending()
+			return nil
+		}
+		attempts := d.writeAttempts
+		d.writeAttempts, d.writeRetryAt = 0, time.Time{}
+		err = fmt.Errorf("transport.Write: error trying to write DualSense controller output report after %d attempt(s): %w", attempts, err)
+		d.logger.Error("failed to write DualSense output report", "attempts", attempts, "error", err)
+		d.ioErrors.record(err, true)
+		d.triggerErrorCallbacks(err)
+	} else {
+		d.writeAttempts, d.writeRetryAt = 0, time.Time{}
+		d.setStateData = setStateData
+		d.setStateDataDirty = false
+		if d.latency.isEnabled() {
+			d.latency.recordSent(outgoingSetStateData.HostTimestamp, d.lastOutputWrite)
 		}
-	}
-	if d.getStateData.PowerPercent != previousGetStateData.PowerPercent {
-		for _, callback := range d.callbacks.OnPowerPercentChange {
-			callback(d.getStateData.PowerPercent)
+		if stamped {
+			d.outputAck.recordSent(outgoingSetStateData.HostTimestamp, outgoingSetStateData, d.lastOutputWrite)
 		}
 	}
-	if d.getStateData.PowerState != previousGetStateData.PowerState {
-		for _, callback := range d.callbacks.OnPowerStateChange {
-			callback(d.getStateData.PowerState)
-		}
+	return err
+}
+
+// checkOutputRanges rejects output values the report cannot represent or the
+// controller does not accept.
+func (d *DualSense) checkOutputRanges(setStateData *SetStateData) error {
+	err := d.checkAudioGains(setStateData)
+	if err != nil {
+		return err
 	}
-	if d.getStateData.PluggedHeadphones != previousGetStateData.PluggedHeadphones {
-		for _, callback := range d.callbacks.OnPluggedHeadphonesChange {
-			callback(d.getStateData.PluggedHeadphones)
-		}
+	return validatePowerReductions(setStateData)
+}
+
+// applySetStateData must be called with setStateDataMu held. Values are
+// checked first, see checkOutputRanges, and nothing is accepted once the
+// controller is closed or disconnected. When an output flush interval is
+// configured or async output is enabled the new state is only marked dirty and
+// left for the I/O goroutine to write.
+func (d *DualSense) applySetStateData(setStateData SetStateData) error {
+	err := d.checkOpen("applySetStateData")
+	if err != nil {
+		return err
 	}
-	if d.getStateData.PluggedMic != previousGetStateData.PluggedMic {
-		for _, callback := range d.callbacks.OnPluggedMicChange {
-			callback(d.getStateData.PluggedMic)
-		}
+	err = d.checkOutputRanges(&setStateData)
+	if err != nil {
+		return err
 	}
-	if d.getStateData.MicMuted != previousGetStateData.MicMuted {
-		for _, callback := range d.callbacks.OnMicMutedChange {
-			callback(d.getStateData.MicMuted)
-		}
+	if d.outputFlushInterval == 0 && !d.asyncOutput && d.outputReportDelay() == 0 {
+		return d.writeSetStateData(setStateData)
 	}
-	if d.getStateData.PluggedUsbData != previousGetStateData.PluggedUsbData {
-		for _, callback := range d.callbacks.OnPluggedUsbDataChange {
-			callback(d.getStateData.PluggedUsbData)
-		}
+	d.setStateData = setStateData
+	d.setStateDataDirty = true
+	if d.outputFlushInterval == 0 {
+		d.signalSetStateDataPending()
 	}
-	if d.getStateData.PluggedExternalMic != previousGetStateData.PluggedExternalMic {
-		for _, callback := range d.callbacks.OnPluggedExternalMicChange {
-			callback(d.getStateData.PluggedExternalMic)
-		}
+	return nil
+}
+
+// outputReportDelay returns how long the next output report has to wait to stay
+// within the configured maximum output report rate, or for the backoff of a
+// write retry. It must be called with setStateDataMu held.
+func (d *DualSense) outputReportDelay() time.Duration {
+	delay := time.Until(d.writeRetryAt)
+	if d.minOutputInterval > 0 {
+		delay = max(delay, d.minOutputInterval-time.Since(d.lastOutputWrite))
 	}
-	if d.getStateData.HapticLowPassFilter != previousGetStateData.HapticLowPassFilter {
-		for _, callback := range d.callbacks.OnHapticLowPassFilterChange {
-			callback(d.getStateData.HapticLowPassFilter)
-		}
+	if delay < 0 {
+		return 0
 	}
+	return delay
 }
 
-func (d *DualSense) listenReportIn() {
-	for {
-		select {
-		case <-d.usbReportInClose:
-			return
-		default:
-			reportIn, err := d.readReportIn()
-			if err == nil {
-				previousGetStateData := d.getStateData
-				d.getStateData = reportIn.USBGetStateData
-				d.triggerCallbacks(previousGetStateData)
-			}
-			time.Sleep(d.pollingRate)
+// writeRetryBackoff returns the backoff after writeAttempts failed attempts.
+// It must be called with setStateDataMu held.
+func (d *DualSense) writeRetryBackoff() time.Duration {
+	backoff := d.writeRetryPolicy.InitialBackoff
+	for range d.writeAttempts - 1 {
+		backoff *= 2
+		if d.writeRetryPolicy.MaxBackoff > 0 && backoff >= d.writeRetryPolicy.MaxBackoff {
+			return d.writeRetryPolicy.MaxBackoff
 		}
 	}
+	return backoff
 }
 
-func (d *DualSense) writeSetStateData(setStateData SetStateData) error {
-	packedUSBReportOut, err := packUSBReportOut(setStateData)
-	if err != nil {
-		return fmt.Errorf("packUSBReportOut: error trying to pack DualSense controller output report: %w", err)
-	}
-	_, err = d.device.Write(packedUSBReportOut)
-	if err != nil {
-		err = fmt.Errorf("device.Write: error trying to write DualSense controller output report: %w", err)
-	} else {
-		d.setStateData = setStateData
+// signalSetStateDataPending wakes the writer without blocking. The channel holds
+// at most one signal, so queued updates collapse into a single write of the
+// latest state.
+func (d *DualSense) signalSetStateDataPending() {
+	select {
+	case d.setStateDataPending <- struct{}{}:
+	default:
 	}
-	return err
 }
 
 func (d *DualSense) GetInStateData() USBGetStateData {
+	d.getStateDataMu.RLock()
+	defer d.getStateDataMu.RUnlock()
 	return d.getStateData
 }
 
 func (d *DualSense) GetOutStateData() SetStateData {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	return d.setStateData
 }
 
-func (d *DualSense) OnLeftStickXChange(callback func(uint8)) {
-	d.callbacks.OnLeftStickXChange = append(d.callbacks.OnLeftStickXChange, callback)
+func (d *DualSense) OnLeftStickXChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldLeftStickX, func(current, _ *USBGetStateData) {
+		callback(current.LeftStickX)
+	}, options)
+}
+
+func (d *DualSense) OnLeftStickYChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldLeftStickY, func(current, _ *USBGetStateData) {
+		callback(current.LeftStickY)
+	}, options)
 }
 
-func (d *DualSense) OnLeftStickYChange(callback func(uint8)) {
-	d.callbacks.OnLeftStickYChange = append(d.callbacks.OnLeftStickYChange, callback)
+func (d *DualSense) OnRightStickXChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldRightStickX, func(current, _ *USBGetStateData) {
+		callback(current.RightStickX)
+	}, options)
 }
 
-func (d *DualSense) OnRightStickXChange(callback func(uint8)) {
-	d.callbacks.OnRightStickXChange = append(d.callbacks.OnRightStickXChange, callback)
+func (d *DualSense) OnRightStickYChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldRightStickY, func(current, _ *USBGetStateData) {
+		callback(current.RightStickY)
+	}, options)
 }
 
-func (d *DualSense) OnRightStickYChange(callback func(uint8)) {
-	d.callbacks.OnRightStickYChange = append(d.callbacks.OnRightStickYChange, callback)
+func (d *DualSense) OnTriggerLeftChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerLeft, func(current, _ *USBGetStateData) {
+		callback(current.TriggerLeft)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerLeftChange(callback func(uint8)) {
-	d.callbacks.OnTriggerLeftChange = append(d.callbacks.OnTriggerLeftChange, callback)
+func (d *DualSense) OnTriggerRightChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerRight, func(current, _ *USBGetStateData) {
+		callback(current.TriggerRight)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerRightChange(callback func(uint8)) {
-	d.callbacks.OnTriggerRightChange = append(d.callbacks.OnTriggerRightChange, callback)
+func (d *DualSense) OnDPadChange(callback func(Direction), options ...SubscriptionOption) {
+	d.subscribe(FieldDPad, func(current, _ *USBGetStateData) {
+		callback(current.DPad)
+	}, options)
 }
 
-func (d *DualSense) OnDPadChange(callback func(Direction)) {
-	d.callbacks.OnDPadChange = append(d.callbacks.OnDPadChange, callback)
+func (d *DualSense) OnButtonSquareChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonSquare, func(current, _ *USBGetStateData) {
+		callback(current.ButtonSquare)
+	}, options)
 }
 
-func (d *DualSense) OnButtonSquareChange(callback func(bool)) {
-	d.callbacks.OnButtonSquareChange = append(d.callbacks.OnButtonSquareChange, callback)
+func (d *DualSense) OnButtonCrossChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonCross, func(current, _ *USBGetStateData) {
+		callback(current.ButtonCross)
+	}, options)
 }
 
-func (d *DualSense) OnButtonCrossChange(callback func(bool)) {
-	d.callbacks.OnButtonCrossChange = append(d.callbacks.OnButtonCrossChange, callback)
+func (d *DualSense) OnButtonCircleChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonCircle, func(current, _ *USBGetStateData) {
+		callback(current.ButtonCircle)
+	}, options)
 }
 
-func (d *DualSense) OnButtonCircleChange(callback func(bool)) {
-	d.callbacks.OnButtonCircleChange = append(d.callbacks.OnButtonCircleChange, callback)
+func (d *DualSense) OnButtonTriangleChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonTriangle, func(current, _ *USBGetStateData) {
+		callback(current.ButtonTriangle)
+	}, options)
 }
 
-func (d *DualSense) OnButtonTriangleChange(callback func(bool)) {
-	d.callbacks.OnButtonTriangleChange = append(d.callbacks.OnButtonTriangleChange, callback)
+func (d *DualSense) OnButtonL1Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonL1, func(current, _ *USBGetStateData) {
+		callback(current.ButtonL1)
+	}, options)
 }
 
-func (d *DualSense) OnButtonL1Change(callback func(bool)) {
-	d.callbacks.OnButtonL1Change = append(d.callbacks.OnButtonL1Change, callback)
+func (d *DualSense) OnButtonR1Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonR1, func(current, _ *USBGetStateData) {
+		callback(current.ButtonR1)
+	}, options)
 }
 
-func (d *DualSense) OnButtonR1Change(callback func(bool)) {
-	d.callbacks.OnButtonR1Change = append(d.callbacks.OnButtonR1Change, callback)
+func (d *DualSense) OnButtonL2Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonL2, func(current, _ *USBGetStateData) {
+		callback(current.ButtonL2)
+	}, options)
 }
 
-func (d *DualSense) OnButtonL2Change(callback func(bool)) {
-	d.callbacks.OnButtonL2Change = append(d.callbacks.OnButtonL2Change, callback)
+func (d *DualSense) OnButtonR2Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonR2, func(current, _ *USBGetStateData) {
+		callback(current.ButtonR2)
+	}, options)
 }
 
-func (d *DualSense) OnButtonR2Change(callback func(bool)) {
-	d.callbacks.OnButtonR2Change = append(d.callbacks.OnButtonR2Change, callback)
+func (d *DualSense) OnButtonCreateChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonCreate, func(current, _ *USBGetStateData) {
+		callback(current.ButtonCreate)
+	}, options)
 }
 
-func (d *DualSense) OnButtonCreateChange(callback func(bool)) {
-	d.callbacks.OnButtonCreateChange = append(d.callbacks.OnButtonCreateChange, callback)
+func (d *DualSense) OnButtonOptionsChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonOptions, func(current, _ *USBGetStateData) {
+		callback(current.ButtonOptions)
+	}, options)
 }
 
-func (d *DualSense) OnButtonOptionsChange(callback func(bool)) {
-	d.callbacks.OnButtonOptionsChange = append(d.callbacks.OnButtonOptionsChange, callback)
+func (d *DualSense) OnButtonL3Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonL3, func(current, _ *USBGetStateData) {
+		callback(current.ButtonL3)
+	}, options)
 }
 
-func (d *DualSense) OnButtonL3Change(callback func(bool)) {
-	d.callbacks.OnButtonL3Change = append(d.callbacks.OnButtonL3Change, callback)
+func (d *DualSense) OnButtonR3Change(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonR3, func(current, _ *USBGetStateData) {
+		callback(current.ButtonR3)
+	}, options)
 }
 
-func (d *DualSense) OnButtonR3Change(callback func(bool)) {
-	d.callbacks.OnButtonR3Change = append(d.callbacks.OnButtonR3Change, callback)
+func (d *DualSense) OnButtonHomeChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonHome, func(current, _ *USBGetStateData) {
+		callback(current.ButtonHome)
+	}, options)
 }
 
-func (d *DualSense) OnButtonHomeChange(callback func(bool)) {
-	d.callbacks.OnButtonHomeChange = append(d.callbacks.OnButtonHomeChange, callback)
+func (d *DualSense) OnButtonPadChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonPad, func(current, _ *USBGetStateData) {
+		callback(current.ButtonPad)
+	}, options)
 }
 
-func (d *DualSense) OnButtonPadChange(callback func(bool)) {
-	d.callbacks.OnButtonPadChange = append(d.callbacks.OnButtonPadChange, callback)
+func (d *DualSense) OnButtonMuteChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonMute, func(current, _ *USBGetStateData) {
+		callback(current.ButtonMute)
+	}, options)
 }
 
-func (d *DualSense) OnButtonMuteChange(callback func(bool)) {
-	d.callbacks.OnButtonMuteChange = append(d.callbacks.OnButtonMuteChange, callback)
+func (d *DualSense) OnButtonLeftFunctionChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonLeftFunction, func(current, _ *USBGetStateData) {
+		callback(current.ButtonLeftFunction)
+	}, options)
 }
 
-func (d *DualSense) OnButtonLeftFunctionChange(callback func(bool)) {
-	d.callbacks.OnButtonLeftFunctionChange = append(d.callbacks.OnButtonLeftFunctionChange, callback)
+func (d *DualSense) OnButtonRightFunctionChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonRightFunction, func(current, _ *USBGetStateData) {
+		callback(current.ButtonRightFunction)
+	}, options)
 }
 
-func (d *DualSense) OnButtonRightFunctionChange(callback func(bool)) {
-	d.callbacks.OnButtonRightFunctionChange = append(d.callbacks.OnButtonRightFunctionChange, callback)
+func (d *DualSense) OnButtonLeftPaddleChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonLeftPaddle, func(current, _ *USBGetStateData) {
+		callback(current.ButtonLeftPaddle)
+	}, options)
 }
 
-func (d *DualSense) OnButtonLeftPaddleChange(callback func(bool)) {
-	d.callbacks.OnButtonLeftPaddleChange = append(d.callbacks.OnButtonLeftPaddleChange, callback)
+func (d *DualSense) OnButtonRightPaddleChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldButtonRightPaddle, func(current, _ *USBGetStateData) {
+		callback(current.ButtonRightPaddle)
+	}, options)
 }
 
-func (d *DualSense) OnButtonRightPaddleChange(callback func(bool)) {
-	d.callbacks.OnButtonRightPaddleChange = append(d.callbacks.OnButtonRightPaddleChange, callback)
+func (d *DualSense) OnAngularVelocityXChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAngularVelocityX, func(current, _ *USBGetStateData) {
+		callback(current.AngularVelocityX)
+	}, options)
 }
 
-func (d *DualSense) OnAngularVelocityXChange(callback func(int16)) {
-	d.callbacks.OnAngularVelocityXChange = append(d.callbacks.OnAngularVelocityXChange, callback)
+func (d *DualSense) OnAngularVelocityZChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAngularVelocityZ, func(current, _ *USBGetStateData) {
+		callback(current.AngularVelocityZ)
+	}, options)
 }
 
-func (d *DualSense) OnAngularVelocityZChange(callback func(int16)) {
-	d.callbacks.OnAngularVelocityZChange = append(d.callbacks.OnAngularVelocityZChange, callback)
+func (d *DualSense) OnAngularVelocityYChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAngularVelocityY, func(current, _ *USBGetStateData) {
+		callback(current.AngularVelocityY)
+	}, options)
 }
 
-func (d *DualSense) OnAngularVelocityYChange(callback func(int16)) {
-	d.callbacks.OnAngularVelocityYChange = append(d.callbacks.OnAngularVelocityYChange, callback)
+func (d *DualSense) OnAccelerometerXChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAccelerometerX, func(current, _ *USBGetStateData) {
+		callback(current.AccelerometerX)
+	}, options)
 }
 
-func (d *DualSense) OnAccelerometerXChange(callback func(int16)) {
-	d.callbacks.OnAccelerometerXChange = append(d.callbacks.OnAccelerometerXChange, callback)
+func (d *DualSense) OnAccelerometerYChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAccelerometerY, func(current, _ *USBGetStateData) {
+		callback(current.AccelerometerY)
+	}, options)
 }
 
-func (d *DualSense) OnAccelerometerYChange(callback func(int16)) {
-	d.callbacks.OnAccelerometerYChange = append(d.callbacks.OnAccelerometerYChange, callback)
+func (d *DualSense) OnAccelerometerZChange(callback func(int16), options ...SubscriptionOption) {
+	d.subscribe(FieldAccelerometerZ, func(current, _ *USBGetStateData) {
+		callback(current.AccelerometerZ)
+	}, options)
 }
 
-func (d *DualSense) OnAccelerometerZChange(callback func(int16)) {
-	d.callbacks.OnAccelerometerZChange = append(d.callbacks.OnAccelerometerZChange, callback)
+func (d *DualSense) OnTemperatureChange(callback func(int8), options ...SubscriptionOption) {
+	d.subscribe(FieldTemperature, func(current, _ *USBGetStateData) {
+		callback(current.Temperature)
+	}, options)
 }
 
-func (d *DualSense) OnTemperatureChange(callback func(int8)) {
-	d.callbacks.OnTemperatureChange = append(d.callbacks.OnTemperatureChange, callback)
+func (d *DualSense) OnTouchFinger1Change(callback func(TouchFinger), options ...SubscriptionOption) {
+	d.subscribe(FieldTouchFinger1, func(current, _ *USBGetStateData) {
+		callback(current.TouchData.TouchFinger1)
+	}, options)
 }
 
-func (d *DualSense) OnTouchFinger1Change(callback func(TouchFinger)) {
-	d.callbacks.OnTouchFinger1Change = append(d.callbacks.OnTouchFinger1Change, callback)
+func (d *DualSense) OnTouchFinger2Change(callback func(TouchFinger), options ...SubscriptionOption) {
+	d.subscribe(FieldTouchFinger2, func(current, _ *USBGetStateData) {
+		callback(current.TouchData.TouchFinger2)
+	}, options)
 }
 
-func (d *DualSense) OnTouchFinger2Change(callback func(TouchFinger)) {
-	d.callbacks.OnTouchFinger2Change = append(d.callbacks.OnTouchFinger2Change, callback)
+func (d *DualSense) OnTriggerRightStopLocationChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerRightStopLocation, func(current, _ *USBGetStateData) {
+		callback(current.TriggerRightStopLocation)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerRightStopLocationChange(callback func(uint8)) {
-	d.callbacks.OnTriggerRightStopLocationChange = append(d.callbacks.OnTriggerRightStopLocationChange, callback)
+func (d *DualSense) OnTriggerRightStatusChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerRightStatus, func(current, _ *USBGetStateData) {
+		callback(current.TriggerRightStatus)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerRightStatusChange(callback func(uint8)) {
-	d.callbacks.OnTriggerRightStatusChange = append(d.callbacks.OnTriggerRightStatusChange, callback)
+func (d *DualSense) OnTriggerLeftStopLocationChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerLeftStopLocation, func(current, _ *USBGetStateData) {
+		callback(current.TriggerLeftStopLocation)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerLeftStopLocationChange(callback func(uint8)) {
-	d.callbacks.OnTriggerLeftStopLocationChange = append(d.callbacks.OnTriggerLeftStopLocationChange, callback)
+func (d *DualSense) OnTriggerLeftStatusChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerLeftStatus, func(current, _ *USBGetStateData) {
+		callback(current.TriggerLeftStatus)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerLeftStatusChange(callback func(uint8)) {
-	d.callbacks.OnTriggerLeftStatusChange = append(d.callbacks.OnTriggerLeftStatusChange, callback)
+func (d *DualSense) OnTriggerRightEffectChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerRightEffect, func(current, _ *USBGetStateData) {
+		callback(current.TriggerRightEffect)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerRightEffectChange(callback func(uint8)) {
-	d.callbacks.OnTriggerRightEffectChange = append(d.callbacks.OnTriggerRightEffectChange, callback)
+func (d *DualSense) OnTriggerLeftEffectChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldTriggerLeftEffect, func(current, _ *USBGetStateData) {
+		callback(current.TriggerLeftEffect)
+	}, options)
 }
 
-func (d *DualSense) OnTriggerLeftEffectChange(callback func(uint8)) {
-	d.callbacks.OnTriggerLeftEffectChange = append(d.callbacks.OnTriggerLeftEffectChange, callback)
+func (d *DualSense) OnPowerPercentChange(callback func(uint8), options ...SubscriptionOption) {
+	d.subscribe(FieldPowerPercent, func(current, _ *USBGetStateData) {
+		callback(current.PowerPercent)
+	}, options)
 }
 
-func (d *DualSense) OnPowerPercentChange(callback func(uint8)) {
-	d.callbacks.OnPowerPercentChange = append(d.callbacks.OnPowerPercentChange, callback)
+func (d *DualSense) OnPowerStateChange(callback func(PowerState), options ...SubscriptionOption) {
+	d.subscribe(FieldPowerState, func(current, _ *USBGetStateData) {
+		callback(current.PowerState)
+	}, options)
 }
 
-func (d *DualSense) OnPowerStateChange(callback func(PowerState)) {
-	d.callbacks.OnPowerStateChange = append(d.callbacks.OnPowerStateChange, callback)
+func (d *DualSense) OnPluggedHeadphonesChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldPluggedHeadphones, func(current, _ *USBGetStateData) {
+		callback(current.PluggedHeadphones)
+	}, options)
 }
 
-func (d *DualSense) OnPluggedHeadphonesChange(callback func(bool)) {
-	d.callbacks.OnPluggedHeadphonesChange = append(d.callbacks.OnPluggedHeadphonesChange, callback)
+func (d *DualSense) OnPluggedMicChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldPluggedMic, func(current, _ *USBGetStateData) {
+		callback(current.PluggedMic)
+	}, options)
 }
 
-func (d *DualSense) OnPluggedMicChange(callback func(bool)) {
-	d.callbacks.OnPluggedMicChange = append(d.callbacks.OnPluggedMicChange, callback)
+func (d *DualSense) OnMicMutedChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldMicMuted, func(current, _ *USBGetStateData) {
+		callback(current.MicMuted)
+	}, options)
 }
 
-func (d *DualSense) OnMicMutedChange(callback func(bool)) {
-	d.callbacks.OnMicMutedChange = append(d.callbacks.OnMicMutedChange, callback)
+func (d *DualSense) OnPluggedUsbDataChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldPluggedUsbData, func(current, _ *USBGetStateData) {
+		callback(current.PluggedUsbData)
+	}, options)
 }
 
-func (d *DualSense) OnPluggedUsbDataChange(callback func(bool)) {
-	d.callbacks.OnPluggedUsbDataChange = append(d.callbacks.OnPluggedUsbDataChange, callback)
+func (d *DualSense) OnPluggedExternalMicChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldPluggedExternalMic, func(current, _ *USBGetStateData) {
+		callback(current.PluggedExternalMic)
+	}, options)
 }
 
-func (d *DualSense) OnPluggedExternalMicChange(callback func(bool)) {
-	d.callbacks.OnPluggedExternalMicChange = append(d.callbacks.OnPluggedExternalMicChange, callback)
+func (d *DualSense) OnHapticLowPassFilterChange(callback func(bool), options ...SubscriptionOption) {
+	d.subscribe(FieldHapticLowPassFilter, func(current, _ *USBGetStateData) {
+		callback(current.HapticLowPassFilter)
+	}, options)
 }
 
-func (d *DualSense) OnHapticLowPassFilterChange(callback func(bool)) {
-	d.callbacks.OnHapticLowPassFilterChange = append(d.callbacks.OnHapticLowPassFilterChange, callback)
+func (d *DualSense) OnError(callback func(error)) {
+	d.callbacks.OnError = append(d.callbacks.OnError, callback)
+}
+
+func (d *DualSense) OnReportGap(callback func(ReportGap)) {
+	d.callbacks.OnReportGap = append(d.callbacks.OnReportGap, callback)
+}
+
+// OnRawReport delivers the unparsed bytes following the report ID of every input
+// report read, including reports that fail to unpack. data must not be retained
+// after the callback returns.
+func (d *DualSense) OnRawReport(callback func(reportID uint8, data []byte)) {
+	d.callbacks.OnRawReport = append(d.callbacks.OnRawReport, callback)
 }
 
 func (d *DualSense) SetStateData(setStateData SetStateData) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData != setStateData {
-		d.setStateDataMu.Lock()
-		err := d.writeSetStateData(setStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(setStateData)
 		if err != nil {
 			return fmt.Errorf("error writing new setStateData: %w", err)
 		}
@@ -630,13 +925,30 @@ func (d *DualSense) SetStateData(setStateData SetStateData) error {
 	return nil
 }
 
+// UpdateState applies several output changes at once under the internal lock,
+// resulting in a single output report instead of one per setter call.
+func (d *DualSense) UpdateState(update func(*SetStateData)) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	newSetStateData := d.setStateData
+	update(&newSetStateData)
+	if newSetStateData == d.setStateData {
+		return nil
+	}
+	err := d.applySetStateData(newSetStateData)
+	if err != nil {
+		return fmt.Errorf("error writing updated setStateData: %w", err)
+	}
+	return nil
+}
+
 func (d *DualSense) SetEnableRunbleEmulation(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.EnableRumbleEmulation != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.EnableRumbleEmulation = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating EnableRunbleEmulation in setStateData: %w", err)
 		}
@@ -645,12 +957,12 @@ func (d *DualSense) SetEnableRunbleEmulation(enable bool) error {
 }
 
 func (d *DualSense) SetUseRumbleNotHaptics(useRumbleNotHaptics bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.UseRumbleNotHaptics != useRumbleNotHaptics {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.UseRumbleNotHaptics = useRumbleNotHaptics
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating UseRumbleNotHaptics in setStateData: %w", err)
 		}
@@ -659,12 +971,12 @@ func (d *DualSense) SetUseRumbleNotHaptics(useRumbleNotHaptics bool) error {
 }
 
 func (d *DualSense) SetAllowRightTriggerFFB(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowRightTriggerFFB != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowRightTriggerFFB = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowRightTriggerFFB in setStateData: %w", err)
 		}
@@ -673,12 +985,12 @@ func (d *DualSense) SetAllowRightTriggerFFB(allow bool) error {
 }
 
 func (d *DualSense) SetAllowLeftTriggerFFB(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowLeftTriggerFFB != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowLeftTriggerFFB = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowLeftTriggerFFB in setStateData: %w", err)
 		}
@@ -687,12 +999,12 @@ func (d *DualSense) SetAllowLeftTriggerFFB(allow bool) error {
 }
 
 func (d *DualSense) SetAllowHeadphoneVolume(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowHeadphoneVolume != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowHeadphoneVolume = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowHeadphoneVolume in setStateData: %w", err)
 		}
@@ -701,12 +1013,12 @@ func (d *DualSense) SetAllowHeadphoneVolume(allow bool) error {
 }
 
 func (d *DualSense) SetAllowSpeakerVolume(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowSpeakerVolume != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowSpeakerVolume = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowSpeakerVolume in setStateData: %w", err)
 		}
@@ -715,12 +1027,12 @@ func (d *DualSense) SetAllowSpeakerVolume(allow bool) error {
 }
 
 func (d *DualSense) SetAllowMicVolume(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowMicVolume != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowMicVolume = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowMicVolume in setStateData: %w", err)
 		}
@@ -729,12 +1041,12 @@ func (d *DualSense) SetAllowMicVolume(allow bool) error {
 }
 
 func (d *DualSense) SetAllowAudioControl(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowAudioControl != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowAudioControl = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowAudioControl in setStateData: %w", err)
 		}
@@ -743,12 +1055,12 @@ func (d *DualSense) SetAllowAudioControl(allow bool) error {
 }
 
 func (d *DualSense) SetAllowMuteLight(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowMuteLight != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowMuteLight = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowMuteLight in setStateData: %w", err)
 		}
@@ -757,12 +1069,12 @@ func (d *DualSense) SetAllowMuteLight(allow bool) error {
 }
 
 func (d *DualSense) SetAllowAudioMute(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowAudioMute != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowAudioMute = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowAudioMute in setStateData: %w", err)
 		}
@@ -771,12 +1083,12 @@ func (d *DualSense) SetAllowAudioMute(allow bool) error {
 }
 
 func (d *DualSense) SetAllowLedColor(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowLedColor != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowLedColor = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowLedColor in setStateData: %w", err)
 		}
@@ -785,12 +1097,12 @@ func (d *DualSense) SetAllowLedColor(allow bool) error {
 }
 
 func (d *DualSense) SetResetLights(reset bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.ResetLights != reset {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.ResetLights = reset
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating ResetLights in setStateData: %w", err)
 		}
@@ -799,12 +1111,12 @@ func (d *DualSense) SetResetLights(reset bool) error {
 }
 
 func (d *DualSense) SetAllowPlayerIndicators(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowPlayerIndicators != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowPlayerIndicators = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowPlayerIndicators in setStateData: %w", err)
 		}
@@ -813,12 +1125,12 @@ func (d *DualSense) SetAllowPlayerIndicators(allow bool) error {
 }
 
 func (d *DualSense) SetAllowHapticLowPassFilter(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowHapticLowPassFilter != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowHapticLowPassFilter = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowHapticLowPassFilter in setStateData: %w", err)
 		}
@@ -827,12 +1139,12 @@ func (d *DualSense) SetAllowHapticLowPassFilter(allow bool) error {
 }
 
 func (d *DualSense) SetAllowMotorPowerLevel(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowMotorPowerLevel != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowMotorPowerLevel = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowMotorPowerLevel in setStateData: %w", err)
 		}
@@ -841,12 +1153,12 @@ func (d *DualSense) SetAllowMotorPowerLevel(allow bool) error {
 }
 
 func (d *DualSense) SetAllowAudioControl2(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowAudioControl2 != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowAudioControl2 = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowAudioControl2 in setStateData: %w", err)
 		}
@@ -855,12 +1167,12 @@ func (d *DualSense) SetAllowAudioControl2(allow bool) error {
 }
 
 func (d *DualSense) SetRumbleEmulationRight(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.RumbleEmulationRight != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.RumbleEmulationRight = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating RumbleEmulationRight in setStateData: %w", err)
 		}
@@ -869,12 +1181,12 @@ func (d *DualSense) SetRumbleEmulationRight(value uint8) error {
 }
 
 func (d *DualSense) SetRumbleEmulationLeft(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.RumbleEmulationLeft != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.RumbleEmulationLeft = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating RumbleEmulationLeft in setStateData: %w", err)
 		}
@@ -883,12 +1195,12 @@ func (d *DualSense) SetRumbleEmulationLeft(value uint8) error {
 }
 
 func (d *DualSense) SetVolumeHeadphones(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.VolumeHeadphones != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.VolumeHeadphones = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating VolumeHeadphones in setStateData: %w", err)
 		}
@@ -897,12 +1209,12 @@ func (d *DualSense) SetVolumeHeadphones(value uint8) error {
 }
 
 func (d *DualSense) SetVolumeSpeaker(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.VolumeSpeaker != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.VolumeSpeaker = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating VolumeSpeaker in setStateData: %w", err)
 		}
@@ -911,12 +1223,12 @@ func (d *DualSense) SetVolumeSpeaker(value uint8) error {
 }
 
 func (d *DualSense) SetVolumeMic(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.VolumeMic != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.VolumeMic = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating VolumeMic in setStateData: %w", err)
 		}
@@ -925,12 +1237,12 @@ func (d *DualSense) SetVolumeMic(value uint8) error {
 }
 
 func (d *DualSense) SetMicSelect(value MicSelectType) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.MicSelect != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.MicSelect = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating MicSelect in setStateData: %w", err)
 		}
@@ -939,12 +1251,12 @@ func (d *DualSense) SetMicSelect(value MicSelectType) error {
 }
 
 func (d *DualSense) SetEchoCancelEnable(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.EchoCancelEnable != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.EchoCancelEnable = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating EchoCancelEnable in setStateData: %w", err)
 		}
@@ -953,12 +1265,12 @@ func (d *DualSense) SetEchoCancelEnable(enable bool) error {
 }
 
 func (d *DualSense) SetNoiseCancelEnable(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.NoiseCancelEnable != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.NoiseCancelEnable = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating NoiseCancelEnable in setStateData: %w", err)
 		}
@@ -967,12 +1279,12 @@ func (d *DualSense) SetNoiseCancelEnable(enable bool) error {
 }
 
 func (d *DualSense) SetOutputPathSelect(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.OutputPathSelect != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.OutputPathSelect = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating OutputPathSelect in setStateData: %w", err)
 		}
@@ -981,12 +1293,12 @@ func (d *DualSense) SetOutputPathSelect(value uint8) error {
 }
 
 func (d *DualSense) SetInputPathSelect(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.InputPathSelect != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.InputPathSelect = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating InputPathSelect in setStateData: %w", err)
 		}
@@ -995,12 +1307,12 @@ func (d *DualSense) SetInputPathSelect(value uint8) error {
 }
 
 func (d *DualSense) SetMuteLight(value MuteLightMode) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.MuteLight != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.MuteLight = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating MuteLight in setStateData: %w", err)
 		}
@@ -1009,12 +1321,12 @@ func (d *DualSense) SetMuteLight(value MuteLightMode) error {
 }
 
 func (d *DualSense) SetTouchPowerSave(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.TouchPowerSave != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.TouchPowerSave = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating TouchPowerSave in setStateData: %w", err)
 		}
@@ -1023,12 +1335,12 @@ func (d *DualSense) SetTouchPowerSave(enable bool) error {
 }
 
 func (d *DualSense) SetMotionPowerSave(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.MotionPowerSave != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.MotionPowerSave = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating MotionPowerSave in setStateData: %w", err)
 		}
@@ -1037,12 +1349,12 @@ func (d *DualSense) SetMotionPowerSave(enable bool) error {
 }
 
 func (d *DualSense) SetHapticPowerSave(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.HapticPowerSave != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.HapticPowerSave = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating HapticPowerSave in setStateData: %w", err)
 		}
@@ -1051,12 +1363,12 @@ func (d *DualSense) SetHapticPowerSave(enable bool) error {
 }
 
 func (d *DualSense) SetAudioPowerSave(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AudioPowerSave != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AudioPowerSave = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AudioPowerSave in setStateData: %w", err)
 		}
@@ -1065,12 +1377,12 @@ func (d *DualSense) SetAudioPowerSave(enable bool) error {
 }
 
 func (d *DualSense) SetMicMute(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.MicMute != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.MicMute = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating MicMute in setStateData: %w", err)
 		}
@@ -1079,12 +1391,12 @@ func (d *DualSense) SetMicMute(enable bool) error {
 }
 
 func (d *DualSense) SetSpeakerMute(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.SpeakerMute != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.SpeakerMute = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating SpeakerMute in setStateData: %w", err)
 		}
@@ -1093,12 +1405,12 @@ func (d *DualSense) SetSpeakerMute(enable bool) error {
 }
 
 func (d *DualSense) SetHeadphoneMute(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.HeadphoneMute != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.HeadphoneMute = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating HeadphoneMute in setStateData: %w", err)
 		}
@@ -1107,12 +1419,12 @@ func (d *DualSense) SetHeadphoneMute(enable bool) error {
 }
 
 func (d *DualSense) SetHapticMute(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.HapticMute != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.HapticMute = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating HapticMute in setStateData: %w", err)
 		}
@@ -1121,12 +1433,12 @@ func (d *DualSense) SetHapticMute(enable bool) error {
 }
 
 func (d *DualSense) SetRightTriggerFFB(params [11]uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.RightTriggerFFB != params {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.RightTriggerFFB = params
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating RightTriggerFFB in setStateData: %w", err)
 		}
@@ -1135,12 +1447,12 @@ func (d *DualSense) SetRightTriggerFFB(params [11]uint8) error {
 }
 
 func (d *DualSense) SetLeftTriggerFFB(params [11]uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LeftTriggerFFB != params {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LeftTriggerFFB = params
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LeftTriggerFFB in setStateData: %w", err)
 		}
@@ -1148,13 +1460,13 @@ func (d *DualSense) SetLeftTriggerFFB(params [11]uint8) error {
 	return nil
 }
 
-func (d *DualSense) SetTriggerMotorPowerReduction(level uint8) error {
+func (d *DualSense) SetTriggerMotorPowerReduction(level PowerReduction) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.TriggerMotorPowerReduction != level {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.TriggerMotorPowerReduction = level
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating TriggerMotorPowerReduction in setStateData: %w", err)
 		}
@@ -1162,13 +1474,13 @@ func (d *DualSense) SetTriggerMotorPowerReduction(level uint8) error {
 	return nil
 }
 
-func (d *DualSense) SetRumbleMotorPowerReduction(level uint8) error {
+func (d *DualSense) SetRumbleMotorPowerReduction(level PowerReduction) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.RumbleMotorPowerReduction != level {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.RumbleMotorPowerReduction = level
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating RumbleMotorPowerReduction in setStateData: %w", err)
 		}
@@ -1177,12 +1489,12 @@ func (d *DualSense) SetRumbleMotorPowerReduction(level uint8) error {
 }
 
 func (d *DualSense) SetSpeakerCompPreGain(gain uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.SpeakerCompPreGain != gain {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.SpeakerCompPreGain = gain
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating SpeakerCompPreGain in setStateData: %w", err)
 		}
@@ -1191,12 +1503,12 @@ func (d *DualSense) SetSpeakerCompPreGain(gain uint8) error {
 }
 
 func (d *DualSense) SetBeamformingEnable(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.BeamformingEnable != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.BeamformingEnable = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating BeamformingEnable in setStateData: %w", err)
 		}
@@ -1205,12 +1517,12 @@ func (d *DualSense) SetBeamformingEnable(enable bool) error {
 }
 
 func (d *DualSense) SetAllowLightBrightnessChange(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowLightBrightnessChange != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowLightBrightnessChange = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowLightBrightnessChange in setStateData: %w", err)
 		}
@@ -1219,12 +1531,12 @@ func (d *DualSense) SetAllowLightBrightnessChange(allow bool) error {
 }
 
 func (d *DualSense) SetAllowColorLightFadeAnimation(allow bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.AllowColorLightFadeAnimation != allow {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.AllowColorLightFadeAnimation = allow
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating AllowColorLightFadeAnimation in setStateData: %w", err)
 		}
@@ -1233,12 +1545,12 @@ func (d *DualSense) SetAllowColorLightFadeAnimation(allow bool) error {
 }
 
 func (d *DualSense) SetEnableImprovedRumbleEmulation(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.EnableImprovedRumbleEmulation != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.EnableImprovedRumbleEmulation = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating EnableImprovedRumbleEmulation in setStateData: %w", err)
 		}
@@ -1247,12 +1559,12 @@ func (d *DualSense) SetEnableImprovedRumbleEmulation(enable bool) error {
 }
 
 func (d *DualSense) SetLightFadeAnimation(animation LightFadeAnimation) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LightFadeAnimation != animation {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LightFadeAnimation = animation
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LightFadeAnimation in setStateData: %w", err)
 		}
@@ -1261,12 +1573,12 @@ func (d *DualSense) SetLightFadeAnimation(animation LightFadeAnimation) error {
 }
 
 func (d *DualSense) SetLightBrightness(brightness LightBrightness) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LightBrightness != brightness {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LightBrightness = brightness
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LightBrightness in setStateData: %w", err)
 		}
@@ -1275,12 +1587,12 @@ func (d *DualSense) SetLightBrightness(brightness LightBrightness) error {
 }
 
 func (d *DualSense) SetPlayerLight1(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLight1 != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLight1 = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLight1 in setStateData: %w", err)
 		}
@@ -1289,12 +1601,12 @@ func (d *DualSense) SetPlayerLight1(enable bool) error {
 }
 
 func (d *DualSense) SetPlayerLight2(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLight2 != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLight2 = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLight2 in setStateData: %w", err)
 		}
@@ -1303,12 +1615,12 @@ func (d *DualSense) SetPlayerLight2(enable bool) error {
 }
 
 func (d *DualSense) SetPlayerLight3(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLight3 != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLight3 = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLight3 in setStateData: %w", err)
 		}
@@ -1317,12 +1629,12 @@ func (d *DualSense) SetPlayerLight3(enable bool) error {
 }
 
 func (d *DualSense) SetPlayerLight4(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLight4 != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLight4 = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLight4 in setStateData: %w", err)
 		}
@@ -1331,12 +1643,12 @@ func (d *DualSense) SetPlayerLight4(enable bool) error {
 }
 
 func (d *DualSense) SetPlayerLight5(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLight5 != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLight5 = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLight5 in setStateData: %w", err)
 		}
@@ -1345,12 +1657,12 @@ func (d *DualSense) SetPlayerLight5(enable bool) error {
 }
 
 func (d *DualSense) SetPlayerLightFade(enable bool) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.PlayerLightFade != enable {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.PlayerLightFade = enable
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating PlayerLightFade in setStateData: %w", err)
 		}
@@ -1359,12 +1671,12 @@ func (d *DualSense) SetPlayerLightFade(enable bool) error {
 }
 
 func (d *DualSense) SetLedRed(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LedRed != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LedRed = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LedRed in setStateData: %w", err)
 		}
@@ -1373,12 +1685,12 @@ func (d *DualSense) SetLedRed(value uint8) error {
 }
 
 func (d *DualSense) SetLedGreen(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LedGreen != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LedGreen = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LedGreen in setStateData: %w", err)
 		}
@@ -1387,12 +1699,12 @@ func (d *DualSense) SetLedGreen(value uint8) error {
 }
 
 func (d *DualSense) SetLedBlue(value uint8) error {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
 	if d.setStateData.LedBlue != value {
-		d.setStateDataMu.Lock()
 		newSetStateData := d.setStateData
 		newSetStateData.LedBlue = value
-		err := d.writeSetStateData(newSetStateData)
-		d.setStateDataMu.Unlock()
+		err := d.applySetStateData(newSetStateData)
 		if err != nil {
 			return fmt.Errorf("error updating LedBlue in setStateData: %w", err)
 		}
@@ -0,0 +1,247 @@
+package dualsense
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateState(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.ResetOutputReports()
+
+	weapon := GenerateTriggerFFBParams(EffectTypeWeapon, 2, 7, 8)
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = 1, 2, 3
+		setStateData.RumbleEmulationLeft, setStateData.RumbleEmulationRight = 4, 5
+		setStateData.RightTriggerFFB = weapon
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) != 1 {
+		t.Fatalf("got %d output reports, want 1 for all changes", len(outStates))
+	}
+	if out := outStates[0]; out.LedRed != 1 || out.LedGreen != 2 || out.LedBlue != 3 || out.RumbleEmulationLeft != 4 || out.RumbleEmulationRight != 5 || out.RightTriggerFFB != weapon {
+		t.Fatalf("got %+v", out)
+	}
+
+	if err := d.UpdateState(func(*SetStateData) {}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(d.OutStates()); got != 1 {
+		t.Fatalf("got %d output reports after an update changing nothing, want 1", got)
+	}
+
+	err = d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.LedRed = 0xFF
+		setStateData.RumbleMotorPowerReduction = PowerReductionMax + 1
+	})
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 1 {
+		t.Fatalf("rejected update applied LedRed %d", got.LedRed)
+	}
+}
+
+func TestOutputFlushInterval(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetOutputFlushInterval(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	d.ResetOutputReports()
+
+	for _, set := range []func() error{
+		func() error { return d.SetLedRed(1) },
+		func() error { return d.SetLedGreen(2) },
+		func() error { return d.SetLedBlue(3) },
+	} {
+		if err := set(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(d.OutStates()); got != 0 {
+		t.Fatalf("got %d output reports within the flush interval, want 0", got)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 1 || got.LedGreen != 2 || got.LedBlue != 3 {
+		t.Fatalf("GetOutStateData returned %+v, want the pending state", ledColorOf(&got))
+	}
+
+	if err := d.SetOutputFlushInterval(0); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) != 1 {
+		t.Fatalf("got %d output reports, want 1 combined flush", len(outStates))
+	}
+	if out := outStates[0]; out.LedRed != 1 || out.LedGreen != 2 || out.LedBlue != 3 {
+		t.Fatalf("flushed %+v", ledColorOf(&out))
+	}
+	if err := d.SetLedRed(4); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(d.OutStates()); got != 2 {
+		t.Fatalf("got %d output reports, want an immediate write without a flush interval", got)
+	}
+}
+
+// blockedWriteTransport holds writes while gate is locked.
+type blockedWriteTransport struct {
+	mockTransport
+	gate sync.Mutex
+}
+
+func (t *blockedWriteTransport) Write(p []byte) (int, error) {
+	t.gate.Lock()
+	defer t.gate.Unlock()
+	return t.mockTransport.Write(p)
+}
+
+func TestAsyncOutput(t *testing.T) {
+	transport := &blockedWriteTransport{}
+	d := newDualSense(transport, nil)
+	d.SetAsyncOutput(true)
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	transport.gate.Lock()
+	transport.mu.Lock()
+	transport.outputReports = nil
+	transport.mu.Unlock()
+
+	// Setters return while the writer is stuck in a write.
+	set := make(chan error)
+	go func() {
+		for red := range uint8(10) {
+			if err := d.SetLedRed(red + 1); err != nil {
+				set <- err
+				return
+			}
+		}
+		set <- nil
+	}()
+	select {
+	case err := <-set:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("setter blocked on output I/O")
+	}
+
+	transport.gate.Unlock()
+	deadline := time.Now().Add(time.Second)
+	for {
+		transport.mu.Lock()
+		reports := append([][]byte(nil), transport.outputReports...)
+		transport.mu.Unlock()
+		if len(reports) > 0 {
+			last, err := UnmarshalOutputReport(reports[len(reports)-1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last.LedRed == 10 {
+				// Updates queued behind a write collapse into a write of the
+				// latest state.
+				if len(reports) > 2 {
+					t.Fatalf("got %d output reports for 10 queued updates", len(reports))
+				}
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("latest state not written after %d output reports", len(reports))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMaxOutputReportRate(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.SetMaxOutputReportRate(1)
+	d.ResetOutputReports()
+
+	if err := d.SetLedRed(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetLedGreen(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(d.OutStates()); got != 0 {
+		t.Fatalf("got %d output reports within a second of the last, want 0", got)
+	}
+
+	d.setStateDataMu.Lock()
+	d.lastOutputWrite = d.lastOutputWrite.Add(-time.Second)
+	d.setStateDataMu.Unlock()
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) != 1 || outStates[0].LedRed != 1 || outStates[0].LedGreen != 2 {
+		t.Fatalf("got %d output reports, want 1 merging both updates", len(outStates))
+	}
+
+	d.SetMaxOutputReportRate(0)
+	if err := d.SetLedBlue(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(d.OutStates()); got != 2 {
+		t.Fatalf("got %d output reports, want an immediate write without a limit", got)
+	}
+}
+
+func TestOnRawReport(t *testing.T) {
+	d := NewMockDualSense()
+	type rawReport struct {
+		id   uint8
+		data []byte
+	}
+	var reports []rawReport
+	d.OnRawReport(func(reportID uint8, data []byte) {
+		reports = append(reports, rawReport{reportID, append([]byte(nil), data...)})
+	})
+	report := make([]byte, USB_PACKET_SIZE)
+	report[0], report[1] = 0x01, 42
+	d.QueueRawReport(report)
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	// Reports that fail to unpack are delivered too.
+	d.QueueRawReport([]byte{0x01, 7, 8})
+	if err := d.Poll(); err == nil {
+		t.Fatal("Poll accepted a short input report")
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d raw reports, want 2", len(reports))
+	}
+	if reports[0].id != 0x01 || !bytes.Equal(reports[0].data, report[1:]) {
+		t.Errorf("got report 0x%02x % x, want the report after its ID", reports[0].id, reports[0].data[:4])
+	}
+	if reports[1].id != 0x01 || !bytes.Equal(reports[1].data, []byte{7, 8}) {
+		t.Errorf("got short report 0x%02x % x, want 01 07 08", reports[1].id, reports[1].data)
+	}
+}
@@ -0,0 +1,174 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DUALSHOCK4_PRODUCT_ID_V1 = 0x05C4
+	DUALSHOCK4_PRODUCT_ID_V2 = 0x09CC
+	// DUALSHOCK4_OUTPUT_REPORT_SIZE is the size of USB output report 0x05.
+	DUALSHOCK4_OUTPUT_REPORT_SIZE = 32
+	// DualShock 4 sensor timestamps count in units of 16/3 µs, 16 times the
+	// DualSense unit.
+	dualShock4TimestampScale = 16
+)
+
+var dualShock4ProductIDs = []uint16{DUALSHOCK4_PRODUCT_ID_V1, DUALSHOCK4_PRODUCT_ID_V2}
+
+// DualShock4Transport adapts a DualShock 4 connected over USB to the DualSense
+// report format, so it can be driven through DualSense and the Controller
+// interface. Input reports are rewritten into DualSense input reports and
+// DualSense output reports into DualShock 4 output reports, keeping the
+// sticks, buttons, triggers, motion sensors, touchpad, battery, rumble and
+// lightbar. Fields the DualShock 4 lacks, such as adaptive triggers, the mute
+// button and LED and the player lights, read as zero and are ignored on
+// output.
+type DualShock4Transport struct {
+	transport Transport
+
+	mu              sync.Mutex
+	readBuffer      [USB_PACKET_SIZE]byte
+	seqNo           uint8
+	hasTimestamp    bool
+	lastTimestamp   uint16
+	sensorTimestamp uint32
+}
+
+func NewDualShock4Transport(transport Transport) *DualShock4Transport {
+	return &DualShock4Transport{transport: transport}
+}
+
+// NewDualShock4 opens the first DualShock 4 controller found, like
+// NewDualSense, unless a transport is provided WithTransport.
+func NewDualShock4(options ...Option) (*DualSense, error) {
+	dualsense := newDualSense(nil, options)
+	if dualsense.transport == nil {
+		transport, err := openDefaultDualShock4Transport()
+		if err != nil {
+			return nil, err
+		}
+		dualsense.transport = NewDualShock4Transport(transport)
+		dualsense.openTransport = func() (Transport, error) {
+			transport, err := openDefaultDualShock4Transport()
+			if err != nil {
+				return nil, err
+			}
+			return NewDualShock4Transport(transport), nil
+		}
+	}
+	dualsense.applyLabels()
+	dualsense.applyWhitePoints()
+	return dualsense, nil
+}
+
+func (t *DualShock4Transport) Read(p []byte, timeout time.Duration) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, err := t.transport.Read(t.readBuffer[:], timeout)
+	if err != nil {
+		return n, err
+	}
+	if n != USB_PACKET_SIZE || t.readBuffer[0] != 0x01 || len(p) < USB_PACKET_SIZE {
+		// Not an input report, passed through unchanged.
+		return copy(p, t.readBuffer[:n]), nil
+	}
+	t.convertInputReport(p[:USB_PACKET_SIZE], t.readBuffer[:])
+	return USB_PACKET_SIZE, nil
+}
+
+// convertInputReport rewrites the DualShock 4 USB input report ds4 into the
+// DualSense input report ds.
+func (t *DualShock4Transport) convertInputReport(ds, ds4 []byte) {
+	clear(ds)
+	ds[0] = 0x01
+	copy(ds[1:5], ds4[1:5]) // Sticks
+	ds[5] = ds4[8]          // TriggerLeft
+	ds[6] = ds4[9]          // TriggerRight
+	ds[7] = t.seqNo
+	t.seqNo++
+	// DPad, face buttons, shoulder buttons, Share as Create and Options share
+	// the DualSense layout.
+	ds[8] = ds4[5]
+	ds[9] = ds4[6]
+	ds[10] = ds4[7] & 0x03      // PS as Home and touchpad click
+	copy(ds[16:28], ds4[13:25]) // Gyroscope and accelerometer
+
+	timestamp := binary.LittleEndian.Uint16(ds4[10:])
+	if t.hasTimestamp {
+		t.sensorTimestamp += uint32(timestamp-t.lastTimestamp) * dualShock4TimestampScale
+	}
+	t.hasTimestamp = true
+	t.lastTimestamp = timestamp
+	binary.LittleEndian.PutUint32(ds[28:], t.sensorTimestamp)
+
+	ds[32] = ds4[12]            // Temperature
+	copy(ds[33:41], ds4[35:43]) // Both touch points share the DualSense packing
+	ds[41] = ds4[34]            // Touch timestamp
+
+	status := ds4[30]
+	level := status & 0x0F
+	powerState := PowerStateDischarging
+	if status&0x10 != 0 {
+		// Levels run to 10 while charging and 11 once charged.
+		powerState = PowerStateCharging
+		if level > 10 {
+			powerState = PowerStateComplete
+		}
+	}
+	ds[53] = min(level, 10) | uint8(powerState)<<4
+	// PluggedHeadphones, PluggedMic and PluggedUsbPower.
+	ds[54] = (status>>5)&0x01 | (status>>6&0x01)<<1 | (status>>4&0x01)<<4
+}
+
+// Write translates DualSense output reports and passes other reports through
+// unchanged.
+func (t *DualShock4Transport) Write(p []byte) (int, error) {
+	if len(p) == 0 || p[0] != 0x02 {
+		return t.transport.Write(p)
+	}
+	setStateData, err := UnmarshalOutputReport(p)
+	if err != nil {
+		return 0, fmt.Errorf("UnmarshalOutputReport: error trying to translate output report for DualShock 4: %w", err)
+	}
+	report := make([]byte, DUALSHOCK4_OUTPUT_REPORT_SIZE)
+	report[0] = 0x05
+	report[1] = 0x03 // Motors and lightbar valid
+	report[4] = setStateData.RumbleEmulationRight
+	report[5] = setStateData.RumbleEmulationLeft
+	report[6] = setStateData.LedRed
+	report[7] = setStateData.LedGreen
+	report[8] = setStateData.LedBlue
+	_, err = t.transport.Write(report)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SendFeature translates the DualSense pairing report, see SetPairedHost, and
+// passes other reports through unchanged.
+func (t *DualShock4Transport) SendFeature(p []byte) (int, error) {
+	if len(p) >= 1+6+LINK_KEY_SIZE && p[0] == SET_PAIRING_REPORT_ID {
+		report := make([]byte, DUALSHOCK4_SET_PAIRING_REPORT_SIZE)
+		report[0] = DUALSHOCK4_SET_PAIRING_REPORT_ID
+		copy(report[1:], p[1:1+6+LINK_KEY_SIZE])
+		_, err := t.transport.SendFeature(report)
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return t.transport.SendFeature(p)
+}
+
+func (t *DualShock4Transport) GetFeature(p []byte) (int, error) {
+	return t.transport.GetFeature(p)
+}
+
+func (t *DualShock4Transport) Close() error {
+	return t.transport.Close()
+}
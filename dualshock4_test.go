@@ -0,0 +1,89 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDualShock4TransportInput(t *testing.T) {
+	inner := &mockTransport{}
+	report := make([]byte, USB_PACKET_SIZE)
+	report[0] = 0x01
+	report[1], report[2], report[3], report[4] = 10, 20, 30, 40
+	report[5] = uint8(DirectionEast) | 0x20 // Cross
+	report[6] = 0x11                        // L1 and Share
+	report[7] = 0x02 | 0x04<<2              // Touchpad click and counter
+	report[8], report[9] = 50, 60
+	binary.LittleEndian.PutUint16(report[10:], 0xFFFF)
+	report[12] = 25
+	gyroX := int16(-100)
+	binary.LittleEndian.PutUint16(report[13:], uint16(gyroX))
+	binary.LittleEndian.PutUint16(report[23:], 8192)
+	report[30] = 0x10 | 0x20 | 7 // Charging at level 7, headphones
+	report[34] = 9
+	report[35] = 0x05 // Touching, index 5
+	report[36], report[37], report[38] = 0x34, 0x12, 0x40
+	report[39] = 0x80 // Not touching
+	inner.inputReports = append(inner.inputReports, report)
+	second := append([]byte(nil), report...)
+	binary.LittleEndian.PutUint16(second[10:], 2)
+	second[30] = 0x10 | 11
+	inner.inputReports = append(inner.inputReports, second)
+
+	transport := NewDualShock4Transport(inner)
+	buffer := make([]byte, USB_PACKET_SIZE)
+	n, err := transport.Read(buffer, 0)
+	if err != nil || n != USB_PACKET_SIZE {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	reportIn, err := UnmarshalInputReport(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := reportIn.USBGetStateData
+	if state.LeftStickX != 10 || state.LeftStickY != 20 || state.RightStickX != 30 || state.RightStickY != 40 ||
+		state.TriggerLeft != 50 || state.TriggerRight != 60 || state.DPad != DirectionEast || !state.ButtonCross ||
+		!state.ButtonL1 || !state.ButtonCreate || state.ButtonHome || !state.ButtonPad ||
+		state.AngularVelocityX != -100 || state.AccelerometerZ != 8192 || state.Temperature != 25 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	finger1 := state.TouchData.TouchFinger1
+	if finger1.Index != 5 || finger1.NotTouching || finger1.FingerX != 0x234 || finger1.FingerY != 0x401 || !state.TouchData.TouchFinger2.NotTouching || state.TouchData.Timestamp != 9 {
+		t.Fatalf("unexpected touch data: %+v", state.TouchData)
+	}
+	if state.PowerPercent != 7 || state.PowerState != PowerStateCharging || !state.PluggedUsbPower || !state.PluggedHeadphones || state.PluggedMic {
+		t.Fatalf("unexpected power state: %+v", state)
+	}
+
+	transport.Read(buffer, 0)
+	reportIn, _ = UnmarshalInputReport(buffer)
+	state = reportIn.USBGetStateData
+	// The timestamp wrapped by 3 units.
+	if state.SeqNo != 1 || state.SensorTimestamp != 3*dualShock4TimestampScale {
+		t.Fatalf("got SeqNo %d, SensorTimestamp %d", state.SeqNo, state.SensorTimestamp)
+	}
+	if state.PowerPercent != 10 || state.PowerState != PowerStateComplete {
+		t.Fatalf("got %d%% %v, want complete", state.PowerPercent*10, state.PowerState)
+	}
+}
+
+func TestDualShock4TransportOutput(t *testing.T) {
+	inner := &mockTransport{}
+	d := newDualSense(NewDualShock4Transport(inner), []Option{WithManualPump()})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.RumbleEmulationRight = 10
+		setStateData.RumbleEmulationLeft = 200
+		setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = 1, 2, 3
+	})
+	reports := inner.outputReports
+	if len(reports) != 2 {
+		t.Fatalf("got %d output reports, want 2", len(reports))
+	}
+	report := reports[1]
+	if len(report) != DUALSHOCK4_OUTPUT_REPORT_SIZE || report[0] != 0x05 || report[4] != 10 || report[5] != 200 || report[6] != 1 || report[7] != 2 || report[8] != 3 {
+		t.Fatalf("unexpected output report: %v", report)
+	}
+}
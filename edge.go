@@ -0,0 +1,74 @@
+package dualsense
+
+// DUALSENSE_EDGE_PRODUCT_ID identifies the DualSense Edge.
+const DUALSENSE_EDGE_PRODUCT_ID = 0x0DF2
+
+// dualSenseProductIDs are matched by the default transports.
+var dualSenseProductIDs = []uint16{DUALSENSE_PRODUCT_ID, DUALSENSE_EDGE_PRODUCT_ID}
+
+// EdgeProfileSlot is one of the on-board profiles of a DualSense Edge,
+// selected by holding Fn and pressing a face button.
+type EdgeProfileSlot uint8
+
+const (
+	EdgeProfileTriangle EdgeProfileSlot = iota // The default profile
+	EdgeProfileSquare
+	EdgeProfileCross
+	EdgeProfileCircle
+)
+
+// ProfileSwitchRequest is a Fn + face button combo on a DualSense Edge.
+type ProfileSwitchRequest struct {
+	Slot EdgeProfileSlot
+	// Function is FieldButtonLeftFunction or FieldButtonRightFunction,
+	// whichever Fn button was held; the left one wins if both were.
+	Function Field
+}
+
+// OnProfileSwitchRequested registers a callback for Fn + face button combos,
+// which switch on-board profiles on a DualSense Edge. The combo fires when the
+// face button is pressed while Fn is held. The buttons involved are still
+// reported through their own change callbacks.
+func (d *DualSense) OnProfileSwitchRequested(callback func(ProfileSwitchRequest)) {
+	d.callbacks.OnProfileSwitchRequested = append(d.callbacks.OnProfileSwitchRequested, callback)
+}
+
+// edgeCombo returns the profile switch requested by current, if a face
+// button was pressed since previous while Fn is held.
+func edgeCombo(current, previous *USBGetStateData) (ProfileSwitchRequest, bool) {
+	var request ProfileSwitchRequest
+	switch {
+	case current.ButtonLeftFunction:
+		request.Function = FieldButtonLeftFunction
+	case current.ButtonRightFunction:
+		request.Function = FieldButtonRightFunction
+	default:
+		return ProfileSwitchRequest{}, false
+	}
+	switch {
+	case current.ButtonTriangle && !previous.ButtonTriangle:
+		request.Slot = EdgeProfileTriangle
+	case current.ButtonSquare && !previous.ButtonSquare:
+		request.Slot = EdgeProfileSquare
+	case current.ButtonCross && !previous.ButtonCross:
+		request.Slot = EdgeProfileCross
+	case current.ButtonCircle && !previous.ButtonCircle:
+		request.Slot = EdgeProfileCircle
+	default:
+		return ProfileSwitchRequest{}, false
+	}
+	return request, true
+}
+
+func (d *DualSense) processEdgeCombos(current, previous *USBGetStateData) {
+	if len(d.callbacks.OnProfileSwitchRequested) == 0 {
+		return
+	}
+	request, ok := edgeCombo(current, previous)
+	if !ok {
+		return
+	}
+	for _, callback := range d.callbacks.OnProfileSwitchRequested {
+		callback(request)
+	}
+}
@@ -0,0 +1,28 @@
+package dualsense
+
+import "testing"
+
+func TestOnProfileSwitchRequested(t *testing.T) {
+	mock := NewMockDualSense()
+	var requests []ProfileSwitchRequest
+	mock.OnProfileSwitchRequested(func(request ProfileSwitchRequest) { requests = append(requests, request) })
+
+	// Without Fn a face button is just a button.
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonSquare = true })
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.ButtonSquare = false
+		state.ButtonRightFunction = true
+	})
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	// Holding the face button does not repeat the request.
+	mock.UpdateInState(func(state *USBGetStateData) { state.LeftStickX = 10 })
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = false })
+
+	want := []ProfileSwitchRequest{{Slot: EdgeProfileCross, Function: FieldButtonRightFunction}}
+	if len(requests) != len(want) || requests[0] != want[0] {
+		t.Fatalf("got %+v, want %+v", requests, want)
+	}
+	if EdgeProfileCross.String() != "Cross" {
+		t.Fatalf("got %q, want Cross", EdgeProfileCross.String())
+	}
+}
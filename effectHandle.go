@@ -0,0 +1,86 @@
+package dualsense
+
+import "sync"
+
+// EffectHandle controls an effect or animation running in the background,
+// as returned by StartEffects, StartTimeline, StartRumblePattern and
+// StartLightbarFade.
+type EffectHandle struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// startEffect runs run on its own goroutine, with the handle's stop channel.
+func startEffect(run func(stop <-chan struct{}) error) *EffectHandle {
+	h := &EffectHandle{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(h.done)
+		h.err = run(h.stop)
+	}()
+	return h
+}
+
+// Stop asks the effect to end early and returns without waiting; receive
+// from Done to wait until it has ended and restored the outputs it set.
+// Stopping an effect that already ended does nothing.
+func (h *EffectHandle) Stop() {
+	h.stopOnce.Do(func() { close(h.stop) })
+}
+
+// Done is closed once the effect has ended, by itself or through Stop.
+func (h *EffectHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Err returns the error that ended the effect, once Done is closed. It is nil
+// while the effect runs and if it ended normally or was stopped.
+func (h *EffectHandle) Err() error {
+	select {
+	case <-h.done:
+		return h.err
+	default:
+		return nil
+	}
+}
+
+// StartEffects runs effects in the background, see RunEffects.
+func (d *DualSense) StartEffects(effects ...Effect) *EffectHandle {
+	return startEffect(func(stop <-chan struct{}) error {
+		return d.RunEffects(stop, effects...)
+	})
+}
+
+// StartEffects runs effects on the layer in the background, see
+// OutputLayer.RunEffects.
+func (l *OutputLayer) StartEffects(effects ...Effect) *EffectHandle {
+	return startEffect(func(stop <-chan struct{}) error {
+		return l.RunEffects(stop, effects...)
+	})
+}
+
+// StartTimeline plays timeline in the background, see PlayTimeline. Invalid
+// timelines are reported at once.
+func (d *DualSense) StartTimeline(timeline Timeline) (*EffectHandle, error) {
+	effect, err := timeline.Effect()
+	if err != nil {
+		return nil, err
+	}
+	return d.StartEffects(effect), nil
+}
+
+// StartRumblePattern plays pattern in the background, see PlayRumblePattern.
+func (d *DualSense) StartRumblePattern(pattern RumblePattern) *EffectHandle {
+	return startEffect(func(stop <-chan struct{}) error {
+		return d.playRumblePattern(pattern, stop)
+	})
+}
+
+// StartLightbarFade plays a lightbar fade in the background, see
+// FadeLightbar. Stopping it hands the lightbar back to thenColor at once.
+func (d *DualSense) StartLightbarFade(direction LightFadeAnimation, thenColor LedColor) *EffectHandle {
+	return startEffect(func(stop <-chan struct{}) error {
+		return d.fadeLightbar(direction, thenColor, stop)
+	})
+}
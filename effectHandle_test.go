@@ -0,0 +1,56 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func waitDone(t *testing.T, h *EffectHandle) {
+	t.Helper()
+	select {
+	case <-h.Done():
+	case <-time.After(time.Second):
+		t.Fatal("effect did not end")
+	}
+	if err := h.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEffectHandles(t *testing.T) {
+	d := NewMockDualSense()
+	d.lightbarFadeDuration = time.Hour
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	before := d.GetOutStateData()
+
+	timeline, err := d.StartTimeline(Timeline{Rumble: []RumbleKeyframe{{Left: 10}, {At: time.Hour}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := d.StartRumblePattern(RumblePattern{{Right: 20, Duration: time.Hour}})
+	fade := d.StartLightbarFade(LightFadeAnimationFadeOut, LedColor{Blue: 30})
+	for _, h := range []*EffectHandle{timeline, pattern, fade} {
+		select {
+		case <-h.Done():
+			t.Fatal("effect ended before Stop")
+		case <-time.After(20 * time.Millisecond):
+		}
+		h.Stop()
+		h.Stop()
+		waitDone(t, h)
+	}
+	got := d.GetOutStateData()
+	if got.RumbleEmulationLeft != before.RumbleEmulationLeft || got.RumbleEmulationRight != before.RumbleEmulationRight {
+		t.Errorf("rumble not restored: got %d, %d", got.RumbleEmulationLeft, got.RumbleEmulationRight)
+	}
+	if ledColorOf(&got) != (LedColor{Blue: 30}) || got.LightFadeAnimation != LightFadeAnimationNothing {
+		t.Errorf("lightbar after stopped fade: got %+v and %v", ledColorOf(&got), got.LightFadeAnimation)
+	}
+
+	// An effect that ends by itself closes Done without Stop.
+	h := d.StartEffects(&pulseEffect{level: 1, ticks: 1})
+	waitDone(t, h)
+}
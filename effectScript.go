@@ -0,0 +1,159 @@
+package dualsense
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// EffectScript is a named Timeline kept as JSON, so controller feedback can be
+// tuned without recompiling:
+//
+//	{
+//	  "name": "heartbeat",
+//	  "lightbar": [{"at": "0s", "color": {"red": 255, "green": 0, "blue": 0}}],
+//	  "rumble": [{"at": "0s", "left": 200, "right": 0}, {"at": "120ms", "left": 0, "right": 0}],
+//	  "triggers": [{"at": "0s", "right": {"type": "Weapon", "start": 2, "end": 5, "strength": 8}}]
+//	}
+//
+// Times are Go durations such as "1.5s" or "250ms", or plain numbers of
+// milliseconds. Trigger effect types are the EffectType names.
+type EffectScript struct {
+	Name string `json:"name"`
+	Timeline
+}
+
+// scriptDuration is a duration as written in an effect script.
+type scriptDuration time.Duration
+
+func (d scriptDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *scriptDuration) UnmarshalJSON(data []byte) error {
+	var milliseconds float64
+	if json.Unmarshal(data, &milliseconds) == nil {
+		*d = scriptDuration(milliseconds * float64(time.Millisecond))
+		return nil
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return fmt.Errorf("invalid time %s, expected a duration such as \"250ms\" or milliseconds", data)
+	}
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return fmt.Errorf("invalid time %q: %w", text, err)
+	}
+	*d = scriptDuration(duration)
+	return nil
+}
+
+// The keyframe and cue types write At as a scriptDuration. The At field of the
+// embedded alias is shadowed by the shallower one.
+
+func (k LightbarKeyframe) MarshalJSON() ([]byte, error) {
+	type keyframe LightbarKeyframe
+	return json.Marshal(struct {
+		At scriptDuration `json:"at"`
+		keyframe
+	}{scriptDuration(k.At), keyframe(k)})
+}
+
+func (k *LightbarKeyframe) UnmarshalJSON(data []byte) error {
+	type keyframe LightbarKeyframe
+	script := struct {
+		At scriptDuration `json:"at"`
+		*keyframe
+	}{keyframe: (*keyframe)(k)}
+	err := unmarshalScriptJSON(data, &script)
+	k.At = time.Duration(script.At)
+	return err
+}
+
+func (k RumbleKeyframe) MarshalJSON() ([]byte, error) {
+	type keyframe RumbleKeyframe
+	return json.Marshal(struct {
+		At scriptDuration `json:"at"`
+		keyframe
+	}{scriptDuration(k.At), keyframe(k)})
+}
+
+func (k *RumbleKeyframe) UnmarshalJSON(data []byte) error {
+	type keyframe RumbleKeyframe
+	script := struct {
+		At scriptDuration `json:"at"`
+		*keyframe
+	}{keyframe: (*keyframe)(k)}
+	err := unmarshalScriptJSON(data, &script)
+	k.At = time.Duration(script.At)
+	return err
+}
+
+func (c TriggerCue) MarshalJSON() ([]byte, error) {
+	type cue TriggerCue
+	return json.Marshal(struct {
+		At scriptDuration `json:"at"`
+		cue
+	}{scriptDuration(c.At), cue(c)})
+}
+
+func (c *TriggerCue) UnmarshalJSON(data []byte) error {
+	type cue TriggerCue
+	script := struct {
+		At scriptDuration `json:"at"`
+		*cue
+	}{cue: (*cue)(c)}
+	err := unmarshalScriptJSON(data, &script)
+	c.At = time.Duration(script.At)
+	return err
+}
+
+// unmarshalScriptJSON decodes data rejecting unknown fields, so misspelt
+// fields in hand written scripts are reported instead of ignored.
+func unmarshalScriptJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// ParseEffectScript decodes an effect script and checks its times.
+func ParseEffectScript(data []byte) (EffectScript, error) {
+	var script EffectScript
+	err := unmarshalScriptJSON(data, &script)
+	if err != nil {
+		return EffectScript{}, fmt.Errorf("json.Unmarshal: error trying to decode effect script: %w", err)
+	}
+	_, err = script.Timeline.sorted()
+	if err != nil {
+		return EffectScript{}, fmt.Errorf("error in effect script %q: %w", script.Name, err)
+	}
+	return script, nil
+}
+
+// LoadEffectScript reads an effect script, see EffectScript.
+func LoadEffectScript(path string) (EffectScript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EffectScript{}, fmt.Errorf("os.ReadFile: error trying to load effect script: %w", err)
+	}
+	script, err := ParseEffectScript(data)
+	if err != nil {
+		return EffectScript{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return script, nil
+}
+
+func SaveEffectScript(path string, script EffectScript) error {
+	data, err := json.MarshalIndent(script, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: error trying to encode effect script %q: %w", script.Name, err)
+	}
+	err = os.WriteFile(path, append(data, '\n'), 0o644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: error trying to save effect script %q: %w", script.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package dualsense
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEffectScript(t *testing.T) {
+	script, err := ParseEffectScript([]byte(`{
+		"name": "heartbeat",
+		"lightbar": [{"at": "0s", "color": {"red": 255, "green": 0, "blue": 0}}, {"at": 500, "color": {"red": 0, "green": 0, "blue": 0}}],
+		"rumble": [{"at": "0s", "left": 200, "right": 0}, {"at": "120ms", "left": 0, "right": 0}],
+		"triggers": [{"at": "1.5s", "right": {"type": "Weapon", "start": 2, "end": 5, "strength": 8}}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := EffectScript{
+		Name: "heartbeat",
+		Timeline: Timeline{
+			Lightbar: []LightbarKeyframe{{Color: LedColor{Red: 255}}, {At: 500 * time.Millisecond}},
+			Rumble:   []RumbleKeyframe{{Left: 200}, {At: 120 * time.Millisecond}},
+			Triggers: []TriggerCue{{At: 1500 * time.Millisecond, Right: &TriggerEffect{Type: EffectTypeWeapon, Start: 2, End: 5, Strength: 8}}},
+		},
+	}
+	if !reflect.DeepEqual(script, want) {
+		t.Errorf("got %+v, want %+v", script, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "heartbeat.json")
+	if err := SaveEffectScript(path, script); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadEffectScript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("round trip: got %+v, want %+v", loaded, want)
+	}
+}
+
+func TestParseEffectScriptErrors(t *testing.T) {
+	for name, script := range map[string]string{
+		"unknown field":  `{"name": "x", "rumble": [{"at": "0s", "lfet": 10}]}`,
+		"invalid time":   `{"rumble": [{"at": "soon"}]}`,
+		"negative time":  `{"lightbar": [{"at": "-1s"}]}`,
+		"invalid effect": `{"triggers": [{"at": 0, "left": {"type": "Sticky"}}]}`,
+	} {
+		if _, err := ParseEffectScript([]byte(script)); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+}
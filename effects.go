@@ -0,0 +1,138 @@
+package dualsense
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EFFECT_TICK_INTERVAL is how often RunEffects ticks the running effects.
+const EFFECT_TICK_INTERVAL = 10 * time.Millisecond
+
+// Effect is a reusable piece of controller feedback, such as a low health
+// heartbeat, run by RunEffects. Its methods change the output state passed in,
+// which RunEffects then writes, and are called from one goroutine at a time.
+type Effect interface {
+	// Start is called once, right before the first Tick.
+	Start(setStateData *SetStateData)
+	// Tick updates the output for elapsed time since Start and reports
+	// whether the effect goes on. Ticks are timed from Start, so elapsed
+	// skips ahead if writes are slow.
+	Tick(elapsed time.Duration, setStateData *SetStateData) bool
+	// Stop is called once when the effect has ended or is stopped early, to
+	// put back what it changed.
+	Stop(setStateData *SetStateData)
+}
+
+var effectRegistry = struct {
+	mu      sync.Mutex
+	effects map[string]func() Effect
+}{effects: map[string]func() Effect{}}
+
+// RegisterEffect makes an effect available by name through NewEffect, for
+// packages of reusable effects to register from their init functions. It
+// panics if name is already registered or newEffect is nil.
+func RegisterEffect(name string, newEffect func() Effect) {
+	effectRegistry.mu.Lock()
+	defer effectRegistry.mu.Unlock()
+	if newEffect == nil {
+		panic("dualsense: RegisterEffect " + name + " with nil constructor")
+	}
+	if _, ok := effectRegistry.effects[name]; ok {
+		panic("dualsense: RegisterEffect called twice for " + name)
+	}
+	effectRegistry.effects[name] = newEffect
+}
+
+// NewEffect returns a new instance of a registered effect.
+func NewEffect(name string) (Effect, error) {
+	effectRegistry.mu.Lock()
+	newEffect, ok := effectRegistry.effects[name]
+	effectRegistry.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown effect %q, expected one of %v", name, EffectNames())
+	}
+	return newEffect(), nil
+}
+
+func EffectNames() []string {
+	effectRegistry.mu.Lock()
+	defer effectRegistry.mu.Unlock()
+	names := make([]string, 0, len(effectRegistry.effects))
+	for name := range effectRegistry.effects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RunEffects runs effects together until all have ended or stop is closed,
+// writing one output report per tick with every running effect applied in
+// order, so later effects are layered over earlier ones. It blocks, so call it
+// from its own goroutine rather than from an input callback.
+func (d *DualSense) RunEffects(stop <-chan struct{}, effects ...Effect) error {
+	return runEffects(d.UpdateState, d.logger, stop, effects)
+}
+
+// runEffects runs effects as RunEffects does, through update.
+func runEffects(update func(func(*SetStateData)) error, logger *slog.Logger, stop <-chan struct{}, effects []Effect) error {
+	running := slices.Clone(effects)
+	stopEffects := func(stopped []Effect) error {
+		if len(stopped) == 0 {
+			return nil
+		}
+		return update(func(setStateData *SetStateData) {
+			for _, effect := range stopped {
+				effect.Stop(setStateData)
+			}
+		})
+	}
+	start := time.Now()
+	for tick := 0; ; tick++ {
+		elapsed := time.Duration(tick) * EFFECT_TICK_INTERVAL
+		if tick > 0 {
+			elapsed = time.Since(start).Truncate(EFFECT_TICK_INTERVAL)
+		}
+		var ended, next []Effect
+		err := update(func(setStateData *SetStateData) {
+			for _, effect := range running {
+				if tick == 0 {
+					effect.Start(setStateData)
+				}
+				if effect.Tick(elapsed, setStateData) {
+					next = append(next, effect)
+				} else {
+					ended = append(ended, effect)
+				}
+			}
+		})
+		if err != nil {
+			stopErr := stopEffects(running)
+			if stopErr != nil {
+				logger.Debug("could not stop DualSense effects", "error", stopErr)
+			}
+			return fmt.Errorf("error running effects: %w", err)
+		}
+		running = next
+		err = stopEffects(ended)
+		if err != nil {
+			return fmt.Errorf("error stopping effects: %w", err)
+		}
+		if len(running) == 0 {
+			return nil
+		}
+		timer := time.NewTimer(time.Until(start.Add(elapsed + EFFECT_TICK_INTERVAL)))
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			err = stopEffects(running)
+			if err != nil {
+				return fmt.Errorf("error stopping effects: %w", err)
+			}
+			return nil
+		}
+	}
+}
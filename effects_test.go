@@ -0,0 +1,93 @@
+package dualsense
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// pulseEffect holds the left motor at level for ticks ticks and records the
+// calls it gets.
+type pulseEffect struct {
+	level uint8
+	ticks int
+	calls []string
+}
+
+func (e *pulseEffect) Start(setStateData *SetStateData) {
+	e.calls = append(e.calls, "start")
+}
+
+func (e *pulseEffect) Tick(elapsed time.Duration, setStateData *SetStateData) bool {
+	e.calls = append(e.calls, "tick")
+	setStateData.RumbleEmulationLeft = e.level
+	return len(e.calls)-1 < e.ticks
+}
+
+func (e *pulseEffect) Stop(setStateData *SetStateData) {
+	e.calls = append(e.calls, "stop")
+	setStateData.RumbleEmulationLeft = 0
+}
+
+func TestEffectRegistry(t *testing.T) {
+	RegisterEffect("test-pulse", func() Effect { return &pulseEffect{level: 1} })
+	defer func() {
+		effectRegistry.mu.Lock()
+		delete(effectRegistry.effects, "test-pulse")
+		effectRegistry.mu.Unlock()
+	}()
+	effect, err := NewEffect("test-pulse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pulse, ok := effect.(*pulseEffect); !ok || pulse.level != 1 {
+		t.Errorf("got %#v", effect)
+	}
+	if _, err := NewEffect("missing"); err == nil || !strings.Contains(err.Error(), "test-pulse") {
+		t.Errorf("unknown effect: got %v, want an error listing test-pulse", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering test-pulse twice did not panic")
+		}
+	}()
+	RegisterEffect("test-pulse", func() Effect { return &pulseEffect{} })
+}
+
+func TestRunEffects(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.ResetOutputReports()
+
+	short, long := &pulseEffect{level: 10, ticks: 1}, &pulseEffect{level: 20, ticks: 3}
+	if err := d.RunEffects(nil, short, long); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(short.calls, " "), "start tick stop"; got != want {
+		t.Errorf("short effect: got %q, want %q", got, want)
+	}
+	if got, want := strings.Join(long.calls, " "), "start tick tick tick stop"; got != want {
+		t.Errorf("long effect: got %q, want %q", got, want)
+	}
+	var levels []uint8
+	for _, outState := range d.OutStates() {
+		levels = append(levels, outState.RumbleEmulationLeft)
+	}
+	// The later effect is layered over the earlier one.
+	if len(levels) < 2 || levels[0] != 20 || levels[len(levels)-1] != 0 {
+		t.Errorf("got left rumble %v, want 20 first and 0 last", levels)
+	}
+
+	stop := make(chan struct{})
+	close(stop)
+	endless := &pulseEffect{level: 30, ticks: 1 << 30}
+	if err := d.RunEffects(stop, endless); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(endless.calls, " "), "start tick stop"; got != want {
+		t.Errorf("stopped effect: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,390 @@
+package emulation
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+const DEFAULT_TICK_INTERVAL = 8 * time.Millisecond
+
+var dPadButtons = [4]string{"DPadUp", "DPadDown", "DPadLeft", "DPadRight"}
+
+// dPadPressed lists which of dPadButtons a D-pad direction holds down.
+var dPadPressed = map[dualsense.Direction][4]bool{
+	dualsense.DirectionNorth:     {true, false, false, false},
+	dualsense.DirectionNorthEast: {true, false, false, true},
+	dualsense.DirectionEast:      {false, false, false, true},
+	dualsense.DirectionSouthEast: {false, true, false, true},
+	dualsense.DirectionSouth:     {false, true, false, false},
+	dualsense.DirectionSouthWest: {false, true, true, false},
+	dualsense.DirectionWest:      {false, false, true, false},
+	dualsense.DirectionNorthWest: {true, false, true, false},
+}
+
+// untouched is the touchpad with both fingers lifted.
+var untouched = dualsense.TouchData{
+	TouchFinger1: dualsense.TouchFinger{NotTouching: true},
+	TouchFinger2: dualsense.TouchFinger{NotTouching: true},
+}
+
+// Engine applies a Profile to a controller. Buttons are translated as their
+// callbacks fire; sticks and the touchpad are sampled every tick while the
+// Engine is started.
+type Engine struct {
+	controller   dualsense.Controller
+	injector     Injector
+	tickInterval time.Duration
+	onErr        func(error)
+
+	mu           sync.Mutex
+	profile      Profile
+	closed       bool
+	held         map[string]Action
+	stickKeys    map[Key]bool
+	pointerX     float64
+	pointerY     float64
+	scrollX      float64
+	scrollY      float64
+	lastTouch    dualsense.TouchData
+	gyro         gyroState
+	lastTickTime time.Time
+	tickerClose  chan struct{}
+}
+
+type Option func(*Engine)
+
+// WithTickInterval sets how often sticks and the touchpad are sampled,
+// DEFAULT_TICK_INTERVAL by default.
+func WithTickInterval(interval time.Duration) Option {
+	return func(e *Engine) {
+		e.tickInterval = interval
+	}
+}
+
+// WithErrorHandler reports injector errors, which are otherwise dropped.
+func WithErrorHandler(onErr func(error)) Option {
+	return func(e *Engine) {
+		e.onErr = onErr
+	}
+}
+
+// NewEngine subscribes to controller and translates its input through
+// injector according to profile. Call Start to enable stick and touchpad
+// movement.
+func NewEngine(controller dualsense.Controller, injector Injector, profile Profile, options ...Option) (*Engine, error) {
+	err := validateProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	e := &Engine{
+		controller:   controller,
+		injector:     injector,
+		tickInterval: DEFAULT_TICK_INTERVAL,
+		profile:      profile,
+		held:         map[string]Action{},
+		stickKeys:    map[Key]bool{},
+		lastTouch:    untouched,
+	}
+	for _, option := range options {
+		option(e)
+	}
+	for _, field := range dualsense.Fields() {
+		if !isButton(field) {
+			continue
+		}
+		name := field.String()
+		controller.OnFieldChange(field, func(change dualsense.FieldChange) {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			e.button(name, change.New.(bool))
+		})
+	}
+	controller.OnFieldChange(dualsense.FieldDPad, func(change dualsense.FieldChange) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		previous := dPadPressed[change.Old.(dualsense.Direction)]
+		current := dPadPressed[change.New.(dualsense.Direction)]
+		for i, name := range dPadButtons {
+			if previous[i] != current[i] {
+				e.button(name, current[i])
+			}
+		}
+	})
+	return e, nil
+}
+
+// isButton reports whether field holds a bool.
+func isButton(field dualsense.Field) bool {
+	value, err := field.Value(&dualsense.USBGetStateData{})
+	_, ok := value.(bool)
+	return err == nil && ok
+}
+
+func validateProfile(profile Profile) error {
+	for name, action := range profile.Buttons {
+		if action.Key == "" && action.Mouse == "" {
+			return fmt.Errorf("invalid emulation profile: %s has an empty action", name)
+		}
+		if name == dPadButtons[0] || name == dPadButtons[1] || name == dPadButtons[2] || name == dPadButtons[3] {
+			continue
+		}
+		field, err := dualsense.ParseField(name)
+		if err != nil {
+			return fmt.Errorf("invalid emulation profile: %w", err)
+		}
+		if !isButton(field) {
+			return fmt.Errorf("invalid emulation profile: %s is not a button", name)
+		}
+	}
+	err := validateGyroProfile(profile.Gyro)
+	if err != nil {
+		return err
+	}
+	for _, stick := range []StickProfile{profile.LeftStick, profile.RightStick} {
+		switch stick.Mode {
+		case StickNone, StickMouse, StickScroll, StickKeys:
+		default:
+			return fmt.Errorf("invalid emulation profile: unknown stick mode %q", stick.Mode)
+		}
+		if stick.Deadzone < 0 || stick.Deadzone >= 1 {
+			return fmt.Errorf("invalid emulation profile: stick deadzone %v out of range [0, 1)", stick.Deadzone)
+		}
+	}
+	return nil
+}
+
+// Start samples sticks and the touchpad every tick until Close.
+func (e *Engine) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.tickerClose != nil || e.closed {
+		return
+	}
+	e.tickerClose = make(chan struct{})
+	e.lastTickTime = time.Now()
+	go e.tickLoop(e.tickerClose)
+}
+
+func (e *Engine) tickLoop(tickerClose chan struct{}) {
+	ticker := time.NewTicker(e.tickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tickerClose:
+			return
+		case now := <-ticker.C:
+			state := e.controller.GetInStateData()
+			e.mu.Lock()
+			e.tick(&state, now.Sub(e.lastTickTime))
+			e.lastTickTime = now
+			e.mu.Unlock()
+		}
+	}
+}
+
+// Close stops the Engine and releases every key and mouse button it holds.
+func (e *Engine) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.tickerClose != nil {
+		close(e.tickerClose)
+		e.tickerClose = nil
+	}
+	e.releaseAll()
+	e.closed = true
+}
+
+// Profile returns the active profile.
+func (e *Engine) Profile() Profile {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.profile
+}
+
+// SetProfile releases everything held under the old profile and switches to
+// profile.
+func (e *Engine) SetProfile(profile Profile) error {
+	err := validateProfile(profile)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.releaseAll()
+	e.profile = profile
+	return nil
+}
+
+func (e *Engine) report(err error) {
+	if err != nil && e.onErr != nil {
+		e.onErr(fmt.Errorf("error injecting input: %w", err))
+	}
+}
+
+func (e *Engine) press(action Action) {
+	if action.Key != "" {
+		e.report(e.injector.KeyDown(action.Key))
+	}
+	if action.Mouse != "" {
+		e.report(e.injector.MouseButtonDown(action.Mouse))
+	}
+}
+
+func (e *Engine) release(action Action) {
+	if action.Mouse != "" {
+		e.report(e.injector.MouseButtonUp(action.Mouse))
+	}
+	if action.Key != "" {
+		e.report(e.injector.KeyUp(action.Key))
+	}
+}
+
+// button presses or releases the action mapped to name. Releases use the
+// action that was pressed, so profile switches never leave keys stuck. Toggle
+// actions ignore the button release and are released by the next press.
+func (e *Engine) button(name string, pressed bool) {
+	if e.closed {
+		return
+	}
+	held, isHeld := e.held[name]
+	if !pressed {
+		if isHeld && !held.Toggle {
+			delete(e.held, name)
+			e.release(held)
+		}
+		return
+	}
+	if isHeld && held.Toggle {
+		delete(e.held, name)
+		e.release(held)
+		return
+	}
+	action, ok := e.profile.Buttons[name]
+	if !ok {
+		return
+	}
+	e.held[name] = action
+	e.press(action)
+}
+
+// releaseAll releases in name order so the injected sequence is reproducible.
+func (e *Engine) releaseAll() {
+	names := make([]string, 0, len(e.held))
+	for name := range e.held {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		e.release(e.held[name])
+		delete(e.held, name)
+	}
+	keys := make([]Key, 0, len(e.stickKeys))
+	for key := range e.stickKeys {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		e.report(e.injector.KeyUp(key))
+		delete(e.stickKeys, key)
+	}
+	e.pointerX, e.pointerY, e.scrollX, e.scrollY = 0, 0, 0, 0
+	e.lastTouch = untouched
+	e.gyro.resetSmoothing()
+}
+
+// stickDeflection maps raw stick values to [-1, 1] with a radial deadzone,
+// rescaled so motion starts from 0 at the deadzone edge.
+func stickDeflection(rawX, rawY uint8, deadzone float64) (float64, float64) {
+	x := (float64(rawX) - 127.5) / 127.5
+	y := (float64(rawY) - 127.5) / 127.5
+	magnitude := math.Hypot(x, y)
+	if magnitude <= deadzone {
+		return 0, 0
+	}
+	scale := min((magnitude-deadzone)/(1-deadzone), 1) / magnitude
+	return x * scale, y * scale
+}
+
+// take returns the whole part of *accumulator and keeps the remainder, so
+// slow movements still add up to whole pixels.
+func take(accumulator *float64) int {
+	whole := math.Trunc(*accumulator)
+	*accumulator -= whole
+	return int(whole)
+}
+
+func (e *Engine) tick(state *dualsense.USBGetStateData, elapsed time.Duration) {
+	if e.closed {
+		return
+	}
+	seconds := elapsed.Seconds()
+	var moveX, moveY float64
+	for i, stick := range []StickProfile{e.profile.LeftStick, e.profile.RightStick} {
+		rawX, rawY := state.LeftStickX, state.LeftStickY
+		if i == 1 {
+			rawX, rawY = state.RightStickX, state.RightStickY
+		}
+		x, y := stickDeflection(rawX, rawY, stick.Deadzone)
+		switch stick.Mode {
+		case StickMouse:
+			moveX += x * stick.Speed * seconds
+			moveY += y * stick.Speed * seconds
+		case StickScroll:
+			// Stick Y grows downwards, wheel notches grow upwards.
+			e.scrollX += x * stick.Speed * seconds
+			e.scrollY -= y * stick.Speed * seconds
+		case StickKeys:
+			e.stickKey(stick.Keys[0], y < -0.5)
+			e.stickKey(stick.Keys[1], y > 0.5)
+			e.stickKey(stick.Keys[2], x < -0.5)
+			e.stickKey(stick.Keys[3], x > 0.5)
+		}
+	}
+
+	scroll, scrolling := dualsense.TwoFingerScroll(e.lastTouch, state.TouchData)
+	scrolling = scrolling && e.profile.TouchpadScrollSpeed > 0
+	if scrolling {
+		// As with a stick, moving down scrolls down.
+		e.scrollX += float64(scroll.DX) * e.profile.TouchpadScrollSpeed
+		e.scrollY -= float64(scroll.DY) * e.profile.TouchpadScrollSpeed
+	}
+	finger, lastFinger := state.TouchData.TouchFinger1, e.lastTouch.TouchFinger1
+	if e.profile.TouchpadSpeed > 0 && !scrolling && !finger.NotTouching && !lastFinger.NotTouching && finger.Index == lastFinger.Index {
+		moveX += (float64(finger.FingerX) - float64(lastFinger.FingerX)) * e.profile.TouchpadSpeed
+		moveY += (float64(finger.FingerY) - float64(lastFinger.FingerY)) * e.profile.TouchpadSpeed
+	}
+	e.lastTouch = state.TouchData
+
+	gyroX, gyroY := e.gyroDelta(state, seconds)
+	moveX += gyroX
+	moveY += gyroY
+
+	e.pointerX += moveX
+	e.pointerY += moveY
+	dx, dy := take(&e.pointerX), take(&e.pointerY)
+	if dx != 0 || dy != 0 {
+		e.report(e.injector.MouseMove(dx, dy))
+	}
+	scrollX, scrollY := take(&e.scrollX), take(&e.scrollY)
+	if scrollX != 0 || scrollY != 0 {
+		e.report(e.injector.MouseScroll(scrollX, scrollY))
+	}
+}
+
+func (e *Engine) stickKey(key Key, pressed bool) {
+	if key == "" || e.stickKeys[key] == pressed {
+		return
+	}
+	if pressed {
+		e.stickKeys[key] = true
+		e.report(e.injector.KeyDown(key))
+	} else {
+		delete(e.stickKeys, key)
+		e.report(e.injector.KeyUp(key))
+	}
+}
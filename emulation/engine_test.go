@@ -0,0 +1,211 @@
+package emulation
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+type recordingInjector struct {
+	events []string
+}
+
+func (r *recordingInjector) record(format string, args ...any) error {
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (r *recordingInjector) KeyDown(key Key) error { return r.record("down %s", key) }
+
+func (r *recordingInjector) KeyUp(key Key) error { return r.record("up %s", key) }
+
+func (r *recordingInjector) MouseButtonDown(button MouseButton) error {
+	return r.record("mouse down %s", button)
+}
+
+func (r *recordingInjector) MouseButtonUp(button MouseButton) error {
+	return r.record("mouse up %s", button)
+}
+
+func (r *recordingInjector) MouseMove(dx, dy int) error { return r.record("move %d %d", dx, dy) }
+
+func (r *recordingInjector) MouseScroll(dx, dy int) error { return r.record("scroll %d %d", dx, dy) }
+
+func newTestEngine(t *testing.T, profile Profile) (*Engine, *dualsense.MockDualSense, *recordingInjector) {
+	mock := dualsense.NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mock.Close)
+	injector := &recordingInjector{}
+	engine, err := NewEngine(mock, injector, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine, mock, injector
+}
+
+func TestEngineButtons(t *testing.T) {
+	engine, mock, injector := newTestEngine(t, DefaultProfile())
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.DPad = dualsense.DirectionNone
+	})
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = true
+		state.DPad = dualsense.DirectionNorthEast
+	})
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.DPad = dualsense.DirectionEast
+	})
+	// Switching profiles releases what the old profile holds.
+	if err := engine.SetProfile(Profile{Buttons: map[string]Action{"ButtonCross": {Key: "A"}}}); err != nil {
+		t.Fatal(err)
+	}
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = false
+	})
+	want := []string{
+		"mouse down Left",
+		"down Up",
+		"down Right",
+		"up Up",
+		"mouse up Left",
+		"up Right",
+	}
+	if !reflect.DeepEqual(injector.events, want) {
+		t.Fatalf("got %q, want %q", injector.events, want)
+	}
+}
+
+func TestEngineToggleButtons(t *testing.T) {
+	profile := Profile{Buttons: map[string]Action{
+		"ButtonL2":    {Mouse: MouseLeft, Toggle: true},
+		"ButtonCross": {Key: "A"},
+	}}
+	engine, mock, injector := newTestEngine(t, profile)
+	press := func(pressed bool) {
+		mock.UpdateInState(func(state *dualsense.USBGetStateData) { state.ButtonL2 = pressed })
+	}
+	press(true)
+	press(false)
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) { state.ButtonCross = true })
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) { state.ButtonCross = false })
+	press(true)
+	press(false)
+	// A latched toggle is released when the engine closes.
+	press(true)
+	press(false)
+	engine.Close()
+	want := []string{
+		"mouse down Left",
+		"down A",
+		"up A",
+		"mouse up Left",
+		"mouse down Left",
+		"mouse up Left",
+	}
+	if !reflect.DeepEqual(injector.events, want) {
+		t.Fatalf("got %q, want %q", injector.events, want)
+	}
+}
+
+func TestEngineTick(t *testing.T) {
+	profile := Profile{
+		LeftStick:     StickProfile{Mode: StickKeys, Keys: [4]Key{"W", "S", "A", "D"}},
+		RightStick:    StickProfile{Mode: StickMouse, Deadzone: 0.1, Speed: 1000},
+		TouchpadSpeed: 1,
+	}
+	engine, _, injector := newTestEngine(t, profile)
+	state := dualsense.USBGetStateData{LeftStickX: 128, LeftStickY: 0, RightStickX: 255, RightStickY: 128}
+	state.TouchData.TouchFinger1 = dualsense.TouchFinger{NotTouching: true}
+	engine.tick(&state, 10*time.Millisecond)
+	state.TouchData.TouchFinger1 = dualsense.TouchFinger{FingerX: 100, FingerY: 100}
+	state.RightStickX = 128
+	engine.tick(&state, 10*time.Millisecond)
+	state.TouchData.TouchFinger1 = dualsense.TouchFinger{FingerX: 130, FingerY: 90}
+	engine.tick(&state, 10*time.Millisecond)
+	engine.Close()
+	want := []string{
+		"down W",
+		"move 9 0",
+		"move 30 -9", // Includes the sub-pixel remainder of the first tick.
+		"up W",
+	}
+	if !reflect.DeepEqual(injector.events, want) {
+		t.Fatalf("got %q, want %q", injector.events, want)
+	}
+}
+
+func TestEngineTwoFingerScroll(t *testing.T) {
+	profile := Profile{TouchpadSpeed: 1, TouchpadScrollSpeed: 0.1}
+	engine, _, injector := newTestEngine(t, profile)
+	touch := func(y uint16) *dualsense.USBGetStateData {
+		state := &dualsense.USBGetStateData{}
+		state.TouchData.TouchFinger1 = dualsense.TouchFinger{Index: 1, FingerX: 500, FingerY: y}
+		state.TouchData.TouchFinger2 = dualsense.TouchFinger{Index: 2, FingerX: 700, FingerY: y}
+		return state
+	}
+	engine.tick(touch(500), 10*time.Millisecond)
+	engine.tick(touch(530), 10*time.Millisecond)
+	engine.tick(touch(505), 10*time.Millisecond)
+	engine.Close()
+	// Two fingers scroll without moving the pointer.
+	want := []string{"scroll 0 -3", "scroll 0 2"}
+	if !reflect.DeepEqual(injector.events, want) {
+		t.Fatalf("got %q, want %q", injector.events, want)
+	}
+}
+
+func TestNewEngineRejectsInvalidProfiles(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	for _, profile := range []Profile{
+		{Buttons: map[string]Action{"ButtonNope": {Key: "A"}}},
+		{Buttons: map[string]Action{"LeftStickX": {Key: "A"}}},
+		{Buttons: map[string]Action{"ButtonCross": {}}},
+		{LeftStick: StickProfile{Mode: "joystick"}},
+	} {
+		if _, err := NewEngine(mock, &recordingInjector{}, profile); err == nil {
+			t.Errorf("NewEngine(%+v): expected error", profile)
+		}
+	}
+}
+
+func TestEngineGyro(t *testing.T) {
+	profile := Profile{Gyro: GyroProfile{Enabled: true, Sensitivity: 10, Activation: "ButtonL2", SmoothingThreshold: 5}}
+	engine, _, injector := newTestEngine(t, profile)
+
+	// A constant drift of 128 counts (7.8125°/s) is measured as bias while
+	// resting.
+	state := dualsense.USBGetStateData{AngularVelocityY: 128}
+	engine.CalibrateGyro(4)
+	for i := 0; i < 4; i++ {
+		engine.tick(&state, 10*time.Millisecond)
+	}
+	// Not held: no movement.
+	state.AngularVelocityY = 128 + 2048 // 125°/s above the bias
+	engine.tick(&state, 100*time.Millisecond)
+	// A fast flick passes through unsmoothed, minus the bias.
+	state.ButtonL2 = true
+	engine.tick(&state, 100*time.Millisecond)
+	want := []string{"move -125 0"}
+	if !reflect.DeepEqual(injector.events, want) {
+		t.Fatalf("got %q, want %q", injector.events, want)
+	}
+}
+
+func TestGyroSmoothing(t *testing.T) {
+	var gyro gyroState
+	// Slow motion is spread over the window.
+	x, _ := gyro.smooth(1, 0, 10)
+	if x != 1.0/GYRO_SMOOTHING_SAMPLES {
+		t.Fatalf("got %v, want %v", x, 1.0/GYRO_SMOOTHING_SAMPLES)
+	}
+	gyro.resetSmoothing()
+	x, _ = gyro.smooth(20, 0, 10)
+	if x != 20 {
+		t.Fatalf("got %v, want 20", x)
+	}
+}
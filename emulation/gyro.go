@@ -0,0 +1,125 @@
+package emulation
+
+import (
+	"fmt"
+	"math"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+const (
+	// GYRO_COUNTS_PER_DEGREE converts raw angular velocity to degrees per
+	// second for the ±2000°/s range the DualSense reports in.
+	GYRO_COUNTS_PER_DEGREE = 32768.0 / 2000
+	GYRO_SMOOTHING_SAMPLES = 8
+)
+
+// GyroProfile configures the gyro mouse. Turning the controller left/right
+// (yaw) moves the pointer horizontally, tilting it (pitch) vertically.
+type GyroProfile struct {
+	Enabled bool `json:"enabled"`
+	// Sensitivity is pointer pixels per degree of rotation.
+	Sensitivity float64 `json:"sensitivity"`
+	// Activation is a button field name that must be held for the gyro to
+	// move the pointer; empty keeps the gyro always active.
+	Activation string `json:"activation,omitempty"`
+	// SmoothingThreshold in degrees per second: slower rotations are
+	// averaged to hide hand tremor while fast flicks pass through
+	// unsmoothed. 0 disables smoothing.
+	SmoothingThreshold float64 `json:"smoothingThreshold"`
+	InvertX            bool    `json:"invertX"`
+	InvertY            bool    `json:"invertY"`
+}
+
+// gyroState holds the bias calibration and the smoothing window.
+type gyroState struct {
+	biasX, biasY float64
+	calibrating  int
+	sumX, sumY   float64
+	samples      int
+	window       [GYRO_SMOOTHING_SAMPLES][2]float64
+	windowNext   int
+}
+
+func validateGyroProfile(gyro GyroProfile) error {
+	if gyro.Activation == "" {
+		return nil
+	}
+	field, err := dualsense.ParseField(gyro.Activation)
+	if err != nil {
+		return fmt.Errorf("invalid emulation profile: gyro activation: %w", err)
+	}
+	if !isButton(field) {
+		return fmt.Errorf("invalid emulation profile: gyro activation %s is not a button", gyro.Activation)
+	}
+	return nil
+}
+
+// CalibrateGyro measures gyro bias over the next samples ticks; keep the
+// controller still meanwhile. The gyro does not move the pointer until
+// calibration completes.
+func (e *Engine) CalibrateGyro(samples int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.gyro.calibrating = samples
+	e.gyro.sumX, e.gyro.sumY, e.gyro.samples = 0, 0, 0
+}
+
+// smooth applies tiered smoothing: the share of a sample below threshold is
+// averaged over the window, the rest passes through directly.
+func (g *gyroState) smooth(x, y, threshold float64) (float64, float64) {
+	if threshold <= 0 {
+		return x, y
+	}
+	directWeight := min(max((math.Hypot(x, y)-threshold/2)/(threshold/2), 0), 1)
+	g.window[g.windowNext] = [2]float64{x * (1 - directWeight), y * (1 - directWeight)}
+	g.windowNext = (g.windowNext + 1) % len(g.window)
+	var smoothX, smoothY float64
+	for _, sample := range g.window {
+		smoothX += sample[0]
+		smoothY += sample[1]
+	}
+	return x*directWeight + smoothX/float64(len(g.window)), y*directWeight + smoothY/float64(len(g.window))
+}
+
+func (g *gyroState) resetSmoothing() {
+	g.window = [GYRO_SMOOTHING_SAMPLES][2]float64{}
+}
+
+// gyroDelta returns pointer movement in pixels for one tick.
+func (e *Engine) gyroDelta(state *dualsense.USBGetStateData, seconds float64) (float64, float64) {
+	gyro := e.profile.Gyro
+	if !gyro.Enabled {
+		return 0, 0
+	}
+	// Yaw turns left with positive values, pitch tilts up.
+	yaw := float64(state.AngularVelocityY) / GYRO_COUNTS_PER_DEGREE
+	pitch := float64(state.AngularVelocityX) / GYRO_COUNTS_PER_DEGREE
+	if e.gyro.calibrating > 0 {
+		e.gyro.sumX += yaw
+		e.gyro.sumY += pitch
+		e.gyro.samples++
+		e.gyro.calibrating--
+		if e.gyro.calibrating == 0 {
+			e.gyro.biasX = e.gyro.sumX / float64(e.gyro.samples)
+			e.gyro.biasY = e.gyro.sumY / float64(e.gyro.samples)
+		}
+		return 0, 0
+	}
+	if gyro.Activation != "" {
+		field, _ := dualsense.ParseField(gyro.Activation)
+		value, _ := field.Value(state)
+		if pressed, _ := value.(bool); !pressed {
+			e.gyro.resetSmoothing()
+			return 0, 0
+		}
+	}
+	x, y := e.gyro.smooth(-(yaw - e.gyro.biasX), -(pitch - e.gyro.biasY), gyro.SmoothingThreshold)
+	if gyro.InvertX {
+		x = -x
+	}
+	if gyro.InvertY {
+		y = -y
+	}
+	return x * gyro.Sensitivity * seconds, y * gyro.Sensitivity * seconds
+}
@@ -0,0 +1,29 @@
+// Package emulation turns DualSense input into desktop keyboard and mouse
+// events through a pluggable Injector.
+package emulation
+
+// Key names a keyboard key: "A"-"Z", "0"-"9", "F1"-"F12", "Space", "Enter",
+// "Escape", "Tab", "Backspace", "Shift", "Ctrl", "Alt", "Meta", "Up", "Down",
+// "Left", "Right", "Home", "End", "PageUp", "PageDown", "Minus" and "Equal".
+// Injectors translate names to their platform's key codes.
+type Key string
+
+type MouseButton string
+
+const (
+	MouseLeft   MouseButton = "Left"
+	MouseRight  MouseButton = "Right"
+	MouseMiddle MouseButton = "Middle"
+)
+
+// Injector delivers synthetic input to the operating system.
+type Injector interface {
+	KeyDown(key Key) error
+	KeyUp(key Key) error
+	MouseButtonDown(button MouseButton) error
+	MouseButtonUp(button MouseButton) error
+	// MouseMove moves the pointer by a relative amount in pixels.
+	MouseMove(dx, dy int) error
+	// MouseScroll scrolls by whole wheel notches.
+	MouseScroll(dx, dy int) error
+}
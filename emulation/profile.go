@@ -0,0 +1,109 @@
+package emulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Action is what a button does: press Key, press Mouse, or both. With Toggle
+// set, a press latches the action on until the button is pressed again, for
+// users who cannot hold buttons.
+type Action struct {
+	Key    Key         `json:"key,omitempty"`
+	Mouse  MouseButton `json:"mouse,omitempty"`
+	Toggle bool        `json:"toggle,omitempty"`
+}
+
+type StickMode string
+
+const (
+	StickNone   StickMode = ""
+	StickMouse  StickMode = "mouse"  // Moves the pointer
+	StickScroll StickMode = "scroll" // Scrolls the wheel
+	StickKeys   StickMode = "keys"   // Holds Up/Down/Left/Right keys
+)
+
+// StickProfile configures one analog stick.
+type StickProfile struct {
+	Mode     StickMode `json:"mode"`
+	Deadzone float64   `json:"deadzone"` // 0-1 fraction of full deflection
+	// Speed is pixels per second for StickMouse and notches per second for
+	// StickScroll at full deflection.
+	Speed float64 `json:"speed"`
+	// Keys held in StickKeys mode, in the order up, down, left, right.
+	Keys [4]Key `json:"keys,omitempty"`
+}
+
+// Profile maps controller input to keyboard and mouse events. Buttons are
+// keyed by dualsense.Field name ("ButtonCross") or "DPadUp", "DPadDown",
+// "DPadLeft" and "DPadRight".
+type Profile struct {
+	Name       string            `json:"name"`
+	Buttons    map[string]Action `json:"buttons"`
+	LeftStick  StickProfile      `json:"leftStick"`
+	RightStick StickProfile      `json:"rightStick"`
+	// TouchpadSpeed scales touchpad finger motion into pointer pixels; 0
+	// disables the touchpad as a trackpad.
+	TouchpadSpeed float64 `json:"touchpadSpeed"`
+	// TouchpadScrollSpeed scales the motion of two fingers moving together on
+	// the touchpad into wheel notches; 0 disables two-finger scrolling. The
+	// pointer does not move while two fingers scroll.
+	TouchpadScrollSpeed float64     `json:"touchpadScrollSpeed"`
+	Gyro                GyroProfile `json:"gyro"`
+}
+
+// DefaultProfile is a desktop layout: right stick and touchpad move the
+// pointer, left stick and two fingers on the touchpad scroll, Cross and Circle
+// click, and the D-pad sends arrow keys. Holding L2 aims the pointer with the
+// gyro.
+func DefaultProfile() Profile {
+	return Profile{
+		Name: "Desktop",
+		Buttons: map[string]Action{
+			"ButtonCross":    {Mouse: MouseLeft},
+			"ButtonCircle":   {Mouse: MouseRight},
+			"ButtonR3":       {Mouse: MouseMiddle},
+			"ButtonSquare":   {Key: "Enter"},
+			"ButtonTriangle": {Key: "Escape"},
+			"ButtonOptions":  {Key: "Meta"},
+			"ButtonL1":       {Key: "Shift"},
+			"ButtonR1":       {Key: "Ctrl"},
+			"DPadUp":         {Key: "Up"},
+			"DPadDown":       {Key: "Down"},
+			"DPadLeft":       {Key: "Left"},
+			"DPadRight":      {Key: "Right"},
+		},
+		LeftStick:     StickProfile{Mode: StickScroll, Deadzone: 0.15, Speed: 20},
+		RightStick:    StickProfile{Mode: StickMouse, Deadzone: 0.1, Speed: 1500},
+		TouchpadSpeed: 1,
+		// A notch per 50 touchpad units, about 20 over the height of the pad.
+		TouchpadScrollSpeed: 0.02,
+		Gyro:                GyroProfile{Enabled: true, Sensitivity: 20, Activation: "ButtonL2", SmoothingThreshold: 5},
+	}
+}
+
+func SaveProfile(path string, profile Profile) error {
+	data, err := json.MarshalIndent(profile, "", "\t")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: error trying to encode emulation profile: %w", err)
+	}
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: error trying to save emulation profile: %w", err)
+	}
+	return nil
+}
+
+func LoadProfile(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("os.ReadFile: error trying to read emulation profile: %w", err)
+	}
+	var profile Profile
+	err = json.Unmarshal(data, &profile)
+	if err != nil {
+		return Profile{}, fmt.Errorf("json.Unmarshal: error trying to decode emulation profile: %w", err)
+	}
+	return profile, nil
+}
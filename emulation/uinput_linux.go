@@ -0,0 +1,190 @@
+package emulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Event types and codes from linux/input-event-codes.h.
+const (
+	evSyn      = 0x00
+	evKey      = 0x01
+	evRel      = 0x02
+	relX       = 0x00
+	relY       = 0x01
+	relHWheel  = 0x06
+	relWheel   = 0x08
+	busVirtual = 0x06
+)
+
+var uinputKeyCodes = map[Key]uint16{
+	"Escape": 1, "1": 2, "2": 3, "3": 4, "4": 5, "5": 6, "6": 7, "7": 8, "8": 9, "9": 10, "0": 11,
+	"Minus": 12, "Equal": 13, "Backspace": 14, "Tab": 15,
+	"Q": 16, "W": 17, "E": 18, "R": 19, "T": 20, "Y": 21, "U": 22, "I": 23, "O": 24, "P": 25,
+	"Enter": 28, "Ctrl": 29,
+	"A": 30, "S": 31, "D": 32, "F": 33, "G": 34, "H": 35, "J": 36, "K": 37, "L": 38,
+	"Shift": 42,
+	"Z":     44, "X": 45, "C": 46, "V": 47, "B": 48, "N": 49, "M": 50,
+	"Alt": 56, "Space": 57,
+	"F1": 59, "F2": 60, "F3": 61, "F4": 62, "F5": 63, "F6": 64, "F7": 65, "F8": 66, "F9": 67, "F10": 68,
+	"F11": 87, "F12": 88,
+	"Home": 102, "Up": 103, "PageUp": 104, "Left": 105, "Right": 106, "End": 107, "Down": 108, "PageDown": 109,
+	"Meta": 125,
+}
+
+var uinputButtonCodes = map[MouseButton]uint16{
+	MouseLeft:   0x110,
+	MouseRight:  0x111,
+	MouseMiddle: 0x112,
+}
+
+// uinputSetup mirrors struct uinput_setup.
+type uinputSetup struct {
+	BusType      uint16
+	Vendor       uint16
+	Product      uint16
+	Version      uint16
+	Name         [80]byte
+	FFEffectsMax uint32
+}
+
+// inputEvent mirrors struct input_event.
+type inputEvent struct {
+	Time  syscall.Timeval
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+func uinputRequest(direction, nr, size uintptr) uintptr {
+	return direction<<30 | size<<16 | 'U'<<8 | nr
+}
+
+var (
+	uiDevCreate  = uinputRequest(0, 1, 0)
+	uiDevDestroy = uinputRequest(0, 2, 0)
+	uiDevSetup   = uinputRequest(1, 3, unsafe.Sizeof(uinputSetup{}))
+	uiSetEvBit   = uinputRequest(1, 100, unsafe.Sizeof(int32(0)))
+	uiSetKeyBit  = uinputRequest(1, 101, unsafe.Sizeof(int32(0)))
+	uiSetRelBit  = uinputRequest(1, 102, unsafe.Sizeof(int32(0)))
+)
+
+// UinputInjector creates a virtual keyboard and mouse through Linux
+// /dev/uinput. It needs write access to /dev/uinput.
+type UinputInjector struct {
+	file *os.File
+}
+
+func NewUinputInjector(name string) (*UinputInjector, error) {
+	file, err := os.OpenFile("/dev/uinput", os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: error trying to open uinput: %w", err)
+	}
+	injector := &UinputInjector{file: file}
+	err = injector.setup(name)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error trying to create uinput device: %w", err)
+	}
+	return injector, nil
+}
+
+func (u *UinputInjector) ioctl(request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, u.file.Fd(), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// ioctlValue makes an ioctl taking an integer argument rather than a pointer.
+func (u *UinputInjector) ioctlValue(request, value uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, u.file.Fd(), request, value)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func (u *UinputInjector) setup(name string) error {
+	for _, bit := range [][2]uintptr{{uiSetEvBit, evKey}, {uiSetEvBit, evRel}, {uiSetRelBit, relX}, {uiSetRelBit, relY}, {uiSetRelBit, relWheel}, {uiSetRelBit, relHWheel}} {
+		err := u.ioctlValue(bit[0], bit[1])
+		if err != nil {
+			return err
+		}
+	}
+	for _, code := range uinputKeyCodes {
+		err := u.ioctlValue(uiSetKeyBit, uintptr(code))
+		if err != nil {
+			return err
+		}
+	}
+	for _, code := range uinputButtonCodes {
+		err := u.ioctlValue(uiSetKeyBit, uintptr(code))
+		if err != nil {
+			return err
+		}
+	}
+	setup := uinputSetup{BusType: busVirtual}
+	copy(setup.Name[:len(setup.Name)-1], name)
+	err := u.ioctl(uiDevSetup, unsafe.Pointer(&setup))
+	if err != nil {
+		return err
+	}
+	return u.ioctlValue(uiDevCreate, 0)
+}
+
+// emit writes events followed by a SYN_REPORT.
+func (u *UinputInjector) emit(events ...inputEvent) error {
+	var buffer bytes.Buffer
+	for _, event := range append(events, inputEvent{Type: evSyn}) {
+		binary.Write(&buffer, binary.NativeEndian, event)
+	}
+	_, err := u.file.Write(buffer.Bytes())
+	return err
+}
+
+func (u *UinputInjector) key(key Key, value int32) error {
+	code, ok := uinputKeyCodes[key]
+	if !ok {
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return u.emit(inputEvent{Type: evKey, Code: code, Value: value})
+}
+
+func (u *UinputInjector) mouseButton(button MouseButton, value int32) error {
+	code, ok := uinputButtonCodes[button]
+	if !ok {
+		return fmt.Errorf("unknown mouse button %q", button)
+	}
+	return u.emit(inputEvent{Type: evKey, Code: code, Value: value})
+}
+
+func (u *UinputInjector) KeyDown(key Key) error { return u.key(key, 1) }
+
+func (u *UinputInjector) KeyUp(key Key) error { return u.key(key, 0) }
+
+func (u *UinputInjector) MouseButtonDown(button MouseButton) error {
+	return u.mouseButton(button, 1)
+}
+
+func (u *UinputInjector) MouseButtonUp(button MouseButton) error {
+	return u.mouseButton(button, 0)
+}
+
+func (u *UinputInjector) MouseMove(dx, dy int) error {
+	return u.emit(inputEvent{Type: evRel, Code: relX, Value: int32(dx)}, inputEvent{Type: evRel, Code: relY, Value: int32(dy)})
+}
+
+func (u *UinputInjector) MouseScroll(dx, dy int) error {
+	return u.emit(inputEvent{Type: evRel, Code: relHWheel, Value: int32(dx)}, inputEvent{Type: evRel, Code: relWheel, Value: int32(dy)})
+}
+
+func (u *UinputInjector) Close() error {
+	u.ioctlValue(uiDevDestroy, 0)
+	return u.file.Close()
+}
@@ -0,0 +1,211 @@
+package dualsense
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var directionNames = map[Direction]string{
+	DirectionNorth:     "North",
+	DirectionNorthEast: "NorthEast",
+	DirectionEast:      "East",
+	DirectionSouthEast: "SouthEast",
+	DirectionSouth:     "South",
+	DirectionSouthWest: "SouthWest",
+	DirectionWest:      "West",
+	DirectionNorthWest: "NorthWest",
+	DirectionNone:      "None",
+}
+
+var powerStateNames = map[PowerState]string{
+	PowerStateDischarging:         "Discharging",
+	PowerStateCharging:            "Charging",
+	PowerStateComplete:            "Complete",
+	PowerStateAbnormalVoltage:     "AbnormalVoltage",
+	PowerStateAbnormalTemperature: "AbnormalTemperature",
+	PowerStateChargingError:       "ChargingError",
+}
+
+var muteLightModeNames = map[MuteLightMode]string{
+	MuteLightModeOff:       "Off",
+	MuteLightModeOn:        "On",
+	MuteLightModeBreathing: "Breathing",
+	MuteLightModeDoNothing: "DoNothing",
+	MuteLightModeNoAction4: "NoAction4",
+	MuteLightModeNoAction5: "NoAction5",
+	MuteLightModeNoAction6: "NoAction6",
+	MuteLightModeNoAction7: "NoAction7",
+}
+
+var lightFadeAnimationNames = map[LightFadeAnimation]string{
+	LightFadeAnimationNothing: "Nothing",
+	LightFadeAnimationFadeIn:  "FadeIn",
+	LightFadeAnimationFadeOut: "FadeOut",
+}
+
+var lightBrightnessNames = map[LightBrightness]string{
+	LightBrightnessBright:    "Bright",
+	LightBrightnessMid:       "Mid",
+	LightBrightnessDim:       "Dim",
+	LightBrightnessNoAction3: "NoAction3",
+	LightBrightnessNoAction4: "NoAction4",
+	LightBrightnessNoAction5: "NoAction5",
+	LightBrightnessNoAction6: "NoAction6",
+	LightBrightnessNoAction7: "NoAction7",
+}
+
+var micSelectNames = map[MicSelectType]string{
+	MicSelectAuto:         "Auto",
+	MicSelectInternalOnly: "InternalOnly",
+	MicSelectExternalOnly: "ExternalOnly",
+	MicSelectUnknown:      "Unknown",
+}
+
+var effectTypeNames = map[EffectType]string{
+	EffectTypeOff:       "Off",
+	EffectTypeFeedback:  "Feedback",
+	EffectTypeWeapon:    "Weapon",
+	EffectTypeVibration: "Vibration",
+}
+
+var lifecycleStateNames = map[LifecycleState]string{
+	LifecycleCreated:      "Created",
+	LifecycleStarted:      "Started",
+	LifecycleStopped:      "Stopped",
+	LifecycleClosed:       "Closed",
+	LifecycleDisconnected: "Disconnected",
+}
+
+var edgeProfileSlotNames = map[EdgeProfileSlot]string{
+	EdgeProfileTriangle: "Triangle",
+	EdgeProfileSquare:   "Square",
+	EdgeProfileCross:    "Cross",
+	EdgeProfileCircle:   "Circle",
+}
+
+var shapeNames = map[Shape]string{
+	ShapeCircle:    "Circle",
+	ShapeZ:         "Z",
+	ShapeCheckmark: "Checkmark",
+}
+
+var stickNames = map[Stick]string{
+	StickLeft:  "Left",
+	StickRight: "Right",
+}
+
+// enumString falls back to the numeric value for values without a name, so
+// undocumented values reported by the controller survive a round trip.
+func enumString[T ~uint8](value T, names map[T]string) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return strconv.Itoa(int(value))
+}
+
+func parseEnum[T ~uint8](text []byte, names map[T]string, typeName string) (T, error) {
+	for value, name := range names {
+		if name == string(text) {
+			return value, nil
+		}
+	}
+	number, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", typeName, text)
+	}
+	return T(number), nil
+}
+
+func (d Direction) String() string { return enumString(d, directionNames) }
+
+func (d Direction) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+func (d *Direction) UnmarshalText(text []byte) (err error) {
+	*d, err = parseEnum(text, directionNames, "Direction")
+	return err
+}
+
+func (p PowerState) String() string { return enumString(p, powerStateNames) }
+
+func (p PowerState) MarshalText() ([]byte, error) { return []byte(p.String()), nil }
+
+func (p *PowerState) UnmarshalText(text []byte) (err error) {
+	*p, err = parseEnum(text, powerStateNames, "PowerState")
+	return err
+}
+
+func (m MuteLightMode) String() string { return enumString(m, muteLightModeNames) }
+
+func (m MuteLightMode) MarshalText() ([]byte, error) { return []byte(m.String()), nil }
+
+func (m *MuteLightMode) UnmarshalText(text []byte) (err error) {
+	*m, err = parseEnum(text, muteLightModeNames, "MuteLightMode")
+	return err
+}
+
+func (l LightFadeAnimation) String() string { return enumString(l, lightFadeAnimationNames) }
+
+func (l LightFadeAnimation) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+func (l *LightFadeAnimation) UnmarshalText(text []byte) (err error) {
+	*l, err = parseEnum(text, lightFadeAnimationNames, "LightFadeAnimation")
+	return err
+}
+
+func (l LightBrightness) String() string { return enumString(l, lightBrightnessNames) }
+
+func (l LightBrightness) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+func (l *LightBrightness) UnmarshalText(text []byte) (err error) {
+	*l, err = parseEnum(text, lightBrightnessNames, "LightBrightness")
+	return err
+}
+
+func (m MicSelectType) String() string { return enumString(m, micSelectNames) }
+
+func (m MicSelectType) MarshalText() ([]byte, error) { return []byte(m.String()), nil }
+
+func (m *MicSelectType) UnmarshalText(text []byte) (err error) {
+	*m, err = parseEnum(text, micSelectNames, "MicSelectType")
+	return err
+}
+
+func (e EffectType) String() string { return enumString(e, effectTypeNames) }
+
+func (e EffectType) MarshalText() ([]byte, error) { return []byte(e.String()), nil }
+
+func (e *EffectType) UnmarshalText(text []byte) (err error) {
+	*e, err = parseEnum(text, effectTypeNames, "EffectType")
+	return err
+}
+
+func (s EdgeProfileSlot) String() string { return enumString(s, edgeProfileSlotNames) }
+
+func (s EdgeProfileSlot) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *EdgeProfileSlot) UnmarshalText(text []byte) (err error) {
+	*s, err = parseEnum(text, edgeProfileSlotNames, "EdgeProfileSlot")
+	return err
+}
+
+func (l LifecycleState) String() string { return enumString(l, lifecycleStateNames) }
+
+func (l LifecycleState) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+func (s Shape) String() string { return enumString(s, shapeNames) }
+
+func (s Shape) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *Shape) UnmarshalText(text []byte) (err error) {
+	*s, err = parseEnum(text, shapeNames, "Shape")
+	return err
+}
+
+func (s Stick) String() string { return enumString(s, stickNames) }
+
+func (s Stick) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+func (s *Stick) UnmarshalText(text []byte) (err error) {
+	*s, err = parseEnum(text, stickNames, "Stick")
+	return err
+}
@@ -0,0 +1,41 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestStateJSONRoundTrip(t *testing.T) {
+	in := USBGetStateData{DPad: DirectionSouthWest, PowerState: PowerState(0x05), ButtonCross: true}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"dpad":"SouthWest"`) || !strings.Contains(string(data), `"powerState":"5"`) {
+		t.Fatalf("unexpected JSON: %s", data)
+	}
+	var out USBGetStateData
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Fatalf("got %+v, want %+v", out, in)
+	}
+
+	setData, err := json.Marshal(defaultSetStateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var setOut SetStateData
+	if err := json.Unmarshal(setData, &setOut); err != nil {
+		t.Fatal(err)
+	}
+	if setOut != defaultSetStateData {
+		t.Fatalf("SetStateData did not survive a JSON round trip: %s", setData)
+	}
+
+	if err := json.Unmarshal([]byte(`{"muteLight":"Blinking"}`), &setOut); err == nil {
+		t.Fatal("expected error for unknown MuteLightMode")
+	}
+}
@@ -0,0 +1,130 @@
+package dualsense
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ControllerID identifies a controller among several, e.g. its serial number
+// or a player slot.
+type ControllerID string
+
+// ControllerEvent is an Event tagged with the controller it came from.
+type ControllerEvent struct {
+	Controller ControllerID
+	Event
+}
+
+// EventMux merges the Events channels of several controllers into a single
+// stream. Events of one controller keep their order; events of different
+// controllers interleave as they arrive. A slow receiver holds events back in
+// each controller's own Events buffer.
+type EventMux struct {
+	events chan ControllerEvent
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	closed  bool
+	sources map[ControllerID]chan struct{}
+}
+
+// NewEventMux returns an EventMux whose stream buffers up to bufferSize
+// events.
+func NewEventMux(bufferSize int) *EventMux {
+	return &EventMux{
+		events:  make(chan ControllerEvent, max(bufferSize, 0)),
+		sources: map[ControllerID]chan struct{}{},
+	}
+}
+
+// Add forwards events, typically from DualSense.Events, tagged with id until
+// the channel is closed or id is removed.
+func (m *EventMux) Add(id ControllerID, events <-chan Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return fmt.Errorf("error adding controller %q to EventMux: EventMux is closed", id)
+	}
+	if _, ok := m.sources[id]; ok {
+		return fmt.Errorf("error adding controller %q to EventMux: already added", id)
+	}
+	stop := make(chan struct{})
+	m.sources[id] = stop
+	m.wg.Add(1)
+	go m.forward(id, events, stop)
+	return nil
+}
+
+func (m *EventMux) forward(id ControllerID, events <-chan Event, stop chan struct{}) {
+	defer m.wg.Done()
+	defer m.remove(id, stop)
+	for {
+		select {
+		case <-stop:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			select {
+			case m.events <- ControllerEvent{Controller: id, Event: event}:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
+
+// remove forgets id if it is still forwarded by stop, and stops forwarding.
+func (m *EventMux) remove(id ControllerID, stop chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sources[id] == stop {
+		delete(m.sources, id)
+		close(stop)
+	}
+}
+
+// Remove stops forwarding the events of id. Events already merged stay in the
+// stream.
+func (m *EventMux) Remove(id ControllerID) {
+	m.mu.Lock()
+	stop, ok := m.sources[id]
+	m.mu.Unlock()
+	if ok {
+		m.remove(id, stop)
+	}
+}
+
+// Controllers returns the IDs currently forwarded, in no particular order.
+func (m *EventMux) Controllers() []ControllerID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]ControllerID, 0, len(m.sources))
+	for id := range m.sources {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Events returns the merged stream. It is closed by Close.
+func (m *EventMux) Events() <-chan ControllerEvent {
+	return m.events
+}
+
+// Close stops forwarding every controller and closes the merged stream.
+func (m *EventMux) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	for id, stop := range m.sources {
+		delete(m.sources, id)
+		close(stop)
+	}
+	m.mu.Unlock()
+	m.wg.Wait()
+	close(m.events)
+}
@@ -0,0 +1,61 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventMux(t *testing.T) {
+	first, second := NewMockDualSense(), NewMockDualSense()
+	mux := NewEventMux(0)
+	if err := mux.Add("first", first.Events(16)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mux.Add("second", second.Events(16)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mux.Add("first", first.Events(16)); err == nil {
+		t.Fatal("expected an error adding a controller twice")
+	}
+
+	first.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	second.UpdateInState(func(state *USBGetStateData) { state.ButtonTriangle = true })
+	first.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = false })
+
+	var fromFirst []bool
+	var fromSecond []Field
+	for range 3 {
+		select {
+		case event := <-mux.Events():
+			switch event.Controller {
+			case "first":
+				fromFirst = append(fromFirst, event.New.(bool))
+			case "second":
+				fromSecond = append(fromSecond, event.Field)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for merged events")
+		}
+	}
+	if len(fromFirst) != 2 || !fromFirst[0] || fromFirst[1] {
+		t.Fatalf("got ButtonCross values %v from first, want [true false]", fromFirst)
+	}
+	if len(fromSecond) != 1 || fromSecond[0] != FieldButtonTriangle {
+		t.Fatalf("got %v from second, want [ButtonTriangle]", fromSecond)
+	}
+
+	// Closing a controller closes its Events channel and drops it from the mux.
+	second.Close()
+	deadline := time.Now().Add(time.Second)
+	for len(mux.Controllers()) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("got controllers %v after closing second", mux.Controllers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mux.Close()
+	if _, ok := <-mux.Events(); ok {
+		t.Fatal("merged stream not closed")
+	}
+}
@@ -0,0 +1,136 @@
+package dualsense
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// ENGINE_REDLINE_RPM is the engine speed at which EngineRumble is strongest.
+const ENGINE_REDLINE_RPM = 8000
+
+// Names the feedback presets are registered under, see NewEffect.
+const (
+	EffectDamageFeedback = "DamageFeedback"
+	EffectReload         = "Reload"
+	EffectEngineRumble   = "EngineRumble"
+)
+
+func init() {
+	RegisterEffect(EffectDamageFeedback, func() Effect { return DamageFeedback(1) })
+	RegisterEffect(EffectReload, Reload)
+	RegisterEffect(EffectEngineRumble, func() Effect { return EngineRumble(1000) })
+}
+
+// mustEffect returns the Effect of a timeline known to be valid.
+func mustEffect(timeline Timeline) Effect {
+	effect, err := timeline.Effect()
+	if err != nil {
+		panic(err)
+	}
+	return effect
+}
+
+// DamageFeedback is a hit: a red lightbar flash, a rumble burst fading out and
+// a vibration in both triggers, all scaled by intensity from 0 to 1.
+func DamageFeedback(intensity float64) Effect {
+	intensity = min(max(intensity, 0), 1)
+	level := uint8(math.Round(255 * intensity))
+	vibration := &TriggerEffect{Type: EffectTypeVibration, Start: 0x00, End: 0xFF, Strength: level}
+	return mustEffect(Timeline{
+		Lightbar: []LightbarKeyframe{
+			{Color: LedColor{Red: level}},
+			{At: 300 * time.Millisecond, Color: LedColor{Red: level}},
+		},
+		Rumble: []RumbleKeyframe{
+			{Left: level, Right: level},
+			{At: 300 * time.Millisecond},
+		},
+		Triggers: []TriggerCue{
+			{Left: vibration, Right: vibration},
+			{At: 200 * time.Millisecond, Left: &TriggerEffect{Type: EffectTypeOff}, Right: &TriggerEffect{Type: EffectTypeOff}},
+		},
+	})
+}
+
+// Reload is a weapon reload: the right trigger goes slack, two rumble clicks
+// mark the magazine coming out and going in, the lightbar flashes amber and
+// the right trigger gets its weapon resistance back, ready to fire.
+func Reload() Effect {
+	amber := LedColor{Red: 255, Green: 120}
+	return mustEffect(Timeline{
+		Lightbar: []LightbarKeyframe{
+			{At: 400 * time.Millisecond, Color: amber},
+			{At: 600 * time.Millisecond, Color: amber},
+		},
+		Rumble: []RumbleKeyframe{
+			{Right: 160},
+			{At: 40 * time.Millisecond},
+			{At: 400 * time.Millisecond},
+			{At: 401 * time.Millisecond, Left: 120, Right: 220},
+			{At: 460 * time.Millisecond},
+		},
+		Triggers: []TriggerCue{
+			{Right: &TriggerEffect{Type: EffectTypeOff}},
+			{At: 450 * time.Millisecond, Right: &TriggerEffect{Type: EffectTypeWeapon, Start: 0x02, End: 0x05, Strength: 0x08}},
+		},
+	})
+}
+
+// EngineRumbleEffect rumbles like an engine until stopped. Its speed can be
+// changed while it runs with SetRPM.
+type EngineRumbleEffect struct {
+	mu  sync.Mutex
+	rpm float64
+
+	saved, last SetStateData
+}
+
+// EngineRumble returns an engine running at rpm: the left motor carries a
+// steady drone and the right one pulses with the crankshaft, both growing
+// towards ENGINE_REDLINE_RPM, while the right trigger stiffens and the
+// lightbar turns from green to red.
+func EngineRumble(rpm float64) *EngineRumbleEffect {
+	e := &EngineRumbleEffect{}
+	e.SetRPM(rpm)
+	return e
+}
+
+// SetRPM changes the engine speed. It is safe to call while the effect runs.
+func (e *EngineRumbleEffect) SetRPM(rpm float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rpm = min(max(rpm, 0), ENGINE_REDLINE_RPM)
+}
+
+func (e *EngineRumbleEffect) Start(setStateData *SetStateData) {
+	e.saved = *setStateData
+}
+
+func (e *EngineRumbleEffect) Tick(elapsed time.Duration, setStateData *SetStateData) bool {
+	e.mu.Lock()
+	rpm := e.rpm
+	e.mu.Unlock()
+	load := rpm / ENGINE_REDLINE_RPM
+	// One pulse per crankshaft turn, as a raised cosine. Past the rate the
+	// ticks can show, the pulse blurs into the drone.
+	pulse := 0.5 + 0.5*math.Cos(2*math.Pi*rpm/60*elapsed.Seconds())
+	if rpm/60 > 0.5/EFFECT_TICK_INTERVAL.Seconds() {
+		pulse = 0.5
+	}
+	hapticRumble{
+		enableRumbleEmulation: true,
+		useRumbleNotHaptics:   true,
+		left:                  uint8(math.Round(40 + 160*load)),
+		right:                 uint8(math.Round(pulse * 255 * load)),
+	}.apply(setStateData)
+	blendLedColor(LedColor{Green: 255}, LedColor{Red: 255}, load).apply(setStateData)
+	setStateData.AllowRightTriggerFFB = true
+	setStateData.RightTriggerFFB = GenerateTriggerFFBParams(EffectTypeFeedback, 0x00, 0xFF, uint8(math.Round(255*load)))
+	e.last = *setStateData
+	return true
+}
+
+func (e *EngineRumbleEffect) Stop(setStateData *SetStateData) {
+	timelineTracks{lightbar: true, rumble: true, right: true}.restore(setStateData, &e.saved, &e.last)
+}
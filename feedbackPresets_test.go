@@ -0,0 +1,66 @@
+package dualsense
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFeedbackPresetsRegistered(t *testing.T) {
+	names := EffectNames()
+	for _, name := range []string{EffectDamageFeedback, EffectReload, EffectEngineRumble} {
+		if !slices.Contains(names, name) {
+			t.Errorf("%s missing from %v", name, names)
+		}
+	}
+}
+
+func TestDamageFeedback(t *testing.T) {
+	effect := DamageFeedback(0.5)
+	original := defaultSetStateData
+	setStateData := original
+	effect.Start(&setStateData)
+	if !effect.Tick(0, &setStateData) {
+		t.Fatal("ended at once")
+	}
+	if got := ledColorOf(&setStateData); got != (LedColor{Red: 128}) {
+		t.Errorf("got lightbar %+v, want half red", got)
+	}
+	if setStateData.RumbleEmulationLeft != 128 || setStateData.LeftTriggerFFB != GenerateTriggerFFBParams(EffectTypeVibration, 0, 0xFF, 128) {
+		t.Errorf("got rumble %d and left trigger %v", setStateData.RumbleEmulationLeft, setStateData.LeftTriggerFFB)
+	}
+	if effect.Tick(time.Second, &setStateData) {
+		t.Error("still running after a second")
+	}
+	effect.Stop(&setStateData)
+	if setStateData != original {
+		t.Errorf("not restored: got %+v, want %+v", setStateData, original)
+	}
+}
+
+func TestEngineRumble(t *testing.T) {
+	engine := EngineRumble(ENGINE_REDLINE_RPM * 2)
+	original := defaultSetStateData
+	setStateData := original
+	engine.Start(&setStateData)
+	engine.Tick(0, &setStateData)
+	if setStateData.RumbleEmulationLeft != 200 || ledColorOf(&setStateData) != (LedColor{Red: 255}) {
+		t.Errorf("at redline: got left rumble %d and lightbar %+v", setStateData.RumbleEmulationLeft, ledColorOf(&setStateData))
+	}
+
+	// At 600 rpm the right motor pulses ten times a second.
+	engine.SetRPM(600)
+	engine.Tick(0, &setStateData)
+	peak := setStateData.RumbleEmulationRight
+	engine.Tick(50*time.Millisecond, &setStateData)
+	if trough := setStateData.RumbleEmulationRight; peak == 0 || trough != 0 {
+		t.Errorf("got pulse from %d to %d, want from above 0 to 0", peak, trough)
+	}
+	if !engine.Tick(time.Hour, &setStateData) {
+		t.Error("engine stopped by itself")
+	}
+	engine.Stop(&setStateData)
+	if setStateData != original {
+		t.Errorf("not restored: got %+v, want %+v", setStateData, original)
+	}
+}
@@ -0,0 +1,199 @@
+package dualsense
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// goldenParsers decodes the reports of each directory of testdata/golden.
+var goldenParsers = map[string]func([]byte) (USBReportIn, error){
+	"usb":       UnmarshalInputReport,
+	"bluetooth": UnmarshalBluetoothInputReport,
+}
+
+// goldenInputReports returns the hex encoded input reports in the variant
+// directory of testdata/golden keyed by file name without extension. Every
+// file starts with a comment line saying whether the report was captured from
+// a controller, as printed by dualsensectl capture, or synthesized by hand
+// from the documented layout.
+func goldenInputReports(tb testing.TB, variant string) map[string][]byte {
+	paths, err := filepath.Glob(filepath.Join("testdata", "golden", variant, "*.hex"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	reports := map[string][]byte{}
+	for _, path := range paths {
+		encoded, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		source, report, _ := strings.Cut(string(encoded), "\n")
+		if !strings.HasPrefix(source, "# captured") && !strings.HasPrefix(source, "# synthesized") {
+			tb.Fatalf("%s: first line %q is neither # captured nor # synthesized", path, source)
+		}
+		data, err := hex.DecodeString(strings.TrimSpace(report))
+		if err != nil {
+			tb.Fatalf("%s: %v", path, err)
+		}
+		reports[strings.TrimSuffix(path, ".hex")] = data
+	}
+	if len(reports) == 0 {
+		tb.Fatalf("no golden %s reports found", variant)
+	}
+	return reports
+}
+
+func TestGoldenInputReports(t *testing.T) {
+	for variant, parse := range goldenParsers {
+		for name, data := range goldenInputReports(t, variant) {
+			reportIn, err := parse(data)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			decoded, err := json.MarshalIndent(reportIn, "", "\t")
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded = append(decoded, '\n')
+			if *updateGolden {
+				err := os.WriteFile(name+".json", decoded, 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			want, err := os.ReadFile(name + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decoded, want) {
+				t.Errorf("%s: decoded report does not match %s.json, got:\n%s", name, name, decoded)
+			}
+		}
+	}
+}
+
+// TestGoldenInputReportsCaptured is skipped for every transport whose golden
+// reports are all synthesized, as those only check the parsers against the
+// documented layout and cannot catch a wrong offset.
+func TestGoldenInputReportsCaptured(t *testing.T) {
+	for variant := range goldenParsers {
+		t.Run(variant, func(t *testing.T) {
+			paths, err := filepath.Glob(filepath.Join("testdata", "golden", variant, "*.hex"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, path := range paths {
+				encoded, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if strings.HasPrefix(string(encoded), "# captured") {
+					return
+				}
+			}
+			t.Skipf("no captured %s golden reports: the %d reports in testdata/golden/%s are all synthesized", variant, len(paths), variant)
+		})
+	}
+}
+
+func FuzzUnmarshalInputReport(f *testing.F) {
+	for _, data := range goldenInputReports(f, "usb") {
+		f.Add(data)
+	}
+	f.Add([]byte{})
+	f.Add(make([]byte, USB_PACKET_SIZE-1))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		reportIn, err := UnmarshalInputReport(data)
+		if err != nil {
+			return
+		}
+		again, err := UnmarshalInputReport(data)
+		if err != nil || again != reportIn {
+			t.Fatalf("decoding is not deterministic: %+v, %+v, %v", reportIn, again, err)
+		}
+		if reportIn.USBGetStateData.DPad > 0x0F || reportIn.USBGetStateData.PowerPercent > 0x0F {
+			t.Fatalf("decoded field out of range: %+v", reportIn.USBGetStateData)
+		}
+	})
+}
+
+func FuzzUnmarshalBluetoothInputReport(f *testing.F) {
+	for _, data := range goldenInputReports(f, "bluetooth") {
+		f.Add(data)
+		// The same report with a broken CRC32.
+		corrupt := bytes.Clone(data)
+		corrupt[len(corrupt)-1] ^= 0xFF
+		f.Add(corrupt)
+	}
+	f.Add([]byte{})
+	f.Add(make([]byte, BT_INPUT_REPORT_SIZE))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		reportIn, err := UnmarshalBluetoothInputReport(data)
+		if err != nil {
+			return
+		}
+		if len(data) != BT_INPUT_REPORT_SIZE || data[0] != BT_INPUT_REPORT_ID || reportIn.ReportID != BT_INPUT_REPORT_ID {
+			t.Fatalf("decoded a %d byte report with ID 0x%02X as %+v", len(data), data[0], reportIn)
+		}
+		if crc := bluetoothCRC(BT_INPUT_CRC_SEED, data[:BT_INPUT_REPORT_SIZE-4]); crc != binary.LittleEndian.Uint32(data[BT_INPUT_REPORT_SIZE-4:]) {
+			t.Fatalf("decoded a report with a broken CRC32 as %+v", reportIn)
+		}
+		usb := make([]byte, USB_PACKET_SIZE)
+		usb[0] = 0x01
+		copy(usb[1:], data[2:])
+		want, err := UnmarshalInputReport(usb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want.ReportID = BT_INPUT_REPORT_ID
+		if reportIn != want {
+			t.Fatalf("Bluetooth report does not decode like its USB fields:\n%+v\n%+v", reportIn, want)
+		}
+	})
+}
+
+func FuzzUnmarshalOutputReport(f *testing.F) {
+	data, err := MarshalOutputReport(defaultSetStateData)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	for _, name := range PresetNames() {
+		profile, err := Preset(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		data, err := MarshalOutputReport(profile.SetStateData)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		setStateData, err := UnmarshalOutputReport(data)
+		if err != nil {
+			return
+		}
+		encoded, err := MarshalOutputReport(setStateData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := UnmarshalOutputReport(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again != setStateData {
+			t.Fatalf("output report does not round trip:\n%+v\n%+v", setStateData, again)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package dualsense
+
+// Axis indices of GamepadState.Axes. Sticks range over [-1, 1] with up and
+// left negative; triggers range over [0, 1].
+const (
+	StandardAxisLeftStickX = iota
+	StandardAxisLeftStickY
+	StandardAxisRightStickX
+	StandardAxisRightStickY
+	StandardAxisTriggerLeft
+	StandardAxisTriggerRight
+	STANDARD_AXIS_COUNT
+)
+
+// Button indices of GamepadState.Buttons, in the W3C standard gamepad order
+// used by browsers, Ebiten and SDL based engines, with the touchpad click
+// appended.
+const (
+	StandardButtonSouth = iota // Cross
+	StandardButtonEast         // Circle
+	StandardButtonWest         // Square
+	StandardButtonNorth        // Triangle
+	StandardButtonLeftShoulder
+	StandardButtonRightShoulder
+	StandardButtonLeftTrigger
+	StandardButtonRightTrigger
+	StandardButtonBack  // Create
+	StandardButtonStart // Options
+	StandardButtonLeftStick
+	StandardButtonRightStick
+	StandardButtonDPadUp
+	StandardButtonDPadDown
+	StandardButtonDPadLeft
+	StandardButtonDPadRight
+	StandardButtonGuide // PS
+	StandardButtonTouchpad
+	STANDARD_BUTTON_COUNT
+)
+
+// GamepadState is the controller input in a cross-platform layout, indexed by
+// the StandardAxis and StandardButton constants.
+type GamepadState struct {
+	Axes    []float64
+	Buttons []bool
+}
+
+func normalizeStickAxis(value uint8) float64 {
+	return (float64(value) - 127.5) / 127.5
+}
+
+// ToGamepadState converts state to the standard layout.
+func ToGamepadState(state USBGetStateData) GamepadState {
+	axes := make([]float64, STANDARD_AXIS_COUNT)
+	axes[StandardAxisLeftStickX] = normalizeStickAxis(state.LeftStickX)
+	axes[StandardAxisLeftStickY] = normalizeStickAxis(state.LeftStickY)
+	axes[StandardAxisRightStickX] = normalizeStickAxis(state.RightStickX)
+	axes[StandardAxisRightStickY] = normalizeStickAxis(state.RightStickY)
+	axes[StandardAxisTriggerLeft] = float64(state.TriggerLeft) / 255
+	axes[StandardAxisTriggerRight] = float64(state.TriggerRight) / 255
+
+	dPad := state.DPad
+	buttons := make([]bool, STANDARD_BUTTON_COUNT)
+	buttons[StandardButtonSouth] = state.ButtonCross
+	buttons[StandardButtonEast] = state.ButtonCircle
+	buttons[StandardButtonWest] = state.ButtonSquare
+	buttons[StandardButtonNorth] = state.ButtonTriangle
+	buttons[StandardButtonLeftShoulder] = state.ButtonL1
+	buttons[StandardButtonRightShoulder] = state.ButtonR1
+	buttons[StandardButtonLeftTrigger] = state.ButtonL2
+	buttons[StandardButtonRightTrigger] = state.ButtonR2
+	buttons[StandardButtonBack] = state.ButtonCreate
+	buttons[StandardButtonStart] = state.ButtonOptions
+	buttons[StandardButtonLeftStick] = state.ButtonL3
+	buttons[StandardButtonRightStick] = state.ButtonR3
+	buttons[StandardButtonDPadUp] = dPad == DirectionNorthWest || dPad == DirectionNorth || dPad == DirectionNorthEast
+	buttons[StandardButtonDPadDown] = dPad == DirectionSouthWest || dPad == DirectionSouth || dPad == DirectionSouthEast
+	buttons[StandardButtonDPadLeft] = dPad == DirectionNorthWest || dPad == DirectionWest || dPad == DirectionSouthWest
+	buttons[StandardButtonDPadRight] = dPad == DirectionNorthEast || dPad == DirectionEast || dPad == DirectionSouthEast
+	buttons[StandardButtonGuide] = state.ButtonHome
+	buttons[StandardButtonTouchpad] = state.ButtonPad
+	return GamepadState{Axes: axes, Buttons: buttons}
+}
+
+// GamepadState returns the current input state in the standard layout.
+func (d *DualSense) GamepadState() GamepadState {
+	return ToGamepadState(d.GetInStateData())
+}
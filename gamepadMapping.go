@@ -0,0 +1,429 @@
+package dualsense
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Raw button indices of RawGamepadState, numbered like the Linux
+// hid-playstation driver so SDL mappings written for the DualSense on Linux
+// apply unchanged. Touchpad, mute and the Edge buttons follow the driver's
+// buttons.
+const (
+	RawButtonCross = iota
+	RawButtonCircle
+	RawButtonTriangle
+	RawButtonSquare
+	RawButtonL1
+	RawButtonR1
+	RawButtonL2
+	RawButtonR2
+	RawButtonCreate
+	RawButtonOptions
+	RawButtonHome
+	RawButtonL3
+	RawButtonR3
+	RawButtonPad
+	RawButtonMute
+	RawButtonLeftFunction
+	RawButtonRightFunction
+	RawButtonLeftPaddle
+	RawButtonRightPaddle
+	RAW_BUTTON_COUNT
+)
+
+// Raw axis indices of RawGamepadState. All raw axes range over [-1, 1],
+// triggers included.
+const (
+	RawAxisLeftStickX = iota
+	RawAxisLeftStickY
+	RawAxisTriggerLeft
+	RawAxisRightStickX
+	RawAxisRightStickY
+	RawAxisTriggerRight
+	RAW_AXIS_COUNT
+)
+
+// Hat bits of RawGamepadState.Hat, as used by SDL "h0.N" sources.
+const (
+	HatUp    = 1
+	HatRight = 2
+	HatDown  = 4
+	HatLeft  = 8
+)
+
+// DEFAULT_SDL_MAPPING maps RawGamepadState to the standard layout, matching
+// the SDL_GameControllerDB entry for the DualSense on Linux.
+const DEFAULT_SDL_MAPPING = "030000004c050000e60c000000000000,PS5 Controller,a:b0,b:b1,x:b3,y:b2,back:b8,guide:b10,start:b9,leftstick:b11,rightstick:b12,leftshoulder:b4,rightshoulder:b5,dpup:h0.1,dpdown:h0.4,dpleft:h0.8,dpright:h0.2,touchpad:b13,leftx:a0,lefty:a1,rightx:a3,righty:a4,lefttrigger:a2,righttrigger:a5,misc1:b14,platform:Linux,"
+
+// RawGamepadState is the controller input as a generic joystick: indexed
+// buttons and axes plus one hat, as SDL mappings address them.
+type RawGamepadState struct {
+	Buttons []bool
+	Axes    []float64
+	Hat     uint8
+}
+
+var dPadHats = map[Direction]uint8{
+	DirectionNorth:     HatUp,
+	DirectionNorthEast: HatUp | HatRight,
+	DirectionEast:      HatRight,
+	DirectionSouthEast: HatDown | HatRight,
+	DirectionSouth:     HatDown,
+	DirectionSouthWest: HatDown | HatLeft,
+	DirectionWest:      HatLeft,
+	DirectionNorthWest: HatUp | HatLeft,
+}
+
+func ToRawGamepadState(state USBGetStateData) RawGamepadState {
+	buttons := make([]bool, RAW_BUTTON_COUNT)
+	buttons[RawButtonCross] = state.ButtonCross
+	buttons[RawButtonCircle] = state.ButtonCircle
+	buttons[RawButtonTriangle] = state.ButtonTriangle
+	buttons[RawButtonSquare] = state.ButtonSquare
+	buttons[RawButtonL1] = state.ButtonL1
+	buttons[RawButtonR1] = state.ButtonR1
+	buttons[RawButtonL2] = state.ButtonL2
+	buttons[RawButtonR2] = state.ButtonR2
+	buttons[RawButtonCreate] = state.ButtonCreate
+	buttons[RawButtonOptions] = state.ButtonOptions
+	buttons[RawButtonHome] = state.ButtonHome
+	buttons[RawButtonL3] = state.ButtonL3
+	buttons[RawButtonR3] = state.ButtonR3
+	buttons[RawButtonPad] = state.ButtonPad
+	buttons[RawButtonMute] = state.ButtonMute
+	buttons[RawButtonLeftFunction] = state.ButtonLeftFunction
+	buttons[RawButtonRightFunction] = state.ButtonRightFunction
+	buttons[RawButtonLeftPaddle] = state.ButtonLeftPaddle
+	buttons[RawButtonRightPaddle] = state.ButtonRightPaddle
+
+	axes := make([]float64, RAW_AXIS_COUNT)
+	axes[RawAxisLeftStickX] = normalizeStickAxis(state.LeftStickX)
+	axes[RawAxisLeftStickY] = normalizeStickAxis(state.LeftStickY)
+	axes[RawAxisTriggerLeft] = float64(state.TriggerLeft)/127.5 - 1
+	axes[RawAxisRightStickX] = normalizeStickAxis(state.RightStickX)
+	axes[RawAxisRightStickY] = normalizeStickAxis(state.RightStickY)
+	axes[RawAxisTriggerRight] = float64(state.TriggerRight)/127.5 - 1
+	return RawGamepadState{Buttons: buttons, Axes: axes, Hat: dPadHats[state.DPad]}
+}
+
+type MappingSourceKind uint8
+
+const (
+	MappingSourceNone MappingSourceKind = iota
+	MappingSourceButton
+	MappingSourceAxis
+	MappingSourceHat
+)
+
+// MappingSource is the raw input an SDL mapping binds a target to: "b3",
+// "a2", "+a2", "-a2", "a2~" or "h0.4".
+type MappingSource struct {
+	Kind  MappingSourceKind
+	Index int
+	// HatMask selects the hat direction for MappingSourceHat.
+	HatMask uint8
+	// Half is '+' or '-' to use one half of an axis, 0 for the full range.
+	Half byte
+	// Invert flips the axis, written as a trailing "~".
+	Invert bool
+}
+
+func parseMappingSource(text string) (MappingSource, error) {
+	var source MappingSource
+	if strings.HasPrefix(text, "+") || strings.HasPrefix(text, "-") {
+		source.Half = text[0]
+		text = text[1:]
+	}
+	if strings.HasSuffix(text, "~") {
+		source.Invert = true
+		text = strings.TrimSuffix(text, "~")
+	}
+	if len(text) < 2 {
+		return MappingSource{}, fmt.Errorf("invalid mapping source %q", text)
+	}
+	var err error
+	switch text[0] {
+	case 'b':
+		source.Kind = MappingSourceButton
+		source.Index, err = strconv.Atoi(text[1:])
+	case 'a':
+		source.Kind = MappingSourceAxis
+		source.Index, err = strconv.Atoi(text[1:])
+	case 'h':
+		source.Kind = MappingSourceHat
+		hat, mask, ok := strings.Cut(text[1:], ".")
+		if !ok {
+			return MappingSource{}, fmt.Errorf("invalid hat mapping source %q", text)
+		}
+		source.Index, err = strconv.Atoi(hat)
+		if err == nil {
+			var hatMask uint64
+			hatMask, err = strconv.ParseUint(mask, 10, 8)
+			source.HatMask = uint8(hatMask)
+		}
+	default:
+		return MappingSource{}, fmt.Errorf("invalid mapping source %q", text)
+	}
+	if err != nil || source.Index < 0 {
+		return MappingSource{}, fmt.Errorf("invalid mapping source %q", text)
+	}
+	if (source.Half != 0 || source.Invert) && source.Kind != MappingSourceAxis {
+		return MappingSource{}, fmt.Errorf("invalid mapping source %q: only axes can be halved or inverted", text)
+	}
+	return source, nil
+}
+
+func (s MappingSource) String() string {
+	var text string
+	switch s.Kind {
+	case MappingSourceButton:
+		text = "b" + strconv.Itoa(s.Index)
+	case MappingSourceAxis:
+		text = "a" + strconv.Itoa(s.Index)
+	case MappingSourceHat:
+		return "h" + strconv.Itoa(s.Index) + "." + strconv.Itoa(int(s.HatMask))
+	default:
+		return ""
+	}
+	if s.Half != 0 {
+		text = string(s.Half) + text
+	}
+	if s.Invert {
+		text += "~"
+	}
+	return text
+}
+
+// value reads the source from raw as an axis value in [-1, 1]; buttons and
+// hats read 0 or 1.
+func (s MappingSource) value(raw RawGamepadState) float64 {
+	switch s.Kind {
+	case MappingSourceButton:
+		if s.Index < len(raw.Buttons) && raw.Buttons[s.Index] {
+			return 1
+		}
+	case MappingSourceHat:
+		if s.Index == 0 && raw.Hat&s.HatMask != 0 {
+			return 1
+		}
+	case MappingSourceAxis:
+		if s.Index >= len(raw.Axes) {
+			return 0
+		}
+		value := raw.Axes[s.Index]
+		if s.Invert {
+			value = -value
+		}
+		switch s.Half {
+		case '+':
+			value = max(value, 0)
+		case '-':
+			value = max(-value, 0)
+		}
+		return value
+	}
+	return 0
+}
+
+// sdlButtonTargets and sdlAxisTargets list SDL target names in the order
+// GamepadMapping.String writes them.
+var sdlButtonTargets = []struct {
+	name   string
+	button int
+}{
+	{"a", StandardButtonSouth},
+	{"b", StandardButtonEast},
+	{"x", StandardButtonWest},
+	{"y", StandardButtonNorth},
+	{"back", StandardButtonBack},
+	{"guide", StandardButtonGuide},
+	{"start", StandardButtonStart},
+	{"leftstick", StandardButtonLeftStick},
+	{"rightstick", StandardButtonRightStick},
+	{"leftshoulder", StandardButtonLeftShoulder},
+	{"rightshoulder", StandardButtonRightShoulder},
+	{"dpup", StandardButtonDPadUp},
+	{"dpdown", StandardButtonDPadDown},
+	{"dpleft", StandardButtonDPadLeft},
+	{"dpright", StandardButtonDPadRight},
+	{"touchpad", StandardButtonTouchpad},
+}
+
+var sdlAxisTargets = []struct {
+	name string
+	axis int
+}{
+	{"leftx", StandardAxisLeftStickX},
+	{"lefty", StandardAxisLeftStickY},
+	{"rightx", StandardAxisRightStickX},
+	{"righty", StandardAxisRightStickY},
+	{"lefttrigger", StandardAxisTriggerLeft},
+	{"righttrigger", StandardAxisTriggerRight},
+}
+
+// GamepadMapping is an SDL GameController mapping: for each standard button
+// and axis, the raw input it reads from.
+type GamepadMapping struct {
+	GUID    string
+	Name    string
+	Buttons [STANDARD_BUTTON_COUNT]MappingSource
+	Axes    [STANDARD_AXIS_COUNT]MappingSource
+	// Extra keeps entries without a standard target, such as "platform:Linux"
+	// or "misc1:b14", so they survive a round trip.
+	Extra []string
+	// Paddles binds the Edge back paddles to standard buttons. SDL mappings
+	// have no syntax for it, so String leaves it out; keep it in a Profile.
+	Paddles PaddleMapping
+}
+
+// PaddleMapping makes the DualSense Edge back paddles press standard buttons,
+// on top of whatever raw input the buttons are already bound to. Buttons are
+// named as SDL button targets, such as "a" or "leftshoulder"; an empty name
+// leaves the paddle unmapped.
+type PaddleMapping struct {
+	LeftPaddle  string `json:"leftPaddle,omitempty"`
+	RightPaddle string `json:"rightPaddle,omitempty"`
+}
+
+func sdlButtonTarget(name string) (int, bool) {
+	for _, buttonTarget := range sdlButtonTargets {
+		if buttonTarget.name == name {
+			return buttonTarget.button, true
+		}
+	}
+	return 0, false
+}
+
+// Validate checks that every mapped paddle names an SDL button target.
+func (p PaddleMapping) Validate() error {
+	for _, name := range []string{p.LeftPaddle, p.RightPaddle} {
+		if _, ok := sdlButtonTarget(name); name != "" && !ok {
+			return fmt.Errorf("invalid paddle mapping: unknown button %q", name)
+		}
+	}
+	return nil
+}
+
+// MapPaddle binds paddle, FieldButtonLeftPaddle or FieldButtonRightPaddle, to
+// the standard button named button, or unmaps it if button is empty.
+func (m *GamepadMapping) MapPaddle(paddle Field, button string) error {
+	if _, ok := sdlButtonTarget(button); button != "" && !ok {
+		return fmt.Errorf("invalid paddle mapping: unknown button %q", button)
+	}
+	switch paddle {
+	case FieldButtonLeftPaddle:
+		m.Paddles.LeftPaddle = button
+	case FieldButtonRightPaddle:
+		m.Paddles.RightPaddle = button
+	default:
+		return fmt.Errorf("invalid paddle mapping: %s is not a paddle", paddle)
+	}
+	return nil
+}
+
+// ParseSDLMapping parses an SDL GameController mapping string.
+func ParseSDLMapping(text string) (GamepadMapping, error) {
+	parts := strings.Split(strings.TrimSuffix(strings.TrimSpace(text), ","), ",")
+	if len(parts) < 2 {
+		return GamepadMapping{}, fmt.Errorf("invalid SDL mapping %q: missing GUID or name", text)
+	}
+	mapping := GamepadMapping{GUID: parts[0], Name: parts[1]}
+parts:
+	for _, part := range parts[2:] {
+		target, sourceText, ok := strings.Cut(part, ":")
+		if !ok {
+			return GamepadMapping{}, fmt.Errorf("invalid SDL mapping entry %q", part)
+		}
+		for _, buttonTarget := range sdlButtonTargets {
+			if buttonTarget.name == target {
+				source, err := parseMappingSource(sourceText)
+				if err != nil {
+					return GamepadMapping{}, fmt.Errorf("invalid SDL mapping entry %q: %w", part, err)
+				}
+				mapping.Buttons[buttonTarget.button] = source
+				continue parts
+			}
+		}
+		for _, axisTarget := range sdlAxisTargets {
+			if axisTarget.name == target {
+				source, err := parseMappingSource(sourceText)
+				if err != nil {
+					return GamepadMapping{}, fmt.Errorf("invalid SDL mapping entry %q: %w", part, err)
+				}
+				mapping.Axes[axisTarget.axis] = source
+				continue parts
+			}
+		}
+		mapping.Extra = append(mapping.Extra, part)
+	}
+	return mapping, nil
+}
+
+// DefaultGamepadMapping returns DEFAULT_SDL_MAPPING parsed.
+func DefaultGamepadMapping() GamepadMapping {
+	mapping, err := ParseSDLMapping(DEFAULT_SDL_MAPPING)
+	if err != nil {
+		panic(err)
+	}
+	return mapping
+}
+
+// String formats m as an SDL GameController mapping string.
+func (m GamepadMapping) String() string {
+	var builder strings.Builder
+	builder.WriteString(m.GUID + "," + m.Name + ",")
+	for _, buttonTarget := range sdlButtonTargets {
+		if source := m.Buttons[buttonTarget.button]; source.Kind != MappingSourceNone {
+			builder.WriteString(buttonTarget.name + ":" + source.String() + ",")
+		}
+	}
+	for _, axisTarget := range sdlAxisTargets {
+		if source := m.Axes[axisTarget.axis]; source.Kind != MappingSourceNone {
+			builder.WriteString(axisTarget.name + ":" + source.String() + ",")
+		}
+	}
+	for _, extra := range m.Extra {
+		builder.WriteString(extra + ",")
+	}
+	return builder.String()
+}
+
+// Apply remaps raw into the standard layout. Mapped paddles press their
+// buttons in addition to the buttons' own sources. Trigger axes bound to a full
+// raw axis are rescaled from [-1, 1] to [0, 1], and the trigger buttons
+// follow the trigger axes.
+func (m GamepadMapping) Apply(raw RawGamepadState) GamepadState {
+	state := GamepadState{
+		Axes:    make([]float64, STANDARD_AXIS_COUNT),
+		Buttons: make([]bool, STANDARD_BUTTON_COUNT),
+	}
+	for button, source := range m.Buttons {
+		state.Buttons[button] = source.value(raw) > 0.5
+	}
+	for axis, source := range m.Axes {
+		value := source.value(raw)
+		isTrigger := axis == StandardAxisTriggerLeft || axis == StandardAxisTriggerRight
+		if isTrigger && source.Kind == MappingSourceAxis && source.Half == 0 {
+			value = (value + 1) / 2
+		}
+		state.Axes[axis] = value
+	}
+	paddles := [...]struct {
+		rawButton int
+		name      string
+	}{
+		{RawButtonLeftPaddle, m.Paddles.LeftPaddle},
+		{RawButtonRightPaddle, m.Paddles.RightPaddle},
+	}
+	for _, paddle := range paddles {
+		button, ok := sdlButtonTarget(paddle.name)
+		if ok && paddle.rawButton < len(raw.Buttons) && raw.Buttons[paddle.rawButton] {
+			state.Buttons[button] = true
+		}
+	}
+	state.Buttons[StandardButtonLeftTrigger] = state.Axes[StandardAxisTriggerLeft] > 0
+	state.Buttons[StandardButtonRightTrigger] = state.Axes[StandardAxisTriggerRight] > 0
+	return state
+}
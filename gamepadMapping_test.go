@@ -0,0 +1,100 @@
+package dualsense
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultGamepadMappingMatchesStandardLayout(t *testing.T) {
+	state := USBGetStateData{
+		LeftStickX:   12,
+		LeftStickY:   200,
+		RightStickX:  255,
+		RightStickY:  0,
+		TriggerLeft:  255,
+		ButtonL2:     true,
+		DPad:         DirectionNorthWest,
+		ButtonSquare: true,
+		ButtonHome:   true,
+		ButtonPad:    true,
+	}
+	got := DefaultGamepadMapping().Apply(ToRawGamepadState(state))
+	want := ToGamepadState(state)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestSDLMappingRoundTrip(t *testing.T) {
+	mapping, err := ParseSDLMapping(DEFAULT_SDL_MAPPING)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mapping.String(); got != DEFAULT_SDL_MAPPING {
+		t.Fatalf("got %q, want %q", got, DEFAULT_SDL_MAPPING)
+	}
+}
+
+func TestSDLMappingRemaps(t *testing.T) {
+	// Swap Cross and Circle, drive leftx from the D-pad and invert lefty.
+	mapping, err := ParseSDLMapping("0,Custom,a:b1,b:b0,leftx:h0.2,lefty:a1~,righttrigger:+a5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := ToRawGamepadState(USBGetStateData{ButtonCross: true, DPad: DirectionEast, LeftStickY: 0, TriggerRight: 0})
+	gamepad := mapping.Apply(state)
+	if gamepad.Buttons[StandardButtonSouth] || !gamepad.Buttons[StandardButtonEast] {
+		t.Errorf("Cross and Circle not swapped: %v", gamepad.Buttons)
+	}
+	if gamepad.Axes[StandardAxisLeftStickX] != 1 || gamepad.Axes[StandardAxisLeftStickY] != 1 {
+		t.Errorf("got left stick %v, %v, want 1, 1", gamepad.Axes[StandardAxisLeftStickX], gamepad.Axes[StandardAxisLeftStickY])
+	}
+	if gamepad.Axes[StandardAxisTriggerRight] != 0 || gamepad.Buttons[StandardButtonRightTrigger] {
+		t.Errorf("got right trigger %v", gamepad.Axes[StandardAxisTriggerRight])
+	}
+
+	for _, text := range []string{"", "0,Name,a", "0,Name,a:c1", "0,Name,a:+b1", "0,Name,dpup:h0"} {
+		if _, err := ParseSDLMapping(text); err == nil {
+			t.Errorf("ParseSDLMapping(%q): expected error", text)
+		}
+	}
+}
+
+func TestPaddleMapping(t *testing.T) {
+	mapping := DefaultGamepadMapping()
+	if err := mapping.MapPaddle(FieldButtonLeftPaddle, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mapping.MapPaddle(FieldButtonRightPaddle, "rightshoulder"); err != nil {
+		t.Fatal(err)
+	}
+	gamepad := mapping.Apply(ToRawGamepadState(USBGetStateData{ButtonLeftPaddle: true}))
+	if !gamepad.Buttons[StandardButtonSouth] || gamepad.Buttons[StandardButtonRightShoulder] {
+		t.Errorf("left paddle: got %v", gamepad.Buttons)
+	}
+	gamepad = mapping.Apply(ToRawGamepadState(USBGetStateData{ButtonCross: true}))
+	if !gamepad.Buttons[StandardButtonSouth] {
+		t.Error("Cross no longer presses South with a paddle mapped to it")
+	}
+
+	if err := mapping.MapPaddle(FieldButtonCross, "a"); err == nil {
+		t.Error("MapPaddle(FieldButtonCross): expected error")
+	}
+	if err := mapping.MapPaddle(FieldButtonLeftPaddle, "lefttrigger"); err == nil {
+		t.Error("MapPaddle to an axis: expected error")
+	}
+
+	path := filepath.Join(t.TempDir(), "paddles.json")
+	profile := Profile{Name: "paddles", SetStateData: defaultSetStateData, Paddles: mapping.Paddles}
+	if err := SaveProfile(path, profile); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Paddles != mapping.Paddles {
+		t.Fatalf("got %+v, want %+v", loaded.Paddles, mapping.Paddles)
+	}
+}
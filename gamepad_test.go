@@ -0,0 +1,44 @@
+package dualsense
+
+import "testing"
+
+func TestToGamepadState(t *testing.T) {
+	state := USBGetStateData{
+		LeftStickX:   0,
+		LeftStickY:   255,
+		RightStickX:  128,
+		RightStickY:  128,
+		TriggerRight: 255,
+		DPad:         DirectionSouthEast,
+		ButtonCross:  true,
+		ButtonHome:   true,
+	}
+	gamepad := ToGamepadState(state)
+	if len(gamepad.Axes) != STANDARD_AXIS_COUNT || len(gamepad.Buttons) != STANDARD_BUTTON_COUNT {
+		t.Fatalf("got %d axes and %d buttons", len(gamepad.Axes), len(gamepad.Buttons))
+	}
+	if gamepad.Axes[StandardAxisLeftStickX] != -1 || gamepad.Axes[StandardAxisLeftStickY] != 1 {
+		t.Errorf("got left stick %v, %v, want -1, 1", gamepad.Axes[StandardAxisLeftStickX], gamepad.Axes[StandardAxisLeftStickY])
+	}
+	if x := gamepad.Axes[StandardAxisRightStickX]; x <= 0 || x > 0.01 {
+		t.Errorf("got centered right stick %v, want near 0", x)
+	}
+	if gamepad.Axes[StandardAxisTriggerLeft] != 0 || gamepad.Axes[StandardAxisTriggerRight] != 1 {
+		t.Errorf("got triggers %v", gamepad.Axes[StandardAxisTriggerLeft:])
+	}
+	pressed := []int{}
+	for i, button := range gamepad.Buttons {
+		if button {
+			pressed = append(pressed, i)
+		}
+	}
+	want := []int{StandardButtonSouth, StandardButtonDPadDown, StandardButtonDPadRight, StandardButtonGuide}
+	if len(pressed) != len(want) {
+		t.Fatalf("got pressed buttons %v, want %v", pressed, want)
+	}
+	for i := range want {
+		if pressed[i] != want[i] {
+			t.Fatalf("got pressed buttons %v, want %v", pressed, want)
+		}
+	}
+}
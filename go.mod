@@ -5,6 +5,7 @@ go 1.22.3
 require (
 	github.com/rivo/tview v0.0.0-20241227133733-17b7edb88c57
 	github.com/sstallion/go-hid v0.14.1
+	golang.org/x/sys v0.24.0
 )
 
 require (
@@ -13,7 +14,6 @@ require (
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
-	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/term v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/term v0.23.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
 )
@@ -0,0 +1,83 @@
+package dualsense
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HAPTIC_TICK_DURATION is how long a HapticTick pulse lasts.
+const HAPTIC_TICK_DURATION = 30 * time.Millisecond
+
+// hapticRumble is the output state a HapticTick takes over.
+type hapticRumble struct {
+	enableRumbleEmulation bool
+	useRumbleNotHaptics   bool
+	left                  uint8
+	right                 uint8
+}
+
+func hapticRumbleOf(setStateData *SetStateData) hapticRumble {
+	return hapticRumble{
+		enableRumbleEmulation: setStateData.EnableRumbleEmulation,
+		useRumbleNotHaptics:   setStateData.UseRumbleNotHaptics,
+		left:                  setStateData.RumbleEmulationLeft,
+		right:                 setStateData.RumbleEmulationRight,
+	}
+}
+
+func (r hapticRumble) apply(setStateData *SetStateData) {
+	setStateData.EnableRumbleEmulation = r.enableRumbleEmulation
+	setStateData.UseRumbleNotHaptics = r.useRumbleNotHaptics
+	setStateData.RumbleEmulationLeft = r.left
+	setStateData.RumbleEmulationRight = r.right
+}
+
+type hapticTicker struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	// layer carries the pulse, mixed over the rumble as set.
+	layer *OutputLayer
+}
+
+// HapticTick plays a short rumble pulse of intensity on both motors for UI
+// feedback such as scrolling detents, lasting HAPTIC_TICK_DURATION. The pulse
+// is mixed over the rumble as set on its own OutputLayer at
+// HAPTIC_TICK_LAYER_PRIORITY, so the rumble below is untouched. A tick during
+// a pending one restarts the pulse. When output is coalesced or rate limited
+// the pulse is kept until it has been written, so it is not lost. It does not
+// block and is safe to call from input callbacks.
+func (d *DualSense) HapticTick(intensity uint8) error {
+	d.hapticTick.mu.Lock()
+	defer d.hapticTick.mu.Unlock()
+	if d.hapticTick.timer != nil {
+		d.hapticTick.timer.Stop()
+		d.hapticTick.timer = nil
+	}
+	err := d.hapticTick.layer.SetRumble(intensity, intensity)
+	if err != nil {
+		return fmt.Errorf("error starting haptic tick: %w", err)
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(HAPTIC_TICK_DURATION, func() {
+		d.hapticTick.mu.Lock()
+		defer d.hapticTick.mu.Unlock()
+		if d.hapticTick.timer != timer {
+			return
+		}
+		d.setStateDataMu.Lock()
+		unwritten := d.setStateDataDirty
+		d.setStateDataMu.Unlock()
+		if unwritten {
+			timer.Reset(HAPTIC_TICK_DURATION)
+			return
+		}
+		d.hapticTick.timer = nil
+		err := d.hapticTick.layer.Clear()
+		if err != nil {
+			d.logger.Debug("could not end DualSense haptic tick", "error", err)
+		}
+	})
+	d.hapticTick.timer = timer
+	return nil
+}
@@ -0,0 +1,86 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHapticTick(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetRumbleEmulationLeft(10); err != nil {
+		t.Fatal(err)
+	}
+	before := d.GetOutStateData()
+
+	if err := d.HapticTick(200); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.HapticTick(200); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	last := outStates[len(outStates)-1]
+	if last.RumbleEmulationLeft != 200 || last.RumbleEmulationRight != 200 || !last.EnableRumbleEmulation || !last.UseRumbleNotHaptics {
+		t.Fatalf("got pulse %+v", hapticRumbleOf(&last))
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		outStates = d.OutStates()
+		if last = outStates[len(outStates)-1]; last.RumbleEmulationLeft != 200 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("pulse not stopped")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	want := hapticRumbleOf(&before)
+	if got := hapticRumbleOf(&last); got != want {
+		t.Errorf("restored %+v, want %+v", got, want)
+	}
+}
+
+func TestHapticTickKeepsLaterRumble(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := d.HapticTick(200); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetRumbleEmulationRight(50); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(3 * HAPTIC_TICK_DURATION)
+	outStates := d.OutStates()
+	if last := outStates[len(outStates)-1]; last.RumbleEmulationRight != 50 {
+		t.Errorf("got right rumble %d, want 50", last.RumbleEmulationRight)
+	}
+}
+
+func TestHapticTickMixesOverRumble(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetRumbleEmulationLeft(250); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.HapticTick(100); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if last := outStates[len(outStates)-1]; last.RumbleEmulationLeft != 250 || last.RumbleEmulationRight != 100 {
+		t.Fatalf("got rumble %d, %d during the pulse, want 250, 100", last.RumbleEmulationLeft, last.RumbleEmulationRight)
+	}
+	if got := d.GetOutStateData(); got.RumbleEmulationRight != 0 {
+		t.Fatalf("the pulse changed the rumble as set to %d", got.RumbleEmulationRight)
+	}
+}
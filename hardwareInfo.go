@@ -0,0 +1,92 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Feature reports describing the controller hardware.
+const (
+	FIRMWARE_INFO_REPORT_ID   = 0x20
+	FIRMWARE_INFO_REPORT_SIZE = 64
+	PAIRING_INFO_REPORT_ID    = 0x09
+	PAIRING_INFO_REPORT_SIZE  = 20
+)
+
+// HardwareInfo identifies a controller, as decoded from the firmware info
+// feature report and, where available, the pairing info feature report.
+// Fields are named after the reference structures; the meaning of the bits of
+// HardwareVersion and DeviceInfo, such as the board revision, is not
+// documented, so they are kept raw.
+type HardwareInfo struct {
+	// BuildTime is when the firmware was built, zero if it cannot be parsed.
+	BuildTime                time.Time `json:"buildTime"`
+	FirmwareType             uint16    `json:"firmwareType"`
+	SoftwareSeries           uint16    `json:"softwareSeries"`
+	HardwareVersion          uint32    `json:"hardwareVersion"`
+	FirmwareVersion          uint32    `json:"firmwareVersion"`
+	DeviceInfo               string    `json:"deviceInfo"` // 12 bytes, hex encoded
+	UpdateVersion            uint16    `json:"updateVersion"`
+	UpdateImageInfo          uint8     `json:"updateImageInfo"`
+	SblFirmwareVersion       uint32    `json:"sblFirmwareVersion"`
+	VenomFirmwareVersion     uint32    `json:"venomFirmwareVersion"`
+	SpiderDspFirmwareVersion uint32    `json:"spiderDspFirmwareVersion"`
+	// MacAddress is the Bluetooth address of the controller, empty if the
+	// pairing info report could not be read.
+	MacAddress string `json:"macAddress,omitempty"`
+}
+
+// UnmarshalHardwareInfo decodes a firmware info feature report, including its
+// report ID. MacAddress is left empty.
+func UnmarshalHardwareInfo(data []byte) (HardwareInfo, error) {
+	if len(data) < FIRMWARE_INFO_REPORT_SIZE || data[0] != FIRMWARE_INFO_REPORT_ID {
+		return HardwareInfo{}, fmt.Errorf("invalid firmware info report of %d bytes", len(data))
+	}
+	info := HardwareInfo{
+		FirmwareType:             binary.LittleEndian.Uint16(data[20:]),
+		SoftwareSeries:           binary.LittleEndian.Uint16(data[22:]),
+		HardwareVersion:          binary.LittleEndian.Uint32(data[24:]),
+		FirmwareVersion:          binary.LittleEndian.Uint32(data[28:]),
+		DeviceInfo:               hex.EncodeToString(data[32:44]),
+		UpdateVersion:            binary.LittleEndian.Uint16(data[44:]),
+		UpdateImageInfo:          data[46],
+		SblFirmwareVersion:       binary.LittleEndian.Uint32(data[48:]),
+		VenomFirmwareVersion:     binary.LittleEndian.Uint32(data[52:]),
+		SpiderDspFirmwareVersion: binary.LittleEndian.Uint32(data[56:]),
+	}
+	// Build date and time as in __DATE__ and __TIME__, e.g. "Jun 10 2021" and
+	// "11:35:41".
+	buildTime, err := time.Parse("Jan _2 2006 15:04:05", strings.TrimRight(string(data[1:12]), "\x00")+" "+strings.TrimRight(string(data[12:20]), "\x00"))
+	if err == nil {
+		info.BuildTime = buildTime
+	}
+	return info, nil
+}
+
+// HardwareInfo reads the firmware info feature report and, if the controller
+// provides it, the pairing info feature report.
+func (d *DualSense) HardwareInfo() (HardwareInfo, error) {
+	data := make([]byte, FIRMWARE_INFO_REPORT_SIZE)
+	data[0] = FIRMWARE_INFO_REPORT_ID
+	n, err := d.GetFeatureReport(data)
+	if err != nil {
+		return HardwareInfo{}, err
+	}
+	info, err := UnmarshalHardwareInfo(data[:n])
+	if err != nil {
+		return HardwareInfo{}, fmt.Errorf("UnmarshalHardwareInfo: error trying to decode DualSense firmware info: %w", err)
+	}
+
+	pairing := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairing[0] = PAIRING_INFO_REPORT_ID
+	n, err = d.GetFeatureReport(pairing)
+	if err != nil || n < 7 {
+		d.logger.Debug("could not read DualSense pairing info", "error", err, "length", n)
+		return info, nil
+	}
+	info.MacAddress = readAddress(pairing[1:]).String()
+	return info, nil
+}
@@ -0,0 +1,46 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestHardwareInfo(t *testing.T) {
+	d := NewMockDualSense()
+	firmwareInfo := make([]byte, FIRMWARE_INFO_REPORT_SIZE)
+	firmwareInfo[0] = FIRMWARE_INFO_REPORT_ID
+	copy(firmwareInfo[1:], "Jun  1 2021")
+	copy(firmwareInfo[12:], "11:35:41")
+	binary.LittleEndian.PutUint32(firmwareInfo[24:], 0x00000417)
+	binary.LittleEndian.PutUint32(firmwareInfo[28:], 0x0100002A)
+	firmwareInfo[32] = 0xAB
+	binary.LittleEndian.PutUint16(firmwareInfo[44:], 0x0324)
+	d.SetFeatureReport(firmwareInfo)
+
+	info, err := d.HardwareInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2021, time.June, 1, 11, 35, 41, 0, time.UTC); !info.BuildTime.Equal(want) {
+		t.Errorf("got build time %v, want %v", info.BuildTime, want)
+	}
+	if info.HardwareVersion != 0x417 || info.FirmwareVersion != 0x0100002A || info.UpdateVersion != 0x0324 {
+		t.Errorf("got %+v", info)
+	}
+	if info.DeviceInfo != "ab0000000000000000000000" || info.MacAddress != "" {
+		t.Errorf("got device info %q and MAC address %q", info.DeviceInfo, info.MacAddress)
+	}
+
+	pairingInfo := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairingInfo[0] = PAIRING_INFO_REPORT_ID
+	copy(pairingInfo[1:], []byte{0x06, 0x05, 0x04, 0x03, 0x02, 0x01})
+	d.SetFeatureReport(pairingInfo)
+	info, err = d.HardwareInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MacAddress != "01:02:03:04:05:06" {
+		t.Errorf("got MAC address %q", info.MacAddress)
+	}
+}
@@ -0,0 +1,123 @@
+//go:build cgo
+
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	hid "github.com/sstallion/go-hid"
+)
+
+// HIDTransport is the default Transport, backed by hidapi. Controllers
+// connected over USB and Bluetooth are both supported.
+type HIDTransport struct {
+	device   *hid.Device
+	path     string
+	wireless bool
+}
+
+// OpenHIDTransport opens the first DualSense or DualSense Edge controller
+// found through hidapi.
+func OpenHIDTransport() (*HIDTransport, error) {
+	transport, err := openHIDTransport(dualSenseProductIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to open DualSense controller: %w", err)
+	}
+	return transport, nil
+}
+
+func newHIDTransport(device *hid.Device) *HIDTransport {
+	transport := &HIDTransport{device: device}
+	// Without device info, e.g. with an old hidapi, the controller is
+	// treated as connected over USB.
+	if info, err := device.GetDeviceInfo(); err == nil {
+		transport.path = info.Path
+		transport.wireless = info.BusType == hid.BusBluetooth
+	}
+	return transport
+}
+
+// OpenDualShock4HIDTransport opens the first DualShock 4 controller found
+// through hidapi. Wrap it in a DualShock4Transport to use it with DualSense.
+func OpenDualShock4HIDTransport() (*HIDTransport, error) {
+	transport, err := openHIDTransport(dualShock4ProductIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to open DualShock 4 controller: %w", err)
+	}
+	return transport, nil
+}
+
+// openHIDTransport opens the first controller matching one of productIDs, in
+// order.
+func openHIDTransport(productIDs ...uint16) (*HIDTransport, error) {
+	var err error
+	for _, productID := range productIDs {
+		var device *hid.Device
+		device, err = hid.OpenFirst(DUALSENSE_VENDOR_ID, productID)
+		if err != nil {
+			continue
+		}
+		err = device.SetNonblock(false)
+		if err != nil {
+			device.Close()
+			return nil, fmt.Errorf("error trying to set controller to blocking mode: %w", err)
+		}
+		return newHIDTransport(device), nil
+	}
+	return nil, err
+}
+
+func (t *HIDTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	n, err := t.device.ReadWithTimeout(p, timeout)
+	if errors.Is(err, hid.ErrTimeout) {
+		return 0, ErrTimeout
+	}
+	// hidapi only fails reads once the device is gone, with a message that
+	// depends on the platform backend.
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDisconnected, err)
+	}
+	return n, nil
+}
+
+func (t *HIDTransport) Write(p []byte) (int, error) {
+	return t.device.Write(p)
+}
+
+func (t *HIDTransport) SendFeature(p []byte) (int, error) {
+	return t.device.SendFeatureReport(p)
+}
+
+func (t *HIDTransport) GetFeature(p []byte) (int, error) {
+	return t.device.GetFeatureReport(p)
+}
+
+// Wireless reports whether the controller is connected over Bluetooth.
+func (t *HIDTransport) Wireless() bool {
+	return t.wireless
+}
+
+// Disconnect drops the Bluetooth link of the controller, see
+// DualSense.Disconnect. It is supported on Linux, where hidapi opens hidraw
+// nodes, and needs CAP_NET_RAW; USB connections cannot be dropped.
+func (t *HIDTransport) Disconnect() error {
+	if !t.wireless {
+		return fmt.Errorf("error trying to disconnect DualSense controller connected over USB: %w", errors.ErrUnsupported)
+	}
+	return disconnectHIDRaw(t.path)
+}
+
+func (t *HIDTransport) ReportDescriptor() ([]byte, error) {
+	descriptor := make([]byte, MAX_REPORT_DESCRIPTOR_SIZE)
+	n, err := t.device.GetReportDescriptor(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return descriptor[:n], nil
+}
+
+func (t *HIDTransport) Close() error {
+	return t.device.Close()
+}
@@ -0,0 +1,223 @@
+package dualsense
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// HID bus types, from linux/input.h.
+const (
+	busUSB       = 0x03
+	busBluetooth = 0x05
+)
+
+// HIDRawTransport talks to a controller through a Linux /dev/hidraw* node,
+// bypassing hidapi. Reads go through the runtime poller, so a blocked Read
+// does not hold an OS thread. Controllers connected over USB and Bluetooth are
+// both supported.
+type HIDRawTransport struct {
+	file *os.File
+	bus  uint32
+}
+
+// OpenHIDRawTransport opens the hidraw node of the first DualSense or
+// DualSense Edge controller, matched on the HID_ID that udev exposes in the device's uevent.
+func OpenHIDRawTransport() (*HIDRawTransport, error) {
+	nodePaths, err := filepath.Glob("/sys/class/hidraw/hidraw*")
+	if err != nil {
+		return nil, fmt.Errorf("filepath.Glob: error trying to list hidraw devices: %w", err)
+	}
+	for _, nodePath := range nodePaths {
+		bus, vendorID, productID, err := readHIDUevent(filepath.Join(nodePath, "device", "uevent"))
+		if err != nil || !isDualSenseHID(bus, vendorID, productID) {
+			continue
+		}
+		return OpenHIDRawTransportPath(filepath.Join("/dev", filepath.Base(nodePath)))
+	}
+	return nil, errors.New("error trying to open DualSense controller: no DualSense hidraw device found")
+}
+
+// isDualSenseHID reports whether a HID_ID identifies a DualSense or DualSense
+// Edge connected over USB or Bluetooth.
+func isDualSenseHID(bus, vendorID, productID uint64) bool {
+	return (bus == busUSB || bus == busBluetooth) && vendorID == DUALSENSE_VENDOR_ID && slices.Contains(dualSenseProductIDs, uint16(productID))
+}
+
+// OpenHIDRawTransportPath opens the hidraw node at path, e.g. /dev/hidraw0.
+func OpenHIDRawTransportPath(path string) (*HIDRawTransport, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: error trying to open DualSense controller: %w", err)
+	}
+	t := &HIDRawTransport{file: file}
+	t.bus, err = t.rawInfo()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error trying to read DualSense controller bus type: %w", err)
+	}
+	return t, nil
+}
+
+// rawInfo returns the bus type reported by HIDIOCGRAWINFO.
+func (t *HIDRawTransport) rawInfo() (uint32, error) {
+	var info struct {
+		BusType uint32
+		Vendor  int16
+		Product int16
+	}
+	conn, err := t.file.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+	var errno syscall.Errno
+	err = conn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlRequest(2, 'H', 0x03, unsafe.Sizeof(info)), uintptr(unsafe.Pointer(&info)))
+	})
+	if err != nil {
+		return 0, err
+	}
+	if errno != 0 {
+		return 0, errno
+	}
+	return info.BusType, nil
+}
+
+// Wireless reports whether the controller is connected over Bluetooth.
+func (t *HIDRawTransport) Wireless() bool {
+	return t.bus == busBluetooth
+}
+
+// Disconnect drops the Bluetooth link of the controller, see
+// DualSense.Disconnect. It needs CAP_NET_RAW; USB connections cannot be
+// dropped.
+func (t *HIDRawTransport) Disconnect() error {
+	if !t.Wireless() {
+		return fmt.Errorf("error trying to disconnect DualSense controller connected over USB: %w", errors.ErrUnsupported)
+	}
+	return disconnectHIDRaw(t.file.Name())
+}
+
+// readHIDUevent parses the HID_ID line of a hid device uevent file, formatted
+// as bus:vendor:product in hex.
+func readHIDUevent(path string) (bus, vendorID, productID uint64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		hidID, ok := strings.CutPrefix(scanner.Text(), "HID_ID=")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(hidID, ":")
+		if len(parts) != 3 {
+			return 0, 0, 0, fmt.Errorf("malformed HID_ID %q", hidID)
+		}
+		values := make([]uint64, 3)
+		for i, part := range parts {
+			values[i], err = strconv.ParseUint(part, 16, 32)
+			if err != nil {
+				return 0, 0, 0, fmt.Errorf("malformed HID_ID %q: %w", hidID, err)
+			}
+		}
+		return values[0], values[1], values[2], nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+	return 0, 0, 0, errors.New("missing HID_ID")
+}
+
+func (t *HIDRawTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	// An expired deadline fails before reading, so the shortest poll is 1ms.
+	err := t.file.SetReadDeadline(time.Now().Add(max(timeout, time.Millisecond)))
+	if err != nil {
+		return 0, err
+	}
+	n, err := t.file.Read(p)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return 0, ErrTimeout
+	}
+	// hidraw fails reads with EIO once the device is removed.
+	if errors.Is(err, syscall.EIO) || errors.Is(err, syscall.ENODEV) {
+		return 0, ErrDisconnected
+	}
+	return n, err
+}
+
+func (t *HIDRawTransport) Write(p []byte) (int, error) {
+	return t.file.Write(p)
+}
+
+func (t *HIDRawTransport) featureIoctl(nr uintptr, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, errors.New("missing report ID")
+	}
+	conn, err := t.file.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+	var n uintptr
+	var errno syscall.Errno
+	err = conn.Control(func(fd uintptr) {
+		n, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlRequest(3, 'H', nr, uintptr(len(p))), uintptr(unsafe.Pointer(&p[0])))
+	})
+	if err != nil {
+		return 0, err
+	}
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func (t *HIDRawTransport) SendFeature(p []byte) (int, error) {
+	return t.featureIoctl(0x06, p)
+}
+
+func (t *HIDRawTransport) GetFeature(p []byte) (int, error) {
+	return t.featureIoctl(0x07, p)
+}
+
+// ReportDescriptor reads the report descriptor with HIDIOCGRDESCSIZE and
+// HIDIOCGRDESC.
+func (t *HIDRawTransport) ReportDescriptor() ([]byte, error) {
+	var descriptor struct {
+		Size  uint32
+		Value [MAX_REPORT_DESCRIPTOR_SIZE]byte
+	}
+	conn, err := t.file.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var errno syscall.Errno
+	err = conn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlRequest(2, 'H', 0x01, unsafe.Sizeof(descriptor.Size)), uintptr(unsafe.Pointer(&descriptor.Size)))
+		if errno != 0 {
+			return
+		}
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlRequest(2, 'H', 0x02, unsafe.Sizeof(descriptor)), uintptr(unsafe.Pointer(&descriptor)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errno != 0 {
+		return nil, errno
+	}
+	return append([]byte(nil), descriptor.Value[:min(descriptor.Size, MAX_REPORT_DESCRIPTOR_SIZE)]...), nil
+}
+
+func (t *HIDRawTransport) Close() error {
+	return t.file.Close()
+}
@@ -0,0 +1,47 @@
+package dualsense
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadHIDUevent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uevent")
+	uevent := "DRIVER=playstation\nHID_ID=0003:0000054C:00000CE6\nHID_NAME=Sony Interactive Entertainment DualSense Wireless Controller\n"
+	err := os.WriteFile(path, []byte(uevent), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus, vendorID, productID, err := readHIDUevent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bus != busUSB || vendorID != DUALSENSE_VENDOR_ID || productID != DUALSENSE_PRODUCT_ID {
+		t.Errorf("readHIDUevent() = %#x, %#x, %#x", bus, vendorID, productID)
+	}
+	err = os.WriteFile(path, []byte("DRIVER=playstation\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err = readHIDUevent(path)
+	if err == nil {
+		t.Error("readHIDUevent() without HID_ID: expected error")
+	}
+}
+
+func TestIsDualSenseHID(t *testing.T) {
+	for _, test := range []struct {
+		bus, vendorID, productID uint64
+		want                     bool
+	}{
+		{busUSB, DUALSENSE_VENDOR_ID, DUALSENSE_PRODUCT_ID, true},
+		{busBluetooth, DUALSENSE_VENDOR_ID, DUALSENSE_EDGE_PRODUCT_ID, true},
+		{busUSB, DUALSENSE_VENDOR_ID, DUALSHOCK4_PRODUCT_ID_V2, false},
+		{0x06, DUALSENSE_VENDOR_ID, DUALSENSE_PRODUCT_ID, false},
+	} {
+		if got := isDualSenseHID(test.bus, test.vendorID, test.productID); got != test.want {
+			t.Errorf("isDualSenseHID(%#x, %#x, %#x) = %v, want %v", test.bus, test.vendorID, test.productID, got, test.want)
+		}
+	}
+}
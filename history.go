@@ -0,0 +1,201 @@
+package dualsense
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Event is a single field change recorded by WithEventHistory.
+type Event struct {
+	Field Field
+	Old   any
+	New   any
+	// Label is the label of the controller, see SetLabel.
+	Label string
+	// ReportSeq counts input reports since the DualSense was created,
+	// without wrapping like SeqNo.
+	ReportSeq uint64
+	Time      time.Time
+}
+
+// StateSnapshot is an input state recorded by WithStateHistory.
+type StateSnapshot struct {
+	State USBGetStateData
+	Time  time.Time
+}
+
+// ring is a fixed size buffer keeping the latest values pushed to it.
+type ring[T any] struct {
+	values []T
+	next   int
+	full   bool
+}
+
+func newRing[T any](size int) ring[T] {
+	return ring[T]{values: make([]T, size)}
+}
+
+func (r *ring[T]) push(value T) {
+	if len(r.values) == 0 {
+		return
+	}
+	r.values[r.next] = value
+	r.next = (r.next + 1) % len(r.values)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// last returns up to n of the latest values, oldest first. A negative n
+// returns every value.
+func (r *ring[T]) last(n int) []T {
+	count := r.next
+	if r.full {
+		count = len(r.values)
+	}
+	if n >= 0 && n < count {
+		count = n
+	}
+	values := make([]T, 0, count)
+	for i := r.next - count; i < r.next; i++ {
+		values = append(values, r.values[(i+len(r.values))%len(r.values)])
+	}
+	return values
+}
+
+type eventHistory struct {
+	mu        sync.Mutex
+	events    ring[Event]
+	streams   []chan Event
+	reportSeq uint64
+}
+
+// WithEventHistory keeps the last size field changes for History. Fields
+// suppressed with WithSuppressedFields are not recorded, so suppressing
+// TimestampFields keeps the history to actual input.
+func WithEventHistory(size int) Option {
+	return func(d *DualSense) {
+		d.eventHistory.events = newRing[Event](max(size, 0))
+	}
+}
+
+// recordEvents adds an Event for every field that changed between previous and
+// current.
+func (d *DualSense) recordEvents(current, previous *USBGetStateData, now time.Time) {
+	h := &d.eventHistory
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reportSeq++
+	if len(h.events.values) == 0 && len(h.streams) == 0 {
+		return
+	}
+	label := d.Label()
+	for field := range inputFields {
+		if d.callbacks.suppressed[field] || !inputFields[field].changed(current, previous) {
+			continue
+		}
+		event := Event{
+			Field:     Field(field),
+			Old:       inputFields[field].value(previous),
+			New:       inputFields[field].value(current),
+			Label:     label,
+			ReportSeq: h.reportSeq,
+			Time:      now,
+		}
+		h.events.push(event)
+		for _, stream := range h.streams {
+			sendDroppingOldest(stream, event)
+		}
+	}
+}
+
+// sendDroppingOldest sends event without blocking, discarding the oldest
+// queued event when stream is full.
+func sendDroppingOldest(stream chan Event, event Event) {
+	for {
+		select {
+		case stream <- event:
+			return
+		default:
+		}
+		select {
+		case <-stream:
+		default:
+		}
+	}
+}
+
+// Events returns a channel receiving every field change as an Event, in
+// order. Fields suppressed with WithSuppressedFields are left out, as in
+// History. Up to bufferSize events wait for the receiver; when the buffer is
+// full the oldest is discarded. The channel is closed by Close or
+// StopEvents.
+func (d *DualSense) Events(bufferSize int) <-chan Event {
+	stream := make(chan Event, max(bufferSize, 1))
+	d.eventHistory.mu.Lock()
+	defer d.eventHistory.mu.Unlock()
+	if d.LifecycleState() == LifecycleClosed {
+		close(stream)
+		return stream
+	}
+	d.eventHistory.streams = append(d.eventHistory.streams, stream)
+	return stream
+}
+
+// StopEvents closes stream, returned by Events, and stops sending to it.
+func (d *DualSense) StopEvents(stream <-chan Event) {
+	d.eventHistory.mu.Lock()
+	defer d.eventHistory.mu.Unlock()
+	d.eventHistory.streams = slices.DeleteFunc(d.eventHistory.streams, func(s chan Event) bool {
+		if (<-chan Event)(s) != stream {
+			return false
+		}
+		close(s)
+		return true
+	})
+}
+
+func (h *eventHistory) closeStreams() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, stream := range h.streams {
+		close(stream)
+	}
+	h.streams = nil
+}
+
+// History returns the recorded field changes, oldest first. It is empty
+// unless the DualSense was created WithEventHistory.
+func (d *DualSense) History() []Event {
+	d.eventHistory.mu.Lock()
+	defer d.eventHistory.mu.Unlock()
+	return d.eventHistory.events.last(-1)
+}
+
+type stateHistory struct {
+	mu        sync.Mutex
+	snapshots ring[StateSnapshot]
+}
+
+// WithStateHistory keeps the last size input states for GetStateHistory.
+func WithStateHistory(size int) Option {
+	return func(d *DualSense) {
+		d.stateHistory.snapshots = newRing[StateSnapshot](max(size, 0))
+	}
+}
+
+func (d *DualSense) recordState(state *USBGetStateData, now time.Time) {
+	d.stateHistory.mu.Lock()
+	defer d.stateHistory.mu.Unlock()
+	d.stateHistory.snapshots.push(StateSnapshot{State: *state, Time: now})
+}
+
+// GetStateHistory returns up to the last n input states, oldest first, e.g.
+// for smoothing or computing stick velocity. It is empty unless the DualSense
+// was created WithStateHistory.
+func (d *DualSense) GetStateHistory(n int) []StateSnapshot {
+	d.stateHistory.mu.Lock()
+	defer d.stateHistory.mu.Unlock()
+	return d.stateHistory.snapshots.last(max(n, 0))
+}
@@ -0,0 +1,77 @@
+package dualsense
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := newRing[int](3)
+	if got := r.last(-1); len(got) != 0 {
+		t.Fatalf("got %v from an empty ring", got)
+	}
+	r.push(1)
+	r.push(2)
+	if got := r.last(-1); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+	r.push(3)
+	r.push(4)
+	if got := r.last(-1); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Fatalf("got %v, want [2 3 4]", got)
+	}
+	if got := r.last(2); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("got %v, want [3 4]", got)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	mock := NewMockDualSense(WithEventHistory(2), WithSuppressedFields(TimestampFields...))
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.SeqNo = 1
+		state.ButtonCross = true
+	})
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.SeqNo = 2
+		state.ButtonCross = false
+		state.TriggerLeft = 40
+	})
+	history := mock.History()
+	if len(history) != 2 {
+		t.Fatalf("got %d events, want 2", len(history))
+	}
+	for i, want := range []Event{
+		{Field: FieldTriggerLeft, Old: uint8(0), New: uint8(40), ReportSeq: 2},
+		{Field: FieldButtonCross, Old: true, New: false, ReportSeq: 2},
+	} {
+		got := history[i]
+		if got.Field != want.Field || got.Old != want.Old || got.New != want.New || got.ReportSeq != want.ReportSeq || got.Time.IsZero() {
+			t.Fatalf("event %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestGetStateHistory(t *testing.T) {
+	mock := NewMockDualSense(WithStateHistory(3))
+	if got := mock.GetStateHistory(10); len(got) != 0 {
+		t.Fatalf("got %d snapshots before any report", len(got))
+	}
+	for x := uint8(1); x <= 5; x++ {
+		mock.UpdateInState(func(state *USBGetStateData) { state.LeftStickX = x })
+	}
+	history := mock.GetStateHistory(10)
+	if len(history) != 3 {
+		t.Fatalf("got %d snapshots, want 3", len(history))
+	}
+	for i, snapshot := range history {
+		if want := uint8(i + 3); snapshot.State.LeftStickX != want {
+			t.Fatalf("snapshot %d: got LeftStickX %d, want %d", i, snapshot.State.LeftStickX, want)
+		}
+	}
+	if history[2].Time.Before(history[0].Time) {
+		t.Fatal("snapshots out of order")
+	}
+	if got := mock.GetStateHistory(1); len(got) != 1 || got[0].State.LeftStickX != 5 {
+		t.Fatalf("got %+v, want the latest state", got)
+	}
+}
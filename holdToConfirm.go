@@ -0,0 +1,106 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+// HOLD_PROGRESS_INTERVAL is how often OnButtonHeldFor reports progress while
+// the button is held.
+const HOLD_PROGRESS_INTERVAL = 50 * time.Millisecond
+
+type heldButton struct {
+	mu       sync.Mutex
+	duration time.Duration
+	progress func(float64)
+	confirm  func()
+	// ticks starts the progress ticks of a press and returns the func
+	// stopping them.
+	ticks  func() (<-chan time.Time, func())
+	closed <-chan struct{}
+	// stop ends the goroutine of the held press, and done is closed once the
+	// goroutine of the latest press returned.
+	stop chan struct{}
+	done chan struct{}
+}
+
+// OnButtonHeldFor reports progress from 0 to 1 every HOLD_PROGRESS_INTERVAL
+// while button is held, and calls confirm once it has been held for
+// duration, e.g. to guard destructive actions. Releasing the button resets
+// progress to 0, cancelling the hold if confirm has not run yet. button must be
+// a button field; progress may be nil. Callbacks run on a goroutine per press,
+// one press after another, which ends on release or Close.
+// The returned func unregisters the callbacks, as for OnFieldChange.
+func (d *DualSense) OnButtonHeldFor(button Field, duration time.Duration, progress func(float64), confirm func()) (func(), error) {
+	if progress == nil {
+		progress = func(float64) {}
+	}
+	h := &heldButton{duration: duration, progress: progress, confirm: confirm, ticks: holdTicks, closed: d.closed}
+	return d.OnFieldChange(button, func(change FieldChange) {
+		pressed, ok := change.New.(bool)
+		if !ok {
+			return
+		}
+		h.set(pressed, time.Now())
+	})
+}
+
+func holdTicks() (<-chan time.Time, func()) {
+	ticker := time.NewTicker(HOLD_PROGRESS_INTERVAL)
+	return ticker.C, ticker.Stop
+}
+
+// set starts a hold at now when pressed and cancels it on release.
+func (h *heldButton) set(pressed bool, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !pressed {
+		if h.stop != nil {
+			close(h.stop)
+			h.stop = nil
+		}
+		return
+	}
+	if h.stop != nil {
+		return
+	}
+	ticks, stopTicks := h.ticks()
+	previous := h.done
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	go h.run(now, ticks, stopTicks, h.stop, previous, h.done)
+}
+
+// run reports the progress of the press at start. It waits for the goroutine
+// of the previous press, so callbacks keep their order without mu held.
+func (h *heldButton) run(start time.Time, ticks <-chan time.Time, stopTicks func(), stop, previous, done chan struct{}) {
+	defer close(done)
+	defer stopTicks()
+	if previous != nil {
+		select {
+		case <-previous:
+		case <-h.closed:
+			return
+		}
+	}
+	h.progress(0)
+	for {
+		select {
+		case <-h.closed:
+			return
+		case <-stop:
+			h.progress(0)
+			return
+		case now := <-ticks:
+			elapsed := now.Sub(start)
+			if elapsed < h.duration {
+				h.progress(float64(elapsed) / float64(h.duration))
+				continue
+			}
+			h.progress(1)
+			h.confirm()
+			// Stay armed until release so a long hold confirms once.
+			ticks = nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeldButton(t *testing.T) {
+	ticks := make(chan time.Time)
+	closed := make(chan struct{})
+	progress := make(chan float64, 10)
+	confirmed := make(chan struct{}, 10)
+	h := &heldButton{
+		duration: 4 * HOLD_PROGRESS_INTERVAL,
+		progress: func(value float64) { progress <- value },
+		confirm:  func() { confirmed <- struct{}{} },
+		ticks: func() (<-chan time.Time, func()) {
+			return ticks, func() {}
+		},
+		closed: closed,
+	}
+	wantProgress := func(want float64) {
+		t.Helper()
+		select {
+		case got := <-progress:
+			if got != want {
+				t.Fatalf("got progress %v, want %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no progress, want %v", want)
+		}
+	}
+
+	start := time.Now()
+	h.set(true, start)
+	wantProgress(0)
+	ticks <- start.Add(HOLD_PROGRESS_INTERVAL)
+	wantProgress(0.25)
+	h.set(false, start)
+	wantProgress(0)
+
+	start = start.Add(time.Second)
+	h.set(true, start)
+	h.set(true, start) // Repeated reports of the held button change nothing.
+	wantProgress(0)
+	ticks <- start.Add(2 * HOLD_PROGRESS_INTERVAL)
+	wantProgress(0.5)
+	ticks <- start.Add(5 * HOLD_PROGRESS_INTERVAL)
+	wantProgress(1)
+	select {
+	case <-confirmed:
+	case <-time.After(time.Second):
+		t.Fatal("not confirmed after duration")
+	}
+	h.set(false, start)
+	wantProgress(0)
+	if len(confirmed) != 0 {
+		t.Fatalf("got %d more confirmations, want 1 per hold", len(confirmed))
+	}
+
+	h.set(true, start)
+	wantProgress(0)
+	close(closed)
+	select {
+	case <-h.done:
+	case <-time.After(time.Second):
+		t.Fatal("hold goroutine still running after Close")
+	}
+	if len(progress) != 0 {
+		t.Fatalf("got progress %v after Close", <-progress)
+	}
+}
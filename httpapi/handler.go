@@ -0,0 +1,165 @@
+// Package httpapi serves a DualSense controller as a small REST API with JSON
+// bodies, for scripting it with curl or from languages without HID access.
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+// LedRequest is the body of POST /led.
+type LedRequest struct {
+	Red   uint8 `json:"red"`
+	Green uint8 `json:"green"`
+	Blue  uint8 `json:"blue"`
+}
+
+// RumbleRequest is the body of POST /rumble. A positive DurationMs stops the
+// rumble again after that many milliseconds.
+type RumbleRequest struct {
+	Left       uint8 `json:"left"`
+	Right      uint8 `json:"right"`
+	DurationMs int   `json:"durationMs"`
+}
+
+// TriggerRequest is the body of POST /trigger. Trigger is "left" or "right".
+type TriggerRequest struct {
+	Trigger  string               `json:"trigger"`
+	Effect   dualsense.EffectType `json:"effect"`
+	Start    uint8                `json:"start"`
+	End      uint8                `json:"end"`
+	Strength uint8                `json:"strength"`
+}
+
+// NewHandler returns an http.Handler exposing controller as a small REST API:
+// GET /state returns the input state as JSON, and POST /led, /rumble and
+// /trigger take LedRequest, RumbleRequest and TriggerRequest bodies.
+func NewHandler(controller dualsense.Controller) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(controller.GetInStateData())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error encoding DualSense state: %v", err), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("POST /led", func(w http.ResponseWriter, r *http.Request) {
+		var request LedRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		writeResult(w, controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+			setStateData.AllowLedColor = true
+			setStateData.LedRed = request.Red
+			setStateData.LedGreen = request.Green
+			setStateData.LedBlue = request.Blue
+		}))
+	})
+	rumble := &timedRumble{controller: controller}
+	mux.HandleFunc("POST /rumble", func(w http.ResponseWriter, r *http.Request) {
+		var request RumbleRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		writeResult(w, rumble.start(request))
+	})
+	mux.HandleFunc("POST /trigger", func(w http.ResponseWriter, r *http.Request) {
+		var request TriggerRequest
+		if !decodeRequest(w, r, &request) {
+			return
+		}
+		params := dualsense.GenerateTriggerFFBParams(request.Effect, request.Start, request.End, request.Strength)
+		switch request.Trigger {
+		case "left":
+			writeResult(w, controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+				setStateData.AllowLeftTriggerFFB = true
+				setStateData.LeftTriggerFFB = params
+			}))
+		case "right":
+			writeResult(w, controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+				setStateData.AllowRightTriggerFFB = true
+				setStateData.RightTriggerFFB = params
+			}))
+		default:
+			http.Error(w, fmt.Sprintf("invalid trigger %q, expected \"left\" or \"right\"", request.Trigger), http.StatusBadRequest)
+		}
+	})
+	return mux
+}
+
+// timedRumble sets the rumble of POST /rumble requests, with one timer
+// stopping the rumble of the latest request, so an earlier DurationMs cannot
+// cut a newer rumble short.
+type timedRumble struct {
+	controller dualsense.Controller
+	mu         sync.Mutex
+	timer      *time.Timer
+	// stopAt is when the latest request asked the rumble to stop, zero for
+	// never.
+	stopAt time.Time
+}
+
+func (h *timedRumble) start(request RumbleRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopAt = time.Time{}
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+	err := h.set(request.Left, request.Right)
+	if err != nil || request.DurationMs <= 0 {
+		return err
+	}
+	duration := time.Duration(request.DurationMs) * time.Millisecond
+	h.stopAt = time.Now().Add(duration)
+	if h.timer == nil {
+		h.timer = time.AfterFunc(duration, h.stop)
+	} else {
+		h.timer.Reset(duration)
+	}
+	return nil
+}
+
+func (h *timedRumble) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// A timer that fired while a newer request held the lock is stale.
+	if h.stopAt.IsZero() || time.Now().Before(h.stopAt) {
+		return
+	}
+	h.stopAt = time.Time{}
+	h.set(0, 0)
+}
+
+func (h *timedRumble) set(left, right uint8) error {
+	return h.controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+		setStateData.EnableRumbleEmulation = true
+		setStateData.UseRumbleNotHaptics = true
+		setStateData.RumbleEmulationLeft = left
+		setStateData.RumbleEmulationRight = right
+	})
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, request any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(request)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request body: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeResult(w http.ResponseWriter, err error) {
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error updating DualSense state: %v", err), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+func TestHandler(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	handler := NewHandler(mock)
+
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = true
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/state", nil))
+	var state dualsense.USBGetStateData
+	if err := json.NewDecoder(recorder.Body).Decode(&state); err != nil {
+		t.Fatal(err)
+	}
+	if !state.ButtonCross {
+		t.Fatalf("GET /state returned %+v, want ButtonCross", state)
+	}
+
+	for path, body := range map[string]string{
+		"/led":     `{"red": 1, "green": 2, "blue": 3}`,
+		"/rumble":  `{"left": 255, "right": 128}`,
+		"/trigger": `{"trigger": "right", "effect": "Weapon", "start": 2, "end": 7, "strength": 8}`,
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("POST %s: got status %d: %s", path, recorder.Code, recorder.Body)
+		}
+	}
+	out := mock.GetOutStateData()
+	if out.LedRed != 1 || out.LedGreen != 2 || out.LedBlue != 3 {
+		t.Errorf("got LED %d %d %d, want 1 2 3", out.LedRed, out.LedGreen, out.LedBlue)
+	}
+	if out.RumbleEmulationLeft != 255 || out.RumbleEmulationRight != 128 {
+		t.Errorf("got rumble %d %d, want 255 128", out.RumbleEmulationLeft, out.RumbleEmulationRight)
+	}
+	if out.RightTriggerFFB != dualsense.GenerateTriggerFFBParams(dualsense.EffectTypeWeapon, 2, 7, 8) {
+		t.Errorf("got right trigger %v", out.RightTriggerFFB)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/trigger", strings.NewReader(`{"trigger": "middle"}`)))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("POST /trigger with invalid trigger: got status %d, want 400", recorder.Code)
+	}
+}
+
+func post(t *testing.T, handler http.Handler, path, body string) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return recorder.Code
+}
+
+func TestRumbleDuration(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	handler := NewHandler(mock)
+
+	if code := post(t, handler, "/rumble", `{"left": 255, "right": 255, "durationMs": 1}`); code != http.StatusNoContent {
+		t.Fatalf("got status %d", code)
+	}
+	deadline := time.Now().Add(time.Second)
+	for out := mock.GetOutStateData(); out.RumbleEmulationLeft != 0 || out.RumbleEmulationRight != 0; out = mock.GetOutStateData() {
+		if time.Now().After(deadline) {
+			t.Fatalf("rumble %d %d not stopped after DurationMs", out.RumbleEmulationLeft, out.RumbleEmulationRight)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A newer request replaces the stop of an earlier one.
+	if code := post(t, handler, "/rumble", `{"left": 255, "right": 255, "durationMs": 10}`); code != http.StatusNoContent {
+		t.Fatalf("got status %d", code)
+	}
+	if code := post(t, handler, "/rumble", `{"left": 100, "right": 50}`); code != http.StatusNoContent {
+		t.Fatalf("got status %d", code)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if out := mock.GetOutStateData(); out.RumbleEmulationLeft != 100 || out.RumbleEmulationRight != 50 {
+		t.Fatalf("got rumble %d %d, want 100 50 after the earlier DurationMs", out.RumbleEmulationLeft, out.RumbleEmulationRight)
+	}
+}
+
+// failingController fails every UpdateState.
+type failingController struct {
+	dualsense.Controller
+}
+
+func (failingController) UpdateState(func(*dualsense.SetStateData)) error {
+	return errors.New("write failed")
+}
+
+func TestHandlerErrors(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	handler := NewHandler(mock)
+	for _, test := range []struct {
+		path, body string
+	}{
+		{"/led", `{"red": `},
+		{"/led", `{"red": 1, "alpha": 2}`},
+		{"/rumble", `{"left": 256}`},
+		{"/trigger", `{"trigger": "left", "effect": "Bogus"}`},
+	} {
+		if code := post(t, handler, test.path, test.body); code != http.StatusBadRequest {
+			t.Errorf("POST %s %s: got status %d, want 400", test.path, test.body, code)
+		}
+	}
+
+	handler = NewHandler(failingController{mock})
+	for _, path := range []string{"/led", "/rumble", "/trigger"} {
+		body := `{}`
+		if path == "/trigger" {
+			body = `{"trigger": "left", "effect": "Off"}`
+		}
+		if code := post(t, handler, path, body); code != http.StatusBadGateway {
+			t.Errorf("POST %s with a failing controller: got status %d, want 502", path, code)
+		}
+	}
+}
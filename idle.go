@@ -0,0 +1,164 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+// IDLE_STICK_THRESHOLD is how far a stick must move from where it rested to
+// count as activity, ignoring sensor jitter.
+const IDLE_STICK_THRESHOLD = 8
+
+type IdleLightbar uint8
+
+const (
+	IdleLightbarKeep IdleLightbar = iota
+	IdleLightbarDim               // Quarter brightness
+	IdleLightbarOff
+)
+
+// IdlePolicy configures idle detection. The controller is idle once no
+// button, trigger, touch or stick input has changed for Timeout; a Timeout of
+// 0 disables detection.
+type IdlePolicy struct {
+	Timeout  time.Duration
+	Lightbar IdleLightbar
+	// PollingRate replaces the polling rate while idle, in Hz; 0 keeps it.
+	PollingRate int
+}
+
+type idleTracker struct {
+	mu           sync.Mutex
+	policy       IdlePolicy
+	idle         bool
+	lastActivity time.Time
+	rested       USBGetStateData
+	savedLed     [3]uint8
+	dimmedLed    [3]uint8
+}
+
+// active reports whether current differs from rested in anything a user
+// does on purpose.
+func active(current, rested *USBGetStateData) bool {
+	stickMoved := func(a, b uint8) bool {
+		return max(a, b)-min(a, b) >= IDLE_STICK_THRESHOLD
+	}
+	if stickMoved(current.LeftStickX, rested.LeftStickX) || stickMoved(current.LeftStickY, rested.LeftStickY) ||
+		stickMoved(current.RightStickX, rested.RightStickX) || stickMoved(current.RightStickY, rested.RightStickY) {
+		return true
+	}
+	c, r := current, rested
+	return c.TriggerLeft != r.TriggerLeft || c.TriggerRight != r.TriggerRight || c.DPad != r.DPad ||
+		c.ButtonSquare != r.ButtonSquare || c.ButtonCross != r.ButtonCross || c.ButtonCircle != r.ButtonCircle || c.ButtonTriangle != r.ButtonTriangle ||
+		c.ButtonL1 != r.ButtonL1 || c.ButtonR1 != r.ButtonR1 || c.ButtonL2 != r.ButtonL2 || c.ButtonR2 != r.ButtonR2 ||
+		c.ButtonCreate != r.ButtonCreate || c.ButtonOptions != r.ButtonOptions || c.ButtonL3 != r.ButtonL3 || c.ButtonR3 != r.ButtonR3 ||
+		c.ButtonHome != r.ButtonHome || c.ButtonPad != r.ButtonPad || c.ButtonMute != r.ButtonMute ||
+		c.ButtonLeftFunction != r.ButtonLeftFunction || c.ButtonRightFunction != r.ButtonRightFunction ||
+		c.ButtonLeftPaddle != r.ButtonLeftPaddle || c.ButtonRightPaddle != r.ButtonRightPaddle ||
+		c.TouchData.TouchFinger1 != r.TouchData.TouchFinger1 || c.TouchData.TouchFinger2 != r.TouchData.TouchFinger2 ||
+		c.PluggedHeadphones != r.PluggedHeadphones || c.PluggedMic != r.PluggedMic || c.MicMuted != r.MicMuted ||
+		c.PluggedUsbData != r.PluggedUsbData || c.PluggedUsbPower != r.PluggedUsbPower || c.PluggedExternalMic != r.PluggedExternalMic ||
+		c.HapticLowPassFilter != r.HapticLowPassFilter
+}
+
+// record returns +1 when the controller just became idle and -1 when it just
+// became active again.
+func (i *idleTracker) record(state *USBGetStateData, now time.Time) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.lastActivity.IsZero() || active(state, &i.rested) {
+		i.lastActivity = now
+		i.rested = *state
+		if i.idle {
+			i.idle = false
+			return -1
+		}
+		return 0
+	}
+	if !i.idle && i.policy.Timeout > 0 && now.Sub(i.lastActivity) >= i.policy.Timeout {
+		i.idle = true
+		return 1
+	}
+	return 0
+}
+
+func (i *idleTracker) pollingRate(active time.Duration) time.Duration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.idle && i.policy.PollingRate > 0 {
+		return time.Second / time.Duration(i.policy.PollingRate)
+	}
+	return active
+}
+
+// SetIdlePolicy configures idle detection, see IdlePolicy.
+func (d *DualSense) SetIdlePolicy(policy IdlePolicy) {
+	d.idle.mu.Lock()
+	defer d.idle.mu.Unlock()
+	d.idle.policy = policy
+}
+
+// Idle reports whether the controller is currently idle.
+func (d *DualSense) Idle() bool {
+	d.idle.mu.Lock()
+	defer d.idle.mu.Unlock()
+	return d.idle.idle
+}
+
+func (d *DualSense) OnIdle(callback func()) {
+	d.callbacks.OnIdle = append(d.callbacks.OnIdle, callback)
+}
+
+func (d *DualSense) OnActive(callback func()) {
+	d.callbacks.OnActive = append(d.callbacks.OnActive, callback)
+}
+
+// processIdle applies the idle policy to the lightbar and runs OnIdle and
+// OnActive. A lightbar color set while idle is kept on activity.
+func (d *DualSense) processIdle(state *USBGetStateData) {
+	transition := d.idle.record(state, time.Now())
+	if transition == 0 {
+		return
+	}
+	d.idle.mu.Lock()
+	lightbar := d.idle.policy.Lightbar
+	d.idle.mu.Unlock()
+	if transition > 0 {
+		if lightbar != IdleLightbarKeep {
+			err := d.UpdateState(func(setStateData *SetStateData) {
+				saved := [3]uint8{setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue}
+				dimmed := [3]uint8{}
+				if lightbar == IdleLightbarDim {
+					dimmed = [3]uint8{saved[0] / 4, saved[1] / 4, saved[2] / 4}
+				}
+				d.idle.mu.Lock()
+				d.idle.savedLed, d.idle.dimmedLed = saved, dimmed
+				d.idle.mu.Unlock()
+				setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = dimmed[0], dimmed[1], dimmed[2]
+			})
+			if err != nil {
+				d.logger.Debug("could not dim DualSense lightbar while idle", "error", err)
+			}
+		}
+		for _, callback := range d.callbacks.OnIdle {
+			callback()
+		}
+		return
+	}
+	if lightbar != IdleLightbarKeep {
+		err := d.UpdateState(func(setStateData *SetStateData) {
+			d.idle.mu.Lock()
+			saved, dimmed := d.idle.savedLed, d.idle.dimmedLed
+			d.idle.mu.Unlock()
+			if [3]uint8{setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue} == dimmed {
+				setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = saved[0], saved[1], saved[2]
+			}
+		})
+		if err != nil {
+			d.logger.Debug("could not restore DualSense lightbar after idle", "error", err)
+		}
+	}
+	for _, callback := range d.callbacks.OnActive {
+		callback()
+	}
+}
@@ -0,0 +1,85 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleTracker(t *testing.T) {
+	i := idleTracker{policy: IdlePolicy{Timeout: time.Minute, PollingRate: 10}}
+	start := time.Now()
+	state := USBGetStateData{LeftStickX: 128, LeftStickY: 128, DPad: DirectionNone}
+
+	if i.record(&state, start) != 0 {
+		t.Fatal("first report produced a transition")
+	}
+	// Stick jitter below the threshold is not activity.
+	state.LeftStickX = 128 + IDLE_STICK_THRESHOLD - 1
+	if i.record(&state, start.Add(30*time.Second)) != 0 {
+		t.Fatal("jitter produced a transition")
+	}
+	if i.record(&state, start.Add(time.Minute)) != 1 {
+		t.Fatal("idle not detected after the timeout")
+	}
+	if got := i.pollingRate(time.Millisecond); got != 100*time.Millisecond {
+		t.Fatalf("got idle polling rate %v, want 100ms", got)
+	}
+	if i.record(&state, start.Add(2*time.Minute)) != 0 {
+		t.Fatal("idle reported twice")
+	}
+	state.ButtonCross = true
+	if i.record(&state, start.Add(3*time.Minute)) != -1 {
+		t.Fatal("activity not detected")
+	}
+	if got := i.pollingRate(time.Millisecond); got != time.Millisecond {
+		t.Fatalf("got active polling rate %v, want 1ms", got)
+	}
+}
+
+func TestIdleLightbar(t *testing.T) {
+	mock := NewMockDualSense()
+	var idle, active int
+	mock.OnIdle(func() { idle++ })
+	mock.OnActive(func() { active++ })
+	mock.UpdateState(func(setStateData *SetStateData) {
+		setStateData.LedRed, setStateData.LedGreen, setStateData.LedBlue = 200, 100, 40
+	})
+	mock.SetIdlePolicy(IdlePolicy{Timeout: time.Nanosecond, Lightbar: IdleLightbarDim})
+
+	mock.UpdateInState(func(state *USBGetStateData) { state.DPad = DirectionNone })
+	time.Sleep(time.Millisecond)
+	mock.UpdateInState(func(state *USBGetStateData) {})
+	out := mock.GetOutStateData()
+	if !mock.Idle() || idle != 1 || out.LedRed != 50 || out.LedGreen != 25 || out.LedBlue != 10 {
+		t.Fatalf("got idle %v (%d callbacks), lightbar %d,%d,%d", mock.Idle(), idle, out.LedRed, out.LedGreen, out.LedBlue)
+	}
+
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCircle = true })
+	out = mock.GetOutStateData()
+	if mock.Idle() || active != 1 || out.LedRed != 200 || out.LedGreen != 100 || out.LedBlue != 40 {
+		t.Fatalf("got idle %v (%d callbacks), lightbar %d,%d,%d", mock.Idle(), active, out.LedRed, out.LedGreen, out.LedBlue)
+	}
+}
+
+func TestIdleActive(t *testing.T) {
+	rested := USBGetStateData{LeftStickX: 128, AngularVelocityX: 10, SeqNo: 1}
+	for _, test := range []struct {
+		name   string
+		update func(*USBGetStateData)
+		want   bool
+	}{
+		{"stick jitter", func(s *USBGetStateData) { s.LeftStickX += IDLE_STICK_THRESHOLD - 1 }, false},
+		{"stick", func(s *USBGetStateData) { s.LeftStickX -= IDLE_STICK_THRESHOLD }, true},
+		{"motion and timestamps", func(s *USBGetStateData) { s.AngularVelocityX, s.SeqNo, s.PowerPercent = 500, 2, 5 }, false},
+		{"trigger", func(s *USBGetStateData) { s.TriggerRight = 1 }, true},
+		{"button", func(s *USBGetStateData) { s.ButtonRightPaddle = true }, true},
+		{"dpad", func(s *USBGetStateData) { s.DPad = DirectionEast }, true},
+		{"touch", func(s *USBGetStateData) { s.TouchData.TouchFinger2.FingerX = 100 }, true},
+	} {
+		current := rested
+		test.update(&current)
+		if got := active(&current, &rested); got != test.want {
+			t.Errorf("%s: got active %v, want %v", test.name, got, test.want)
+		}
+	}
+}
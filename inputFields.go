@@ -0,0 +1,270 @@
+package dualsense
+
+import "fmt"
+
+// Field identifies a single value of USBGetStateData for change detection.
+type Field uint8
+
+const (
+	FieldLeftStickX Field = iota
+	FieldLeftStickY
+	FieldRightStickX
+	FieldRightStickY
+	FieldTriggerLeft
+	FieldTriggerRight
+	FieldSeqNo
+	FieldDPad
+	FieldButtonSquare
+	FieldButtonCross
+	FieldButtonCircle
+	FieldButtonTriangle
+	FieldButtonL1
+	FieldButtonR1
+	FieldButtonL2
+	FieldButtonR2
+	FieldButtonCreate
+	FieldButtonOptions
+	FieldButtonL3
+	FieldButtonR3
+	FieldButtonHome
+	FieldButtonPad
+	FieldButtonMute
+	FieldButtonLeftFunction
+	FieldButtonRightFunction
+	FieldButtonLeftPaddle
+	FieldButtonRightPaddle
+	FieldAngularVelocityX
+	FieldAngularVelocityZ
+	FieldAngularVelocityY
+	FieldAccelerometerX
+	FieldAccelerometerY
+	FieldAccelerometerZ
+	FieldSensorTimestamp
+	FieldTemperature
+	FieldTouchFinger1
+	FieldTouchFinger2
+	FieldTouchTimestamp
+	FieldTriggerRightStopLocation
+	FieldTriggerRightStatus
+	FieldTriggerLeftStopLocation
+	FieldTriggerLeftStatus
+	FieldHostTimestamp
+	FieldTriggerRightEffect
+	FieldTriggerLeftEffect
+	FieldDeviceTimestamp
+	FieldPowerPercent
+	FieldPowerState
+	FieldPluggedHeadphones
+	FieldPluggedMic
+	FieldMicMuted
+	FieldPluggedUsbData
+	FieldPluggedUsbPower
+	FieldPluggedExternalMic
+	FieldHapticLowPassFilter
+	FieldAesCmac
+	fieldCount
+)
+
+// MotionFields are the gyroscope and accelerometer fields, which change on
+// nearly every report.
+var MotionFields = []Field{
+	FieldAngularVelocityX,
+	FieldAngularVelocityZ,
+	FieldAngularVelocityY,
+	FieldAccelerometerX,
+	FieldAccelerometerY,
+	FieldAccelerometerZ,
+}
+
+// TimestampFields are the counters and timestamps that change on every report.
+var TimestampFields = []Field{
+	FieldSeqNo,
+	FieldSensorTimestamp,
+	FieldTouchTimestamp,
+	FieldHostTimestamp,
+	FieldDeviceTimestamp,
+	FieldAesCmac,
+}
+
+// FieldChange describes a field whose value differs between two consecutive
+// input reports.
+type FieldChange struct {
+	Field Field
+	Old   any
+	New   any
+}
+
+func (f Field) String() string {
+	if f >= fieldCount {
+		return "Unknown"
+	}
+	return inputFields[f].name
+}
+
+// Validate returns an error wrapping ErrOutOfRange if f is not a Field
+// constant.
+func (f Field) Validate() error {
+	if f >= fieldCount {
+		return fmt.Errorf("%w: invalid Field %d", ErrOutOfRange, f)
+	}
+	return nil
+}
+
+// Value returns the value of f in state, typed as in USBGetStateData.
+func (f Field) Value(state *USBGetStateData) (any, error) {
+	err := f.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return inputFields[f].value(state), nil
+}
+
+// Fields returns every Field in declaration order.
+func Fields() []Field {
+	fields := make([]Field, fieldCount)
+	for i := range fields {
+		fields[i] = Field(i)
+	}
+	return fields
+}
+
+// ParseField returns the Field whose String is name.
+func ParseField(name string) (Field, error) {
+	for field, inputField := range inputFields {
+		if inputField.name == name {
+			return Field(field), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid Field: %q", name)
+}
+
+type inputField struct {
+	name    string
+	changed func(current, previous *USBGetStateData) bool
+	value   func(state *USBGetStateData) any
+}
+
+func newInputField[T comparable](name string, get func(*USBGetStateData) T) inputField {
+	return inputField{
+		name: name,
+		changed: func(current, previous *USBGetStateData) bool {
+			return get(current) != get(previous)
+		},
+		value: func(state *USBGetStateData) any {
+			return get(state)
+		},
+	}
+}
+
+var inputFields = [fieldCount]inputField{
+	FieldLeftStickX:               newInputField("LeftStickX", func(s *USBGetStateData) uint8 { return s.LeftStickX }),
+	FieldLeftStickY:               newInputField("LeftStickY", func(s *USBGetStateData) uint8 { return s.LeftStickY }),
+	FieldRightStickX:              newInputField("RightStickX", func(s *USBGetStateData) uint8 { return s.RightStickX }),
+	FieldRightStickY:              newInputField("RightStickY", func(s *USBGetStateData) uint8 { return s.RightStickY }),
+	FieldTriggerLeft:              newInputField("TriggerLeft", func(s *USBGetStateData) uint8 { return s.TriggerLeft }),
+	FieldTriggerRight:             newInputField("TriggerRight", func(s *USBGetStateData) uint8 { return s.TriggerRight }),
+	FieldSeqNo:                    newInputField("SeqNo", func(s *USBGetStateData) uint8 { return s.SeqNo }),
+	FieldDPad:                     newInputField("DPad", func(s *USBGetStateData) Direction { return s.DPad }),
+	FieldButtonSquare:             newInputField("ButtonSquare", func(s *USBGetStateData) bool { return s.ButtonSquare }),
+	FieldButtonCross:              newInputField("ButtonCross", func(s *USBGetStateData) bool { return s.ButtonCross }),
+	FieldButtonCircle:             newInputField("ButtonCircle", func(s *USBGetStateData) bool { return s.ButtonCircle }),
+	FieldButtonTriangle:           newInputField("ButtonTriangle", func(s *USBGetStateData) bool { return s.ButtonTriangle }),
+	FieldButtonL1:                 newInputField("ButtonL1", func(s *USBGetStateData) bool { return s.ButtonL1 }),
+	FieldButtonR1:                 newInputField("ButtonR1", func(s *USBGetStateData) bool { return s.ButtonR1 }),
+	FieldButtonL2:                 newInputField("ButtonL2", func(s *USBGetStateData) bool { return s.ButtonL2 }),
+	FieldButtonR2:                 newInputField("ButtonR2", func(s *USBGetStateData) bool { return s.ButtonR2 }),
+	FieldButtonCreate:             newInputField("ButtonCreate", func(s *USBGetStateData) bool { return s.ButtonCreate }),
+	FieldButtonOptions:            newInputField("ButtonOptions", func(s *USBGetStateData) bool { return s.ButtonOptions }),
+	FieldButtonL3:                 newInputField("ButtonL3", func(s *USBGetStateData) bool { return s.ButtonL3 }),
+	FieldButtonR3:                 newInputField("ButtonR3", func(s *USBGetStateData) bool { return s.ButtonR3 }),
+	FieldButtonHome:               newInputField("ButtonHome", func(s *USBGetStateData) bool { return s.ButtonHome }),
+	FieldButtonPad:                newInputField("ButtonPad", func(s *USBGetStateData) bool { return s.ButtonPad }),
+	FieldButtonMute:               newInputField("ButtonMute", func(s *USBGetStateData) bool { return s.ButtonMute }),
+	FieldButtonLeftFunction:       newInputField("ButtonLeftFunction", func(s *USBGetStateData) bool { return s.ButtonLeftFunction }),
+	FieldButtonRightFunction:      newInputField("ButtonRightFunction", func(s *USBGetStateData) bool { return s.ButtonRightFunction }),
+	FieldButtonLeftPaddle:         newInputField("ButtonLeftPaddle", func(s *USBGetStateData) bool { return s.ButtonLeftPaddle }),
+	FieldButtonRightPaddle:        newInputField("ButtonRightPaddle", func(s *USBGetStateData) bool { return s.ButtonRightPaddle }),
+	FieldAngularVelocityX:         newInputField("AngularVelocityX", func(s *USBGetStateData) int16 { return s.AngularVelocityX }),
+	FieldAngularVelocityZ:         newInputField("AngularVelocityZ", func(s *USBGetStateData) int16 { return s.AngularVelocityZ }),
+	FieldAngularVelocityY:         newInputField("AngularVelocityY", func(s *USBGetStateData) int16 { return s.AngularVelocityY }),
+	FieldAccelerometerX:           newInputField("AccelerometerX", func(s *USBGetStateData) int16 { return s.AccelerometerX }),
+	FieldAccelerometerY:           newInputField("AccelerometerY", func(s *USBGetStateData) int16 { return s.AccelerometerY }),
+	FieldAccelerometerZ:           newInputField("AccelerometerZ", func(s *USBGetStateData) int16 { return s.AccelerometerZ }),
+	FieldSensorTimestamp:          newInputField("SensorTimestamp", func(s *USBGetStateData) uint32 { return s.SensorTimestamp }),
+	FieldTemperature:              newInputField("Temperature", func(s *USBGetStateData) int8 { return s.Temperature }),
+	FieldTouchFinger1:             newInputField("TouchFinger1", func(s *USBGetStateData) TouchFinger { return s.TouchData.TouchFinger1 }),
+	FieldTouchFinger2:             newInputField("TouchFinger2", func(s *USBGetStateData) TouchFinger { return s.TouchData.TouchFinger2 }),
+	FieldTouchTimestamp:           newInputField("TouchTimestamp", func(s *USBGetStateData) uint8 { return s.TouchData.Timestamp }),
+	FieldTriggerRightStopLocation: newInputField("TriggerRightStopLocation", func(s *USBGetStateData) uint8 { return s.TriggerRightStopLocation }),
+	FieldTriggerRightStatus:       newInputField("TriggerRightStatus", func(s *USBGetStateData) uint8 { return s.TriggerRightStatus }),
+	FieldTriggerLeftStopLocation:  newInputField("TriggerLeftStopLocation", func(s *USBGetStateData) uint8 { return s.TriggerLeftStopLocation }),
+	FieldTriggerLeftStatus:        newInputField("TriggerLeftStatus", func(s *USBGetStateData) uint8 { return s.TriggerLeftStatus }),
+	FieldHostTimestamp:            newInputField("HostTimestamp", func(s *USBGetStateData) uint32 { return s.HostTimestamp }),
+	FieldTriggerRightEffect:       newInputField("TriggerRightEffect", func(s *USBGetStateData) uint8 { return s.TriggerRightEffect }),
+	FieldTriggerLeftEffect:        newInputField("TriggerLeftEffect", func(s *USBGetStateData) uint8 { return s.TriggerLeftEffect }),
+	FieldDeviceTimestamp:          newInputField("DeviceTimestamp", func(s *USBGetStateData) uint32 { return s.DeviceTimestamp }),
+	FieldPowerPercent:             newInputField("PowerPercent", func(s *USBGetStateData) uint8 { return s.PowerPercent }),
+	FieldPowerState:               newInputField("PowerState", func(s *USBGetStateData) PowerState { return s.PowerState }),
+	FieldPluggedHeadphones:        newInputField("PluggedHeadphones", func(s *USBGetStateData) bool { return s.PluggedHeadphones }),
+	FieldPluggedMic:               newInputField("PluggedMic", func(s *USBGetStateData) bool { return s.PluggedMic }),
+	FieldMicMuted:                 newInputField("MicMuted", func(s *USBGetStateData) bool { return s.MicMuted }),
+	FieldPluggedUsbData:           newInputField("PluggedUsbData", func(s *USBGetStateData) bool { return s.PluggedUsbData }),
+	FieldPluggedUsbPower:          newInputField("PluggedUsbPower", func(s *USBGetStateData) bool { return s.PluggedUsbPower }),
+	FieldPluggedExternalMic:       newInputField("PluggedExternalMic", func(s *USBGetStateData) bool { return s.PluggedExternalMic }),
+	FieldHapticLowPassFilter:      newInputField("HapticLowPassFilter", func(s *USBGetStateData) bool { return s.HapticLowPassFilter }),
+	FieldAesCmac:                  newInputField("AesCmac", func(s *USBGetStateData) uint64 { return s.AesCmac }),
+}
+
+// StateDiff returns the fields that differ between a and b in Field order, with
+// Old taken from a and New from b.
+func StateDiff(a, b USBGetStateData) []FieldChange {
+	var changes []FieldChange
+	for field := range inputFields {
+		inputField := &inputFields[field]
+		if inputField.changed(&b, &a) {
+			changes = append(changes, FieldChange{
+				Field: Field(field),
+				Old:   inputField.value(&a),
+				New:   inputField.value(&b),
+			})
+		}
+	}
+	return changes
+}
+
+// triggerCallbacks runs the callbacks of every field that differs between the
+// two states. Within a report, callbacks with a higher priority run first and
+// callbacks of equal priority run in registration order, regardless of field.
+func (d *DualSense) triggerCallbacks(current, previous *USBGetStateData) {
+	var changed [fieldCount]bool
+	for field := range inputFields {
+		changed[field] = !d.callbacks.suppressed[field] && inputFields[field].changed(current, previous)
+	}
+	d.callbacks.subscriptionsMu.Lock()
+	subscriptions := d.callbacks.subscriptions
+	d.callbacks.subscriptionsMu.Unlock()
+	for _, subscription := range subscriptions {
+		if (changed[subscription.field] || subscription.everyReport) && !subscription.cancelled.Load() {
+			subscription.call(current, previous)
+		}
+	}
+}
+
+// OnFieldChange registers a callback for any field, including those without a
+// typed OnXChange method, receiving the old and new values. The returned func
+// unregisters the callback; a report being processed meanwhile may still
+// deliver one last change.
+func (d *DualSense) OnFieldChange(field Field, callback func(FieldChange), options ...SubscriptionOption) (func(), error) {
+	err := field.Validate()
+	if err != nil {
+		return nil, err
+	}
+	inputField := &inputFields[field]
+	return d.subscribe(field, func(current, previous *USBGetStateData) {
+		callback(FieldChange{
+			Field: field,
+			Old:   inputField.value(previous),
+			New:   inputField.value(current),
+		})
+	}, options), nil
+}
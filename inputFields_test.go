@@ -0,0 +1,316 @@
+package dualsense
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInputFieldsCoverState(t *testing.T) {
+	var count func(reflect.Type) int
+	count = func(typ reflect.Type) int {
+		n := 0
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).Type == reflect.TypeOf(TouchData{}) {
+				n += count(typ.Field(i).Type)
+			} else {
+				n++
+			}
+		}
+		return n
+	}
+	if got := count(reflect.TypeOf(USBGetStateData{})); got != int(fieldCount) {
+		t.Fatalf("USBGetStateData has %d fields, inputFields has %d", got, fieldCount)
+	}
+}
+
+func TestTriggerCallbacks(t *testing.T) {
+	d := &DualSense{}
+	var leftStickX uint8
+	var crossCalls int
+	var changes []FieldChange
+	d.OnLeftStickXChange(func(value uint8) { leftStickX = value })
+	d.OnButtonCrossChange(func(bool) { crossCalls++ })
+	d.OnFieldChange(FieldSeqNo, func(change FieldChange) { changes = append(changes, change) })
+
+	previous := d.getStateData
+	d.getStateData.LeftStickX = 200
+	d.getStateData.SeqNo = 7
+	d.triggerCallbacks(&d.getStateData, &previous)
+
+	if leftStickX != 200 {
+		t.Fatalf("got LeftStickX %d, want 200", leftStickX)
+	}
+	if crossCalls != 0 {
+		t.Fatalf("ButtonCross callback called %d times for an unchanged field", crossCalls)
+	}
+	want := []FieldChange{{Field: FieldSeqNo, Old: uint8(0), New: uint8(7)}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("got %+v, want %+v", changes, want)
+	}
+	if FieldTouchFinger1.String() != "TouchFinger1" {
+		t.Fatalf("got %q, want TouchFinger1", FieldTouchFinger1.String())
+	}
+}
+
+func TestTriggerCallbacksSuppressedFields(t *testing.T) {
+	mock := NewMockDualSense(WithSuppressedFields(MotionFields...), WithSuppressedFields(TimestampFields...))
+	var changes []Field
+	for _, field := range Fields() {
+		mock.OnFieldChange(field, func(change FieldChange) { changes = append(changes, change.Field) })
+	}
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.AngularVelocityX = 100
+		state.AccelerometerZ = -100
+		state.SeqNo = 1
+		state.DeviceTimestamp = 1000
+		state.ButtonCross = true
+	})
+	if want := []Field{FieldButtonCross}; !reflect.DeepEqual(changes, want) {
+		t.Fatalf("got changes %v, want %v", changes, want)
+	}
+}
+
+func TestQueueCallbacksCoalesces(t *testing.T) {
+	q := &callbackQueue{field: FieldLeftStickX, size: 1, wake: make(chan struct{}, 1)}
+	var discarded int
+	for _, x := range []uint8{1, 2, 3} {
+		if q.queue(&USBGetStateData{LeftStickX: x}, &USBGetStateData{LeftStickX: x - 1}) {
+			discarded++
+		}
+	}
+	current, previous, ok := q.next()
+	if !ok || current.LeftStickX != 3 || previous.LeftStickX != 0 {
+		t.Fatalf("got queued LeftStickX %d from %d, want 3 from 0", current.LeftStickX, previous.LeftStickX)
+	}
+	if discarded != 2 {
+		t.Fatalf("got %d discarded states, want 2", discarded)
+	}
+}
+
+func TestAsyncCallbacksPerSubscriber(t *testing.T) {
+	d := NewMockDualSense(WithAsyncCallbacks(4))
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	d.OnButtonCrossChange(func(bool) { <-release })
+	fast := make(chan bool, 1)
+	d.OnButtonCrossChange(func(pressed bool) { fast <- pressed })
+	closed := make(chan struct{})
+	d.OnButtonCircleChange(func(bool) {
+		d.Close()
+		close(closed)
+	})
+
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.ButtonCross = true
+	})
+	select {
+	case <-fast:
+	case <-time.After(time.Second):
+		t.Fatal("a blocked subscriber stalled another one")
+	}
+	close(release)
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.ButtonCircle = true
+	})
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close from an async callback deadlocked")
+	}
+}
+
+func TestTriggerCallbacksPriorityOrder(t *testing.T) {
+	d := &DualSense{}
+	var order []string
+	d.OnButtonCrossChange(func(bool) { order = append(order, "cross") })
+	d.OnLeftStickXChange(func(uint8) { order = append(order, "leftStickX") })
+	d.OnFieldChange(FieldButtonCross, func(FieldChange) { order = append(order, "remap") }, WithPriority(10))
+	d.OnFieldChange(FieldLeftStickX, func(FieldChange) { order = append(order, "late") }, WithPriority(-1))
+	d.OnLeftStickXChange(func(uint8) { order = append(order, "remap2") }, WithPriority(10))
+
+	previous := d.getStateData
+	d.getStateData.ButtonCross = true
+	d.getStateData.LeftStickX = 1
+	d.triggerCallbacks(&d.getStateData, &previous)
+
+	want := []string{"remap", "remap2", "cross", "leftStickX", "late"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+}
+
+func TestThrottledCallStopsOnClose(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	values := make(chan uint8, 10)
+	d.OnLeftStickXChange(func(value uint8) { values <- value }, WithThrottle(20*time.Millisecond))
+	for _, x := range []uint8{1, 2} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.LeftStickX = x
+		})
+	}
+	if got := <-values; got != 1 {
+		t.Fatalf("got first value %d, want 1", got)
+	}
+	d.Close()
+	time.Sleep(40 * time.Millisecond)
+	if len(values) != 0 {
+		t.Fatalf("got %d deliveries after Close", len(values))
+	}
+}
+
+func TestThrottledCallCancelFromCallback(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	var cancel func()
+	cancelled := make(chan uint8, 10)
+	cancel, err := d.OnFieldChange(FieldLeftStickX, func(change FieldChange) {
+		cancel()
+		cancelled <- change.New.(uint8)
+	}, WithThrottle(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := make(chan struct{})
+	d.OnLeftStickXChange(func(value uint8) {
+		if value == 2 {
+			d.Close()
+			close(closed)
+		}
+	}, WithThrottle(20*time.Millisecond))
+
+	// The first change is delivered straight away, the second once the
+	// interval has passed.
+	for _, x := range []uint8{1, 2} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.LeftStickX = x
+		})
+	}
+	select {
+	case got := <-cancelled:
+		if got != 1 {
+			t.Fatalf("got value %d, want 1", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelling from a throttled callback deadlocked")
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close from a delayed throttled callback deadlocked")
+	}
+	if len(cancelled) != 0 {
+		t.Fatalf("got %d deliveries after cancel", len(cancelled))
+	}
+}
+
+func TestThrottledCallDeliversLatest(t *testing.T) {
+	d := &DualSense{}
+	values := make(chan uint8, 10)
+	d.OnLeftStickXChange(func(value uint8) { values <- value }, WithThrottle(20*time.Millisecond))
+
+	previous := d.getStateData
+	for _, x := range []uint8{1, 2, 3} {
+		d.getStateData.LeftStickX = x
+		d.triggerCallbacks(&d.getStateData, &previous)
+		previous = d.getStateData
+	}
+	if got := <-values; got != 1 {
+		t.Fatalf("got first value %d, want 1", got)
+	}
+	select {
+	case got := <-values:
+		if got != 3 {
+			t.Fatalf("got throttled value %d, want 3", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("throttled value was never delivered")
+	}
+	if len(values) != 0 {
+		t.Fatalf("got %d extra deliveries", len(values))
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	a := USBGetStateData{DPad: DirectionNone}
+	b := a
+	b.DPad = DirectionNorth
+	b.TouchData.TouchFinger1.FingerX = 100
+	want := []FieldChange{
+		{Field: FieldDPad, Old: DirectionNone, New: DirectionNorth},
+		{Field: FieldTouchFinger1, Old: TouchFinger{}, New: TouchFinger{FingerX: 100}},
+	}
+	if got := StateDiff(a, b); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got := StateDiff(a, a); len(got) != 0 {
+		t.Fatalf("got %+v for identical states", got)
+	}
+}
+
+func TestParseField(t *testing.T) {
+	for _, field := range Fields() {
+		parsed, err := ParseField(field.String())
+		if err != nil || parsed != field {
+			t.Fatalf("ParseField(%q) = %v, %v, want %v", field.String(), parsed, err, field)
+		}
+	}
+	if _, err := ParseField("Unknown"); err == nil {
+		t.Fatal("expected error for unknown field name")
+	}
+}
+
+func TestOnFieldChangeCancel(t *testing.T) {
+	d := &DualSense{}
+	var calls int
+	cancel, err := d.OnFieldChange(FieldSeqNo, func(FieldChange) { calls++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	throttled := make(chan FieldChange, 1)
+	cancelThrottled, err := d.OnFieldChange(FieldSeqNo, func(change FieldChange) { throttled <- change }, WithThrottle(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := d.getStateData
+	d.getStateData.SeqNo = 1
+	d.triggerCallbacks(&d.getStateData, &previous)
+	<-throttled
+	cancel()
+	cancel()
+	previous = d.getStateData
+	d.getStateData.SeqNo = 2
+	d.triggerCallbacks(&d.getStateData, &previous)
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1 before cancelling", calls)
+	}
+	cancelThrottled()
+	if len(d.callbacks.subscriptions) != 0 {
+		t.Fatalf("%d subscriptions left after cancelling", len(d.callbacks.subscriptions))
+	}
+}
+
+func TestFieldValidate(t *testing.T) {
+	d := &DualSense{}
+	if _, err := d.OnFieldChange(fieldCount, func(FieldChange) {}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("OnFieldChange: got %v, want ErrOutOfRange", err)
+	}
+	if _, err := Field(255).Value(&USBGetStateData{}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Value: got %v, want ErrOutOfRange", err)
+	}
+	if got := Field(255).String(); got != "Unknown" {
+		t.Fatalf("got %q, want Unknown", got)
+	}
+	if value, err := FieldButtonCross.Value(&USBGetStateData{ButtonCross: true}); err != nil || value != true {
+		t.Fatalf("got %v, %v, want true", value, err)
+	}
+}
@@ -0,0 +1,180 @@
+package dualsense
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type InputLogFormat uint8
+
+const (
+	// InputLogCSV writes a header row followed by one row per report. A
+	// TouchFinger field takes four columns: <Field>Index, <Field>NotTouching,
+	// <Field>X and <Field>Y.
+	InputLogCSV InputLogFormat = iota
+	// InputLogJSONL writes one JSON object per line and report.
+	InputLogJSONL
+)
+
+// InputLogger writes selected fields of input reports to CSV or JSON Lines,
+// for offline analysis. Every row starts with the time of the report in RFC
+// 3339 format, followed by the fields named as in Field.String. It is safe for
+// concurrent use.
+type InputLogger struct {
+	mu     sync.Mutex
+	format InputLogFormat
+	fields []Field
+	writer *bufio.Writer
+	csv    *csv.Writer
+	row    []string
+	closer io.Closer
+	closed bool
+	err    error
+}
+
+// NewInputLogger logs fields to w, or every field if none are given.
+func NewInputLogger(w io.Writer, format InputLogFormat, fields ...Field) (*InputLogger, error) {
+	if len(fields) == 0 {
+		fields = Fields()
+	}
+	for _, field := range fields {
+		if field >= fieldCount {
+			return nil, fmt.Errorf("unknown field %d", field)
+		}
+	}
+	l := &InputLogger{format: format, fields: fields, writer: bufio.NewWriter(w)}
+	switch format {
+	case InputLogCSV:
+		l.csv = csv.NewWriter(l.writer)
+		header := []string{"Time"}
+		for _, field := range fields {
+			if _, ok := inputFields[field].value(&USBGetStateData{}).(TouchFinger); ok {
+				header = append(header, field.String()+"Index", field.String()+"NotTouching", field.String()+"X", field.String()+"Y")
+			} else {
+				header = append(header, field.String())
+			}
+		}
+		err := l.csv.Write(header)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to write input log header: %w", err)
+		}
+	case InputLogJSONL:
+	default:
+		return nil, fmt.Errorf("unknown input log format %d", format)
+	}
+	return l, nil
+}
+
+// CreateInputLog creates the file at path and logs fields to it.
+func CreateInputLog(path string, format InputLogFormat, fields ...Field) (*InputLogger, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Create: error trying to create input log: %w", err)
+	}
+	logger, err := NewInputLogger(file, format, fields...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	logger.closer = file
+	return logger, nil
+}
+
+// Log appends a single report. The first error is sticky and returned by every
+// later call, including Close.
+func (l *InputLogger) Log(timestamp time.Time, state *USBGetStateData) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return errors.New("input logger is closed")
+	}
+	if l.err != nil {
+		return l.err
+	}
+	var err error
+	if l.format == InputLogCSV {
+		err = l.writeCSV(timestamp, state)
+	} else {
+		err = l.writeJSON(timestamp, state)
+	}
+	if err != nil {
+		l.err = fmt.Errorf("error trying to write input log: %w", err)
+	}
+	return l.err
+}
+
+func (l *InputLogger) writeCSV(timestamp time.Time, state *USBGetStateData) error {
+	l.row = append(l.row[:0], timestamp.Format(time.RFC3339Nano))
+	for _, field := range l.fields {
+		switch value := inputFields[field].value(state).(type) {
+		case TouchFinger:
+			l.row = append(l.row,
+				strconv.Itoa(int(value.Index)),
+				strconv.FormatBool(value.NotTouching),
+				strconv.Itoa(int(value.FingerX)),
+				strconv.Itoa(int(value.FingerY)))
+		default:
+			l.row = append(l.row, fmt.Sprint(value))
+		}
+	}
+	return l.csv.Write(l.row)
+}
+
+func (l *InputLogger) writeJSON(timestamp time.Time, state *USBGetStateData) error {
+	// Objects are built by hand to keep the fields in the configured order.
+	l.writer.WriteString(`{"Time":"`)
+	l.writer.WriteString(timestamp.Format(time.RFC3339Nano))
+	l.writer.WriteByte('"')
+	for _, field := range l.fields {
+		value, err := json.Marshal(inputFields[field].value(state))
+		if err != nil {
+			return err
+		}
+		l.writer.WriteString(`,"`)
+		l.writer.WriteString(field.String())
+		l.writer.WriteString(`":`)
+		l.writer.Write(value)
+	}
+	_, err := l.writer.WriteString("}\n")
+	return err
+}
+
+// Attach logs the state of every input report processed by d, as its callbacks
+// see it, until the logger is closed or the returned func detaches it.
+func (l *InputLogger) Attach(d *DualSense) func() {
+	return d.subscribeReports(func(current, _ *USBGetStateData) {
+		// Write failures stay in the logger for Close to return.
+		l.Log(time.Now(), current)
+	})
+}
+
+func (l *InputLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return l.err
+	}
+	l.closed = true
+	if l.csv != nil {
+		l.csv.Flush()
+	}
+	err := l.writer.Flush()
+	if l.err == nil && err != nil {
+		l.err = fmt.Errorf("error trying to flush input log: %w", err)
+	}
+	if l.closer != nil {
+		err = l.closer.Close()
+		if l.err == nil && err != nil {
+			l.err = fmt.Errorf("error trying to close input log: %w", err)
+		}
+	}
+	return l.err
+}
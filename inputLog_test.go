@@ -0,0 +1,84 @@
+package dualsense
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInputLoggerCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	logger, err := NewInputLogger(&buffer, InputLogCSV, FieldLeftStickX, FieldDPad, FieldTouchFinger1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := USBGetStateData{
+		LeftStickX: 200,
+		DPad:       DirectionNorthEast,
+		TouchData:  TouchData{TouchFinger1: TouchFinger{Index: 3, FingerX: 100, FingerY: 50}},
+	}
+	if err := logger.Log(time.Date(2024, 5, 1, 12, 0, 0, 5000000, time.UTC), &state); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "Time,LeftStickX,DPad,TouchFinger1Index,TouchFinger1NotTouching,TouchFinger1X,TouchFinger1Y\n" +
+		"2024-05-01T12:00:00.005Z,200,NorthEast,3,false,100,50\n"
+	if got := buffer.String(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInputLoggerJSONL(t *testing.T) {
+	var buffer bytes.Buffer
+	logger, err := NewInputLogger(&buffer, InputLogJSONL, FieldButtonCross, FieldTouchFinger2, FieldPowerState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := USBGetStateData{ButtonCross: true, TouchData: TouchData{TouchFinger2: TouchFinger{NotTouching: true}}, PowerState: PowerStateCharging}
+	timestamp := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logger.Log(timestamp, &state)
+	state.ButtonCross = false
+	logger.Log(timestamp.Add(4*time.Millisecond), &state)
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Time":"2024-05-01T12:00:00Z","ButtonCross":true,"TouchFinger2":{"index":0,"notTouching":true,"fingerX":0,"fingerY":0},"PowerState":"Charging"}` + "\n" +
+		`{"Time":"2024-05-01T12:00:00.004Z","ButtonCross":false,"TouchFinger2":{"index":0,"notTouching":true,"fingerX":0,"fingerY":0},"PowerState":"Charging"}` + "\n"
+	if got := buffer.String(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if err := logger.Log(timestamp, &state); err == nil {
+		t.Fatal("Log after Close succeeded")
+	}
+}
+
+func TestInputLoggerAttach(t *testing.T) {
+	var buffer bytes.Buffer
+	logger, err := NewInputLogger(&buffer, InputLogCSV, FieldLeftStickX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewMockDualSense()
+	d.SetAxisInversion(AxisInversion{LeftStickX: true})
+	detach := logger.Attach(d.DualSense)
+	// Every report is logged, changed or not, as normalized for callbacks.
+	d.SetInState(USBGetStateData{LeftStickX: 10})
+	d.SetInState(USBGetStateData{LeftStickX: 10})
+	detach()
+	d.SetInState(USBGetStateData{LeftStickX: 20})
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and 2 reports:\n%s", len(lines), buffer.String())
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasSuffix(line, ",245") {
+			t.Fatalf("got row %q, want the inverted LeftStickX 245", line)
+		}
+	}
+}
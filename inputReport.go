@@ -3,66 +3,27 @@
 package dualsense
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 )
 
-type packedTouchData struct {
-	TouchFinger1 uint32
-	TouchFinger2 uint32
-	Timestamp    uint8
-}
-
-type packedUSBGetStateData struct {
-	LeftStickX             uint8
-	LeftStickY             uint8
-	RightStickX            uint8
-	RightStickY            uint8
-	TriggerLeft            uint8
-	TriggerRight           uint8
-	SeqNo                  uint8
-	DPadActionButtons      uint8 // Contains DPad, Square, Cross, Circle, Triangle
-	LeftRightCreateOptions uint8 // Contains L1, R1, L2, R2, Create, Options, L3, R3
-	OtherButtons           uint8 // Contains Home, Touchpad, Mute, UNK1, ButtonLeftFunction, ButtonRightFunction, ButtonLeftPaddle, ButtonRightPaddle
-	UNK2                   uint8 // unused
-	UNK_COUNTER            uint32
-	AngularVelocityX       int16
-	AngularVelocityZ       int16
-	AngularVelocityY       int16
-	AccelerometerX         int16
-	AccelerometerY         int16
-	AccelerometerZ         int16
-	SensorTimestamp        uint32
-	Temperature            int8
-	TouchData              packedTouchData
-	TriggerRightDetails    uint8 // contains TriggerRightStopLocation and TriggerRight Status
-	TriggerLeftDetails     uint8 // contains TriggerLeftStopLocation and TriggerLeft Status
-	HostTimestamp          uint32
-	TriggerEffects         uint8 // contains TriggerRightEffect and TriggerLeftEffect
-	DeviceTimestamp        uint32
-	PowerDetails           uint8 // contains PowerPercent and PowerState
-	PlugInfoA              uint8 // contains PluggedHeadphones, PluggedMic, MicMuted, PluggedUsbData, PluggedUsbPower, PluggedUnk1
-	PlugInfoB              uint8 // contains PluggedExternalMic, HapticLowPassFilter, PluggedUnk3
-	AesCmac                uint64
-}
-
-type packedUSBReportIn struct {
-	ReportID        uint8
-	USBGetStateData packedUSBGetStateData
-}
+// Touchpad coordinate ranges reported in TouchFinger.
+const (
+	TOUCHPAD_WIDTH  = 1920
+	TOUCHPAD_HEIGHT = 1080
+)
 
 type TouchFinger struct {
-	Index       uint8
-	NotTouching bool
-	FingerX     uint16
-	FingerY     uint16
+	Index       uint8  `json:"index"`
+	NotTouching bool   `json:"notTouching"`
+	FingerX     uint16 `json:"fingerX"`
+	FingerY     uint16 `json:"fingerY"`
 }
 
 type TouchData struct {
-	TouchFinger1 TouchFinger
-	TouchFinger2 TouchFinger
-	Timestamp    uint8
+	TouchFinger1 TouchFinger `json:"touchFinger1"`
+	TouchFinger2 TouchFinger `json:"touchFinger2"`
+	Timestamp    uint8       `json:"timestamp"`
 }
 
 type Direction uint8
@@ -91,60 +52,60 @@ const (
 )
 
 type USBGetStateData struct {
-	LeftStickX               uint8
-	LeftStickY               uint8
-	RightStickX              uint8
-	RightStickY              uint8
-	TriggerLeft              uint8
-	TriggerRight             uint8
-	SeqNo                    uint8
-	DPad                     Direction
-	ButtonSquare             bool
-	ButtonCross              bool
-	ButtonCircle             bool
-	ButtonTriangle           bool
-	ButtonL1                 bool
-	ButtonR1                 bool
-	ButtonL2                 bool
-	ButtonR2                 bool
-	ButtonCreate             bool
-	ButtonOptions            bool
-	ButtonL3                 bool
-	ButtonR3                 bool
-	ButtonHome               bool
-	ButtonPad                bool
-	ButtonMute               bool
-	ButtonLeftFunction       bool // DualSense Edge
-	ButtonRightFunction      bool // DualSense Edge
-	ButtonLeftPaddle         bool // DualSense Edge
-	ButtonRightPaddle        bool // DualSense Edge
-	AngularVelocityX         int16
-	AngularVelocityZ         int16
-	AngularVelocityY         int16
-	AccelerometerX           int16
-	AccelerometerY           int16
-	AccelerometerZ           int16
-	SensorTimestamp          uint32
-	Temperature              int8
-	TouchData                TouchData
-	TriggerRightStopLocation uint8
-	TriggerRightStatus       uint8
-	TriggerLeftStopLocation  uint8
-	TriggerLeftStatus        uint8
-	HostTimestamp            uint32
-	TriggerRightEffect       uint8
-	TriggerLeftEffect        uint8
-	DeviceTimestamp          uint32
-	PowerPercent             uint8
-	PowerState               PowerState
-	PluggedHeadphones        bool
-	PluggedMic               bool
-	MicMuted                 bool
-	PluggedUsbData           bool
-	PluggedUsbPower          bool
-	PluggedExternalMic       bool
-	HapticLowPassFilter      bool
-	AesCmac                  uint64
+	LeftStickX               uint8      `json:"leftStickX"`
+	LeftStickY               uint8      `json:"leftStickY"`
+	RightStickX              uint8      `json:"rightStickX"`
+	RightStickY              uint8      `json:"rightStickY"`
+	TriggerLeft              uint8      `json:"triggerLeft"`
+	TriggerRight             uint8      `json:"triggerRight"`
+	SeqNo                    uint8      `json:"seqNo"`
+	DPad                     Direction  `json:"dpad"`
+	ButtonSquare             bool       `json:"buttonSquare"`
+	ButtonCross              bool       `json:"buttonCross"`
+	ButtonCircle             bool       `json:"buttonCircle"`
+	ButtonTriangle           bool       `json:"buttonTriangle"`
+	ButtonL1                 bool       `json:"buttonL1"`
+	ButtonR1                 bool       `json:"buttonR1"`
+	ButtonL2                 bool       `json:"buttonL2"`
+	ButtonR2                 bool       `json:"buttonR2"`
+	ButtonCreate             bool       `json:"buttonCreate"`
+	ButtonOptions            bool       `json:"buttonOptions"`
+	ButtonL3                 bool       `json:"buttonL3"`
+	ButtonR3                 bool       `json:"buttonR3"`
+	ButtonHome               bool       `json:"buttonHome"`
+	ButtonPad                bool       `json:"buttonPad"`
+	ButtonMute               bool       `json:"buttonMute"`
+	ButtonLeftFunction       bool       `json:"buttonLeftFunction"`  // DualSense Edge
+	ButtonRightFunction      bool       `json:"buttonRightFunction"` // DualSense Edge
+	ButtonLeftPaddle         bool       `json:"buttonLeftPaddle"`    // DualSense Edge
+	ButtonRightPaddle        bool       `json:"buttonRightPaddle"`   // DualSense Edge
+	AngularVelocityX         int16      `json:"angularVelocityX"`
+	AngularVelocityZ         int16      `json:"angularVelocityZ"`
+	AngularVelocityY         int16      `json:"angularVelocityY"`
+	AccelerometerX           int16      `json:"accelerometerX"`
+	AccelerometerY           int16      `json:"accelerometerY"`
+	AccelerometerZ           int16      `json:"accelerometerZ"`
+	SensorTimestamp          uint32     `json:"sensorTimestamp"`
+	Temperature              int8       `json:"temperature"`
+	TouchData                TouchData  `json:"touchData"`
+	TriggerRightStopLocation uint8      `json:"triggerRightStopLocation"`
+	TriggerRightStatus       uint8      `json:"triggerRightStatus"`
+	TriggerLeftStopLocation  uint8      `json:"triggerLeftStopLocation"`
+	TriggerLeftStatus        uint8      `json:"triggerLeftStatus"`
+	HostTimestamp            uint32     `json:"hostTimestamp"`
+	TriggerRightEffect       uint8      `json:"triggerRightEffect"`
+	TriggerLeftEffect        uint8      `json:"triggerLeftEffect"`
+	DeviceTimestamp          uint32     `json:"deviceTimestamp"`
+	PowerPercent             uint8      `json:"powerPercent"`
+	PowerState               PowerState `json:"powerState"`
+	PluggedHeadphones        bool       `json:"pluggedHeadphones"`
+	PluggedMic               bool       `json:"pluggedMic"`
+	MicMuted                 bool       `json:"micMuted"`
+	PluggedUsbData           bool       `json:"pluggedUsbData"`
+	PluggedUsbPower          bool       `json:"pluggedUsbPower"`
+	PluggedExternalMic       bool       `json:"pluggedExternalMic"`
+	HapticLowPassFilter      bool       `json:"hapticLowPassFilter"`
+	AesCmac                  uint64     `json:"aesCmac"`
 }
 
 type USBReportIn struct {
@@ -156,88 +117,94 @@ func getNthLittleEndianBitUint8(b uint8, n uint) uint8 {
 	return (b >> n) & 1
 }
 
-func unpackUSBReportIn(data []byte) (USBReportIn, error) {
+func unpackTouchFinger(packed uint32) TouchFinger {
+	return TouchFinger{
+		Index:       uint8(packed & 0x7F),
+		NotTouching: ((packed >> 7) & 1) == 1,
+		FingerX:     uint16((packed >> 8) & 0xFFF),
+		FingerY:     uint16((packed >> 20) & 0xFFF),
+	}
+}
+
+// UnmarshalInputReport parses a 64 byte USB input report, including its report
+// ID, as read from the controller. Fields are decoded straight from their byte
+// offsets so parsing does not allocate.
+func UnmarshalInputReport(data []byte) (USBReportIn, error) {
 	if len(data) != USB_PACKET_SIZE {
 		return USBReportIn{}, fmt.Errorf("invalid length of data: %d", len(data))
 	}
 
-	var report packedUSBReportIn
-	err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &report)
-	if err != nil {
-		return USBReportIn{}, fmt.Errorf("error trying to unpack USBReportIn: %w", err)
-	}
+	dPadActionButtons := data[8]      // Contains DPad, Square, Cross, Circle, Triangle
+	leftRightCreateOptions := data[9] // Contains L1, R1, L2, R2, Create, Options, L3, R3
+	otherButtons := data[10]          // Contains Home, Touchpad, Mute, UNK1, ButtonLeftFunction, ButtonRightFunction, ButtonLeftPaddle, ButtonRightPaddle
+	triggerRightDetails := data[42]   // Contains TriggerRightStopLocation and TriggerRightStatus
+	triggerLeftDetails := data[43]    // Contains TriggerLeftStopLocation and TriggerLeftStatus
+	triggerEffects := data[48]        // Contains TriggerRightEffect and TriggerLeftEffect
+	powerDetails := data[53]          // Contains PowerPercent and PowerState
+	plugInfoA := data[54]             // Contains PluggedHeadphones, PluggedMic, MicMuted, PluggedUsbData, PluggedUsbPower, PluggedUnk1
+	plugInfoB := data[55]             // Contains PluggedExternalMic, HapticLowPassFilter, PluggedUnk3
 
 	return USBReportIn{
-		ReportID: report.ReportID,
+		ReportID: data[0],
 		USBGetStateData: USBGetStateData{
-			LeftStickX:          report.USBGetStateData.LeftStickX,
-			LeftStickY:          report.USBGetStateData.LeftStickY,
-			RightStickX:         report.USBGetStateData.RightStickX,
-			RightStickY:         report.USBGetStateData.RightStickY,
-			TriggerLeft:         report.USBGetStateData.TriggerLeft,
-			TriggerRight:        report.USBGetStateData.TriggerRight,
-			SeqNo:               report.USBGetStateData.SeqNo,
-			DPad:                Direction(report.USBGetStateData.DPadActionButtons & 0x0F),
-			ButtonSquare:        getNthLittleEndianBitUint8(report.USBGetStateData.DPadActionButtons, 4) == 1,
-			ButtonCross:         getNthLittleEndianBitUint8(report.USBGetStateData.DPadActionButtons, 5) == 1,
-			ButtonCircle:        getNthLittleEndianBitUint8(report.USBGetStateData.DPadActionButtons, 6) == 1,
-			ButtonTriangle:      getNthLittleEndianBitUint8(report.USBGetStateData.DPadActionButtons, 7) == 1,
-			ButtonL1:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 0) == 1,
-			ButtonR1:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 1) == 1,
-			ButtonL2:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 2) == 1,
-			ButtonR2:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 3) == 1,
-			ButtonCreate:        getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 4) == 1,
-			ButtonOptions:       getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 5) == 1,
-			ButtonL3:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 6) == 1,
-			ButtonR3:            getNthLittleEndianBitUint8(report.USBGetStateData.LeftRightCreateOptions, 7) == 1,
-			ButtonHome:          getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 0) == 1,
-			ButtonPad:           getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 1) == 1,
-			ButtonMute:          getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 2) == 1,
-			ButtonLeftFunction:  getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 4) == 1,
-			ButtonRightFunction: getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 5) == 1,
-			ButtonLeftPaddle:    getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 6) == 1,
-			ButtonRightPaddle:   getNthLittleEndianBitUint8(report.USBGetStateData.OtherButtons, 7) == 1,
-			AngularVelocityX:    report.USBGetStateData.AngularVelocityX,
-			AngularVelocityZ:    report.USBGetStateData.AngularVelocityZ,
-			AngularVelocityY:    report.USBGetStateData.AngularVelocityY,
-			AccelerometerX:      report.USBGetStateData.AccelerometerX,
-			AccelerometerY:      report.USBGetStateData.AccelerometerY,
-			AccelerometerZ:      report.USBGetStateData.AccelerometerZ,
-			SensorTimestamp:     report.USBGetStateData.SensorTimestamp,
-			Temperature:         report.USBGetStateData.Temperature,
+			LeftStickX:          data[1],
+			LeftStickY:          data[2],
+			RightStickX:         data[3],
+			RightStickY:         data[4],
+			TriggerLeft:         data[5],
+			TriggerRight:        data[6],
+			SeqNo:               data[7],
+			DPad:                Direction(dPadActionButtons & 0x0F),
+			ButtonSquare:        getNthLittleEndianBitUint8(dPadActionButtons, 4) == 1,
+			ButtonCross:         getNthLittleEndianBitUint8(dPadActionButtons, 5) == 1,
+			ButtonCircle:        getNthLittleEndianBitUint8(dPadActionButtons, 6) == 1,
+			ButtonTriangle:      getNthLittleEndianBitUint8(dPadActionButtons, 7) == 1,
+			ButtonL1:            getNthLittleEndianBitUint8(leftRightCreateOptions, 0) == 1,
+			ButtonR1:            getNthLittleEndianBitUint8(leftRightCreateOptions, 1) == 1,
+			ButtonL2:            getNthLittleEndianBitUint8(leftRightCreateOptions, 2) == 1,
+			ButtonR2:            getNthLittleEndianBitUint8(leftRightCreateOptions, 3) == 1,
+			ButtonCreate:        getNthLittleEndianBitUint8(leftRightCreateOptions, 4) == 1,
+			ButtonOptions:       getNthLittleEndianBitUint8(leftRightCreateOptions, 5) == 1,
+			ButtonL3:            getNthLittleEndianBitUint8(leftRightCreateOptions, 6) == 1,
+			ButtonR3:            getNthLittleEndianBitUint8(leftRightCreateOptions, 7) == 1,
+			ButtonHome:          getNthLittleEndianBitUint8(otherButtons, 0) == 1,
+			ButtonPad:           getNthLittleEndianBitUint8(otherButtons, 1) == 1,
+			ButtonMute:          getNthLittleEndianBitUint8(otherButtons, 2) == 1,
+			ButtonLeftFunction:  getNthLittleEndianBitUint8(otherButtons, 4) == 1,
+			ButtonRightFunction: getNthLittleEndianBitUint8(otherButtons, 5) == 1,
+			ButtonLeftPaddle:    getNthLittleEndianBitUint8(otherButtons, 6) == 1,
+			ButtonRightPaddle:   getNthLittleEndianBitUint8(otherButtons, 7) == 1,
+			AngularVelocityX:    int16(binary.LittleEndian.Uint16(data[16:])),
+			AngularVelocityZ:    int16(binary.LittleEndian.Uint16(data[18:])),
+			AngularVelocityY:    int16(binary.LittleEndian.Uint16(data[20:])),
+			AccelerometerX:      int16(binary.LittleEndian.Uint16(data[22:])),
+			AccelerometerY:      int16(binary.LittleEndian.Uint16(data[24:])),
+			AccelerometerZ:      int16(binary.LittleEndian.Uint16(data[26:])),
+			SensorTimestamp:     binary.LittleEndian.Uint32(data[28:]),
+			Temperature:         int8(data[32]),
 			TouchData: TouchData{
-				TouchFinger1: TouchFinger{
-					Index:       uint8(report.USBGetStateData.TouchData.TouchFinger1 & 0x7F),
-					NotTouching: ((report.USBGetStateData.TouchData.TouchFinger1 >> 7) & 1) == 1,
-					FingerX:     uint16((report.USBGetStateData.TouchData.TouchFinger1 >> 8) & 0xFFF),
-					FingerY:     uint16((report.USBGetStateData.TouchData.TouchFinger1 >> 20) & 0xFFF),
-				},
-				TouchFinger2: TouchFinger{
-					Index:       uint8(report.USBGetStateData.TouchData.TouchFinger2 & 0x7F),
-					NotTouching: ((report.USBGetStateData.TouchData.TouchFinger2 >> 7) & 1) == 1,
-					FingerX:     uint16((report.USBGetStateData.TouchData.TouchFinger2 >> 8) & 0xFFF),
-					FingerY:     uint16((report.USBGetStateData.TouchData.TouchFinger2 >> 20) & 0xFFF),
-				},
-				Timestamp: report.USBGetStateData.TouchData.Timestamp,
+				TouchFinger1: unpackTouchFinger(binary.LittleEndian.Uint32(data[33:])),
+				TouchFinger2: unpackTouchFinger(binary.LittleEndian.Uint32(data[37:])),
+				Timestamp:    data[41],
 			},
-			TriggerRightStopLocation: report.USBGetStateData.TriggerRightDetails & 0x0F,
-			TriggerRightStatus:       report.USBGetStateData.TriggerRightDetails >> 4,
-			TriggerLeftStopLocation:  report.USBGetStateData.TriggerLeftDetails & 0x0F,
-			TriggerLeftStatus:        report.USBGetStateData.TriggerLeftDetails >> 4,
-			HostTimestamp:            report.USBGetStateData.HostTimestamp,
-			TriggerRightEffect:       report.USBGetStateData.TriggerEffects & 0x0F,
-			TriggerLeftEffect:        report.USBGetStateData.TriggerEffects >> 4,
-			DeviceTimestamp:          report.USBGetStateData.DeviceTimestamp,
-			PowerPercent:             report.USBGetStateData.PowerDetails & 0x0F,
-			PowerState:               PowerState(report.USBGetStateData.PowerDetails >> 4),
-			PluggedHeadphones:        getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoA, 0) == 1,
-			PluggedMic:               getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoA, 1) == 1,
-			MicMuted:                 getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoA, 2) == 1,
-			PluggedUsbData:           getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoA, 3) == 1,
-			PluggedUsbPower:          getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoA, 4) == 1,
-			PluggedExternalMic:       getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoB, 0) == 1,
-			HapticLowPassFilter:      getNthLittleEndianBitUint8(report.USBGetStateData.PlugInfoB, 1) == 1,
-			AesCmac:                  report.USBGetStateData.AesCmac,
+			TriggerRightStopLocation: triggerRightDetails & 0x0F,
+			TriggerRightStatus:       triggerRightDetails >> 4,
+			TriggerLeftStopLocation:  triggerLeftDetails & 0x0F,
+			TriggerLeftStatus:        triggerLeftDetails >> 4,
+			HostTimestamp:            binary.LittleEndian.Uint32(data[44:]),
+			TriggerRightEffect:       triggerEffects & 0x0F,
+			TriggerLeftEffect:        triggerEffects >> 4,
+			DeviceTimestamp:          binary.LittleEndian.Uint32(data[49:]),
+			PowerPercent:             powerDetails & 0x0F,
+			PowerState:               PowerState(powerDetails >> 4),
+			PluggedHeadphones:        getNthLittleEndianBitUint8(plugInfoA, 0) == 1,
+			PluggedMic:               getNthLittleEndianBitUint8(plugInfoA, 1) == 1,
+			MicMuted:                 getNthLittleEndianBitUint8(plugInfoA, 2) == 1,
+			PluggedUsbData:           getNthLittleEndianBitUint8(plugInfoA, 3) == 1,
+			PluggedUsbPower:          getNthLittleEndianBitUint8(plugInfoA, 4) == 1,
+			PluggedExternalMic:       getNthLittleEndianBitUint8(plugInfoB, 0) == 1,
+			HapticLowPassFilter:      getNthLittleEndianBitUint8(plugInfoB, 1) == 1,
+			AesCmac:                  binary.LittleEndian.Uint64(data[56:]),
 		},
 	}, nil
 }
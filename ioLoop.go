@@ -0,0 +1,293 @@
+package dualsense
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// IO_READ_TIMEOUT is how long the I/O goroutine waits for an input report on
+// each poll, one USB report interval. Transport requests queue up behind a
+// read, so it is kept short; a poll that times out is retried at the next
+// polling interval.
+const IO_READ_TIMEOUT = 4 * time.Millisecond
+
+// ioRequest is a transport access queued for the I/O goroutine.
+type ioRequest struct {
+	run  func(Transport)
+	done chan struct{}
+}
+
+// ioChannels connects the I/O goroutine spawned by one Start to the rest of
+// DualSense.
+type ioChannels struct {
+	requests chan ioRequest
+	close    chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	// work and worked hand functions to the report worker and signal their
+	// return, see runServingIO.
+	work   chan func()
+	worked chan struct{}
+}
+
+func newIOChannels() *ioChannels {
+	return &ioChannels{
+		requests: make(chan ioRequest),
+		close:    make(chan struct{}),
+		done:     make(chan struct{}),
+		work:     make(chan func()),
+		worked:   make(chan struct{}),
+	}
+}
+
+// stop asks the I/O goroutine to return. It may be called more than once.
+func (c *ioChannels) stop() {
+	c.stopOnce.Do(func() {
+		close(c.close)
+	})
+}
+
+// doIO runs f with exclusive access to the transport. Once Start has spawned
+// the I/O goroutine, f runs there and doIO waits for it to return; otherwise,
+// with WithManualPump or while the controller is not started, f runs on the
+// caller's goroutine.
+func (d *DualSense) doIO(f func(Transport)) {
+	channels := d.io.Load()
+	if channels == nil {
+		f(d.currentTransport())
+		return
+	}
+	request := ioRequest{run: f, done: make(chan struct{})}
+	select {
+	case channels.requests <- request:
+		<-request.done
+	case <-channels.done:
+		f(d.currentTransport())
+	}
+}
+
+// runServingIO runs f on the report worker and serves transport requests until
+// it returns. Report processing and timed writes go through it, so callbacks
+// and setters they trigger can reach the transport through doIO without the
+// I/O goroutine waiting on itself.
+func (d *DualSense) runServingIO(channels *ioChannels, f func()) {
+	channels.work <- f
+	for {
+		select {
+		case <-channels.worked:
+			return
+		case request := <-channels.requests:
+			request.run(d.transport)
+			close(request.done)
+		}
+	}
+}
+
+// reportWorker runs the functions handed over by runServingIO, one at a time,
+// until work is closed. It lives as long as the I/O goroutine.
+func reportWorker(work <-chan func(), worked chan<- struct{}) {
+	for f := range work {
+		f()
+		worked <- struct{}{}
+	}
+}
+
+// outputSchedule holds the timings of the writes the I/O goroutine makes on
+// its own. It must be read with setStateDataMu held.
+type outputSchedule struct {
+	flushInterval  time.Duration
+	keepAliveDelay time.Duration
+	rateDelay      time.Duration
+}
+
+func (d *DualSense) outputSchedule() outputSchedule {
+	interval := d.outputFlushInterval
+	if interval > 0 && interval < d.minOutputInterval {
+		interval = d.minOutputInterval
+	}
+	return outputSchedule{
+		flushInterval:  interval,
+		keepAliveDelay: d.keepAliveDelay(),
+		rateDelay:      d.outputReportDelay(),
+	}
+}
+
+// ioLoop is the I/O goroutine. It is the only goroutine accessing the
+// transport: it reads an input report every polling interval, writes pending
+// output state, keep-alives and latency probes when due, and serves the
+// transport requests of every other goroutine in between. Reads wait at most
+// IO_READ_TIMEOUT, so they hold up queued writes no longer than that. It never
+// takes setStateDataMu itself, since a setter holding it may be waiting on a
+// request.
+func (d *DualSense) ioLoop(channels *ioChannels) {
+	defer close(channels.done)
+	go reportWorker(channels.work, channels.worked)
+	defer close(channels.work)
+	readTimer := time.NewTimer(0)
+	defer readTimer.Stop()
+	var schedule outputSchedule
+	var probeInterval time.Duration
+	var flushTick, keepAliveTick, probeTick, rateTick <-chan time.Time
+	// While disconnected, only reconnectTick is set.
+	var reconnectTick <-chan time.Time
+	reconnectAttempt := 0
+	// withOutputState runs f with setStateDataMu held and reschedules the
+	// writes that depend on the output state.
+	withOutputState := func(f func()) {
+		var next outputSchedule
+		d.runServingIO(channels, func() {
+			d.setStateDataMu.Lock()
+			defer d.setStateDataMu.Unlock()
+			f()
+			next = d.outputSchedule()
+		})
+		if next.flushInterval != schedule.flushInterval {
+			flushTick = nil
+			if next.flushInterval > 0 {
+				flushTick = time.After(next.flushInterval)
+			}
+		}
+		keepAliveTick = nil
+		if next.keepAliveDelay >= 0 {
+			keepAliveTick = time.After(next.keepAliveDelay)
+		}
+		schedule = next
+	}
+	// flushPending writes the pending output state unless the rate limit or
+	// the backoff of a failed write holds it back, reporting whether it did.
+	flushPending := func() (delayed bool) {
+		if !d.setStateDataDirty {
+			return false
+		}
+		if d.outputReportDelay() > 0 {
+			return true
+		}
+		d.writeSetStateData(d.setStateData)
+		return false
+	}
+	withOutputState(func() {})
+
+	for {
+		if interval := d.latency.getProbeInterval(); interval != probeInterval && reconnectTick == nil {
+			probeInterval = interval
+			probeTick = nil
+			if interval > 0 {
+				probeTick = time.After(interval)
+			}
+		}
+		select {
+		case <-channels.close:
+			return
+		case request := <-channels.requests:
+			request.run(d.transport)
+			close(request.done)
+		case <-readTimer.C:
+			bytesRead, err := d.transport.Read(d.readBuffer, IO_READ_TIMEOUT)
+			d.runServingIO(channels, func() {
+				d.handleReportIn(bytesRead, err)
+			})
+			if d.LifecycleState() == LifecycleDisconnected {
+				if !d.canReconnect() {
+					return
+				}
+				reconnectAttempt = 1
+				reconnectTick = d.nextReconnect(reconnectAttempt, ErrDisconnected)
+				if reconnectTick == nil {
+					return
+				}
+				flushTick, keepAliveTick, probeTick, rateTick = nil, nil, nil, nil
+				break
+			}
+			readTimer.Reset(d.idle.pollingRate(d.pollingRate))
+		case <-reconnectTick:
+			err := d.reconnect()
+			if err != nil {
+				reconnectAttempt++
+				reconnectTick = d.nextReconnect(reconnectAttempt, err)
+				if reconnectTick == nil {
+					return
+				}
+				break
+			}
+			d.logger.Info("reconnected DualSense controller", "attempts", reconnectAttempt)
+			reconnectTick = nil
+			schedule, probeInterval = outputSchedule{}, 0
+			// Lifecycle callbacks may call setters, which need the I/O
+			// goroutine to serve their writes.
+			d.runServingIO(channels, func() {
+				d.transition("reconnect", LifecycleStarted, LifecycleDisconnected)
+				d.identifyReconnected()
+			})
+			// Restore the output state the controller lost with the link.
+			withOutputState(func() {
+				d.writeSetStateData(d.setStateData)
+			})
+			d.playConnectAnimation()
+			readTimer.Reset(0)
+		case <-d.setStateDataPending:
+			if reconnectTick != nil {
+				break
+			}
+			rateLimited := false
+			withOutputState(func() {
+				if d.outputFlushInterval > 0 {
+					return
+				}
+				rateLimited = flushPending()
+			})
+			if rateLimited && rateTick == nil {
+				rateTick = time.After(schedule.rateDelay)
+			}
+		case <-rateTick:
+			rateTick = nil
+			rateLimited := false
+			withOutputState(func() {
+				rateLimited = flushPending()
+			})
+			if rateLimited {
+				rateTick = time.After(schedule.rateDelay)
+			}
+		case <-flushTick:
+			// A delayed flush is left for a later tick.
+			flushTick = time.After(schedule.flushInterval)
+			withOutputState(func() {
+				flushPending()
+			})
+		case <-probeTick:
+			probeTick = time.After(probeInterval)
+			// A probe due while writes are held back is skipped; the next
+			// one follows probeInterval later.
+			withOutputState(func() {
+				if d.outputReportDelay() == 0 {
+					d.writeSetStateData(d.setStateData)
+				}
+			})
+		case <-keepAliveTick:
+			withOutputState(func() {
+				if d.keepAliveDelay() == 0 {
+					d.writeSetStateData(d.setStateData)
+				}
+			})
+		}
+	}
+}
+
+// handleReportIn parses and processes an input report read by the I/O
+// goroutine.
+func (d *DualSense) handleReportIn(bytesRead int, err error) {
+	reportIn, err := d.parseReportIn(bytesRead, err)
+	if errors.Is(err, ErrTimeout) {
+		d.logger.Debug("timed out reading DualSense input report", "error", err)
+		return
+	}
+	if err != nil {
+		d.ioErrors.record(err, false)
+		d.logger.Warn("failed to read DualSense input report", "error", err)
+		if errors.Is(err, ErrDisconnected) {
+			d.markDisconnected()
+		}
+		return
+	}
+	d.processReportIn(reportIn)
+}
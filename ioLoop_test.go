@@ -0,0 +1,253 @@
+package dualsense
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// exclusiveTransport flags any transport call overlapping another one.
+type exclusiveTransport struct {
+	mockTransport
+	active  atomic.Int32
+	overlap atomic.Bool
+}
+
+func (t *exclusiveTransport) enter() func() {
+	if t.active.Add(1) > 1 {
+		t.overlap.Store(true)
+	}
+	return func() { t.active.Add(-1) }
+}
+
+func (t *exclusiveTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	defer t.enter()()
+	return t.mockTransport.Read(p, time.Millisecond)
+}
+
+func (t *exclusiveTransport) Write(p []byte) (int, error) {
+	defer t.enter()()
+	time.Sleep(100 * time.Microsecond)
+	return t.mockTransport.Write(p)
+}
+
+func TestIOLoopOwnsTransport(t *testing.T) {
+	transport := &exclusiveTransport{}
+	d := newDualSense(transport, nil)
+	d.SetPollingRate(1000)
+	applied := make(chan struct{})
+	d.OnButtonCrossChange(func(pressed bool) {
+		if !pressed {
+			return
+		}
+		// A synchronous write from a callback must not wait on the I/O goroutine.
+		if err := d.SetLedColor(LedColor{Red: 0xFF}); err != nil {
+			t.Error(err)
+		}
+		close(applied)
+	})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			d.UpdateState(func(setStateData *SetStateData) {
+				setStateData.RumbleEmulationLeft = uint8(i + 1)
+			})
+		}
+	}()
+	report := make([]byte, USB_PACKET_SIZE)
+	report[0] = 0x01
+	report[8] = 0x20 // Cross
+	transport.mu.Lock()
+	transport.inputReports = append(transport.inputReports, report)
+	transport.mu.Unlock()
+
+	select {
+	case <-applied:
+	case <-time.After(time.Second):
+		t.Fatal("callback did not run")
+	}
+	wg.Wait()
+	d.Close()
+	if transport.overlap.Load() {
+		t.Fatal("transport accessed from several goroutines at once")
+	}
+	if writes := len(transport.outputReports); writes != 22 {
+		t.Fatalf("got %d writes, want 22", writes)
+	}
+	if !transport.closed {
+		t.Fatal("transport was not closed")
+	}
+}
+
+// timeoutTransport records the longest read timeout asked for.
+type timeoutTransport struct {
+	mockTransport
+	longest atomic.Int64
+}
+
+func (t *timeoutTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	if int64(timeout) > t.longest.Load() {
+		t.longest.Store(int64(timeout))
+	}
+	return t.mockTransport.Read(p, timeout)
+}
+
+func TestIOLoopShortReads(t *testing.T) {
+	transport := &timeoutTransport{}
+	d := newDualSense(transport, nil)
+	d.SetPollingRate(1000)
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 10 {
+		if err := d.SetLedRed(uint8(i + 1)); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	d.Close()
+	if longest := time.Duration(transport.longest.Load()); longest == 0 || longest > IO_READ_TIMEOUT {
+		t.Fatalf("got read timeout %v, want at most %v", longest, IO_READ_TIMEOUT)
+	}
+}
+
+// flakyTransport fails the first failures writes, counting every attempt.
+type flakyTransport struct {
+	mockTransport
+	failures atomic.Int32
+	attempts atomic.Int32
+}
+
+func (t *flakyTransport) Write(p []byte) (int, error) {
+	t.attempts.Add(1)
+	if t.failures.Add(-1) >= 0 {
+		return 0, errors.New("flaky write")
+	}
+	return t.mockTransport.Write(p)
+}
+
+func TestWriteRetry(t *testing.T) {
+	transport := &flakyTransport{}
+	d := newDualSense(transport, nil)
+	d.SetWriteRetryPolicy(WriteRetryPolicy{MaxAttempts: 3, InitialBackoff: 20 * time.Millisecond})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	var errs atomic.Int32
+	d.OnError(func(error) { errs.Add(1) })
+
+	transport.failures.Store(2)
+	start := time.Now()
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+	// The setter returns once the retry is scheduled, and other transport
+	// users are not held up by the backoff.
+	if elapsed := time.Since(start); elapsed >= 20*time.Millisecond {
+		t.Fatalf("setter blocked for %v of backoff", elapsed)
+	}
+	if err := d.SetLedGreen(0x20); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		transport.mu.Lock()
+		written := len(transport.outputReports)
+		transport.mu.Unlock()
+		if written == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d output reports, want the initial one and the retried one", written)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond+40*time.Millisecond {
+		t.Fatalf("retried after %v, before the doubled backoff", elapsed)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 0x40 || got.LedGreen != 0x20 {
+		t.Fatalf("retried state lost an update: %+v", ledColorOf(&got))
+	}
+	if errs.Load() != 0 {
+		t.Fatalf("got %d errors for a write that succeeded on retry", errs.Load())
+	}
+}
+
+func TestWriteRetryBackoffWithFlushInterval(t *testing.T) {
+	transport := &flakyTransport{}
+	d := newDualSense(transport, nil)
+	d.SetWriteRetryPolicy(WriteRetryPolicy{MaxAttempts: 3, InitialBackoff: 50 * time.Millisecond})
+	if err := d.SetOutputFlushInterval(2 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	// Start writes the initial output state.
+	initial := transport.attempts.Load()
+	transport.failures.Store(1)
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for transport.attempts.Load() == initial {
+		if time.Now().After(deadline) {
+			t.Fatal("flush tick never wrote the pending state")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// Flush ticks during the backoff must not retry the failed write.
+	time.Sleep(25 * time.Millisecond)
+	if attempts := transport.attempts.Load() - initial; attempts != 1 {
+		t.Fatalf("got %d write attempts within the backoff, want 1", attempts)
+	}
+	for transport.attempts.Load()-initial < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("failed write was never retried")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 0x40 {
+		t.Fatalf("got LED red %#x after the retry", got.LedRed)
+	}
+}
+
+func TestWriteRetryGivesUp(t *testing.T) {
+	transport := &flakyTransport{}
+	d := newDualSense(transport, []Option{WithManualPump()})
+	d.SetWriteRetryPolicy(WriteRetryPolicy{MaxAttempts: 2})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	var errs atomic.Int32
+	d.OnError(func(error) { errs.Add(1) })
+
+	transport.failures.Store(2)
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Poll(); err == nil {
+		t.Fatal("Poll returned no error for the last failed attempt")
+	}
+	if errs.Load() != 1 {
+		t.Fatalf("got %d errors, want 1 after the last attempt", errs.Load())
+	}
+
+	transport.failures.Store(1)
+	d.SetWriteRetryPolicy(WriteRetryPolicy{})
+	if err := d.SetLedRed(0x50); err == nil {
+		t.Fatal("got no error without retries")
+	}
+}
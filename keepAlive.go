@@ -0,0 +1,37 @@
+package dualsense
+
+import "time"
+
+// DEFAULT_KEEP_ALIVE_INTERVAL is how long a Bluetooth controller may go
+// without an output report before the current state is sent again.
+const DEFAULT_KEEP_ALIVE_INTERVAL = time.Second
+
+// WirelessTransport is implemented by transports that know whether the
+// controller is connected over Bluetooth. Transports that do not implement it
+// are treated as USB.
+type WirelessTransport interface {
+	Wireless() bool
+}
+
+// SetKeepAliveInterval sets how long the output state may go unsent before it
+// is written again, DEFAULT_KEEP_ALIVE_INTERVAL by default. Over Bluetooth the
+// controller reverts lightbar and rumble state when output reports stop; over
+// USB it does not, so keep-alive writes are never sent there. An interval of 0
+// disables them.
+func (d *DualSense) SetKeepAliveInterval(interval time.Duration) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.keepAliveInterval = interval
+	d.signalSetStateDataPending()
+}
+
+// keepAliveDelay returns how long until the next keep-alive write is due, or
+// -1 if none is. A keep-alive waits for the rate limit and write retry backoff
+// like any other write, see outputReportDelay. It must be called with
+// setStateDataMu held.
+func (d *DualSense) keepAliveDelay() time.Duration {
+	if d.keepAliveInterval == 0 || !d.wireless() {
+		return -1
+	}
+	return max(d.keepAliveInterval-time.Since(d.lastOutputWrite), d.outputReportDelay())
+}
@@ -0,0 +1,60 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+type wirelessMockTransport struct {
+	mockTransport
+	wireless bool
+}
+
+func (w *wirelessMockTransport) Wireless() bool {
+	return w.wireless
+}
+
+func TestKeepAlive(t *testing.T) {
+	for _, wireless := range []bool{false, true} {
+		transport := &wirelessMockTransport{wireless: wireless}
+		d := newDualSense(transport, []Option{WithManualPump()})
+		d.SetKeepAliveInterval(5 * time.Millisecond)
+		if err := d.Start(nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Poll(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if err := d.Poll(); err != nil {
+			t.Fatal(err)
+		}
+		want := 1
+		if wireless {
+			want = 2
+		}
+		if got := len(transport.outputReports); got != want {
+			t.Errorf("wireless %v: got %d output reports, want %d", wireless, got, want)
+		}
+	}
+}
+
+func TestKeepAliveWaitsForWriteRetryBackoff(t *testing.T) {
+	transport := &wirelessMockTransport{wireless: true}
+	d := newDualSense(transport, []Option{WithManualPump()})
+	d.SetKeepAliveInterval(5 * time.Millisecond)
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	written := len(transport.outputReports)
+	d.setStateDataMu.Lock()
+	d.writeRetryAt = time.Now().Add(time.Hour)
+	d.setStateDataMu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(transport.outputReports); got != written {
+		t.Fatalf("got %d keep-alives during a write retry backoff", got-written)
+	}
+}
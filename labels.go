@@ -0,0 +1,136 @@
+package dualsense
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Labels maps controller serial numbers, see SerialNumber, to user-assigned
+// names such as "P1-red" or "test-rig-3".
+type Labels map[string]string
+
+// DefaultLabelsPath returns where dualsensectl and dualsense-monitor keep
+// controller labels, labels.json in the dualsense-go directory of the user
+// configuration directory.
+func DefaultLabelsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("os.UserConfigDir: error trying to locate controller labels: %w", err)
+	}
+	return filepath.Join(dir, "dualsense-go", "labels.json"), nil
+}
+
+// LoadLabels reads labels saved by SaveLabels. A missing file holds no labels.
+func LoadLabels(path string) (Labels, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Labels{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: error trying to load controller labels: %w", err)
+	}
+	labels := Labels{}
+	err = json.Unmarshal(data, &labels)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: error trying to decode controller labels %s: %w", path, err)
+	}
+	return labels, nil
+}
+
+// SaveLabels writes labels to path, creating its directory if needed.
+func SaveLabels(path string, labels Labels) error {
+	data, err := json.MarshalIndent(labels, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: error trying to encode controller labels: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: error trying to save controller labels: %w", err)
+	}
+	err = os.WriteFile(path, append(data, '\n'), 0o644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: error trying to save controller labels: %w", err)
+	}
+	return nil
+}
+
+// SerialNumber returns the Bluetooth address of the controller, which serves
+// as its serial number, read from the pairing info feature report.
+func (d *DualSense) SerialNumber() (string, error) {
+	pairing := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairing[0] = PAIRING_INFO_REPORT_ID
+	n, err := d.GetFeatureReport(pairing)
+	if err != nil {
+		return "", fmt.Errorf("error trying to read DualSense serial number: %w", err)
+	}
+	if n < 7 {
+		return "", fmt.Errorf("error trying to read DualSense serial number: pairing info has %d bytes", n)
+	}
+	return readAddress(pairing[1:]).String(), nil
+}
+
+// ApplyLabels labels the controller with the entry of labels for its serial
+// number, if any.
+func (d *DualSense) ApplyLabels(labels Labels) error {
+	serial, err := d.SerialNumber()
+	if err != nil {
+		return err
+	}
+	if label, ok := labels[serial]; ok {
+		d.SetLabel(label)
+	}
+	return nil
+}
+
+// SetLabel names the controller. The label is added to its log records,
+// events and support info.
+func (d *DualSense) SetLabel(label string) {
+	d.label.Store(label)
+}
+
+// Label returns the name set with SetLabel, WithLabel or WithLabels, empty if
+// none is.
+func (d *DualSense) Label() string {
+	label, _ := d.label.Load().(string)
+	return label
+}
+
+// applyLabels looks up the label set WithLabels once the transport is open or
+// reopened. Failing to read the serial number leaves the label unchanged.
+func (d *DualSense) applyLabels() {
+	if d.labels == nil {
+		return
+	}
+	err := d.ApplyLabels(d.labels)
+	if err != nil {
+		d.logger.Debug("could not look up DualSense label", "error", err)
+	}
+}
+
+// labelHandler adds the current controller label to every log record.
+type labelHandler struct {
+	slog.Handler
+	label func() string
+}
+
+func (h labelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if label := h.label(); label != "" {
+		record = record.Clone()
+		record.AddAttrs(slog.String("label", label))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h labelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return labelHandler{Handler: h.Handler.WithAttrs(attrs), label: h.label}
+}
+
+func (h labelHandler) WithGroup(name string) slog.Handler {
+	return labelHandler{Handler: h.Handler.WithGroup(name), label: h.label}
+}
@@ -0,0 +1,50 @@
+package dualsense
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "labels.json")
+	labels, err := LoadLabels(path)
+	if err != nil || len(labels) != 0 {
+		t.Fatalf("got %v, %v for a missing file, want no labels", labels, err)
+	}
+	labels["a0:b1:c2:d3:e4:f5"] = "P1-red"
+	if err := SaveLabels(path, labels); err != nil {
+		t.Fatal(err)
+	}
+	labels, err = LoadLabels(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	d := NewMockDualSense(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))), WithEventHistory(1))
+	pairingInfo := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairingInfo[0] = PAIRING_INFO_REPORT_ID
+	copy(pairingInfo[1:], []byte{0xF5, 0xE4, 0xD3, 0xC2, 0xB1, 0xA0})
+	d.SetFeatureReport(pairingInfo)
+	if err := d.ApplyLabels(labels); err != nil {
+		t.Fatal(err)
+	}
+	if label := d.Label(); label != "P1-red" {
+		t.Fatalf("got label %q, want P1-red", label)
+	}
+
+	d.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	if history := d.History(); len(history) != 1 || history[0].Label != "P1-red" {
+		t.Fatalf("got history %+v, want a ButtonCross event labeled P1-red", history)
+	}
+	d.logger.Warn("test")
+	if !strings.Contains(logs.String(), "label=P1-red") {
+		t.Fatalf("label missing from log record: %s", logs.String())
+	}
+	if info := d.DumpSupportInfo(); info.Label != "P1-red" {
+		t.Fatalf("got support info label %q, want P1-red", info.Label)
+	}
+}
@@ -0,0 +1,187 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	LATENCY_SAMPLE_COUNT = 256
+	latencyPendingWrites = 16
+)
+
+var latencyHistogramBounds = []time.Duration{
+	1 * time.Millisecond,
+	2 * time.Millisecond,
+	4 * time.Millisecond,
+	8 * time.Millisecond,
+	16 * time.Millisecond,
+	32 * time.Millisecond,
+	64 * time.Millisecond,
+}
+
+// LatencyBucket counts samples below UpperBound. The last bucket of a histogram
+// has an UpperBound of 0 and counts every sample above the previous bound.
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
+// LatencyStats summarizes the round-trip latency of the most recent output
+// reports, measured from the write until the controller echoes its
+// HostTimestamp back in an input report.
+type LatencyStats struct {
+	Samples   int
+	Min       time.Duration
+	Avg       time.Duration
+	Max       time.Duration
+	Histogram []LatencyBucket
+}
+
+type pendingHostTimestamp struct {
+	hostTimestamp uint32
+	sentAt        time.Time
+	valid         bool
+}
+
+type latencyTracker struct {
+	mu            sync.Mutex
+	enabled       bool
+	probeInterval time.Duration
+	epoch         time.Time
+	lastStamp     uint32
+	pending       [latencyPendingWrites]pendingHostTimestamp
+	pendingNext   int
+	samples       [LATENCY_SAMPLE_COUNT]time.Duration
+	sampleCount   int
+	sampleNext    int
+}
+
+func (l *latencyTracker) enable(probeInterval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		l.epoch = time.Now()
+	}
+	l.enabled = true
+	l.probeInterval = probeInterval
+}
+
+func (l *latencyTracker) disable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.enabled = false
+	l.probeInterval = 0
+}
+
+func (l *latencyTracker) isEnabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enabled
+}
+
+func (l *latencyTracker) getProbeInterval() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.probeInterval
+}
+
+// nextHostTimestamp returns a fresh microsecond timestamp, guaranteed to differ
+// from the previous one so consecutive writes can be told apart in the echo.
+func (l *latencyTracker) nextHostTimestamp() uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.epoch.IsZero() {
+		l.epoch = time.Now()
+	}
+	stamp := uint32(time.Since(l.epoch) / time.Microsecond)
+	if stamp == 0 || stamp == l.lastStamp {
+		stamp = l.lastStamp + 1
+	}
+	l.lastStamp = stamp
+	return stamp
+}
+
+func (l *latencyTracker) recordSent(hostTimestamp uint32, sentAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending[l.pendingNext] = pendingHostTimestamp{
+		hostTimestamp: hostTimestamp,
+		sentAt:        sentAt,
+		valid:         true,
+	}
+	l.pendingNext = (l.pendingNext + 1) % latencyPendingWrites
+}
+
+// recordEcho matches the HostTimestamp of an input report against pending
+// writes. The controller keeps echoing the same value until the next write, so
+// only the first matching report produces a sample.
+func (l *latencyTracker) recordEcho(hostTimestamp uint32, arrivedAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return
+	}
+	for i := range l.pending {
+		pending := &l.pending[i]
+		if pending.valid && pending.hostTimestamp == hostTimestamp {
+			pending.valid = false
+			l.samples[l.sampleNext] = arrivedAt.Sub(pending.sentAt)
+			l.sampleNext = (l.sampleNext + 1) % LATENCY_SAMPLE_COUNT
+			l.sampleCount = min(l.sampleCount+1, LATENCY_SAMPLE_COUNT)
+			return
+		}
+	}
+}
+
+func (l *latencyTracker) stats() LatencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := LatencyStats{
+		Samples:   l.sampleCount,
+		Histogram: make([]LatencyBucket, len(latencyHistogramBounds)+1),
+	}
+	for i, bound := range latencyHistogramBounds {
+		stats.Histogram[i].UpperBound = bound
+	}
+	if l.sampleCount == 0 {
+		return stats
+	}
+	var total time.Duration
+	for i, sample := range l.samples[:l.sampleCount] {
+		if i == 0 || sample < stats.Min {
+			stats.Min = sample
+		}
+		if sample > stats.Max {
+			stats.Max = sample
+		}
+		total += sample
+		bucket := len(latencyHistogramBounds)
+		for j, bound := range latencyHistogramBounds {
+			if sample < bound {
+				bucket = j
+				break
+			}
+		}
+		stats.Histogram[bucket].Count++
+	}
+	stats.Avg = total / time.Duration(l.sampleCount)
+	return stats
+}
+
+// EnableLatencyMeasurement stamps every output report with a HostTimestamp and
+// measures how long it takes to show up in the input reports. When
+// probeInterval is non-zero the current output state is re-sent at that
+// interval so samples keep coming without any application writes.
+func (d *DualSense) EnableLatencyMeasurement(probeInterval time.Duration) {
+	d.latency.enable(probeInterval)
+	d.signalSetStateDataPending()
+}
+
+func (d *DualSense) DisableLatencyMeasurement() {
+	d.latency.disable()
+}
+
+func (d *DualSense) Latency() LatencyStats {
+	return d.latency.stats()
+}
@@ -0,0 +1,51 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+)
+
+// LedColor is a lightbar color, as set in LedRed, LedGreen and LedBlue.
+type LedColor struct {
+	Red   uint8 `json:"red"`
+	Green uint8 `json:"green"`
+	Blue  uint8 `json:"blue"`
+}
+
+func (c LedColor) apply(setStateData *SetStateData) {
+	setStateData.AllowLedColor = true
+	setStateData.LedRed = c.Red
+	setStateData.LedGreen = c.Green
+	setStateData.LedBlue = c.Blue
+}
+
+func ledColorOf(setStateData *SetStateData) LedColor {
+	return LedColor{Red: setStateData.LedRed, Green: setStateData.LedGreen, Blue: setStateData.LedBlue}
+}
+
+// blendLedColor returns the color amount of the way from from to to, amount
+// ranging from 0 to 1.
+func blendLedColor(from, to LedColor, amount float64) LedColor {
+	blend := func(from, to uint8) uint8 {
+		return uint8(math.Round(float64(from) + amount*(float64(to)-float64(from))))
+	}
+	return LedColor{Red: blend(from.Red, to.Red), Green: blend(from.Green, to.Green), Blue: blend(from.Blue, to.Blue)}
+}
+
+// SetLedColor sets LedRed, LedGreen and LedBlue at once, in a single output
+// report.
+func (d *DualSense) SetLedColor(color LedColor) error {
+	err := d.UpdateState(color.apply)
+	if err != nil {
+		return fmt.Errorf("error updating LED color in setStateData: %w", err)
+	}
+	return nil
+}
+
+// LedColor returns the lightbar color as set, before SetLightbarBrightness
+// scaling.
+func (d *DualSense) LedColor() LedColor {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	return ledColorOf(&d.setStateData)
+}
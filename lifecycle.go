@@ -0,0 +1,100 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// LifecycleState is where a DualSense stands between NewDualSense and Close.
+//
+//	Created -> Started <-> Stopped
+//	Started <-> Disconnected
+//	any state -> Closed
+type LifecycleState uint8
+
+const (
+	LifecycleCreated LifecycleState = iota
+	// LifecycleStarted is entered by Start. Unless created WithManualPump,
+	// the I/O goroutine is running.
+	LifecycleStarted
+	// LifecycleStopped is entered by Stop. Setters still write directly to
+	// the controller, but no input reports are read until Start.
+	LifecycleStopped
+	LifecycleClosed
+	// LifecycleDisconnected is entered when the transport reports
+	// ErrDisconnected. Only Close is accepted from there, unless a
+	// ReconnectPolicy reopens the controller and moves it back to
+	// LifecycleStarted.
+	LifecycleDisconnected
+)
+
+// ErrInvalidLifecycleState is returned by calls the current LifecycleState does
+// not allow, such as Start twice or a setter after Close.
+var ErrInvalidLifecycleState = errors.New("invalid lifecycle state")
+
+// LifecycleChange is delivered on every LifecycleState transition.
+type LifecycleChange struct {
+	Old LifecycleState
+	New LifecycleState
+}
+
+type lifecycle struct {
+	mu    sync.Mutex
+	state LifecycleState
+}
+
+// transition moves to state to if the current state is one of from, returning
+// the state left, and returns an error wrapping ErrInvalidLifecycleState
+// otherwise.
+func (d *DualSense) transition(operation string, to LifecycleState, from ...LifecycleState) (LifecycleState, error) {
+	d.lifecycle.mu.Lock()
+	old := d.lifecycle.state
+	if !slices.Contains(from, old) {
+		d.lifecycle.mu.Unlock()
+		return old, fmt.Errorf("%s: DualSense controller is %s: %w", operation, old, ErrInvalidLifecycleState)
+	}
+	d.lifecycle.state = to
+	d.lifecycle.mu.Unlock()
+	d.logger.Debug("DualSense lifecycle state changed", "old", old, "new", to)
+	for _, callback := range d.callbacks.OnLifecycleChange {
+		callback(LifecycleChange{Old: old, New: to})
+	}
+	return old, nil
+}
+
+// checkOpen rejects controller I/O once the controller is closed or
+// disconnected.
+func (d *DualSense) checkOpen(operation string) error {
+	state := d.LifecycleState()
+	if state == LifecycleClosed || state == LifecycleDisconnected {
+		return fmt.Errorf("%s: DualSense controller is %s: %w", operation, state, ErrInvalidLifecycleState)
+	}
+	return nil
+}
+
+// markDisconnected moves a started controller to LifecycleDisconnected. The
+// I/O goroutine then reconnects or returns.
+func (d *DualSense) markDisconnected() {
+	_, err := d.transition("markDisconnected", LifecycleDisconnected, LifecycleStarted)
+	if err != nil {
+		return
+	}
+	d.logger.Warn("DualSense controller disconnected")
+}
+
+// LifecycleState returns the current lifecycle state.
+func (d *DualSense) LifecycleState() LifecycleState {
+	d.lifecycle.mu.Lock()
+	defer d.lifecycle.mu.Unlock()
+	return d.lifecycle.state
+}
+
+// OnLifecycleChange registers a callback for every LifecycleState transition.
+// It runs on the goroutine causing the transition, e.g. the caller of Close;
+// disconnects and reconnects detected by the I/O goroutine are delivered where
+// input callbacks run, so the callback may call setters.
+func (d *DualSense) OnLifecycleChange(callback func(LifecycleChange)) {
+	d.callbacks.OnLifecycleChange = append(d.callbacks.OnLifecycleChange, callback)
+}
@@ -0,0 +1,92 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLifecycleTransitions(t *testing.T) {
+	d := NewMockDualSense()
+	var changes []LifecycleChange
+	d.OnLifecycleChange(func(change LifecycleChange) {
+		changes = append(changes, change)
+	})
+	if err := d.Stop(); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("Stop before Start: got %v, want ErrInvalidLifecycleState", err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Start(nil); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("second Start: got %v, want ErrInvalidLifecycleState", err)
+	}
+	if err := d.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetLedColor(LedColor{Red: 0x10}); err != nil {
+		t.Fatalf("setter while stopped: %v", err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	d.Close()
+	if err := d.SetLedColor(LedColor{Red: 0x20}); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("setter after Close: got %v, want ErrInvalidLifecycleState", err)
+	}
+	if _, err := d.GetFeatureReport([]byte{FIRMWARE_INFO_REPORT_ID}); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("feature report after Close: got %v, want ErrInvalidLifecycleState", err)
+	}
+	if err := d.Start(nil); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("Start after Close: got %v, want ErrInvalidLifecycleState", err)
+	}
+
+	want := []LifecycleChange{
+		{LifecycleCreated, LifecycleStarted},
+		{LifecycleStarted, LifecycleStopped},
+		{LifecycleStopped, LifecycleStarted},
+		{LifecycleStarted, LifecycleClosed},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got changes %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("got changes %v, want %v", changes, want)
+		}
+	}
+}
+
+type unpluggedTransport struct {
+	mockTransport
+}
+
+func (t *unpluggedTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	return 0, ErrDisconnected
+}
+
+func TestLifecycleDisconnected(t *testing.T) {
+	d := newDualSense(&unpluggedTransport{}, nil)
+	disconnected := make(chan struct{})
+	d.OnLifecycleChange(func(change LifecycleChange) {
+		if change.New == LifecycleDisconnected {
+			close(disconnected)
+		}
+	})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("disconnect was not detected")
+	}
+	if err := d.SetLedColor(LedColor{Red: 0x10}); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("setter after disconnect: got %v, want ErrInvalidLifecycleState", err)
+	}
+	d.Close()
+	if state := d.LifecycleState(); state != LifecycleClosed {
+		t.Fatalf("got %s, want Closed", state)
+	}
+}
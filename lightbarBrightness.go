@@ -0,0 +1,62 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+)
+
+// SetLightbarBrightness dims the lightbar to percent, from 0 (off) to 100
+// (the colors as set). LedRed, LedGreen and LedBlue are scaled as each output
+// report is written, so GetOutStateData keeps returning the colors as set and
+// colors set later are dimmed too. LightBrightness is set to the nearest level
+// and AllowLightBrightnessChange is turned on so the controller applies it.
+func (d *DualSense) SetLightbarBrightness(percent float64) error {
+	if math.IsNaN(percent) || percent < 0 || percent > 100 {
+		return fmt.Errorf("%w: lightbar brightness %v%% outside [0, 100]", ErrOutOfRange, percent)
+	}
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.lightbarBrightness = percent
+	newSetStateData := d.setStateData
+	newSetStateData.AllowLightBrightnessChange = true
+	switch {
+	case percent > 200.0/3:
+		newSetStateData.LightBrightness = LightBrightnessBright
+	case percent > 100.0/3:
+		newSetStateData.LightBrightness = LightBrightnessMid
+	default:
+		newSetStateData.LightBrightness = LightBrightnessDim
+	}
+	// Written even if unchanged, as the scaled colors are not part of the
+	// state.
+	err := d.applySetStateData(newSetStateData)
+	if err != nil {
+		return fmt.Errorf("error updating lightbar brightness in setStateData: %w", err)
+	}
+	return nil
+}
+
+// LightbarBrightness returns the lightbar brightness in percent, 100 unless
+// changed with SetLightbarBrightness.
+func (d *DualSense) LightbarBrightness() float64 {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	return d.lightbarBrightness
+}
+
+// scaleLightbar applies the lightbar brightness, capped by an engaged battery
+// saver, to an outgoing output report. It must be called with setStateDataMu
+// held.
+func (d *DualSense) scaleLightbar(setStateData *SetStateData) {
+	brightness := d.lightbarBrightness
+	if d.saverLights.engaged {
+		brightness = min(brightness, d.saverLights.brightness)
+	}
+	if brightness >= 100 {
+		return
+	}
+	scale := brightness / 100
+	for _, led := range []*uint8{&setStateData.LedRed, &setStateData.LedGreen, &setStateData.LedBlue} {
+		*led = uint8(math.Round(float64(*led) * scale))
+	}
+}
@@ -0,0 +1,46 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetLightbarBrightness(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetLedColor(LedColor{Red: 200, Green: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.SetLightbarBrightness(50); err != nil {
+		t.Fatal(err)
+	}
+	out := d.OutStates()[len(d.OutStates())-1]
+	if out.LedRed != 100 || out.LedGreen != 50 || out.LedBlue != 0 {
+		t.Fatalf("got color %d, %d, %d, want 100, 50, 0", out.LedRed, out.LedGreen, out.LedBlue)
+	}
+	if out.LightBrightness != LightBrightnessMid || !out.AllowLightBrightnessChange {
+		t.Fatalf("got %v, allowed %v, want Mid, allowed", out.LightBrightness, out.AllowLightBrightnessChange)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 200 {
+		t.Fatalf("GetOutStateData returned the scaled color %d", got.LedRed)
+	}
+
+	// Colors set later are scaled too.
+	if err := d.SetLedBlue(255); err != nil {
+		t.Fatal(err)
+	}
+	if out := d.OutStates()[len(d.OutStates())-1]; out.LedBlue != 128 {
+		t.Fatalf("got LedBlue %d, want 128", out.LedBlue)
+	}
+
+	if err := d.SetLightbarBrightness(101); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if got := d.LightbarBrightness(); got != 50 {
+		t.Fatalf("got brightness %v, want 50", got)
+	}
+}
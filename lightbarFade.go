@@ -0,0 +1,44 @@
+package dualsense
+
+import (
+	"fmt"
+	"time"
+)
+
+// LIGHTBAR_FADE_DURATION is roughly how long the firmware takes to play a
+// LightFadeAnimation.
+const LIGHTBAR_FADE_DURATION = time.Second
+
+// FadeLightbar plays the firmware lightbar fade, LightFadeAnimationFadeIn or
+// LightFadeAnimationFadeOut, waits LIGHTBAR_FADE_DURATION for it to finish and
+// then hands the lightbar back to regular LED control showing thenColor. It
+// blocks for the whole fade, so call it from its own goroutine rather than from
+// an input callback.
+func (d *DualSense) FadeLightbar(direction LightFadeAnimation, thenColor LedColor) error {
+	return d.fadeLightbar(direction, thenColor, nil)
+}
+
+// fadeLightbar plays the fade as FadeLightbar does, cutting the wait short if
+// stop is closed.
+func (d *DualSense) fadeLightbar(direction LightFadeAnimation, thenColor LedColor, stop <-chan struct{}) error {
+	if direction != LightFadeAnimationFadeIn && direction != LightFadeAnimationFadeOut {
+		return fmt.Errorf("invalid lightbar fade direction %v", direction)
+	}
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.AllowColorLightFadeAnimation = true
+		setStateData.LightFadeAnimation = direction
+	})
+	if err != nil {
+		return fmt.Errorf("error starting lightbar fade: %w", err)
+	}
+	sleepUnless(d.lightbarFadeDuration, stop)
+	err = d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.AllowColorLightFadeAnimation = false
+		setStateData.LightFadeAnimation = LightFadeAnimationNothing
+		thenColor.apply(setStateData)
+	})
+	if err != nil {
+		return fmt.Errorf("error restoring LED control after lightbar fade: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFadeLightbar(t *testing.T) {
+	d := NewMockDualSense()
+	d.lightbarFadeDuration = time.Millisecond
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.ResetOutputReports()
+
+	color := LedColor{Red: 0x10, Green: 0x20, Blue: 0x30}
+	if err := d.FadeLightbar(LightFadeAnimationFadeOut, color); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) != 2 {
+		t.Fatalf("got %d output reports, want 2", len(outStates))
+	}
+	if fade := outStates[0]; !fade.AllowColorLightFadeAnimation || fade.LightFadeAnimation != LightFadeAnimationFadeOut {
+		t.Errorf("fade not started: %+v", fade)
+	}
+	if after := outStates[1]; after.AllowColorLightFadeAnimation || after.LightFadeAnimation != LightFadeAnimationNothing || ledColorOf(&after) != color {
+		t.Errorf("LED control not restored: %+v", after)
+	}
+	if err := d.FadeLightbar(LightFadeAnimationNothing, color); err == nil {
+		t.Error("FadeLightbar(LightFadeAnimationNothing): expected error")
+	}
+}
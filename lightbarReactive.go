@@ -0,0 +1,84 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+)
+
+// LightbarReactiveSource selects what drives an audio-reactive lightbar.
+type LightbarReactiveSource uint8
+
+const (
+	LightbarReactiveOff LightbarReactiveSource = iota
+	// LightbarReactiveAmplitude follows the amplitudes passed to
+	// SetLightbarAmplitude, e.g. from a music player.
+	LightbarReactiveAmplitude
+	// LightbarReactiveRumble follows the stronger of the two rumble motors,
+	// e.g. while a RumblePattern plays.
+	LightbarReactiveRumble
+)
+
+// LightbarReactive makes the lightbar follow an amplitude from 0 to 1,
+// blending from Quiet at 0 to Loud at 1. With Quiet black the lightbar
+// brightness follows the amplitude.
+type LightbarReactive struct {
+	Source LightbarReactiveSource
+	Quiet  LedColor
+	Loud   LedColor
+}
+
+// SetLightbarReactive puts the lightbar in an audio-reactive mode for music
+// visualization. Like SetLightbarBrightness, the color is worked out as each
+// output report is written, so GetOutStateData keeps returning the colors as
+// set, and they show again once the Source is LightbarReactiveOff.
+func (d *DualSense) SetLightbarReactive(reactive LightbarReactive) error {
+	if reactive.Source > LightbarReactiveRumble {
+		return fmt.Errorf("%w: invalid lightbar reactive source %d", ErrOutOfRange, reactive.Source)
+	}
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.lightbarReactive = reactive
+	// Written even if unchanged, as the reactive colors are not part of the
+	// state.
+	err := d.applySetStateData(d.setStateData)
+	if err != nil {
+		return fmt.Errorf("error updating reactive lightbar: %w", err)
+	}
+	return nil
+}
+
+// SetLightbarAmplitude feeds the next amplitude, clamped to [0, 1], to a
+// lightbar in LightbarReactiveAmplitude mode. Each call writes an output
+// report unless output is coalesced, so feed it at the rate the lightbar
+// should update rather than per audio sample.
+func (d *DualSense) SetLightbarAmplitude(amplitude float64) error {
+	if math.IsNaN(amplitude) {
+		return fmt.Errorf("%w: lightbar amplitude is NaN", ErrOutOfRange)
+	}
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.lightbarAmplitude = min(max(amplitude, 0), 1)
+	if d.lightbarReactive.Source != LightbarReactiveAmplitude {
+		return nil
+	}
+	err := d.applySetStateData(d.setStateData)
+	if err != nil {
+		return fmt.Errorf("error updating lightbar amplitude: %w", err)
+	}
+	return nil
+}
+
+// applyLightbarReactive sets the reactive lightbar color on an outgoing output
+// report. It must be called with setStateDataMu held, before scaleLightbar.
+func (d *DualSense) applyLightbarReactive(setStateData *SetStateData) {
+	var amplitude float64
+	switch d.lightbarReactive.Source {
+	case LightbarReactiveAmplitude:
+		amplitude = d.lightbarAmplitude
+	case LightbarReactiveRumble:
+		amplitude = float64(max(setStateData.RumbleEmulationLeft, setStateData.RumbleEmulationRight)) / 255
+	default:
+		return
+	}
+	blendLedColor(d.lightbarReactive.Quiet, d.lightbarReactive.Loud, amplitude).apply(setStateData)
+}
@@ -0,0 +1,80 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+func lastLedColor(d *MockDualSense) LedColor {
+	outStates := d.OutStates()
+	return ledColorOf(&outStates[len(outStates)-1])
+}
+
+func TestLightbarReactiveAmplitude(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	set := LedColor{Red: 1, Green: 2, Blue: 3}
+	if err := d.SetLedColor(set); err != nil {
+		t.Fatal(err)
+	}
+
+	err := d.SetLightbarReactive(LightbarReactive{Source: LightbarReactiveAmplitude, Loud: LedColor{Red: 200, Blue: 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lastLedColor(d); got != (LedColor{}) {
+		t.Errorf("silent: got %+v, want black", got)
+	}
+	if err := d.SetLightbarAmplitude(0.5); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lastLedColor(d), (LedColor{Red: 100, Blue: 50}); got != want {
+		t.Errorf("half amplitude: got %+v, want %+v", got, want)
+	}
+	if err := d.SetLightbarAmplitude(2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lastLedColor(d), (LedColor{Red: 200, Blue: 100}); got != want {
+		t.Errorf("clamped amplitude: got %+v, want %+v", got, want)
+	}
+	if got := d.LedColor(); got != set {
+		t.Errorf("LedColor() = %+v, want the color as set %+v", got, set)
+	}
+
+	if err := d.SetLightbarReactive(LightbarReactive{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastLedColor(d); got != set {
+		t.Errorf("off: got %+v, want %+v", got, set)
+	}
+	if err := d.SetLightbarAmplitude(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastLedColor(d); got != set {
+		t.Errorf("amplitude while off: got %+v, want %+v", got, set)
+	}
+}
+
+func TestLightbarReactiveRumble(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	err := d.SetLightbarReactive(LightbarReactive{Source: LightbarReactiveRumble, Quiet: LedColor{Green: 255}, Loud: LedColor{Red: 255}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetRumbleEmulationRight(255); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lastLedColor(d), (LedColor{Red: 255}); got != want {
+		t.Errorf("full rumble: got %+v, want %+v", got, want)
+	}
+	if err := d.SetLightbarReactive(LightbarReactive{Source: 7}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("invalid source: got %v, want ErrOutOfRange", err)
+	}
+}
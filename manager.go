@@ -0,0 +1,189 @@
+package dualsense
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// MANAGER_EVENT_BUFFER_SIZE is how many events each controller of a Manager
+// buffers for Manager.Events.
+const MANAGER_EVENT_BUFFER_SIZE = 64
+
+// Manager tracks several controllers by ControllerID, so a single handler can
+// serve every connected pad: its callbacks receive the ControllerID of the
+// controller they fired for, and its Events stream tags every event with it.
+// Handlers may be registered before or after controllers are added.
+type Manager struct {
+	mux *EventMux
+
+	mu          sync.Mutex
+	controllers map[ControllerID]*managedController
+	onField     [fieldCount][]func(ControllerID, FieldChange)
+	onError     []func(ControllerID, error)
+	onLifecycle []func(ControllerID, LifecycleChange)
+}
+
+// managedController is one Add of a controller. The Manager's hooks on the
+// controller only fire while it is still managed by the same Add.
+type managedController struct {
+	dualsense *DualSense
+	events    <-chan Event
+	cancels   []func()
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		mux:         NewEventMux(MANAGER_EVENT_BUFFER_SIZE),
+		controllers: map[ControllerID]*managedController{},
+	}
+}
+
+// Add manages dualsense as id. The Manager's callbacks fire for it from then
+// on, until it is removed.
+func (m *Manager) Add(id ControllerID, dualsense *DualSense) error {
+	managed := &managedController{
+		dualsense: dualsense,
+		events:    dualsense.Events(MANAGER_EVENT_BUFFER_SIZE),
+	}
+	for _, field := range Fields() {
+		cancel, err := dualsense.OnFieldChange(field, func(change FieldChange) {
+			for _, callback := range managedHandlers(m, id, managed, &m.onField[field]) {
+				callback(id, change)
+			}
+		})
+		if err != nil {
+			managed.release()
+			return fmt.Errorf("error adding controller %q to Manager: %w", id, err)
+		}
+		managed.cancels = append(managed.cancels, cancel)
+	}
+	m.mu.Lock()
+	if _, ok := m.controllers[id]; ok {
+		m.mu.Unlock()
+		managed.release()
+		return fmt.Errorf("error adding controller %q to Manager: already added", id)
+	}
+	m.controllers[id] = managed
+	m.mu.Unlock()
+	err := m.mux.Add(id, managed.events)
+	if err != nil {
+		m.Remove(id)
+		return fmt.Errorf("error adding controller %q to Manager: %w", id, err)
+	}
+
+	// OnError and OnLifecycleChange cannot be unregistered; once removed,
+	// the controller no longer passes managedHandlers.
+	dualsense.OnError(func(err error) {
+		for _, callback := range managedHandlers(m, id, managed, &m.onError) {
+			callback(id, err)
+		}
+	})
+	dualsense.OnLifecycleChange(func(change LifecycleChange) {
+		for _, callback := range managedHandlers(m, id, managed, &m.onLifecycle) {
+			callback(id, change)
+		}
+	})
+	return nil
+}
+
+// release unregisters the field callbacks and event stream of an Add.
+func (managed *managedController) release() {
+	for _, cancel := range managed.cancels {
+		cancel()
+	}
+	managed.dualsense.StopEvents(managed.events)
+}
+
+// managedHandlers returns the callbacks in handlers while managed is still
+// the Add of id, and none once it was removed or added again.
+func managedHandlers[T any](m *Manager, id ControllerID, managed *managedController, handlers *[]T) []T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.controllers[id] != managed {
+		return nil
+	}
+	return *handlers
+}
+
+// Remove stops managing id without closing it, unregistering the Manager's
+// field callbacks and event stream.
+func (m *Manager) Remove(id ControllerID) {
+	m.mu.Lock()
+	managed, ok := m.controllers[id]
+	delete(m.controllers, id)
+	m.mu.Unlock()
+	m.mux.Remove(id)
+	if ok {
+		managed.release()
+	}
+}
+
+// Controller returns the controller managed as id.
+func (m *Manager) Controller(id ControllerID) (*DualSense, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	managed, ok := m.controllers[id]
+	if !ok {
+		return nil, false
+	}
+	return managed.dualsense, true
+}
+
+// Controllers returns the IDs of every managed controller, sorted.
+func (m *Manager) Controllers() []ControllerID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]ControllerID, 0, len(m.controllers))
+	for id := range m.controllers {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// OnFieldChange registers a callback for changes of field on every managed
+// controller.
+func (m *Manager) OnFieldChange(field Field, callback func(ControllerID, FieldChange)) error {
+	err := field.Validate()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onField[field] = append(m.onField[field], callback)
+	return nil
+}
+
+// OnError registers a callback for the errors of every managed controller.
+func (m *Manager) OnError(callback func(ControllerID, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onError = append(m.onError, callback)
+}
+
+// OnLifecycleChange registers a callback for the lifecycle changes of every
+// managed controller.
+func (m *Manager) OnLifecycleChange(callback func(ControllerID, LifecycleChange)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onLifecycle = append(m.onLifecycle, callback)
+}
+
+// Events returns the field changes of every managed controller tagged with
+// its ControllerID, see EventMux. It is closed by Close.
+func (m *Manager) Events() <-chan ControllerEvent {
+	return m.mux.Events()
+}
+
+// Close closes every managed controller and the Events stream.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	controllers := m.controllers
+	m.controllers = map[ControllerID]*managedController{}
+	m.mu.Unlock()
+	for _, managed := range controllers {
+		managed.dualsense.Close()
+	}
+	m.mux.Close()
+}
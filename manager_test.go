@@ -0,0 +1,101 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestManager(t *testing.T) {
+	manager := NewManager()
+	var pressed []ControllerID
+	err := manager.OnFieldChange(FieldButtonCross, func(id ControllerID, change FieldChange) {
+		if change.New.(bool) {
+			pressed = append(pressed, id)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := NewMockDualSense(), NewMockDualSense()
+	if err := manager.Add("P1", first.DualSense); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Add("P2", second.DualSense); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Add("P2", second.DualSense); err == nil {
+		t.Fatal("expected an error adding a controller ID twice")
+	}
+	var failed []ControllerID
+	manager.OnError(func(id ControllerID, err error) {
+		failed = append(failed, id)
+	})
+
+	second.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	first.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	if len(pressed) != 2 || pressed[0] != "P2" || pressed[1] != "P1" {
+		t.Fatalf("got presses from %v, want [P2 P1]", pressed)
+	}
+	select {
+	case event := <-manager.Events():
+		if event.Field != FieldButtonCross {
+			t.Fatalf("got event for %s, want ButtonCross", event.Field)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event from Manager.Events")
+	}
+
+	first.triggerErrorCallbacks(errors.New("boom"))
+	if len(failed) != 1 || failed[0] != "P1" {
+		t.Fatalf("got errors from %v, want [P1]", failed)
+	}
+
+	manager.Remove("P1")
+	first.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = false })
+	first.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	if len(pressed) != 2 {
+		t.Fatalf("removed controller still reported: %v", pressed)
+	}
+	if ids := manager.Controllers(); len(ids) != 1 || ids[0] != "P2" {
+		t.Fatalf("got controllers %v, want [P2]", ids)
+	}
+
+	manager.Close()
+	if state := second.LifecycleState(); state != LifecycleClosed {
+		t.Fatalf("got %s, want Closed", state)
+	}
+}
+
+func TestManagerReAdd(t *testing.T) {
+	manager := NewManager()
+	defer manager.Close()
+	var presses, errs int
+	if err := manager.OnFieldChange(FieldButtonCross, func(ControllerID, FieldChange) { presses++ }); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.OnFieldChange(fieldCount, func(ControllerID, FieldChange) {}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	manager.OnError(func(ControllerID, error) { errs++ })
+	mock := NewMockDualSense()
+	if err := manager.Add("P1", mock.DualSense); err != nil {
+		t.Fatal(err)
+	}
+	manager.Remove("P1")
+	if got := len(mock.callbacks.subscriptions); got != 0 {
+		t.Fatalf("%d field callbacks left after Remove", got)
+	}
+	if got := len(mock.eventHistory.streams); got != 0 {
+		t.Fatalf("%d event streams left after Remove", got)
+	}
+	if err := manager.Add("P1", mock.DualSense); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.UpdateInState(func(state *USBGetStateData) { state.ButtonCross = true })
+	mock.triggerErrorCallbacks(errors.New("boom"))
+	if presses != 1 || errs != 1 {
+		t.Fatalf("got %d presses and %d errors after adding again, want 1 and 1", presses, errs)
+	}
+}
@@ -0,0 +1,209 @@
+// Package midi turns a DualSense controller into a MIDI performance
+// controller by mapping input fields to note and control change messages.
+package midi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+type MessageKind string
+
+const (
+	MessageNote          MessageKind = "note"
+	MessageControlChange MessageKind = "cc"
+)
+
+const (
+	statusNoteOff       = 0x80
+	statusNoteOn        = 0x90
+	statusControlChange = 0xB0
+)
+
+// Mapping sends a MIDI message when Field changes. Buttons map to note on/off
+// or CC 127/0. Sticks, triggers and motion axes map to CC values scaled to
+// 0-127, or to a note held while the axis is past its midpoint.
+type Mapping struct {
+	Field    string      `json:"field"` // dualsense.Field name, e.g. "ButtonCross"
+	Kind     MessageKind `json:"kind"`
+	Channel  uint8       `json:"channel"` // 0-15
+	Number   uint8       `json:"number"`  // Note or controller number
+	Velocity uint8       `json:"velocity,omitempty"`
+}
+
+// DefaultMappings maps the face buttons to notes C4-D#4 and the sticks and
+// triggers to CC 1-6, all on channel 0.
+func DefaultMappings() []Mapping {
+	return []Mapping{
+		{Field: "ButtonCross", Kind: MessageNote, Number: 60},
+		{Field: "ButtonCircle", Kind: MessageNote, Number: 61},
+		{Field: "ButtonSquare", Kind: MessageNote, Number: 62},
+		{Field: "ButtonTriangle", Kind: MessageNote, Number: 63},
+		{Field: "LeftStickX", Kind: MessageControlChange, Number: 1},
+		{Field: "LeftStickY", Kind: MessageControlChange, Number: 2},
+		{Field: "RightStickX", Kind: MessageControlChange, Number: 3},
+		{Field: "RightStickY", Kind: MessageControlChange, Number: 4},
+		{Field: "TriggerLeft", Kind: MessageControlChange, Number: 5},
+		{Field: "TriggerRight", Kind: MessageControlChange, Number: 6},
+	}
+}
+
+// LoadMappings reads a JSON array of Mapping from path.
+func LoadMappings(path string) ([]Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: error trying to read MIDI mappings: %w", err)
+	}
+	var mappings []Mapping
+	err = json.Unmarshal(data, &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: error trying to decode MIDI mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// Bridge writes MIDI messages for mapped input changes to an io.Writer, such
+// as an ALSA raw MIDI device (/dev/snd/midiC1D0) or a virtual MIDI port.
+type Bridge struct {
+	writer  io.Writer
+	mu      sync.Mutex
+	closed  bool
+	onErr   func(error)
+	cancels []func()
+}
+
+type Option func(*Bridge)
+
+// WithErrorHandler reports failed writes, which are otherwise dropped.
+func WithErrorHandler(onErr func(error)) Option {
+	return func(b *Bridge) {
+		b.onErr = onErr
+	}
+}
+
+// scale7 reduces a field value to a 7-bit MIDI data byte.
+func scale7(value any) (uint8, error) {
+	switch value := value.(type) {
+	case bool:
+		if value {
+			return 127, nil
+		}
+		return 0, nil
+	case uint8:
+		return value >> 1, nil
+	case int16:
+		return uint8((int32(value) + 32768) >> 9), nil
+	default:
+		return 0, fmt.Errorf("unsupported field type %T", value)
+	}
+}
+
+// NewBridge validates mappings and subscribes them on controller until Close.
+func NewBridge(writer io.Writer, controller dualsense.Controller, mappings []Mapping, options ...Option) (*Bridge, error) {
+	b := &Bridge{writer: writer}
+	for _, option := range options {
+		option(b)
+	}
+	err := b.subscribe(controller, mappings)
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// subscribe registers a callback per mapping, stopping at the first invalid
+// one; Close unregisters those already made.
+func (b *Bridge) subscribe(controller dualsense.Controller, mappings []Mapping) error {
+	for _, mapping := range mappings {
+		field, err := dualsense.ParseField(mapping.Field)
+		if err != nil {
+			return fmt.Errorf("invalid MIDI mapping: %w", err)
+		}
+		if mapping.Channel > 15 || mapping.Number > 127 || mapping.Velocity > 127 {
+			return fmt.Errorf("invalid MIDI mapping for %s: channel, number or velocity out of range", mapping.Field)
+		}
+		value, err := field.Value(&dualsense.USBGetStateData{})
+		if err == nil {
+			_, err = scale7(value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid MIDI mapping for %s: %w", mapping.Field, err)
+		}
+		var callback func(dualsense.FieldChange)
+		switch mapping.Kind {
+		case MessageNote:
+			callback = b.note(mapping)
+		case MessageControlChange:
+			callback = b.controlChange(mapping)
+		default:
+			return fmt.Errorf("invalid MIDI mapping for %s: unknown kind %q", mapping.Field, mapping.Kind)
+		}
+		cancel, err := controller.OnFieldChange(field, callback)
+		if err != nil {
+			return fmt.Errorf("invalid MIDI mapping for %s: %w", mapping.Field, err)
+		}
+		b.cancels = append(b.cancels, cancel)
+	}
+	return nil
+}
+
+func (b *Bridge) note(mapping Mapping) func(dualsense.FieldChange) {
+	velocity := mapping.Velocity
+	if velocity == 0 {
+		velocity = 127
+	}
+	on := false
+	return func(change dualsense.FieldChange) {
+		value, _ := scale7(change.New)
+		if value >= 64 == on {
+			return
+		}
+		on = !on
+		if on {
+			b.write(statusNoteOn|mapping.Channel, mapping.Number, velocity)
+		} else {
+			b.write(statusNoteOff|mapping.Channel, mapping.Number, 0)
+		}
+	}
+}
+
+func (b *Bridge) controlChange(mapping Mapping) func(dualsense.FieldChange) {
+	last := -1
+	return func(change dualsense.FieldChange) {
+		value, _ := scale7(change.New)
+		// Skip changes lost in the reduction to 7 bits.
+		if int(value) == last {
+			return
+		}
+		last = int(value)
+		b.write(statusControlChange|mapping.Channel, mapping.Number, value)
+	}
+}
+
+func (b *Bridge) write(message ...uint8) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	_, err := b.writer.Write(message)
+	if err != nil && b.onErr != nil {
+		b.onErr(fmt.Errorf("error writing MIDI message: %w", err))
+	}
+}
+
+// Close unsubscribes from the controller and stops writing.
+func (b *Bridge) Close() {
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+}
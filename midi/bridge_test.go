@@ -0,0 +1,84 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+func TestBridge(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	var out bytes.Buffer
+	mappings := []Mapping{
+		{Field: "ButtonCross", Kind: MessageNote, Channel: 1, Number: 60, Velocity: 100},
+		{Field: "TriggerRight", Kind: MessageControlChange, Number: 7},
+	}
+	_, err := NewBridge(&out, mock, mappings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = true
+		state.TriggerRight = 255
+	})
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.TriggerRight = 254 // Same 7-bit value, no message.
+	})
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = false
+	})
+	want := []byte{
+		0x91, 60, 100,
+		0xB0, 7, 127,
+		0x81, 60, 0,
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Fatalf("got % x, want % x", out.Bytes(), want)
+	}
+}
+
+func TestNewBridgeRejectsInvalidMappings(t *testing.T) {
+	mock := dualsense.NewMockDualSense()
+	for _, mapping := range []Mapping{
+		{Field: "ButtonNope", Kind: MessageNote},
+		{Field: "ButtonCross", Kind: "pitchbend"},
+		{Field: "ButtonCross", Kind: MessageNote, Channel: 16},
+		{Field: "DPad", Kind: MessageControlChange},
+	} {
+		if _, err := NewBridge(&bytes.Buffer{}, mock, []Mapping{mapping}); err == nil {
+			t.Errorf("NewBridge(%+v): expected error", mapping)
+		}
+	}
+	if _, err := NewBridge(&bytes.Buffer{}, mock, DefaultMappings()); err != nil {
+		t.Errorf("NewBridge(DefaultMappings()): %v", err)
+	}
+}
+
+func TestBridgeCloseUnsubscribes(t *testing.T) {
+	controller := dualsense.NewMockDualSense()
+	bridge, err := NewBridge(&bytes.Buffer{}, controller, DefaultMappings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := controller.FieldSubscriptions(), len(DefaultMappings()); got != want {
+		t.Fatalf("got %d subscriptions, want %d", got, want)
+	}
+	bridge.Close()
+	if got := controller.FieldSubscriptions(); got != 0 {
+		t.Fatalf("%d subscriptions left after Close", got)
+	}
+
+	invalid := append(DefaultMappings(), Mapping{Field: "ButtonCross", Kind: "pitchbend"})
+	if _, err := NewBridge(&bytes.Buffer{}, controller, invalid); err == nil {
+		t.Fatal("expected error for an invalid mapping")
+	}
+	if got := controller.FieldSubscriptions(); got != 0 {
+		t.Fatalf("%d subscriptions left after a failed NewBridge", got)
+	}
+}
@@ -0,0 +1,161 @@
+package dualsense
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// mockTransport stands in for the transport of a MockDualSense, serving queued
+// input reports and recording every write.
+type mockTransport struct {
+	mu             sync.Mutex
+	inputReports   [][]byte
+	outputReports  [][]byte
+	featureReports map[uint8][]byte
+	closed         bool
+}
+
+func (m *mockTransport) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return 0, errors.New("mock transport is closed")
+	}
+	m.outputReports = append(m.outputReports, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func (m *mockTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	m.mu.Lock()
+	if len(m.inputReports) > 0 {
+		report := m.inputReports[0]
+		m.inputReports = m.inputReports[1:]
+		m.mu.Unlock()
+		return copy(p, report), nil
+	}
+	m.mu.Unlock()
+	time.Sleep(timeout)
+	return 0, ErrTimeout
+}
+
+func (m *mockTransport) SendFeature(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(p) == 0 {
+		return 0, errors.New("missing feature report ID")
+	}
+	if m.featureReports == nil {
+		m.featureReports = make(map[uint8][]byte)
+	}
+	m.featureReports[p[0]] = append([]byte(nil), p...)
+	return len(p), nil
+}
+
+func (m *mockTransport) GetFeature(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(p) == 0 {
+		return 0, errors.New("missing feature report ID")
+	}
+	report, ok := m.featureReports[p[0]]
+	if !ok {
+		return 0, errors.New("unknown feature report")
+	}
+	return copy(p, report), nil
+}
+
+func (m *mockTransport) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+// MockDualSense is a DualSense without hardware for testing controller logic.
+// Input state is scripted with SetInState, which runs callbacks on the
+// caller's goroutine, and every output report written is recorded. Start does
+// not spawn any goroutines; call Poll to process reports queued with
+// QueueRawReport or to flush deferred output.
+type MockDualSense struct {
+	*DualSense
+	transport *mockTransport
+}
+
+func NewMockDualSense(options ...Option) *MockDualSense {
+	transport := &mockTransport{}
+	dualsense := newDualSense(transport, append(options, WithManualPump()))
+	return &MockDualSense{
+		DualSense: dualsense,
+		transport: transport,
+	}
+}
+
+// SetInState processes state as if it had just been reported by the
+// controller.
+func (m *MockDualSense) SetInState(state USBGetStateData) {
+	m.processReportIn(USBReportIn{ReportID: 0x01, USBGetStateData: state})
+}
+
+// UpdateInState applies update to a copy of the current input state and
+// processes the result with SetInState.
+func (m *MockDualSense) UpdateInState(update func(*USBGetStateData)) {
+	state := m.GetInStateData()
+	update(&state)
+	m.SetInState(state)
+}
+
+// QueueRawReport queues an input report to be returned by the next read, e.g.
+// from a recording.
+func (m *MockDualSense) QueueRawReport(data []byte) {
+	m.transport.mu.Lock()
+	defer m.transport.mu.Unlock()
+	m.transport.inputReports = append(m.transport.inputReports, append([]byte(nil), data...))
+}
+
+// SetFeatureReport sets the response to GetFeatureReport for the report ID in
+// the first byte of data.
+func (m *MockDualSense) SetFeatureReport(data []byte) {
+	m.transport.SendFeature(data)
+}
+
+// OutputReports returns every raw report written so far.
+func (m *MockDualSense) OutputReports() [][]byte {
+	m.transport.mu.Lock()
+	defer m.transport.mu.Unlock()
+	return append([][]byte(nil), m.transport.outputReports...)
+}
+
+// OutStates returns the decoded SetStateData of every output report written so
+// far. Raw writes that are not output reports are skipped.
+func (m *MockDualSense) OutStates() []SetStateData {
+	var states []SetStateData
+	for _, report := range m.OutputReports() {
+		state, err := UnmarshalOutputReport(report)
+		if err == nil {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+func (m *MockDualSense) ResetOutputReports() {
+	m.transport.mu.Lock()
+	defer m.transport.mu.Unlock()
+	m.transport.outputReports = nil
+}
+
+// FieldSubscriptions returns how many field change subscriptions are
+// registered and not yet cancelled, e.g. to check that an integration
+// unsubscribes everything it subscribed.
+func (m *MockDualSense) FieldSubscriptions() int {
+	m.callbacks.subscriptionsMu.Lock()
+	defer m.callbacks.subscriptionsMu.Unlock()
+	count := 0
+	for _, subscription := range m.callbacks.subscriptions {
+		if !subscription.everyReport {
+			count++
+		}
+	}
+	return count
+}
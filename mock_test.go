@@ -0,0 +1,114 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMockDualSense(t *testing.T) {
+	mock := NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.OnTriggerRightChange(func(value uint8) {
+		mock.SetLedRed(value)
+	})
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.TriggerRight = 0x42
+	})
+
+	states := mock.OutStates()
+	if len(states) != 2 {
+		t.Fatalf("got %d output reports, want 2", len(states))
+	}
+	if states[0] != defaultSetStateData {
+		t.Fatalf("got initial state %+v, want default", states[0])
+	}
+	if states[1].LedRed != 0x42 {
+		t.Fatalf("got LedRed %#x, want 0x42", states[1].LedRed)
+	}
+}
+
+func TestMockDualSensePoll(t *testing.T) {
+	mock := NewMockDualSense()
+	var cross bool
+	mock.OnButtonCrossChange(func(value bool) { cross = value })
+	report := make([]byte, USB_PACKET_SIZE)
+	report[0] = 0x01
+	report[8] = 0x20
+	mock.QueueRawReport(report)
+	if err := mock.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if !cross {
+		t.Fatal("ButtonCross callback was not called")
+	}
+}
+
+func TestMockDualSenseFieldSubscriptions(t *testing.T) {
+	mock := NewMockDualSense()
+	if got := mock.FieldSubscriptions(); got != 0 {
+		t.Fatalf("got %d subscriptions on a new mock", got)
+	}
+	cancelCross, err := mock.OnFieldChange(FieldButtonCross, func(FieldChange) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelStick, err := mock.OnFieldChange(FieldLeftStickX, func(FieldChange) {}, WithThrottle(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mock.FieldSubscriptions(); got != 2 {
+		t.Fatalf("got %d subscriptions, want 2", got)
+	}
+	cancelCross()
+	cancelCross()
+	cancelStick()
+	if got := mock.FieldSubscriptions(); got != 0 {
+		t.Fatalf("%d subscriptions left after cancelling", got)
+	}
+}
+
+func TestUnmarshalOutputReportRoundTrip(t *testing.T) {
+	setStateData := defaultSetStateData
+	setStateData.MicSelect = MicSelectExternalOnly
+	setStateData.EchoCancelEnable = true
+	setStateData.OutputPathSelect = 2
+	setStateData.InputPathSelect = 1
+	setStateData.SpeakerCompPreGain = 5
+	setStateData.BeamformingEnable = true
+	setStateData.EnableImprovedRumbleEmulation = true
+	setStateData.TriggerMotorPowerReduction = 3
+	setStateData.RumbleMotorPowerReduction = 7
+	setStateData.PlayerLightFade = true
+	setStateData.HostTimestamp = 0xDEADBEEF
+	setStateData.RightTriggerFFB = GenerateTriggerFFBParams(EffectTypeWeapon, 2, 7, 8)
+	data, err := MarshalOutputReport(setStateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalOutputReport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != setStateData {
+		t.Fatalf("got %+v, want %+v", got, setStateData)
+	}
+}
+
+func TestNewDualSenseWithTransport(t *testing.T) {
+	transport := &mockTransport{}
+	d, err := NewDualSense(WithTransport(transport), WithManualPump())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	if len(transport.outputReports) != 1 || !transport.closed {
+		t.Fatalf("transport was not used: %d writes, closed %v", len(transport.outputReports), transport.closed)
+	}
+}
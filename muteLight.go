@@ -0,0 +1,87 @@
+package dualsense
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type muteLightTimer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	// generation counts the calls, so a call replaced while it wrote does not
+	// start its timer.
+	generation uint64
+	// layer carries the breathing over the mute light as set.
+	layer *OutputLayer
+}
+
+// stop cancels the pending end of breathing and returns the generation of
+// the calling BreatheMuteLight or StopBreathingMuteLight.
+func (m *muteLightTimer) stop() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	m.generation++
+	return m.generation
+}
+
+// BreatheMuteLight sets the mute light to then, usually MuteLightModeOn or
+// MuteLightModeOff, and makes it breathe over that for duration, on its own
+// OutputLayer at MUTE_LIGHT_LAYER_PRIORITY. A duration of 0 or less breathes
+// until StopBreathingMuteLight. Setting the mute light while it breathes takes
+// effect once breathing ends, and a later call replaces the pending end.
+func (d *DualSense) BreatheMuteLight(duration time.Duration, then MuteLightMode) error {
+	generation := d.muteLight.stop()
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.AllowMuteLight = true
+		setStateData.MuteLight = then
+	})
+	if err != nil {
+		return fmt.Errorf("error setting mute light: %w", err)
+	}
+	err = d.muteLight.layer.SetMuteLight(MuteLightModeBreathing)
+	if err != nil {
+		return fmt.Errorf("error starting mute light breathing: %w", err)
+	}
+	if duration <= 0 {
+		return nil
+	}
+	d.muteLight.mu.Lock()
+	defer d.muteLight.mu.Unlock()
+	if d.muteLight.generation != generation {
+		return nil
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		d.muteLight.mu.Lock()
+		current := d.muteLight.timer == timer
+		if current {
+			d.muteLight.timer = nil
+		}
+		d.muteLight.mu.Unlock()
+		if !current {
+			return
+		}
+		err := d.muteLight.layer.Clear()
+		if err != nil {
+			d.logger.Debug("could not end DualSense mute light breathing", "error", err)
+		}
+	})
+	d.muteLight.timer = timer
+	return nil
+}
+
+// StopBreathingMuteLight ends the breathing started by BreatheMuteLight,
+// showing the mute light as set.
+func (d *DualSense) StopBreathingMuteLight() error {
+	d.muteLight.stop()
+	err := d.muteLight.layer.Clear()
+	if err != nil {
+		return fmt.Errorf("error stopping mute light breathing: %w", err)
+	}
+	return nil
+}
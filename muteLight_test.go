@@ -0,0 +1,76 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func lastMuteLight(d *MockDualSense) MuteLightMode {
+	outStates := d.OutStates()
+	if len(outStates) == 0 {
+		return MuteLightModeDoNothing
+	}
+	return outStates[len(outStates)-1].MuteLight
+}
+
+func TestBreatheMuteLight(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := d.BreatheMuteLight(10*time.Millisecond, MuteLightModeOn); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastMuteLight(d); got != MuteLightModeBreathing {
+		t.Fatalf("got %v, want Breathing", got)
+	}
+	deadline := time.Now().Add(time.Second)
+	for lastMuteLight(d) != MuteLightModeOn {
+		if time.Now().After(deadline) {
+			t.Fatalf("mute light still %v, want On", lastMuteLight(d))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A replaced timer must not fire.
+	if err := d.BreatheMuteLight(10*time.Millisecond, MuteLightModeOff); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.BreatheMuteLight(0, MuteLightModeOff); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := lastMuteLight(d); got != MuteLightModeBreathing {
+		t.Fatalf("got %v, want Breathing", got)
+	}
+	if err := d.SetMuteLight(MuteLightModeOn); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastMuteLight(d); got != MuteLightModeBreathing {
+		t.Fatalf("got %v while breathing, want Breathing", got)
+	}
+	if err := d.StopBreathingMuteLight(); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastMuteLight(d); got != MuteLightModeOn {
+		t.Fatalf("got %v after breathing, want the On set meanwhile", got)
+	}
+}
+
+func TestBreatheMuteLightStopsOnClose(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.BreatheMuteLight(time.Hour, MuteLightModeOn); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	d.muteLight.mu.Lock()
+	defer d.muteLight.mu.Unlock()
+	if d.muteLight.timer != nil {
+		t.Fatal("mute light timer still pending after Close")
+	}
+}
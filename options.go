@@ -0,0 +1,116 @@
+package dualsense
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Option configures a DualSense at construction time.
+type Option func(*DualSense)
+
+// WithLogger sets the logger used for read errors and write failures, which
+// are otherwise discarded.
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *DualSense) {
+		d.logger = logger
+	}
+}
+
+// WithLabel names the controller, see SetLabel.
+func WithLabel(label string) Option {
+	return func(d *DualSense) {
+		d.SetLabel(label)
+	}
+}
+
+// WithLabels makes NewDualSense and NewDualShock4 label the controller they
+// open with the entry of labels for its serial number, see ApplyLabels.
+func WithLabels(labels Labels) Option {
+	return func(d *DualSense) {
+		d.labels = labels
+	}
+}
+
+// WithWhitePoints makes NewDualSense and NewDualShock4 calibrate the lightbar
+// of the controller they open with the entry of whitePoints for its serial
+// number, see ApplyWhitePoints.
+func WithWhitePoints(whitePoints WhitePoints) Option {
+	return func(d *DualSense) {
+		d.whitePoints = whitePoints
+	}
+}
+
+// WithConnectAnimation plays animation, e.g. DefaultConnectAnimation, on the
+// lightbar and player indicators once Start has opened the controller and
+// after every reconnect, as visual confirmation it is bound. No animation is
+// played by default.
+func WithConnectAnimation(animation ConnectAnimation) Option {
+	return func(d *DualSense) {
+		d.connectAnimation = animation
+	}
+}
+
+// WithManualPump stops Start from spawning the I/O goroutine.
+// The caller drives the controller instead by calling Poll from its own loop.
+func WithManualPump() Option {
+	return func(d *DualSense) {
+		d.manualPump = true
+	}
+}
+
+// WithAsyncCallbacks runs the input change callbacks of every subscription on
+// a goroutine of its own instead of inline with input report processing, so a
+// slow callback neither stalls report processing nor other subscribers. Up to
+// queueSize input states wait per subscription; when its queue is full the
+// oldest state is discarded, and the next dispatched state is diffed against
+// the last one delivered so no final change is lost. Callbacks may call Stop
+// and Close. The goroutines end when their subscription is cancelled or the
+// DualSense is closed.
+func WithAsyncCallbacks(queueSize int) Option {
+	return func(d *DualSense) {
+		d.callbackQueueSize = max(queueSize, 1)
+	}
+}
+
+// WithReconnectPolicy makes the I/O goroutine reopen a disconnected controller
+// as policy decides, restoring its output state once reconnected. It only
+// applies to controllers opened by NewDualSense or NewDualShock4 without
+// WithTransport and not WithManualPump; others stay disconnected.
+func WithReconnectPolicy(policy ReconnectPolicy) Option {
+	return func(d *DualSense) {
+		d.reconnectPolicy = policy
+	}
+}
+
+// WithTransport makes NewDualSense use transport instead of opening the first
+// controller found through hidapi.
+func WithTransport(transport Transport) Option {
+	return func(d *DualSense) {
+		d.transport = transport
+	}
+}
+
+// WithSuppressedFields turns off change detection for fields, so they are
+// never diffed and callbacks registered for them never run. Applications that
+// only care about buttons can suppress MotionFields and TimestampFields to
+// avoid paying for sensor churn on every report.
+func WithSuppressedFields(fields ...Field) Option {
+	return func(d *DualSense) {
+		for _, field := range fields {
+			if field < fieldCount {
+				d.callbacks.suppressed[field] = true
+			}
+		}
+	}
+}
+
+// WithAudioGainClamping clamps the volumes and SpeakerCompPreGain to their
+// valid range on every output update, instead of rejecting out of range
+// values with an error wrapping ErrOutOfRange.
+func WithAudioGainClamping() Option {
+	return func(d *DualSense) {
+		d.clampAudioGains = true
+	}
+}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -0,0 +1,150 @@
+// Package osc publishes DualSense input as Open Sound Control messages over
+// UDP, for music and lighting software such as Ableton or TouchDesigner.
+package osc
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+const DEFAULT_PREFIX = "/dualsense"
+
+// Bridge sends one OSC message per changed input value:
+//
+//	<prefix>/leftStickX, leftStickY, rightStickX, rightStickY  f -1..1
+//	<prefix>/triggerLeft, triggerRight                        f 0..1
+//	<prefix>/angularVelocityX..Z, accelerometerX..Z           i raw
+//	<prefix>/touchFinger1, touchFinger2                       i touching, f x 0..1, f y 0..1
+type Bridge struct {
+	conn    net.Conn
+	prefix  string
+	mu      sync.Mutex
+	buffer  []byte
+	closed  bool
+	onErr   func(error)
+	cancels []func()
+}
+
+type Option func(*Bridge)
+
+// WithPrefix replaces DEFAULT_PREFIX at the start of every OSC address.
+func WithPrefix(prefix string) Option {
+	return func(b *Bridge) {
+		b.prefix = prefix
+	}
+}
+
+// WithErrorHandler reports failed sends, which are otherwise dropped.
+func WithErrorHandler(onErr func(error)) Option {
+	return func(b *Bridge) {
+		b.onErr = onErr
+	}
+}
+
+func normalizeStick(value uint8) float32 {
+	return (float32(value) - 127.5) / 127.5
+}
+
+func normalizeTrigger(value uint8) float32 {
+	return float32(value) / 255
+}
+
+// Dial connects to the OSC receiver at addr, e.g. "127.0.0.1:9000", and
+// subscribes to controller until Close.
+func Dial(addr string, controller dualsense.Controller, options ...Option) (*Bridge, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("net.Dial: error trying to connect to OSC receiver: %w", err)
+	}
+	b := &Bridge{conn: conn, prefix: DEFAULT_PREFIX}
+	for _, option := range options {
+		option(b)
+	}
+
+	sticks := map[dualsense.Field]string{
+		dualsense.FieldLeftStickX:  "/leftStickX",
+		dualsense.FieldLeftStickY:  "/leftStickY",
+		dualsense.FieldRightStickX: "/rightStickX",
+		dualsense.FieldRightStickY: "/rightStickY",
+	}
+	for field, address := range sticks {
+		b.subscribe(controller, field, func(change dualsense.FieldChange) {
+			b.send(address, normalizeStick(change.New.(uint8)))
+		})
+	}
+	triggers := map[dualsense.Field]string{
+		dualsense.FieldTriggerLeft:  "/triggerLeft",
+		dualsense.FieldTriggerRight: "/triggerRight",
+	}
+	for field, address := range triggers {
+		b.subscribe(controller, field, func(change dualsense.FieldChange) {
+			b.send(address, normalizeTrigger(change.New.(uint8)))
+		})
+	}
+	motion := map[dualsense.Field]string{
+		dualsense.FieldAngularVelocityX: "/angularVelocityX",
+		dualsense.FieldAngularVelocityY: "/angularVelocityY",
+		dualsense.FieldAngularVelocityZ: "/angularVelocityZ",
+		dualsense.FieldAccelerometerX:   "/accelerometerX",
+		dualsense.FieldAccelerometerY:   "/accelerometerY",
+		dualsense.FieldAccelerometerZ:   "/accelerometerZ",
+	}
+	for field, address := range motion {
+		b.subscribe(controller, field, func(change dualsense.FieldChange) {
+			b.send(address, int32(change.New.(int16)))
+		})
+	}
+	touch := map[dualsense.Field]string{
+		dualsense.FieldTouchFinger1: "/touchFinger1",
+		dualsense.FieldTouchFinger2: "/touchFinger2",
+	}
+	for field, address := range touch {
+		b.subscribe(controller, field, func(change dualsense.FieldChange) {
+			finger := change.New.(dualsense.TouchFinger)
+			touching := int32(1)
+			if finger.NotTouching {
+				touching = 0
+			}
+			b.send(address, touching, float32(finger.FingerX)/dualsense.TOUCHPAD_WIDTH, float32(finger.FingerY)/dualsense.TOUCHPAD_HEIGHT)
+		})
+	}
+	return b, nil
+}
+
+// subscribe registers callback for field, which is always valid, until Close.
+func (b *Bridge) subscribe(controller dualsense.Controller, field dualsense.Field, callback func(dualsense.FieldChange)) {
+	cancel, err := controller.OnFieldChange(field, callback)
+	if err == nil {
+		b.cancels = append(b.cancels, cancel)
+	}
+}
+
+func (b *Bridge) send(address string, args ...any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	var err error
+	b.buffer, err = AppendMessage(b.buffer[:0], b.prefix+address, args...)
+	if err == nil {
+		_, err = b.conn.Write(b.buffer)
+	}
+	if err != nil && b.onErr != nil {
+		b.onErr(fmt.Errorf("error sending OSC message %s: %w", address, err))
+	}
+}
+
+// Close unsubscribes from the controller and closes the connection.
+func (b *Bridge) Close() error {
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return b.conn.Close()
+}
@@ -0,0 +1,81 @@
+package osc
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+func TestAppendMessage(t *testing.T) {
+	message, err := AppendMessage(nil, "/a", int32(1), float32(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		'/', 'a', 0, 0,
+		',', 'i', 'f', 0,
+		0, 0, 0, 1,
+		0x3F, 0, 0, 0,
+	}
+	if !bytes.Equal(message, want) {
+		t.Fatalf("got %v, want %v", message, want)
+	}
+	if _, err := AppendMessage(nil, "/a", "text"); err == nil {
+		t.Fatal("expected error for string argument")
+	}
+}
+
+func TestBridge(t *testing.T) {
+	receiver, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+	mock := dualsense.NewMockDualSense()
+	if err := mock.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	bridge, err := Dial(receiver.LocalAddr().String(), mock, WithPrefix("/pad"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bridge.Close()
+
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.TriggerRight = 255
+	})
+	receiver.SetReadDeadline(time.Now().Add(time.Second))
+	packet := make([]byte, 64)
+	n, _, err := receiver.ReadFrom(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := AppendMessage(nil, "/pad/triggerRight", float32(1))
+	if !bytes.Equal(packet[:n], want) {
+		t.Fatalf("got %v, want %v", packet[:n], want)
+	}
+}
+
+func TestBridgeCloseUnsubscribes(t *testing.T) {
+	receiver, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+	controller := dualsense.NewMockDualSense()
+	bridge, err := Dial(receiver.LocalAddr().String(), controller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if controller.FieldSubscriptions() == 0 {
+		t.Fatal("Dial did not subscribe")
+	}
+	bridge.Close()
+	if got := controller.FieldSubscriptions(); got != 0 {
+		t.Fatalf("%d subscriptions left after Close", got)
+	}
+}
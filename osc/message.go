@@ -0,0 +1,46 @@
+package osc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// appendString appends s as an OSC string: null terminated and padded to a
+// multiple of 4 bytes.
+func appendString(buffer []byte, s string) []byte {
+	buffer = append(buffer, s...)
+	padding := 4 - len(s)%4
+	for i := 0; i < padding; i++ {
+		buffer = append(buffer, 0)
+	}
+	return buffer
+}
+
+// AppendMessage appends an OSC message with int32 and float32 arguments to
+// buffer.
+func AppendMessage(buffer []byte, address string, args ...any) ([]byte, error) {
+	typeTags := make([]byte, 0, len(args)+1)
+	typeTags = append(typeTags, ',')
+	for _, arg := range args {
+		switch arg.(type) {
+		case int32:
+			typeTags = append(typeTags, 'i')
+		case float32:
+			typeTags = append(typeTags, 'f')
+		default:
+			return nil, fmt.Errorf("unsupported OSC argument type %T", arg)
+		}
+	}
+	buffer = appendString(buffer, address)
+	buffer = appendString(buffer, string(typeTags))
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case int32:
+			buffer = binary.BigEndian.AppendUint32(buffer, uint32(arg))
+		case float32:
+			buffer = binary.BigEndian.AppendUint32(buffer, math.Float32bits(arg))
+		}
+	}
+	return buffer, nil
+}
@@ -0,0 +1,93 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+// OutputApplied confirms that the controller received an output report: an
+// input report echoed the HostTimestamp the write was stamped with. The
+// trigger effects reported active in that input report are included, so
+// callers can check that a trigger effect they wrote has taken hold.
+type OutputApplied struct {
+	SetStateData       SetStateData
+	Latency            time.Duration // From the write to the first input report echoing it
+	TriggerLeftEffect  uint8
+	TriggerRightEffect uint8
+}
+
+type pendingOutput struct {
+	hostTimestamp uint32
+	setStateData  SetStateData
+	sentAt        time.Time
+}
+
+type outputAckTracker struct {
+	mu      sync.Mutex
+	enabled bool
+	pending []pendingOutput
+	// selfTest receives every confirmation while RunSelfTest runs.
+	selfTest chan OutputApplied
+}
+
+func (t *outputAckTracker) isEnabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.enabled || t.selfTest != nil
+}
+
+// recordSent keeps at most latencyPendingWrites writes awaiting their echo,
+// dropping the oldest.
+func (t *outputAckTracker) recordSent(hostTimestamp uint32, setStateData SetStateData, sentAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.enabled && t.selfTest == nil {
+		return
+	}
+	if len(t.pending) == latencyPendingWrites {
+		t.pending = append(t.pending[:0], t.pending[1:]...)
+	}
+	t.pending = append(t.pending, pendingOutput{hostTimestamp: hostTimestamp, setStateData: setStateData, sentAt: sentAt})
+}
+
+// recordEcho matches the HostTimestamp of an input report against pending
+// writes. Older writes still pending were superseded before the controller
+// reported them and are dropped without confirmation.
+func (t *outputAckTracker) recordEcho(state *USBGetStateData, arrivedAt time.Time) (OutputApplied, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, pending := range t.pending {
+		if pending.hostTimestamp == state.HostTimestamp {
+			t.pending = append(t.pending[:0], t.pending[i+1:]...)
+			return OutputApplied{
+				SetStateData:       pending.setStateData,
+				Latency:            arrivedAt.Sub(pending.sentAt),
+				TriggerLeftEffect:  state.TriggerLeftEffect,
+				TriggerRightEffect: state.TriggerRightEffect,
+			}, true
+		}
+	}
+	return OutputApplied{}, false
+}
+
+// OnOutputApplied registers a callback for every output report the controller
+// confirms by echoing it in an input report. Registering it stamps every later
+// output report with a HostTimestamp, as EnableLatencyMeasurement does. Writes
+// superseded by a newer write before their echo arrives are not reported.
+func (d *DualSense) OnOutputApplied(callback func(OutputApplied)) {
+	d.outputAck.mu.Lock()
+	d.outputAck.enabled = true
+	d.outputAck.mu.Unlock()
+	d.callbacks.OnOutputApplied = append(d.callbacks.OnOutputApplied, callback)
+}
+
+func (d *DualSense) processOutputAck(state *USBGetStateData, now time.Time) {
+	applied, ok := d.outputAck.recordEcho(state, now)
+	if !ok {
+		return
+	}
+	d.outputAck.notifySelfTest(applied)
+	for _, callback := range d.callbacks.OnOutputApplied {
+		callback(applied)
+	}
+}
@@ -0,0 +1,45 @@
+package dualsense
+
+import "testing"
+
+func TestOnOutputApplied(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var applied []OutputApplied
+	d.OnOutputApplied(func(a OutputApplied) {
+		applied = append(applied, a)
+	})
+	if err := d.SetLedRed(0x10); err != nil {
+		t.Fatal(err)
+	}
+	params := [11]uint8{0x21, 0xFF}
+	if err := d.SetRightTriggerFFB(params); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	superseded, last := outStates[len(outStates)-2], outStates[len(outStates)-1]
+	if superseded.HostTimestamp == 0 || superseded.HostTimestamp == last.HostTimestamp {
+		t.Fatalf("writes not stamped: %d, %d", superseded.HostTimestamp, last.HostTimestamp)
+	}
+
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.HostTimestamp = last.HostTimestamp
+		state.TriggerRightEffect = 2
+	})
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.SeqNo++
+	})
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.HostTimestamp = superseded.HostTimestamp
+	})
+	if len(applied) != 1 {
+		t.Fatalf("got %d confirmations, want 1", len(applied))
+	}
+	if applied[0].SetStateData.RightTriggerFFB != params || applied[0].TriggerRightEffect != 2 {
+		t.Fatalf("got %+v", applied[0])
+	}
+}
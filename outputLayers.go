@@ -0,0 +1,245 @@
+package dualsense
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// MixMode is how an OutputLayer combines with the output below it.
+type MixMode uint8
+
+const (
+	// MixPreempt replaces the output below.
+	MixPreempt MixMode = iota
+	// MixMax keeps the stronger of each motor and color channel.
+	MixMax
+	// MixSum adds each motor and color channel, clamped to 255.
+	MixSum
+)
+
+// Priorities of the layers the library itself claims outputs on, above
+// application layers at the usual priority 0.
+const (
+	BATTERY_SAVER_LAYER_PRIORITY     = 100 // Player indicator blink, see BatterySaverPolicy
+	HAPTIC_TICK_LAYER_PRIORITY       = 200 // HapticTick pulses, mixed through MixMax
+	MUTE_LIGHT_LAYER_PRIORITY        = 300 // BreatheMuteLight
+	CONNECT_ANIMATION_LAYER_PRIORITY = 400 // WithConnectAnimation
+)
+
+// OutputLayer is one subsystem's claim on the rumble motors, lightbar, player
+// indicators and mute light, such as a battery indicator next to game effects.
+// Instead of the last writer winning, layers are mixed over the output state
+// as set, in order of priority with the highest on top, each through its
+// MixMode. Like SetLightbarBrightness, mixing happens as each output report is
+// written, so GetOutStateData keeps returning the state as set.
+type OutputLayer struct {
+	d        *DualSense
+	priority int
+	mode     MixMode
+
+	// rumble, lightbar, playerLights and muteLight are the claims, nil when
+	// unclaimed. They are guarded by setStateDataMu.
+	rumble       *[2]uint8
+	lightbar     *LedColor
+	playerLights *[5]bool
+	muteLight    *MuteLightMode
+}
+
+// NewOutputLayer adds a layer mixed through mode at priority. Layers of equal
+// priority are mixed in the order they were added. It claims nothing until
+// set.
+func (d *DualSense) NewOutputLayer(priority int, mode MixMode) (*OutputLayer, error) {
+	if mode > MixSum {
+		return nil, fmt.Errorf("%w: invalid mix mode %d", ErrOutOfRange, mode)
+	}
+	return d.addOutputLayer(priority, mode), nil
+}
+
+func (d *DualSense) addOutputLayer(priority int, mode MixMode) *OutputLayer {
+	layer := &OutputLayer{d: d, priority: priority, mode: mode}
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	// After the layers of lower or equal priority.
+	i, _ := slices.BinarySearchFunc(d.outputLayers, priority+1, func(l *OutputLayer, priority int) int {
+		return cmp.Compare(l.priority, priority)
+	})
+	d.outputLayers = slices.Insert(d.outputLayers, i, layer)
+	return layer
+}
+
+// update changes the layer's claims and writes the mixed output if they
+// changed.
+func (l *OutputLayer) update(change func()) error {
+	l.d.setStateDataMu.Lock()
+	defer l.d.setStateDataMu.Unlock()
+	rumble, lightbar, playerLights, muteLight := l.rumble, l.lightbar, l.playerLights, l.muteLight
+	change()
+	if equalClaim(rumble, l.rumble) && equalClaim(lightbar, l.lightbar) && equalClaim(playerLights, l.playerLights) && equalClaim(muteLight, l.muteLight) {
+		return nil
+	}
+	// Written even if the state is unchanged, as the mix is not part of it.
+	return l.d.applySetStateData(l.d.setStateData)
+}
+
+func equalClaim[T comparable](a, b *T) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+// SetRumble claims the rumble motors.
+func (l *OutputLayer) SetRumble(left, right uint8) error {
+	err := l.update(func() { l.rumble = &[2]uint8{left, right} })
+	if err != nil {
+		return fmt.Errorf("error updating output layer rumble: %w", err)
+	}
+	return nil
+}
+
+// SetLightbar claims the lightbar.
+func (l *OutputLayer) SetLightbar(color LedColor) error {
+	err := l.update(func() { l.lightbar = &color })
+	if err != nil {
+		return fmt.Errorf("error updating output layer lightbar: %w", err)
+	}
+	return nil
+}
+
+// SetPlayerLights claims the player indicators. MixMax and MixSum light an
+// indicator lit by either the layer or the output below.
+func (l *OutputLayer) SetPlayerLights(lights [5]bool) error {
+	err := l.update(func() { l.playerLights = &lights })
+	if err != nil {
+		return fmt.Errorf("error updating output layer player lights: %w", err)
+	}
+	return nil
+}
+
+// SetMuteLight claims the mute light, which has no levels to mix, so it
+// replaces the output below whatever the MixMode.
+func (l *OutputLayer) SetMuteLight(mode MuteLightMode) error {
+	err := l.update(func() { l.muteLight = &mode })
+	if err != nil {
+		return fmt.Errorf("error updating output layer mute light: %w", err)
+	}
+	return nil
+}
+
+func (l *OutputLayer) clearClaims() {
+	l.rumble, l.lightbar, l.playerLights, l.muteLight = nil, nil, nil, nil
+}
+
+// Clear releases the layer's claims, leaving the layer in place.
+func (l *OutputLayer) Clear() error {
+	err := l.update(l.clearClaims)
+	if err != nil {
+		return fmt.Errorf("error clearing output layer: %w", err)
+	}
+	return nil
+}
+
+// Remove releases the layer's claims and removes it.
+func (l *OutputLayer) Remove() error {
+	err := l.update(func() {
+		l.clearClaims()
+		l.d.outputLayers = slices.DeleteFunc(l.d.outputLayers, func(layer *OutputLayer) bool { return layer == l })
+	})
+	if err != nil {
+		return fmt.Errorf("error removing output layer: %w", err)
+	}
+	return nil
+}
+
+// RunEffects runs effects as DualSense.RunEffects does, but on the layer: the
+// rumble and lightbar the effects change are claimed by the layer while they
+// run, and released once they have all ended. Other outputs the effects
+// change, such as trigger effects, are not layered and are left alone.
+func (l *OutputLayer) RunEffects(stop <-chan struct{}, effects ...Effect) error {
+	l.d.setStateDataMu.Lock()
+	base := l.d.setStateData
+	l.d.setStateDataMu.Unlock()
+	scratch := base
+	var claimRumble, claimLightbar bool
+	err := runEffects(func(apply func(*SetStateData)) error {
+		return l.update(func() {
+			apply(&scratch)
+			claimRumble = claimRumble || hapticRumbleOf(&scratch) != hapticRumbleOf(&base)
+			claimLightbar = claimLightbar || ledColorOf(&scratch) != ledColorOf(&base)
+			if claimRumble {
+				l.rumble = &[2]uint8{scratch.RumbleEmulationLeft, scratch.RumbleEmulationRight}
+			}
+			if claimLightbar {
+				color := ledColorOf(&scratch)
+				l.lightbar = &color
+			}
+		})
+	}, l.d.logger, stop, effects)
+	clearErr := l.Clear()
+	if err != nil {
+		return err
+	}
+	return clearErr
+}
+
+// mixOutputLayers mixes the layers into an outgoing output report. It must be
+// called with setStateDataMu held.
+func (d *DualSense) mixOutputLayers(setStateData *SetStateData) {
+	for _, layer := range d.outputLayers {
+		if layer.rumble != nil {
+			setStateData.EnableRumbleEmulation = true
+			setStateData.UseRumbleNotHaptics = true
+			setStateData.RumbleEmulationLeft = layer.mode.mix(setStateData.RumbleEmulationLeft, layer.rumble[0])
+			setStateData.RumbleEmulationRight = layer.mode.mix(setStateData.RumbleEmulationRight, layer.rumble[1])
+		}
+		if layer.lightbar != nil {
+			below := ledColorOf(setStateData)
+			LedColor{
+				Red:   layer.mode.mix(below.Red, layer.lightbar.Red),
+				Green: layer.mode.mix(below.Green, layer.lightbar.Green),
+				Blue:  layer.mode.mix(below.Blue, layer.lightbar.Blue),
+			}.apply(setStateData)
+		}
+		if layer.playerLights != nil {
+			lights := *layer.playerLights
+			if layer.mode != MixPreempt {
+				for i, below := range playerLightsOf(setStateData) {
+					lights[i] = lights[i] || below
+				}
+			}
+			applyPlayerLights(setStateData, lights)
+		}
+		if layer.muteLight != nil {
+			setStateData.AllowMuteLight = true
+			setStateData.MuteLight = *layer.muteLight
+		}
+	}
+}
+
+func playerLightsOf(setStateData *SetStateData) [5]bool {
+	return [5]bool{
+		setStateData.PlayerLight1,
+		setStateData.PlayerLight2,
+		setStateData.PlayerLight3,
+		setStateData.PlayerLight4,
+		setStateData.PlayerLight5,
+	}
+}
+
+func applyPlayerLights(setStateData *SetStateData, lights [5]bool) {
+	setStateData.AllowPlayerIndicators = true
+	setStateData.PlayerLight1 = lights[0]
+	setStateData.PlayerLight2 = lights[1]
+	setStateData.PlayerLight3 = lights[2]
+	setStateData.PlayerLight4 = lights[3]
+	setStateData.PlayerLight5 = lights[4]
+}
+
+func (m MixMode) mix(below, value uint8) uint8 {
+	switch m {
+	case MixMax:
+		return max(below, value)
+	case MixSum:
+		return uint8(min(int(below)+int(value), 255))
+	default:
+		return value
+	}
+}
@@ -0,0 +1,136 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func lastRumble(d *MockDualSense) [2]uint8 {
+	outStates := d.OutStates()
+	last := outStates[len(outStates)-1]
+	return [2]uint8{last.RumbleEmulationLeft, last.RumbleEmulationRight}
+}
+
+func TestOutputLayers(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.UpdateState(func(setStateData *SetStateData) {
+		setStateData.RumbleEmulationLeft, setStateData.RumbleEmulationRight = 100, 10
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := d.NewOutputLayer(0, MixMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	battery, err := d.NewOutputLayer(10, MixPreempt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	boost, err := d.NewOutputLayer(10, MixSum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		name   string
+		change func() error
+		want   [2]uint8
+	}{
+		{"max", func() error { return game.SetRumble(50, 200) }, [2]uint8{100, 200}},
+		{"preempt", func() error { return battery.SetRumble(20, 0) }, [2]uint8{20, 0}},
+		{"sum above preempt", func() error { return boost.SetRumble(250, 5) }, [2]uint8{255, 5}},
+		{"removed", boost.Remove, [2]uint8{20, 0}},
+		{"cleared", battery.Clear, [2]uint8{100, 200}},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatal(err)
+		}
+		if got := lastRumble(d); got != step.want {
+			t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+		}
+	}
+	if got := d.GetOutStateData(); got.RumbleEmulationLeft != 100 || got.RumbleEmulationRight != 10 {
+		t.Errorf("state as set changed to %d, %d", got.RumbleEmulationLeft, got.RumbleEmulationRight)
+	}
+
+	if err := battery.SetLightbar(LedColor{Red: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastLedColor(d); got != (LedColor{Red: 255}) {
+		t.Errorf("lightbar: got %+v, want red", got)
+	}
+}
+
+func TestOutputLayerRunEffects(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	layer, err := d.NewOutputLayer(0, MixMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := d.GetOutStateData()
+
+	d.ResetOutputReports()
+	timeline, err := Timeline{Rumble: []RumbleKeyframe{{Left: 200}, {At: 20 * time.Millisecond, Left: 200}}}.Effect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := layer.RunEffects(nil, timeline); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) < 2 || outStates[0].RumbleEmulationLeft != 200 {
+		t.Fatalf("got %d output reports, want the effect's rumble first", len(outStates))
+	}
+	if got := lastRumble(d); got != [2]uint8{before.RumbleEmulationLeft, before.RumbleEmulationRight} {
+		t.Errorf("claim not released: got %v", got)
+	}
+	if got := d.GetOutStateData(); got != before {
+		t.Errorf("state as set changed: got %+v, want %+v", got, before)
+	}
+}
+
+func TestOutputLayerPlayerAndMuteLights(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetPlayerLight1(true); err != nil {
+		t.Fatal(err)
+	}
+	layer, err := d.NewOutputLayer(0, MixMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := layer.SetPlayerLights([5]bool{false, false, true, false, false}); err != nil {
+		t.Fatal(err)
+	}
+	if err := layer.SetMuteLight(MuteLightModeBreathing); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	last := outStates[len(outStates)-1]
+	if got, want := playerLightsOf(&last), [5]bool{true, false, true, false, false}; got != want {
+		t.Fatalf("got player lights %v, want %v", got, want)
+	}
+	if last.MuteLight != MuteLightModeBreathing {
+		t.Fatalf("got mute light %v, want Breathing", last.MuteLight)
+	}
+	if err := layer.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	outStates = d.OutStates()
+	last = outStates[len(outStates)-1]
+	if got, want := playerLightsOf(&last), [5]bool{true, false, false, false, false}; got != want || last.MuteLight == MuteLightModeBreathing {
+		t.Fatalf("got player lights %v and mute light %v after Clear", got, last.MuteLight)
+	}
+}
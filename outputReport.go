@@ -8,6 +8,8 @@ import (
 	"fmt"
 )
 
+const USB_OUTPUT_REPORT_SIZE = 48
+
 type MuteLightMode uint8
 
 const (
@@ -84,63 +86,63 @@ const (
 )
 
 type SetStateData struct {
-	EnableRumbleEmulation         bool
-	UseRumbleNotHaptics           bool
-	AllowRightTriggerFFB          bool // Enable setting RightTriggerFFB
-	AllowLeftTriggerFFB           bool // Enable setting LeftTriggerFFB
-	AllowHeadphoneVolume          bool // Enable setting VolumeHeadphones
-	AllowSpeakerVolume            bool // Enable setting VolumeSpeaker
-	AllowMicVolume                bool // Enable setting VolumeMic
-	AllowAudioControl             bool // Enable setting the "Audio Control" fields
-	AllowMuteLight                bool // Enable setting MuteLight
-	AllowAudioMute                bool // Enable setting the "Mute Control" fields
-	AllowLedColor                 bool // Enable setting the "RGB LED" fields
-	ResetLights                   bool
-	AllowPlayerIndicators         bool // Enable setting the "Player Indicators" fields
-	AllowHapticLowPassFilter      bool // Enable setting HapticLowPassFilter
-	AllowMotorPowerLevel          bool // Enable setting the "Motor Power Level" fields
-	AllowAudioControl2            bool // Enable setting the "Audio Control 2" fields
-	RumbleEmulationRight          uint8
-	RumbleEmulationLeft           uint8
-	VolumeHeadphones              uint8
-	VolumeSpeaker                 uint8
-	VolumeMic                     uint8
-	MicSelect                     MicSelectType // Audio Control
-	EchoCancelEnable              bool          // Audio Control
-	NoiseCancelEnable             bool          // Audio Control
-	OutputPathSelect              uint8         // Audio Control: 0 L_R_X, 1 L_L_X, 2 L_L_R, 3 X_X_R
-	InputPathSelect               uint8         // Audio Control: 0 CHAT_ASR, 1 CHAT_CHAT, 2 ASR_ASR, 3 invalid
-	MuteLight                     MuteLightMode
-	TouchPowerSave                bool      // Mute Control
-	MotionPowerSave               bool      // Mute Control
-	HapticPowerSave               bool      // Mute Control
-	AudioPowerSave                bool      // Mute Control
-	MicMute                       bool      // Mute Control
-	SpeakerMute                   bool      // Mute Control
-	HeadphoneMute                 bool      // Mute Control
-	HapticMute                    bool      // Mute Control
-	RightTriggerFFB               [11]uint8 // Use GenerateTriggerFFBParams
-	LeftTriggerFFB                [11]uint8 // Use GenerateTriggerFFBParams
-	HostTimestamp                 uint32
-	TriggerMotorPowerReduction    uint8 // Motor Power Level
-	RumbleMotorPowerReduction     uint8 // Motor Power Level
-	SpeakerCompPreGain            uint8 // Audio Control 2
-	BeamformingEnable             bool  // Audio Control 2
-	AllowLightBrightnessChange    bool  // Allow setting LightBrightness
-	AllowColorLightFadeAnimation  bool  // Allow setting LightFadeAnimation
-	EnableImprovedRumbleEmulation bool  // Use instead of EnableRumbleEmulation
-	HapticLowPassFilter           bool
-	LightFadeAnimation            LightFadeAnimation
-	LightBrightness               LightBrightness
-	PlayerLight1                  bool  // Player Indicators
-	PlayerLight2                  bool  // Player Indicators
-	PlayerLight3                  bool  // Player Indicators
-	PlayerLight4                  bool  // Player Indicators
-	PlayerLight5                  bool  // Player Indicators
-	PlayerLightFade               bool  // Player Indicators
-	LedRed                        uint8 // RGB LED
-	LedGreen                      uint8 // RGB LED
-	LedBlue                       uint8 // RGB LED
+	EnableRumbleEmulation         bool               `json:"enableRumbleEmulation"`
+	UseRumbleNotHaptics           bool               `json:"useRumbleNotHaptics"`
+	AllowRightTriggerFFB          bool               `json:"allowRightTriggerFFB"` // Enable setting RightTriggerFFB
+	AllowLeftTriggerFFB           bool               `json:"allowLeftTriggerFFB"`  // Enable setting LeftTriggerFFB
+	AllowHeadphoneVolume          bool               `json:"allowHeadphoneVolume"` // Enable setting VolumeHeadphones
+	AllowSpeakerVolume            bool               `json:"allowSpeakerVolume"`   // Enable setting VolumeSpeaker
+	AllowMicVolume                bool               `json:"allowMicVolume"`       // Enable setting VolumeMic
+	AllowAudioControl             bool               `json:"allowAudioControl"`    // Enable setting the "Audio Control" fields
+	AllowMuteLight                bool               `json:"allowMuteLight"`       // Enable setting MuteLight
+	AllowAudioMute                bool               `json:"allowAudioMute"`       // Enable setting the "Mute Control" fields
+	AllowLedColor                 bool               `json:"allowLedColor"`        // Enable setting the "RGB LED" fields
+	ResetLights                   bool               `json:"resetLights"`
+	AllowPlayerIndicators         bool               `json:"allowPlayerIndicators"`    // Enable setting the "Player Indicators" fields
+	AllowHapticLowPassFilter      bool               `json:"allowHapticLowPassFilter"` // Enable setting HapticLowPassFilter
+	AllowMotorPowerLevel          bool               `json:"allowMotorPowerLevel"`     // Enable setting the "Motor Power Level" fields
+	AllowAudioControl2            bool               `json:"allowAudioControl2"`       // Enable setting the "Audio Control 2" fields
+	RumbleEmulationRight          uint8              `json:"rumbleEmulationRight"`
+	RumbleEmulationLeft           uint8              `json:"rumbleEmulationLeft"`
+	VolumeHeadphones              uint8              `json:"volumeHeadphones"`
+	VolumeSpeaker                 uint8              `json:"volumeSpeaker"`
+	VolumeMic                     uint8              `json:"volumeMic"`
+	MicSelect                     MicSelectType      `json:"micSelect"`         // Audio Control
+	EchoCancelEnable              bool               `json:"echoCancelEnable"`  // Audio Control
+	NoiseCancelEnable             bool               `json:"noiseCancelEnable"` // Audio Control
+	OutputPathSelect              uint8              `json:"outputPathSelect"`  // Audio Control: 0 L_R_X, 1 L_L_X, 2 L_L_R, 3 X_X_R
+	InputPathSelect               uint8              `json:"inputPathSelect"`   // Audio Control: 0 CHAT_ASR, 1 CHAT_CHAT, 2 ASR_ASR, 3 invalid
+	MuteLight                     MuteLightMode      `json:"muteLight"`
+	TouchPowerSave                bool               `json:"touchPowerSave"`  // Mute Control
+	MotionPowerSave               bool               `json:"motionPowerSave"` // Mute Control
+	HapticPowerSave               bool               `json:"hapticPowerSave"` // Mute Control
+	AudioPowerSave                bool               `json:"audioPowerSave"`  // Mute Control
+	MicMute                       bool               `json:"micMute"`         // Mute Control
+	SpeakerMute                   bool               `json:"speakerMute"`     // Mute Control
+	HeadphoneMute                 bool               `json:"headphoneMute"`   // Mute Control
+	HapticMute                    bool               `json:"hapticMute"`      // Mute Control
+	RightTriggerFFB               [11]uint8          `json:"rightTriggerFFB"` // Use GenerateTriggerFFBParams
+	LeftTriggerFFB                [11]uint8          `json:"leftTriggerFFB"`  // Use GenerateTriggerFFBParams
+	HostTimestamp                 uint32             `json:"hostTimestamp"`
+	TriggerMotorPowerReduction    PowerReduction     `json:"triggerMotorPowerReduction"`    // Motor Power Level
+	RumbleMotorPowerReduction     PowerReduction     `json:"rumbleMotorPowerReduction"`     // Motor Power Level
+	SpeakerCompPreGain            uint8              `json:"speakerCompPreGain"`            // Audio Control 2
+	BeamformingEnable             bool               `json:"beamformingEnable"`             // Audio Control 2
+	AllowLightBrightnessChange    bool               `json:"allowLightBrightnessChange"`    // Allow setting LightBrightness
+	AllowColorLightFadeAnimation  bool               `json:"allowColorLightFadeAnimation"`  // Allow setting LightFadeAnimation
+	EnableImprovedRumbleEmulation bool               `json:"enableImprovedRumbleEmulation"` // Use instead of EnableRumbleEmulation
+	HapticLowPassFilter           bool               `json:"hapticLowPassFilter"`
+	LightFadeAnimation            LightFadeAnimation `json:"lightFadeAnimation"`
+	LightBrightness               LightBrightness    `json:"lightBrightness"`
+	PlayerLight1                  bool               `json:"playerLight1"`    // Player Indicators
+	PlayerLight2                  bool               `json:"playerLight2"`    // Player Indicators
+	PlayerLight3                  bool               `json:"playerLight3"`    // Player Indicators
+	PlayerLight4                  bool               `json:"playerLight4"`    // Player Indicators
+	PlayerLight5                  bool               `json:"playerLight5"`    // Player Indicators
+	PlayerLightFade               bool               `json:"playerLightFade"` // Player Indicators
+	LedRed                        uint8              `json:"ledRed"`          // RGB LED
+	LedGreen                      uint8              `json:"ledGreen"`        // RGB LED
+	LedBlue                       uint8              `json:"ledBlue"`         // RGB LED
 }
 
 type USBReportOut struct {
@@ -159,7 +161,9 @@ func packBoolsToLittleEndianUint8(b [8]bool) uint8 {
 	return packed
 }
 
-func packUSBReportOut(setStateData SetStateData) ([]byte, error) {
+// MarshalOutputReport builds the USB output report, including its report ID,
+// that applies setStateData when written to the controller.
+func MarshalOutputReport(setStateData SetStateData) ([]byte, error) {
 	setFlags0 := packBoolsToLittleEndianUint8([8]bool{
 		setStateData.EnableRumbleEmulation,
 		setStateData.UseRumbleNotHaptics,
@@ -207,7 +211,7 @@ func packUSBReportOut(setStateData SetStateData) ([]byte, error) {
 		setStateData.HapticMute,
 	})
 
-	motorPowerLevel := setStateData.TriggerMotorPowerReduction | (setStateData.RumbleMotorPowerReduction << 4)
+	motorPowerLevel := uint8(setStateData.TriggerMotorPowerReduction) | uint8(setStateData.RumbleMotorPowerReduction)<<4
 
 	audioControl2 := setStateData.SpeakerCompPreGain << 5
 	audioControl2 >>= 1
@@ -362,3 +366,86 @@ var defaultSetStateData = SetStateData{
 	LedGreen:                      0xFF,
 	LedBlue:                       0xFF,
 }
+
+// UnmarshalOutputReport is the inverse of MarshalOutputReport, decoding a USB
+// output report, including its report ID, back into a SetStateData.
+func UnmarshalOutputReport(data []byte) (SetStateData, error) {
+	if len(data) != USB_OUTPUT_REPORT_SIZE {
+		return SetStateData{}, fmt.Errorf("invalid length of data: %d", len(data))
+	}
+	if data[0] != 0x02 {
+		return SetStateData{}, fmt.Errorf("invalid output report ID: %#x", data[0])
+	}
+	bit := func(b uint8, n uint) bool {
+		return getNthLittleEndianBitUint8(b, n) == 1
+	}
+	setFlags0 := data[1]
+	setFlags1 := data[2]
+	audioControl := data[8]
+	muteControl := data[10]
+	motorPowerLevel := data[37]
+	audioControl2 := data[38]
+	setFlags38 := data[39]
+	setFlags39 := data[40]
+	playerIndicators := data[44]
+	setStateData := SetStateData{
+		EnableRumbleEmulation:         bit(setFlags0, 0),
+		UseRumbleNotHaptics:           bit(setFlags0, 1),
+		AllowRightTriggerFFB:          bit(setFlags0, 2),
+		AllowLeftTriggerFFB:           bit(setFlags0, 3),
+		AllowHeadphoneVolume:          bit(setFlags0, 4),
+		AllowSpeakerVolume:            bit(setFlags0, 5),
+		AllowMicVolume:                bit(setFlags0, 6),
+		AllowAudioControl:             bit(setFlags0, 7),
+		AllowMuteLight:                bit(setFlags1, 0),
+		AllowAudioMute:                bit(setFlags1, 1),
+		AllowLedColor:                 bit(setFlags1, 2),
+		ResetLights:                   bit(setFlags1, 3),
+		AllowPlayerIndicators:         bit(setFlags1, 4),
+		AllowHapticLowPassFilter:      bit(setFlags1, 5),
+		AllowMotorPowerLevel:          bit(setFlags1, 6),
+		AllowAudioControl2:            bit(setFlags1, 7),
+		RumbleEmulationRight:          data[3],
+		RumbleEmulationLeft:           data[4],
+		VolumeHeadphones:              data[5],
+		VolumeSpeaker:                 data[6],
+		VolumeMic:                     data[7],
+		MicSelect:                     MicSelectType(audioControl & 0x03),
+		EchoCancelEnable:              bit(audioControl, 2),
+		NoiseCancelEnable:             bit(audioControl, 3),
+		OutputPathSelect:              (audioControl >> 4) & 0x03,
+		InputPathSelect:               audioControl >> 6,
+		MuteLight:                     MuteLightMode(data[9]),
+		TouchPowerSave:                bit(muteControl, 0),
+		MotionPowerSave:               bit(muteControl, 1),
+		HapticPowerSave:               bit(muteControl, 2),
+		AudioPowerSave:                bit(muteControl, 3),
+		MicMute:                       bit(muteControl, 4),
+		SpeakerMute:                   bit(muteControl, 5),
+		HeadphoneMute:                 bit(muteControl, 6),
+		HapticMute:                    bit(muteControl, 7),
+		HostTimestamp:                 binary.LittleEndian.Uint32(data[33:]),
+		TriggerMotorPowerReduction:    PowerReduction(motorPowerLevel & 0x0F),
+		RumbleMotorPowerReduction:     PowerReduction(motorPowerLevel >> 4),
+		SpeakerCompPreGain:            audioControl2 & 0x07,
+		BeamformingEnable:             bit(audioControl2, 3),
+		AllowLightBrightnessChange:    bit(setFlags38, 0),
+		AllowColorLightFadeAnimation:  bit(setFlags38, 1),
+		EnableImprovedRumbleEmulation: bit(setFlags38, 2),
+		HapticLowPassFilter:           bit(setFlags39, 0),
+		LightFadeAnimation:            LightFadeAnimation(data[42]),
+		LightBrightness:               LightBrightness(data[43]),
+		PlayerLight1:                  bit(playerIndicators, 0),
+		PlayerLight2:                  bit(playerIndicators, 1),
+		PlayerLight3:                  bit(playerIndicators, 2),
+		PlayerLight4:                  bit(playerIndicators, 3),
+		PlayerLight5:                  bit(playerIndicators, 4),
+		PlayerLightFade:               bit(playerIndicators, 5),
+		LedRed:                        data[45],
+		LedGreen:                      data[46],
+		LedBlue:                       data[47],
+	}
+	copy(setStateData.RightTriggerFFB[:], data[11:22])
+	copy(setStateData.LeftTriggerFFB[:], data[22:33])
+	return setStateData, nil
+}
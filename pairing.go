@@ -0,0 +1,69 @@
+package dualsense
+
+import (
+	"fmt"
+	"net"
+)
+
+// Feature reports setting the Bluetooth host a controller pairs with. The
+// DualShock 4 uses its own report, see DualShock4Transport.
+const (
+	SET_PAIRING_REPORT_ID            = 0x0A
+	SET_PAIRING_REPORT_SIZE          = 27
+	DUALSHOCK4_SET_PAIRING_REPORT_ID = 0x13
+	// DUALSHOCK4_SET_PAIRING_REPORT_SIZE is the size of DualShock 4 feature
+	// report 0x13.
+	DUALSHOCK4_SET_PAIRING_REPORT_SIZE = 23
+	LINK_KEY_SIZE                      = 16
+)
+
+// putAddress writes a Bluetooth address least significant byte first, as the
+// pairing reports store it.
+func putAddress(p []byte, mac net.HardwareAddr) {
+	for i := range 6 {
+		p[i] = mac[5-i]
+	}
+}
+
+func readAddress(p []byte) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		mac[i] = p[5-i]
+	}
+	return mac
+}
+
+// SetPairedHost pairs the controller with the Bluetooth host at mac using
+// linkKey, so it connects to that host without pairing it through the
+// Create and PS buttons. It only works over USB, and the host must be told
+// the same link key for the controller, e.g. through its Bluetooth stack's
+// configuration.
+func (d *DualSense) SetPairedHost(mac net.HardwareAddr, linkKey [LINK_KEY_SIZE]byte) error {
+	if len(mac) != 6 {
+		return fmt.Errorf("invalid Bluetooth address %v: need 6 bytes", mac)
+	}
+	report := make([]byte, SET_PAIRING_REPORT_SIZE)
+	report[0] = SET_PAIRING_REPORT_ID
+	putAddress(report[1:], mac)
+	copy(report[7:], linkKey[:])
+	_, err := d.SendFeatureReport(report)
+	if err != nil {
+		return fmt.Errorf("error trying to set DualSense paired host: %w", err)
+	}
+	return nil
+}
+
+// PairedHost returns the Bluetooth address of the host the controller is
+// paired with, read from the pairing info feature report.
+func (d *DualSense) PairedHost() (net.HardwareAddr, error) {
+	report := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	report[0] = PAIRING_INFO_REPORT_ID
+	n, err := d.GetFeatureReport(report)
+	if err != nil {
+		return nil, err
+	}
+	if n < 16 {
+		return nil, fmt.Errorf("short DualSense pairing info report of %d bytes", n)
+	}
+	return readAddress(report[10:]), nil
+}
@@ -0,0 +1,52 @@
+package dualsense
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestSetPairedHost(t *testing.T) {
+	d := NewMockDualSense()
+	mac := net.HardwareAddr{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	var linkKey [LINK_KEY_SIZE]byte
+	for i := range linkKey {
+		linkKey[i] = byte(0xA0 + i)
+	}
+	if err := d.SetPairedHost(mac, linkKey); err != nil {
+		t.Fatal(err)
+	}
+	report := d.transport.featureReports[SET_PAIRING_REPORT_ID]
+	if len(report) != SET_PAIRING_REPORT_SIZE || !bytes.Equal(report[1:7], []byte{0x06, 0x05, 0x04, 0x03, 0x02, 0x01}) || !bytes.Equal(report[7:23], linkKey[:]) {
+		t.Fatalf("got report % X", report)
+	}
+	if err := d.SetPairedHost(mac[:4], linkKey); err == nil {
+		t.Error("SetPairedHost with a short address: expected error")
+	}
+
+	pairingInfo := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairingInfo[0] = PAIRING_INFO_REPORT_ID
+	copy(pairingInfo[10:], report[1:7])
+	d.SetFeatureReport(pairingInfo)
+	host, err := d.PairedHost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.String() != mac.String() {
+		t.Fatalf("got paired host %v, want %v", host, mac)
+	}
+}
+
+func TestDualShock4SetPairedHost(t *testing.T) {
+	inner := &mockTransport{}
+	d := newDualSense(NewDualShock4Transport(inner), []Option{WithManualPump()})
+	var linkKey [LINK_KEY_SIZE]byte
+	linkKey[0] = 0xAA
+	if err := d.SetPairedHost(net.HardwareAddr{1, 2, 3, 4, 5, 6}, linkKey); err != nil {
+		t.Fatal(err)
+	}
+	report := inner.featureReports[DUALSHOCK4_SET_PAIRING_REPORT_ID]
+	if len(report) != DUALSHOCK4_SET_PAIRING_REPORT_SIZE || report[1] != 6 || report[7] != 0xAA {
+		t.Fatalf("got report % X", report)
+	}
+}
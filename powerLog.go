@@ -0,0 +1,154 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DEFAULT_POWER_LOG_INTERVAL is how often an attached PowerLogger samples the
+// controller unless told otherwise.
+const DEFAULT_POWER_LOG_INTERVAL = time.Minute
+
+// Connection types of a PowerSample.
+const (
+	ConnectionUSB       = "usb"
+	ConnectionBluetooth = "bluetooth"
+)
+
+// PowerSample is one entry of a power log.
+type PowerSample struct {
+	Time         time.Time  `json:"time"`
+	Label        string     `json:"label,omitempty"`
+	PowerPercent uint8      `json:"powerPercent"` // 0-100, the middle of the reported 10% step
+	PowerState   PowerState `json:"powerState"`
+	Temperature  int8       `json:"temperature"`
+	Connection   string     `json:"connection"`
+}
+
+// PowerLogger records battery level, power state, temperature and connection
+// type as JSON Lines, one PowerSample per line, for analyzing battery wear
+// over weeks of use. It is safe for concurrent use.
+type PowerLogger struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	stop    chan struct{}
+	done    chan struct{}
+	closed  bool
+	err     error
+}
+
+// NewPowerLogger logs to w.
+func NewPowerLogger(w io.Writer) *PowerLogger {
+	return &PowerLogger{encoder: json.NewEncoder(w)}
+}
+
+// OpenPowerLog appends to the file at path, creating it if needed, so one file
+// can collect samples across many sessions.
+func OpenPowerLog(path string) (*PowerLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: error trying to open power log: %w", err)
+	}
+	logger := NewPowerLogger(file)
+	logger.closer = file
+	return logger, nil
+}
+
+// Log appends a single sample. The first error is sticky and returned by every
+// later call, including Close.
+func (l *PowerLogger) Log(sample PowerSample) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return errors.New("power logger is closed")
+	}
+	if l.err != nil {
+		return l.err
+	}
+	err := l.encoder.Encode(sample)
+	if err != nil {
+		l.err = fmt.Errorf("error trying to write power log: %w", err)
+	}
+	return l.err
+}
+
+// Attach samples d right away and then every interval, or every
+// DEFAULT_POWER_LOG_INTERVAL if interval is 0 or less, until the logger or d is
+// closed. A logger can be attached once.
+func (l *PowerLogger) Attach(d *DualSense, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DEFAULT_POWER_LOG_INTERVAL
+	}
+	l.mu.Lock()
+	if l.closed || l.stop != nil {
+		l.mu.Unlock()
+		return errors.New("power logger is closed or already attached")
+	}
+	l.stop, l.done = make(chan struct{}), make(chan struct{})
+	l.mu.Unlock()
+	go func() {
+		defer close(l.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// Write failures stay in the logger for Close to return.
+			l.Log(d.powerSample(time.Now()))
+			select {
+			case <-ticker.C:
+			case <-l.stop:
+				return
+			case <-d.closed:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// powerSample reads the current power details of d.
+func (d *DualSense) powerSample(now time.Time) PowerSample {
+	state := d.GetInStateData()
+	connection := ConnectionUSB
+	if d.wireless() {
+		connection = ConnectionBluetooth
+	}
+	return PowerSample{
+		Time:         now,
+		Label:        d.Label(),
+		PowerPercent: batteryPercent(state.PowerPercent, state.PowerState),
+		PowerState:   state.PowerState,
+		Temperature:  state.Temperature,
+		Connection:   connection,
+	}
+}
+
+// Close stops sampling and closes the file opened by OpenPowerLog.
+func (l *PowerLogger) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		defer l.mu.Unlock()
+		return l.err
+	}
+	l.closed = true
+	stop, done := l.stop, l.done
+	l.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closer != nil {
+		err := l.closer.Close()
+		if l.err == nil && err != nil {
+			l.err = fmt.Errorf("error trying to close power log: %w", err)
+		}
+	}
+	return l.err
+}
@@ -0,0 +1,87 @@
+package dualsense
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPowerLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "power.jsonl")
+	d := NewMockDualSense(WithLabel("P1"))
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.PowerPercent = 7
+		state.PowerState = PowerStateDischarging
+		state.Temperature = 31
+	})
+
+	// Two sessions append to the same file.
+	for range 2 {
+		logger, err := OpenPowerLog(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := logger.Attach(d.DualSense, time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if err := logger.Attach(d.DualSense, time.Millisecond); err == nil {
+			t.Error("attached twice")
+		}
+		time.Sleep(5 * time.Millisecond)
+		if err := logger.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var samples []PowerSample
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var sample PowerSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		samples = append(samples, sample)
+	}
+	if len(samples) < 2 {
+		t.Fatalf("got %d samples, want one per session at least", len(samples))
+	}
+	want := PowerSample{Label: "P1", PowerPercent: 75, PowerState: PowerStateDischarging, Temperature: 31, Connection: ConnectionUSB}
+	got := samples[0]
+	got.Time = time.Time{}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPowerLogStopsOnClose(t *testing.T) {
+	d := NewMockDualSense()
+	logger := NewPowerLogger(io.Discard)
+	if err := logger.Attach(d.DualSense, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	select {
+	case <-logger.done:
+	case <-time.After(time.Second):
+		t.Fatal("still sampling after the DualSense was closed")
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPowerSampleConnection(t *testing.T) {
+	d := newDualSense(&wirelessMockTransport{wireless: true}, []Option{WithManualPump()})
+	if got := d.powerSample(time.Now()).Connection; got != ConnectionBluetooth {
+		t.Fatalf("got connection %q, want %q", got, ConnectionBluetooth)
+	}
+}
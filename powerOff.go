@@ -0,0 +1,41 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PowerOffTransport is implemented by transports that can turn the controller
+// off.
+type PowerOffTransport interface {
+	PowerOff() error
+}
+
+// PowerOff turns the controller off, e.g. at the end of a session, instead of
+// waiting for its idle timeout. The DualSense turns itself off when its
+// Bluetooth link is dropped, so transports implementing only
+// DisconnectTransport, like HIDTransport and HIDRawTransport, are
+// disconnected instead. Otherwise, as over USB, an error wrapping
+// errors.ErrUnsupported is returned.
+func (d *DualSense) PowerOff() error {
+	var err error
+	switch transport := d.currentTransport().(type) {
+	case PowerOffTransport:
+		d.doIO(func(Transport) {
+			err = transport.PowerOff()
+		})
+		if err != nil {
+			return fmt.Errorf("transport.PowerOff: error trying to power off DualSense controller: %w", err)
+		}
+	case DisconnectTransport:
+		d.doIO(func(Transport) {
+			err = transport.Disconnect()
+		})
+		if err != nil {
+			return fmt.Errorf("transport.Disconnect: error trying to power off DualSense controller: %w", err)
+		}
+	default:
+		return fmt.Errorf("error trying to power off DualSense controller: %w", errors.ErrUnsupported)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+type powerOffTransport struct {
+	mockTransport
+	poweredOff bool
+}
+
+func (t *powerOffTransport) PowerOff() error {
+	t.poweredOff = true
+	return nil
+}
+
+// wiredTransport disconnects like HIDRawTransport over USB.
+type wiredTransport struct {
+	mockTransport
+}
+
+func (t *wiredTransport) Disconnect() error {
+	return errors.ErrUnsupported
+}
+
+func TestPowerOff(t *testing.T) {
+	if err := NewMockDualSense().PowerOff(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("got %v, want errors.ErrUnsupported", err)
+	}
+
+	transport := &powerOffTransport{}
+	if err := newDualSense(transport, []Option{WithManualPump()}).PowerOff(); err != nil {
+		t.Fatal(err)
+	}
+	if !transport.poweredOff {
+		t.Fatal("transport was not powered off")
+	}
+
+	disconnecter := &disconnectTransport{}
+	if err := newDualSense(disconnecter, []Option{WithManualPump()}).PowerOff(); err != nil {
+		t.Fatal(err)
+	}
+	if !disconnecter.disconnected {
+		t.Fatal("PowerOff did not fall back to Disconnect")
+	}
+
+	if err := newDualSense(&wiredTransport{}, []Option{WithManualPump()}).PowerOff(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("wired: got %v, want errors.ErrUnsupported", err)
+	}
+}
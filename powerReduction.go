@@ -0,0 +1,114 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// MAX_MOTOR_POWER_REDUCTION is the highest TriggerMotorPowerReduction and
+// RumbleMotorPowerReduction level, each level removing 12.5% of the power.
+const MAX_MOTOR_POWER_REDUCTION = 7
+
+// POWER_REDUCTION_STEP_PERCENT is the motor power removed by each
+// PowerReduction level.
+const POWER_REDUCTION_STEP_PERCENT = 12.5
+
+// PowerReduction is a TriggerMotorPowerReduction or RumbleMotorPowerReduction
+// level from 0, full power, to MAX_MOTOR_POWER_REDUCTION, 12.5% power.
+type PowerReduction uint8
+
+const (
+	PowerReductionNone PowerReduction = 0
+	PowerReductionMax  PowerReduction = MAX_MOTOR_POWER_REDUCTION
+)
+
+// PowerReductionFromPercent returns the level closest to reducing motor
+// power by percent, clamped to the valid levels.
+func PowerReductionFromPercent(percent float64) PowerReduction {
+	level := math.Round(percent / POWER_REDUCTION_STEP_PERCENT)
+	return PowerReduction(min(max(level, 0), MAX_MOTOR_POWER_REDUCTION))
+}
+
+// Percent returns how much of the motor power r removes.
+func (r PowerReduction) Percent() float64 {
+	return float64(r) * POWER_REDUCTION_STEP_PERCENT
+}
+
+// PowerPercent returns how much of the motor power is left at r.
+func (r PowerReduction) PowerPercent() float64 {
+	return 100 - r.Percent()
+}
+
+func (r PowerReduction) String() string {
+	return strconv.FormatFloat(r.Percent(), 'f', -1, 64) + "%"
+}
+
+// Validate returns an error wrapping ErrOutOfRange for levels above
+// MAX_MOTOR_POWER_REDUCTION.
+func (r PowerReduction) Validate() error {
+	if r > PowerReductionMax {
+		return fmt.Errorf("%w: power reduction level %d exceeds %d", ErrOutOfRange, uint8(r), MAX_MOTOR_POWER_REDUCTION)
+	}
+	return nil
+}
+
+func validatePowerReductions(setStateData *SetStateData) error {
+	err := setStateData.TriggerMotorPowerReduction.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid TriggerMotorPowerReduction: %w", err)
+	}
+	err = setStateData.RumbleMotorPowerReduction.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid RumbleMotorPowerReduction: %w", err)
+	}
+	return nil
+}
+
+// motorPowerLimiter raises TriggerMotorPowerReduction and
+// RumbleMotorPowerReduction to the levels required by the thermal, battery
+// and battery saver policies, and puts back the levels set by the application
+// once they no longer apply. Levels the application changes while limited are
+// kept.
+type motorPowerLimiter struct {
+	mu sync.Mutex
+	// thermal and saver limit the rumble motors, battery both motors.
+	thermal PowerReduction
+	battery PowerReduction
+	saver   PowerReduction
+	limited bool
+	// saved and applied hold the trigger and rumble levels set by the
+	// application and the levels last written while limited.
+	saved   [2]PowerReduction
+	applied [2]PowerReduction
+}
+
+// apply writes the limited levels into setStateData. It is meant to run inside
+// UpdateState.
+func (l *motorPowerLimiter) apply(setStateData *SetStateData) {
+	levels := [2]*PowerReduction{&setStateData.TriggerMotorPowerReduction, &setStateData.RumbleMotorPowerReduction}
+	limits := [2]PowerReduction{l.battery, max(l.thermal, l.battery, l.saver)}
+	for i, level := range levels {
+		if !l.limited || *level != l.applied[i] {
+			l.saved[i] = *level
+		}
+		*level = max(l.saved[i], limits[i])
+		l.applied[i] = *level
+	}
+	l.limited = limits != [2]PowerReduction{}
+}
+
+// limitMotorPower updates one policy limit through set and rewrites the motor
+// power reductions.
+func (d *DualSense) limitMotorPower(set func(*motorPowerLimiter)) {
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		d.motorPower.mu.Lock()
+		defer d.motorPower.mu.Unlock()
+		set(&d.motorPower)
+		d.motorPower.apply(setStateData)
+	})
+	if err != nil {
+		d.logger.Debug("could not limit DualSense motor power", "error", err)
+	}
+}
@@ -0,0 +1,41 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPowerReductionPercent(t *testing.T) {
+	if got := PowerReduction(3).Percent(); got != 37.5 {
+		t.Errorf("got %v, want 37.5", got)
+	}
+	if got := PowerReduction(3).String(); got != "37.5%" {
+		t.Errorf("got %q, want 37.5%%", got)
+	}
+	if got := PowerReductionMax.PowerPercent(); got != 12.5 {
+		t.Errorf("got %v, want 12.5", got)
+	}
+	for percent, want := range map[float64]PowerReduction{-10: 0, 0: 0, 30: 2, 50: 4, 100: PowerReductionMax} {
+		if got := PowerReductionFromPercent(percent); got != want {
+			t.Errorf("PowerReductionFromPercent(%v) = %d, want %d", percent, got, want)
+		}
+	}
+}
+
+func TestPowerReductionValidation(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := d.SetTriggerMotorPowerReduction(PowerReductionMax); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetRumbleMotorPowerReduction(8); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got %v, want ErrOutOfRange", err)
+	}
+	if got := d.GetOutStateData(); got.TriggerMotorPowerReduction != PowerReductionMax || got.RumbleMotorPowerReduction != 0 {
+		t.Fatalf("got %+v", got)
+	}
+}
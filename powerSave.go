@@ -0,0 +1,113 @@
+package dualsense
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PowerSave is a set of subsystems to put into power save mode.
+type PowerSave uint8
+
+const (
+	PowerSaveTouch PowerSave = 1 << iota
+	PowerSaveMotion
+	PowerSaveHaptic
+	PowerSaveAudio
+
+	PowerSaveNone PowerSave = 0
+	PowerSaveAll            = PowerSaveTouch | PowerSaveMotion | PowerSaveHaptic | PowerSaveAudio
+)
+
+// PowerSavePolicy puts subsystems into power save mode while the battery is
+// low or the controller is idle (see IdlePolicy). Subsystems are taken out of
+// power save mode again once neither condition holds, unless they were
+// already in it before the policy applied.
+type PowerSavePolicy struct {
+	// LowBatteryPercent applies LowBattery while discharging below this
+	// percentage; 0 disables it.
+	LowBatteryPercent uint8
+	LowBattery        PowerSave
+	Idle              PowerSave
+}
+
+type powerSaveManager struct {
+	mu     sync.Mutex
+	policy PowerSavePolicy
+	wanted PowerSave
+	// added holds the subsystems the policy put into power save mode.
+	added PowerSave
+}
+
+func (p PowerSave) apply(setStateData *SetStateData) {
+	setStateData.TouchPowerSave = p&PowerSaveTouch != 0
+	setStateData.MotionPowerSave = p&PowerSaveMotion != 0
+	setStateData.HapticPowerSave = p&PowerSaveHaptic != 0
+	setStateData.AudioPowerSave = p&PowerSaveAudio != 0
+}
+
+func powerSaveOf(setStateData *SetStateData) PowerSave {
+	var p PowerSave
+	if setStateData.TouchPowerSave {
+		p |= PowerSaveTouch
+	}
+	if setStateData.MotionPowerSave {
+		p |= PowerSaveMotion
+	}
+	if setStateData.HapticPowerSave {
+		p |= PowerSaveHaptic
+	}
+	if setStateData.AudioPowerSave {
+		p |= PowerSaveAudio
+	}
+	return p
+}
+
+// SetPowerSave sets TouchPowerSave, MotionPowerSave, HapticPowerSave and
+// AudioPowerSave at once, in a single output report.
+func (d *DualSense) SetPowerSave(powerSave PowerSave) error {
+	err := d.UpdateState(powerSave.apply)
+	if err != nil {
+		return fmt.Errorf("error updating power save in setStateData: %w", err)
+	}
+	return nil
+}
+
+// PowerSave returns the subsystems currently in power save mode.
+func (d *DualSense) PowerSave() PowerSave {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	return powerSaveOf(&d.setStateData)
+}
+
+// SetPowerSavePolicy configures automatic power saving, see PowerSavePolicy.
+func (d *DualSense) SetPowerSavePolicy(policy PowerSavePolicy) {
+	d.powerSave.mu.Lock()
+	defer d.powerSave.mu.Unlock()
+	d.powerSave.policy = policy
+}
+
+// processPowerSave applies the power save policy for the latest report.
+func (d *DualSense) processPowerSave(state *USBGetStateData) {
+	d.powerSave.mu.Lock()
+	defer d.powerSave.mu.Unlock()
+	policy := d.powerSave.policy
+	var want PowerSave
+	if policy.LowBatteryPercent > 0 && dischargingBelow(state, policy.LowBatteryPercent) {
+		want |= policy.LowBattery
+	}
+	if d.Idle() {
+		want |= policy.Idle
+	}
+	if want == d.powerSave.wanted {
+		return
+	}
+	d.powerSave.wanted = want
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		kept := powerSaveOf(setStateData) &^ d.powerSave.added
+		d.powerSave.added = want &^ kept
+		(kept | want).apply(setStateData)
+	})
+	if err != nil {
+		d.logger.Debug("could not apply DualSense power save policy", "error", err)
+	}
+}
@@ -0,0 +1,45 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPowerSavePolicy(t *testing.T) {
+	mock := NewMockDualSense()
+	if err := mock.SetPowerSave(PowerSaveTouch); err != nil {
+		t.Fatal(err)
+	}
+	mock.SetIdlePolicy(IdlePolicy{Timeout: time.Nanosecond})
+	mock.SetPowerSavePolicy(PowerSavePolicy{
+		LowBatteryPercent: 20,
+		LowBattery:        PowerSaveMotion,
+		Idle:              PowerSaveAll,
+	})
+
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionNone
+		state.PowerState = PowerStateDischarging
+		state.PowerPercent = 1
+	})
+	if got := mock.PowerSave(); got != PowerSaveTouch|PowerSaveMotion {
+		t.Fatalf("got %04b on low battery, want touch and motion", got)
+	}
+	time.Sleep(time.Millisecond)
+	mock.UpdateInState(func(state *USBGetStateData) {})
+	if got := mock.PowerSave(); got != PowerSaveAll {
+		t.Fatalf("got %04b while idle, want all", got)
+	}
+	mock.UpdateInState(func(state *USBGetStateData) {
+		state.ButtonCross = true
+		state.PowerState = PowerStateCharging
+	})
+	// Touch was in power save mode before the policy applied.
+	if got := mock.PowerSave(); got != PowerSaveTouch {
+		t.Fatalf("got %04b after recovering, want touch", got)
+	}
+	out := mock.GetOutStateData()
+	if !out.TouchPowerSave || out.MotionPowerSave || out.HapticPowerSave || out.AudioPowerSave {
+		t.Fatalf("got %+v", out)
+	}
+}
@@ -0,0 +1,70 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+const DEFAULT_POWER_SETTLE_TIME = 500 * time.Millisecond
+
+// PowerSource is whether USB power is present and the charging state.
+type PowerSource struct {
+	UsbPower   bool
+	PowerState PowerState
+}
+
+// PowerSourceChange is delivered once a new PowerSource has held for the
+// settle time.
+type PowerSourceChange struct {
+	Old PowerSource
+	New PowerSource
+}
+
+// powerDebouncer ignores power source flaps, e.g. from a wiggled cable,
+// shorter than settleTime. It is driven by input reports, so a candidate
+// settles on the first report after settleTime has passed.
+type powerDebouncer struct {
+	mu             sync.Mutex
+	settleTime     time.Duration
+	initialized    bool
+	stable         PowerSource
+	candidate      PowerSource
+	candidateSince time.Time
+}
+
+func (p *powerDebouncer) setSettleTime(settleTime time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.settleTime = settleTime
+}
+
+func (p *powerDebouncer) record(source PowerSource, now time.Time) (PowerSourceChange, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.initialized {
+		p.initialized = true
+		p.stable, p.candidate, p.candidateSince = source, source, now
+		return PowerSourceChange{}, false
+	}
+	if source != p.candidate {
+		p.candidate, p.candidateSince = source, now
+	}
+	if p.candidate == p.stable || now.Sub(p.candidateSince) < p.settleTime {
+		return PowerSourceChange{}, false
+	}
+	change := PowerSourceChange{Old: p.stable, New: p.candidate}
+	p.stable = p.candidate
+	return change, true
+}
+
+// SetPowerSettleTime sets how long a power source change must hold before
+// OnPowerSourceChange fires, DEFAULT_POWER_SETTLE_TIME by default.
+func (d *DualSense) SetPowerSettleTime(settleTime time.Duration) {
+	d.powerSource.setSettleTime(settleTime)
+}
+
+// OnPowerSourceChange is called when USB power is connected or removed or the
+// charging state changes, after the change has settled.
+func (d *DualSense) OnPowerSourceChange(callback func(PowerSourceChange)) {
+	d.callbacks.OnPowerSourceChange = append(d.callbacks.OnPowerSourceChange, callback)
+}
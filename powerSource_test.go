@@ -0,0 +1,34 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPowerDebouncer(t *testing.T) {
+	p := powerDebouncer{settleTime: 500 * time.Millisecond}
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	discharging := PowerSource{PowerState: PowerStateDischarging}
+	charging := PowerSource{UsbPower: true, PowerState: PowerStateCharging}
+
+	if _, ok := p.record(discharging, at(0)); ok {
+		t.Fatal("first report produced a change")
+	}
+	// A flap shorter than the settle time is ignored.
+	for i, source := range []PowerSource{charging, discharging, charging, discharging} {
+		if _, ok := p.record(source, at(100*(i+1))); ok {
+			t.Fatalf("flap %d produced a change", i)
+		}
+	}
+	if _, ok := p.record(charging, at(1000)); ok {
+		t.Fatal("change reported before settling")
+	}
+	change, ok := p.record(charging, at(1500))
+	if !ok || change.Old != discharging || change.New != charging {
+		t.Fatalf("got %+v, %v, want change to charging", change, ok)
+	}
+	if _, ok := p.record(charging, at(2000)); ok {
+		t.Fatal("settled change reported twice")
+	}
+}
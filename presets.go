@@ -0,0 +1,83 @@
+package dualsense
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	PresetDefault         = "Default"
+	PresetSilent          = "Silent"
+	PresetMaximumFeedback = "MaximumFeedback"
+	PresetLedsOff         = "LedsOff"
+)
+
+var presets = map[string]func() SetStateData{
+	PresetDefault: func() SetStateData {
+		return defaultSetStateData
+	},
+	// Haptics and speaker muted, rumble and trigger effects off.
+	PresetSilent: func() SetStateData {
+		setStateData := defaultSetStateData
+		setStateData.HapticMute = true
+		setStateData.SpeakerMute = true
+		setStateData.VolumeSpeaker = 0x00
+		setStateData.RumbleEmulationLeft = 0x00
+		setStateData.RumbleEmulationRight = 0x00
+		setStateData.RightTriggerFFB = GenerateTriggerFFBParams(EffectTypeOff, 0x00, 0x00, 0x00)
+		setStateData.LeftTriggerFFB = GenerateTriggerFFBParams(EffectTypeOff, 0x00, 0x00, 0x00)
+		return setStateData
+	},
+	// Full motor power, strongest trigger resistance over the whole travel and
+	// the haptic low pass filter disabled.
+	PresetMaximumFeedback: func() SetStateData {
+		setStateData := defaultSetStateData
+		setStateData.TriggerMotorPowerReduction = 0x00
+		setStateData.RumbleMotorPowerReduction = 0x00
+		setStateData.HapticLowPassFilter = false
+		setStateData.RightTriggerFFB = GenerateTriggerFFBParams(EffectTypeFeedback, 0x00, 0xFF, 0xFF)
+		setStateData.LeftTriggerFFB = GenerateTriggerFFBParams(EffectTypeFeedback, 0x00, 0xFF, 0xFF)
+		return setStateData
+	},
+	// Lightbar, player indicators and mute light off.
+	PresetLedsOff: func() SetStateData {
+		setStateData := defaultSetStateData
+		setStateData.LedRed = 0x00
+		setStateData.LedGreen = 0x00
+		setStateData.LedBlue = 0x00
+		setStateData.PlayerLight1 = false
+		setStateData.PlayerLight2 = false
+		setStateData.PlayerLight3 = false
+		setStateData.PlayerLight4 = false
+		setStateData.PlayerLight5 = false
+		setStateData.MuteLight = MuteLightModeOff
+		return setStateData
+	},
+}
+
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Preset returns a built-in output configuration as a Profile, which can be
+// applied as is or used as a starting point.
+func Preset(name string) (Profile, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown preset %q, expected one of %v", name, PresetNames())
+	}
+	return Profile{Name: name, SetStateData: preset()}, nil
+}
+
+func (d *DualSense) ApplyPreset(name string) error {
+	profile, err := Preset(name)
+	if err != nil {
+		return err
+	}
+	return d.ApplyProfile(profile)
+}
@@ -0,0 +1,47 @@
+package dualsense
+
+import "testing"
+
+func TestPresets(t *testing.T) {
+	for _, name := range PresetNames() {
+		profile, err := Preset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile.Name != name {
+			t.Errorf("preset %s named %q", name, profile.Name)
+		}
+		if _, err := MarshalOutputReport(profile.SetStateData); err != nil {
+			t.Errorf("preset %s does not marshal: %v", name, err)
+		}
+	}
+	if _, err := Preset("Loud"); err == nil {
+		t.Fatal("unknown preset returned no error")
+	}
+
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.SetLedColor(LedColor{Red: 0xFF}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ApplyPreset(PresetLedsOff); err != nil {
+		t.Fatal(err)
+	}
+	out := d.GetOutStateData()
+	if ledColorOf(&out) != (LedColor{}) || playerLightsOf(&out) != [5]bool{} || out.MuteLight != MuteLightModeOff {
+		t.Fatalf("LedsOff left lights on: %+v", out)
+	}
+	if err := d.ApplyPreset(PresetSilent); err != nil {
+		t.Fatal(err)
+	}
+	out = d.GetOutStateData()
+	if !out.HapticMute || !out.SpeakerMute || out.RumbleEmulationLeft != 0 || out.RumbleEmulationRight != 0 {
+		t.Fatalf("Silent left output audible: %+v", out)
+	}
+	if err := d.ApplyPreset("Loud"); err == nil {
+		t.Fatal("ApplyPreset accepted an unknown preset")
+	}
+}
@@ -0,0 +1,60 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Profile is a named output configuration that can be kept on disk and applied
+// to a controller on demand. Paddles carries the Edge paddle bindings for a
+// GamepadMapping along with it.
+type Profile struct {
+	Name         string        `json:"name"`
+	SetStateData SetStateData  `json:"setStateData"`
+	Paddles      PaddleMapping `json:"paddles"`
+}
+
+func SaveProfile(path string, profile Profile) error {
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: error trying to encode profile %q: %w", profile.Name, err)
+	}
+	err = os.WriteFile(path, append(data, '\n'), 0o644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: error trying to save profile %q: %w", profile.Name, err)
+	}
+	return nil
+}
+
+// LoadProfile reads a profile saved by SaveProfile. Fields missing from the file
+// keep their values from the default output state.
+func LoadProfile(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("os.ReadFile: error trying to load profile: %w", err)
+	}
+	profile := Profile{SetStateData: defaultSetStateData}
+	err = json.Unmarshal(data, &profile)
+	if err != nil {
+		return Profile{}, fmt.Errorf("json.Unmarshal: error trying to decode profile %s: %w", path, err)
+	}
+	err = profile.Paddles.Validate()
+	if err != nil {
+		return Profile{}, fmt.Errorf("error trying to load profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+func (d *DualSense) ApplyProfile(profile Profile) error {
+	err := d.SetStateData(profile.SetStateData)
+	if err != nil {
+		return fmt.Errorf("error applying profile %q: %w", profile.Name, err)
+	}
+	return nil
+}
+
+// SaveCurrentProfile stores the current output state as a named profile.
+func (d *DualSense) SaveCurrentProfile(path, name string) error {
+	return SaveProfile(path, Profile{Name: name, SetStateData: d.GetOutStateData()})
+}
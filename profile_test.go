@@ -0,0 +1,40 @@
+package dualsense
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "red.json")
+	profile := Profile{Name: "red", SetStateData: defaultSetStateData}
+	profile.SetStateData.LedRed, profile.SetStateData.LedGreen, profile.SetStateData.LedBlue = 0xFF, 0, 0
+	profile.SetStateData.MuteLight = MuteLightModeBreathing
+	if err := SaveProfile(path, profile); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != profile {
+		t.Fatalf("got %+v, want %+v", loaded, profile)
+	}
+}
+
+func TestLoadPartialProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.json")
+	if err := os.WriteFile(path, []byte(`{"name":"blue","setStateData":{"ledRed":0,"ledGreen":0}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := defaultSetStateData
+	want.LedRed, want.LedGreen = 0, 0
+	if loaded.SetStateData != want {
+		t.Fatalf("got %+v, want %+v", loaded.SetStateData, want)
+	}
+}
@@ -0,0 +1,61 @@
+package dualsense
+
+import "fmt"
+
+// WriteRaw writes data to the controller as-is. The first byte must be the
+// report ID. Writes are serialized with regular output reports, but the raw
+// report does not change the state returned by GetOutStateData.
+func (d *DualSense) WriteRaw(data []byte) (int, error) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	err := d.checkOpen("WriteRaw")
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	d.doIO(func(transport Transport) {
+		n, err = transport.Write(data)
+	})
+	if err != nil {
+		return n, fmt.Errorf("transport.Write: error trying to write raw DualSense controller report: %w", err)
+	}
+	return n, nil
+}
+
+// SendFeatureReport sends a feature report. The first byte must be the report
+// ID.
+func (d *DualSense) SendFeatureReport(data []byte) (int, error) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	err := d.checkOpen("SendFeatureReport")
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	d.doIO(func(transport Transport) {
+		n, err = transport.SendFeature(data)
+	})
+	if err != nil {
+		return n, fmt.Errorf("transport.SendFeature: error trying to send DualSense controller feature report: %w", err)
+	}
+	return n, nil
+}
+
+// GetFeatureReport reads the feature report whose ID is set in the first byte
+// of data into data.
+func (d *DualSense) GetFeatureReport(data []byte) (int, error) {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	err := d.checkOpen("GetFeatureReport")
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	d.doIO(func(transport Transport) {
+		n, err = transport.GetFeature(data)
+	})
+	if err != nil {
+		return n, fmt.Errorf("transport.GetFeature: error trying to get DualSense controller feature report: %w", err)
+	}
+	return n, nil
+}
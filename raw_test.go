@@ -0,0 +1,50 @@
+package dualsense
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRawReports(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetLedRed(0x40); err != nil {
+		t.Fatal(err)
+	}
+	raw := []byte{0x80, 1, 2, 3}
+	if n, err := d.WriteRaw(raw); err != nil || n != len(raw) {
+		t.Fatalf("WriteRaw returned %d, %v", n, err)
+	}
+	reports := d.OutputReports()
+	if !bytes.Equal(reports[len(reports)-1], raw) {
+		t.Fatalf("got last write % x, want % x", reports[len(reports)-1], raw)
+	}
+	if got := d.GetOutStateData(); got.LedRed != 0x40 {
+		t.Fatalf("WriteRaw changed the output state: LedRed %d", got.LedRed)
+	}
+
+	feature := []byte{0x81, 4, 5}
+	if _, err := d.SendFeatureReport(feature); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, 8)
+	got[0] = 0x81
+	n, err := d.GetFeatureReport(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[:n], feature) {
+		t.Fatalf("got feature report % x, want % x", got[:n], feature)
+	}
+
+	d.Close()
+	if _, err := d.WriteRaw(raw); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("WriteRaw after Close returned %v, want ErrInvalidLifecycleState", err)
+	}
+	if _, err := d.SendFeatureReport(feature); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Fatalf("SendFeatureReport after Close returned %v, want ErrInvalidLifecycleState", err)
+	}
+}
@@ -0,0 +1,97 @@
+package dualsense
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ReconnectPolicy decides whether and when a disconnected controller is
+// reopened, see WithReconnectPolicy. Attempts are numbered from 1 and err is
+// why the previous attempt failed, ErrDisconnected before the first one.
+type ReconnectPolicy interface {
+	ShouldRetry(attempt int, err error) bool
+	NextDelay(attempt int) time.Duration
+}
+
+// BackoffReconnectPolicy waits InitialDelay before the first attempt and
+// doubles the delay after every failed one, capped at MaxDelay when set. Jitter
+// shortens every delay by a random fraction of up to Jitter, between 0 and 1,
+// so controllers dropped together do not reconnect in lockstep. MaxAttempts of
+// 0 retries forever.
+type BackoffReconnectPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       float64
+}
+
+func (p BackoffReconnectPolicy) ShouldRetry(attempt int, err error) bool {
+	return p.MaxAttempts == 0 || attempt <= p.MaxAttempts
+}
+
+func (p BackoffReconnectPolicy) NextDelay(attempt int) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64 / 2
+	}
+	delay := p.InitialDelay
+	for i := 1; i < attempt && delay > 0 && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(p.Jitter, 1) * float64(delay))
+	}
+	return delay
+}
+
+// canReconnect reports whether the I/O goroutine should try to reopen a
+// disconnected controller rather than return.
+func (d *DualSense) canReconnect() bool {
+	return d.reconnectPolicy != nil && d.openTransport != nil
+}
+
+// nextReconnect returns when to make the given reconnect attempt, or nil once
+// the policy gives up.
+func (d *DualSense) nextReconnect(attempt int, err error) <-chan time.Time {
+	if !d.reconnectPolicy.ShouldRetry(attempt, err) {
+		d.logger.Warn("giving up reconnecting DualSense controller", "attempts", attempt-1, "error", err)
+		return nil
+	}
+	delay := d.reconnectPolicy.NextDelay(attempt)
+	d.logger.Debug("reconnecting DualSense controller", "attempt", attempt, "delay", delay)
+	return time.After(delay)
+}
+
+// reconnect reopens the controller in place of the disconnected transport. It
+// runs on the I/O goroutine.
+func (d *DualSense) reconnect() error {
+	transport, err := d.openTransport()
+	if err != nil {
+		return fmt.Errorf("error trying to reopen DualSense controller: %w", err)
+	}
+	layout, err := d.readReportLayout(transport)
+	if err != nil {
+		transport.Close()
+		return err
+	}
+	d.transportMu.Lock()
+	disconnected := d.transport
+	d.transport = transport
+	d.reportLayout = layout
+	d.readBuffer = make([]byte, layout.Input[inputReportID(transport)])
+	d.transportMu.Unlock()
+	disconnected.Close()
+	return nil
+}
+
+// identifyReconnected looks up the label and white point of a reopened
+// controller, which may be a different pad. It needs the I/O goroutine to
+// serve its feature report reads.
+func (d *DualSense) identifyReconnected() {
+	d.enableBluetoothReports()
+	d.applyLabels()
+	d.applyWhitePoints()
+}
@@ -0,0 +1,152 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoffReconnectPolicy(t *testing.T) {
+	policy := BackoffReconnectPolicy{MaxAttempts: 3, InitialDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 50: 300 * time.Millisecond} {
+		if got := policy.NextDelay(attempt); got != want {
+			t.Errorf("NextDelay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	if !policy.ShouldRetry(3, ErrDisconnected) || policy.ShouldRetry(4, ErrDisconnected) {
+		t.Error("ShouldRetry does not stop after MaxAttempts")
+	}
+	policy.Jitter = 0.5
+	for range 100 {
+		if delay := policy.NextDelay(1); delay < 50*time.Millisecond || delay > 100*time.Millisecond {
+			t.Fatalf("jittered delay %v out of range", delay)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	d := newDualSense(&unpluggedTransport{}, []Option{
+		WithReconnectPolicy(BackoffReconnectPolicy{InitialDelay: time.Millisecond}),
+		WithLabels(Labels{"a0:b1:c2:d3:e4:f5": "P2-blue"}),
+	})
+	// The reopened pad is a different one, with its own label.
+	pairingInfo := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairingInfo[0] = PAIRING_INFO_REPORT_ID
+	copy(pairingInfo[1:], []byte{0xF5, 0xE4, 0xD3, 0xC2, 0xB1, 0xA0})
+	reopened := &mockTransport{featureReports: map[uint8][]byte{PAIRING_INFO_REPORT_ID: pairingInfo}}
+	opens := 0
+	d.openTransport = func() (Transport, error) {
+		opens++
+		if opens == 1 {
+			return nil, errors.New("not plugged in yet")
+		}
+		return reopened, nil
+	}
+	changes := make(chan LifecycleChange, 4)
+	d.OnLifecycleChange(func(change LifecycleChange) {
+		changes <- change
+	})
+	initial := defaultSetStateData
+	initial.LedBlue = 0x80
+	if err := d.Start(&initial); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	for _, want := range []LifecycleChange{
+		{LifecycleCreated, LifecycleStarted},
+		{LifecycleStarted, LifecycleDisconnected},
+		{LifecycleDisconnected, LifecycleStarted},
+	} {
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no lifecycle change to %s", want.New)
+		}
+	}
+	if opens != 2 {
+		t.Fatalf("got %d opens, want 2", opens)
+	}
+
+	var restoredReport []byte
+	for deadline := time.Now().Add(time.Second); restoredReport == nil; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("output state was not restored")
+		}
+		reopened.mu.Lock()
+		if len(reopened.outputReports) > 0 {
+			restoredReport = reopened.outputReports[0]
+		}
+		reopened.mu.Unlock()
+	}
+	if label := d.Label(); label != "P2-blue" {
+		t.Fatalf("got label %q, want P2-blue", label)
+	}
+	restored, err := UnmarshalOutputReport(restoredReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.LedBlue != 0x80 {
+		t.Fatalf("got LedBlue %#x, want 0x80", restored.LedBlue)
+	}
+}
+
+func TestReconnectGivesUp(t *testing.T) {
+	d := newDualSense(&unpluggedTransport{}, []Option{WithReconnectPolicy(BackoffReconnectPolicy{MaxAttempts: 2})})
+	opens := 0
+	d.openTransport = func() (Transport, error) {
+		opens++
+		return nil, errors.New("gone for good")
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-d.io.Load().done:
+	case <-time.After(time.Second):
+		t.Fatal("I/O goroutine did not give up")
+	}
+	if opens != 2 {
+		t.Fatalf("got %d opens, want 2", opens)
+	}
+	if state := d.LifecycleState(); state != LifecycleDisconnected {
+		t.Fatalf("got %s, want Disconnected", state)
+	}
+	d.Close()
+}
+
+func TestReconnectLifecycleCallbackSetter(t *testing.T) {
+	d := newDualSense(&unpluggedTransport{}, []Option{WithReconnectPolicy(BackoffReconnectPolicy{InitialDelay: time.Millisecond})})
+	reopened := &mockTransport{}
+	d.openTransport = func() (Transport, error) {
+		return reopened, nil
+	}
+	set := make(chan error, 1)
+	d.OnLifecycleChange(func(change LifecycleChange) {
+		if change.Old == LifecycleDisconnected && change.New == LifecycleStarted {
+			set <- d.SetLedRed(0x11)
+		}
+	})
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	select {
+	case err := <-set:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("setter in OnLifecycleChange blocked during reconnect")
+	}
+	reopened.mu.Lock()
+	defer reopened.mu.Unlock()
+	if len(reopened.outputReports) == 0 {
+		t.Fatal("setter wrote nothing to the reopened controller")
+	}
+	if out, err := UnmarshalOutputReport(reopened.outputReports[0]); err != nil || out.LedRed != 0x11 {
+		t.Fatalf("got %+v, %v, want LedRed 0x11", out, err)
+	}
+}
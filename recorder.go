@@ -0,0 +1,169 @@
+package dualsense
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Recordings start with RECORDING_MAGIC, followed by one record per input
+// report: the wall-clock time in Unix nanoseconds (int64), the report length
+// (uint16), both little endian, and the raw report bytes including the report
+// ID.
+const RECORDING_MAGIC = "DSREC\x01"
+
+type RecordedReport struct {
+	Time time.Time
+	Data []byte
+}
+
+// Recorder writes input reports to a recording. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	writer  *bufio.Writer
+	closer  io.Closer
+	closed  bool
+	err     error
+	scratch [10]byte
+}
+
+func NewRecorder(w io.Writer) (*Recorder, error) {
+	writer := bufio.NewWriter(w)
+	_, err := writer.WriteString(RECORDING_MAGIC)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to write recording header: %w", err)
+	}
+	return &Recorder{writer: writer}, nil
+}
+
+func CreateRecording(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Create: error trying to create recording: %w", err)
+	}
+	recorder, err := NewRecorder(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	recorder.closer = file
+	return recorder, nil
+}
+
+// Record appends a single report. The first error is sticky and returned by
+// every later call, including Close.
+func (r *Recorder) Record(timestamp time.Time, reportID uint8, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errors.New("recorder is closed")
+	}
+	if r.err != nil {
+		return r.err
+	}
+	binary.LittleEndian.PutUint64(r.scratch[0:8], uint64(timestamp.UnixNano()))
+	binary.LittleEndian.PutUint16(r.scratch[8:10], uint16(len(data)+1))
+	r.writer.Write(r.scratch[:])
+	r.writer.WriteByte(reportID)
+	_, err := r.writer.Write(data)
+	if err != nil {
+		r.err = fmt.Errorf("error trying to write recorded report: %w", err)
+	}
+	return r.err
+}
+
+// Attach records every input report read by d until the recorder is closed.
+func (r *Recorder) Attach(d *DualSense) {
+	d.OnRawReport(func(reportID uint8, data []byte) {
+		r.Record(time.Now(), reportID, data)
+	})
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+	err := r.writer.Flush()
+	if r.err == nil && err != nil {
+		r.err = fmt.Errorf("error trying to flush recording: %w", err)
+	}
+	if r.closer != nil {
+		err = r.closer.Close()
+		if r.err == nil && err != nil {
+			r.err = fmt.Errorf("error trying to close recording: %w", err)
+		}
+	}
+	return r.err
+}
+
+// RecordingReader reads the reports of a recording in order.
+type RecordingReader struct {
+	reader *bufio.Reader
+}
+
+func NewRecordingReader(r io.Reader) (*RecordingReader, error) {
+	reader := bufio.NewReader(r)
+	magic := make([]byte, len(RECORDING_MAGIC))
+	_, err := io.ReadFull(reader, magic)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to read recording header: %w", err)
+	}
+	if !bytes.Equal(magic, []byte(RECORDING_MAGIC)) {
+		return nil, errors.New("not a DualSense recording")
+	}
+	return &RecordingReader{reader: reader}, nil
+}
+
+// Next returns the next report, or io.EOF at the end of the recording.
+func (r *RecordingReader) Next() (RecordedReport, error) {
+	var header [10]byte
+	_, err := io.ReadFull(r.reader, header[:])
+	if err == io.EOF {
+		return RecordedReport{}, io.EOF
+	}
+	if err != nil {
+		return RecordedReport{}, fmt.Errorf("error trying to read recorded report header: %w", err)
+	}
+	data := make([]byte, binary.LittleEndian.Uint16(header[8:10]))
+	_, err = io.ReadFull(r.reader, data)
+	if err != nil {
+		return RecordedReport{}, fmt.Errorf("error trying to read recorded report: %w", err)
+	}
+	return RecordedReport{
+		Time: time.Unix(0, int64(binary.LittleEndian.Uint64(header[0:8]))),
+		Data: data,
+	}, nil
+}
+
+// ReadRecording loads every report of the recording at path.
+func ReadRecording(path string) ([]RecordedReport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: error trying to open recording: %w", err)
+	}
+	defer file.Close()
+	reader, err := NewRecordingReader(file)
+	if err != nil {
+		return nil, err
+	}
+	var reports []RecordedReport
+	for {
+		report, err := reader.Next()
+		if err == io.EOF {
+			return reports, nil
+		}
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+}
@@ -0,0 +1,42 @@
+package dualsense
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorderRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.dsrec")
+	recorder, err := CreateRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(1700000000, 123)
+	payload := make([]byte, USB_PACKET_SIZE-1)
+	payload[0] = 0x80
+	if err := recorder.Record(start, 0x01, payload); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Record(start.Add(4*time.Millisecond), 0x01, payload[:3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := ReadRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+	if !reports[0].Time.Equal(start) || len(reports[0].Data) != USB_PACKET_SIZE || reports[0].Data[0] != 0x01 || reports[0].Data[1] != 0x80 {
+		t.Fatalf("unexpected first report: %+v", reports[0])
+	}
+	if !bytes.Equal(reports[1].Data, []byte{0x01, 0x80, 0x00, 0x00}) {
+		t.Fatalf("unexpected second report: %v", reports[1].Data)
+	}
+}
@@ -0,0 +1,174 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MAX_REPORT_DESCRIPTOR_SIZE is the largest HID report descriptor, as in
+// HID_MAX_DESCRIPTOR_SIZE of the Linux kernel.
+const MAX_REPORT_DESCRIPTOR_SIZE = 4096
+
+// ReportDescriptorTransport is implemented by transports that can read the HID
+// report descriptor of the controller. DualSense uses it at open time to
+// confirm the report layout instead of assuming the USB one.
+type ReportDescriptorTransport interface {
+	ReportDescriptor() ([]byte, error)
+}
+
+// ReportLayout holds the size in bytes of every report declared by a HID
+// report descriptor, keyed by report ID. Sizes include the report ID byte
+// when the descriptor uses report IDs; otherwise the single report has ID 0.
+type ReportLayout struct {
+	Input   map[uint8]int
+	Output  map[uint8]int
+	Feature map[uint8]int
+}
+
+// defaultReportLayout is the USB layout assumed when the transport cannot
+// provide a report descriptor.
+var defaultReportLayout = ReportLayout{
+	Input:  map[uint8]int{0x01: USB_PACKET_SIZE},
+	Output: map[uint8]int{0x02: USB_OUTPUT_REPORT_SIZE},
+}
+
+type reportDescriptorGlobals struct {
+	reportSize  uint32
+	reportCount uint32
+	reportID    uint8
+}
+
+// ParseReportDescriptor sums the Input, Output and Feature main items of a HID
+// report descriptor into a ReportLayout.
+func ParseReportDescriptor(descriptor []byte) (ReportLayout, error) {
+	bits := [3]map[uint8]uint32{{}, {}, {}}
+	var globals reportDescriptorGlobals
+	var stack []reportDescriptorGlobals
+	usesReportIDs := false
+	for i := 0; i < len(descriptor); {
+		prefix := descriptor[i]
+		if prefix == 0xFE {
+			// Long item: size, tag and data, never used for reports.
+			if i+1 >= len(descriptor) {
+				return ReportLayout{}, errors.New("truncated long item")
+			}
+			i += 3 + int(descriptor[i+1])
+			continue
+		}
+		size := int(prefix & 0x03)
+		if size == 3 {
+			size = 4
+		}
+		if i+1+size > len(descriptor) {
+			return ReportLayout{}, fmt.Errorf("truncated item at offset %d", i)
+		}
+		var data uint32
+		for j := 0; j < size; j++ {
+			data |= uint32(descriptor[i+1+j]) << (8 * j)
+		}
+		i += 1 + size
+
+		itemType := (prefix >> 2) & 0x03
+		tag := prefix >> 4
+		switch {
+		case itemType == 0 && tag == 0x08: // Input
+			bits[0][globals.reportID] += globals.reportSize * globals.reportCount
+		case itemType == 0 && tag == 0x09: // Output
+			bits[1][globals.reportID] += globals.reportSize * globals.reportCount
+		case itemType == 0 && tag == 0x0B: // Feature
+			bits[2][globals.reportID] += globals.reportSize * globals.reportCount
+		case itemType == 1 && tag == 0x07: // Report Size
+			globals.reportSize = data
+		case itemType == 1 && tag == 0x08: // Report ID
+			if data == 0 || data > 0xFF {
+				return ReportLayout{}, fmt.Errorf("invalid report ID %d", data)
+			}
+			globals.reportID = uint8(data)
+			usesReportIDs = true
+		case itemType == 1 && tag == 0x09: // Report Count
+			globals.reportCount = data
+		case itemType == 1 && tag == 0x0A: // Push
+			stack = append(stack, globals)
+		case itemType == 1 && tag == 0x0B: // Pop
+			if len(stack) == 0 {
+				return ReportLayout{}, errors.New("pop without push")
+			}
+			globals = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	sizes := [3]map[uint8]int{{}, {}, {}}
+	for kind := range bits {
+		for reportID, reportBits := range bits[kind] {
+			size := int((reportBits + 7) / 8)
+			if usesReportIDs {
+				size++
+			}
+			sizes[kind][reportID] = size
+		}
+	}
+	return ReportLayout{Input: sizes[0], Output: sizes[1], Feature: sizes[2]}, nil
+}
+
+// detectReportLayout reads the report descriptor, when the transport provides
+// one, and checks that it declares the reports DualSense relies on. Larger
+// reports are accepted: input reports are parsed from their first
+// USB_PACKET_SIZE bytes and output reports are padded with zeros.
+func (d *DualSense) detectReportLayout() error {
+	layout, err := d.readReportLayout(d.transport)
+	if err != nil {
+		return err
+	}
+	d.reportLayout = layout
+	d.readBuffer = make([]byte, layout.Input[inputReportID(d.transport)])
+	return nil
+}
+
+// inputReportID returns the ID of the input reports DualSense reads from
+// transport, BT_INPUT_REPORT_ID over Bluetooth and 0x01 otherwise.
+func inputReportID(transport Transport) uint8 {
+	if wireless, ok := transport.(WirelessTransport); ok && wireless.Wireless() {
+		return BT_INPUT_REPORT_ID
+	}
+	return 0x01
+}
+
+// readReportLayout returns the report layout of transport, see
+// detectReportLayout.
+func (d *DualSense) readReportLayout(transport Transport) (ReportLayout, error) {
+	inputID, inputSize, outputID, outputSize := uint8(0x01), USB_PACKET_SIZE, uint8(0x02), USB_OUTPUT_REPORT_SIZE
+	defaultLayout := defaultReportLayout
+	if inputReportID(transport) == BT_INPUT_REPORT_ID {
+		inputID, inputSize, outputID, outputSize = BT_INPUT_REPORT_ID, BT_INPUT_REPORT_SIZE, BT_OUTPUT_REPORT_ID, BT_OUTPUT_REPORT_SIZE
+		defaultLayout = defaultBluetoothReportLayout
+	}
+	descriptorTransport, ok := transport.(ReportDescriptorTransport)
+	if !ok {
+		return defaultLayout, nil
+	}
+	descriptor, err := descriptorTransport.ReportDescriptor()
+	if err != nil {
+		d.logger.Debug("could not read DualSense report descriptor, assuming the default layout", "error", err)
+		return defaultLayout, nil
+	}
+	layout, err := ParseReportDescriptor(descriptor)
+	if err != nil {
+		return ReportLayout{}, fmt.Errorf("ParseReportDescriptor: error trying to parse DualSense report descriptor: %w", err)
+	}
+	if size := layout.Input[inputID]; size < inputSize {
+		return ReportLayout{}, fmt.Errorf("unsupported DualSense report layout: input report 0x%02x has %d bytes, need at least %d", inputID, size, inputSize)
+	}
+	if size := layout.Output[outputID]; size < outputSize {
+		return ReportLayout{}, fmt.Errorf("unsupported DualSense report layout: output report 0x%02x has %d bytes, need at least %d", outputID, size, outputSize)
+	}
+	return layout, nil
+}
+
+// ReportLayout returns the report layout in use, read from the report
+// descriptor when the transport provides one.
+func (d *DualSense) ReportLayout() ReportLayout {
+	d.transportMu.RLock()
+	defer d.transportMu.RUnlock()
+	return d.reportLayout
+}
@@ -0,0 +1,83 @@
+package dualsense
+
+import (
+	"strings"
+	"testing"
+)
+
+// dualSenseReportDescriptor is an abridged DualSense USB report descriptor
+// keeping the report IDs and sizes DualSense relies on.
+var dualSenseReportDescriptor = []byte{
+	0x05, 0x01, // Usage Page (Generic Desktop)
+	0x09, 0x05, // Usage (Game Pad)
+	0xA1, 0x01, // Collection (Application)
+	0x85, 0x01, //   Report ID (1)
+	0x75, 0x08, //   Report Size (8)
+	0x95, 0x3F, //   Report Count (63)
+	0x81, 0x02, //   Input (Data, Variable, Absolute)
+	0x85, 0x02, //   Report ID (2)
+	0xA4,       //   Push
+	0x95, 0x2F, //   Report Count (47)
+	0x91, 0x02, //   Output (Data, Variable, Absolute)
+	0xB4,       //   Pop
+	0x85, 0x05, //   Report ID (5)
+	0x75, 0x01, //   Report Size (1)
+	0x96, 0x00, 0x01, // Report Count (256)
+	0xB1, 0x02, //   Feature (Data, Variable, Absolute)
+	0xC0, // End Collection
+}
+
+type descriptorMockTransport struct {
+	mockTransport
+	descriptor []byte
+}
+
+func (t *descriptorMockTransport) ReportDescriptor() ([]byte, error) {
+	return t.descriptor, nil
+}
+
+func TestParseReportDescriptor(t *testing.T) {
+	layout, err := ParseReportDescriptor(dualSenseReportDescriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layout.Input[0x01] != USB_PACKET_SIZE || layout.Output[0x02] != USB_OUTPUT_REPORT_SIZE || layout.Feature[0x05] != 33 {
+		t.Fatalf("unexpected layout: %+v", layout)
+	}
+	if _, err := ParseReportDescriptor([]byte{0x95}); err == nil {
+		t.Fatal("truncated descriptor parsed")
+	}
+	if _, err := ParseReportDescriptor([]byte{0xB4}); err == nil {
+		t.Fatal("pop without push parsed")
+	}
+}
+
+func TestDetectReportLayout(t *testing.T) {
+	larger := append([]byte(nil), dualSenseReportDescriptor...)
+	larger[11] = 0x4F // 80 byte input report
+	transport := &descriptorMockTransport{descriptor: larger}
+	d, err := NewDualSense(WithTransport(transport), WithManualPump())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.ReportLayout().Input[0x01]; got != 80 {
+		t.Fatalf("got input report size %d, want 80", got)
+	}
+	report := make([]byte, 80)
+	report[0] = 0x01
+	report[1] = 42
+	transport.inputReports = append(transport.inputReports, report)
+	if err := d.Poll(); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.GetInStateData().LeftStickX; got != 42 {
+		t.Fatalf("got LeftStickX %d, want 42", got)
+	}
+
+	smaller := append([]byte(nil), dualSenseReportDescriptor...)
+	smaller[18] = 0x10 // 17 byte output report
+	_, err = NewDualSense(WithTransport(&descriptorMockTransport{descriptor: smaller}))
+	if err == nil || !strings.Contains(err.Error(), "output report 0x02") {
+		t.Fatalf("got %v, want an unsupported output report error", err)
+	}
+}
@@ -0,0 +1,72 @@
+package dualsense
+
+import "testing"
+
+func TestUnmarshalInputReport(t *testing.T) {
+	data := make([]byte, USB_PACKET_SIZE)
+	data[0] = 0x01
+	data[1] = 0x80                        // LeftStickX
+	data[8] = 0x20 | byte(DirectionSouth) // Cross + DPad
+	data[9] = 0x01                        // L1
+	data[53] = 0x1A                       // PowerPercent 10, PowerState Charging
+	data[54] = 0x01                       // PluggedHeadphones
+
+	reportIn, err := UnmarshalInputReport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := reportIn.USBGetStateData
+	if reportIn.ReportID != 0x01 || state.LeftStickX != 0x80 || state.DPad != DirectionSouth || !state.ButtonCross || !state.ButtonL1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if state.PowerPercent != 10 || state.PowerState != PowerStateCharging || !state.PluggedHeadphones {
+		t.Fatalf("unexpected power state: %+v", state)
+	}
+
+	if _, err := UnmarshalInputReport(data[:10]); err == nil {
+		t.Fatal("expected error for short report")
+	}
+}
+
+func TestMarshalOutputReport(t *testing.T) {
+	setStateData := defaultSetStateData
+	setStateData.LedRed = 0x12
+	setStateData.PlayerLight1 = true
+	setStateData.PlayerLight3 = true
+
+	data, err := MarshalOutputReport(setStateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 48 {
+		t.Fatalf("got %d bytes, want 48", len(data))
+	}
+	if data[0] != 0x02 {
+		t.Fatalf("got report ID %#x, want 0x02", data[0])
+	}
+	if data[44] != 0b00101 {
+		t.Fatalf("got player indicators %#b, want 0b101", data[44])
+	}
+	if data[45] != 0x12 {
+		t.Fatalf("got LedRed %#x, want 0x12", data[45])
+	}
+}
+
+func TestUnmarshalInputReportAllocs(t *testing.T) {
+	data := make([]byte, USB_PACKET_SIZE)
+	allocs := testing.AllocsPerRun(100, func() {
+		UnmarshalInputReport(data)
+	})
+	if allocs != 0 {
+		t.Fatalf("got %v allocations per report, want 0", allocs)
+	}
+}
+
+func BenchmarkUnmarshalInputReport(b *testing.B) {
+	data := make([]byte, USB_PACKET_SIZE)
+	data[0] = 0x01
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		UnmarshalInputReport(data)
+	}
+}
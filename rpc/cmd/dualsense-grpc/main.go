@@ -0,0 +1,57 @@
+// Command dualsense-grpc serves a DualSense controller over gRPC, see
+// rpc/dualsense.proto for the service.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"github.com/nikashan02/dualsense-go/rpc"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "address to listen on")
+	id := flag.String("id", "dualsense0", "device ID reported to clients")
+	flag.Parse()
+
+	logger := slog.Default()
+	controller, err := dualsense.NewDualSense(dualsense.WithLogger(logger))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer controller.Close()
+	server := rpc.NewServer()
+	err = server.Register(*id, controller)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	err = controller.Start(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	controller.OnError(func(err error) {
+		logger.Error("DualSense error", "error", err)
+	})
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	grpcServer := grpc.NewServer()
+	rpc.RegisterDualSenseServer(grpcServer, server)
+	logger.Info("listening", "addr", *addr)
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,2021 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: dualsense.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Device struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_dualsense_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_dualsense_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{1}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_dualsense_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type GetInputStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+}
+
+func (x *GetInputStateRequest) Reset() {
+	*x = GetInputStateRequest{}
+	mi := &file_dualsense_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInputStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInputStateRequest) ProtoMessage() {}
+
+func (x *GetInputStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInputStateRequest.ProtoReflect.Descriptor instead.
+func (*GetInputStateRequest) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetInputStateRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type StreamInputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Field names as returned by dualsense.Field.String, e.g. "ButtonCross".
+	// Empty streams changes to any field.
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *StreamInputRequest) Reset() {
+	*x = StreamInputRequest{}
+	mi := &file_dualsense_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamInputRequest) ProtoMessage() {}
+
+func (x *StreamInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInputRequest.ProtoReflect.Descriptor instead.
+func (*StreamInputRequest) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamInputRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *StreamInputRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type InputEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId          string      `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	ChangedFields     []string    `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	State             *InputState `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	TimestampUnixNano int64       `protobuf:"varint,4,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"`
+}
+
+func (x *InputEvent) Reset() {
+	*x = InputEvent{}
+	mi := &file_dualsense_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputEvent) ProtoMessage() {}
+
+func (x *InputEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputEvent.ProtoReflect.Descriptor instead.
+func (*InputEvent) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InputEvent) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *InputEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *InputEvent) GetState() *InputState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *InputEvent) GetTimestampUnixNano() int64 {
+	if x != nil {
+		return x.TimestampUnixNano
+	}
+	return 0
+}
+
+type SetOutputStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Fields not set keep their current value, so a request can update only
+	// what it names.
+	State *OutputState `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *SetOutputStateRequest) Reset() {
+	*x = SetOutputStateRequest{}
+	mi := &file_dualsense_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOutputStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOutputStateRequest) ProtoMessage() {}
+
+func (x *SetOutputStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOutputStateRequest.ProtoReflect.Descriptor instead.
+func (*SetOutputStateRequest) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetOutputStateRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetOutputStateRequest) GetState() *OutputState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type SetOutputStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The full output state after the update.
+	State *OutputState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *SetOutputStateResponse) Reset() {
+	*x = SetOutputStateResponse{}
+	mi := &file_dualsense_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOutputStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOutputStateResponse) ProtoMessage() {}
+
+func (x *SetOutputStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOutputStateResponse.ProtoReflect.Descriptor instead.
+func (*SetOutputStateResponse) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetOutputStateResponse) GetState() *OutputState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type TouchFinger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index       uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	NotTouching bool   `protobuf:"varint,2,opt,name=not_touching,json=notTouching,proto3" json:"not_touching,omitempty"`
+	FingerX     uint32 `protobuf:"varint,3,opt,name=finger_x,json=fingerX,proto3" json:"finger_x,omitempty"`
+	FingerY     uint32 `protobuf:"varint,4,opt,name=finger_y,json=fingerY,proto3" json:"finger_y,omitempty"`
+}
+
+func (x *TouchFinger) Reset() {
+	*x = TouchFinger{}
+	mi := &file_dualsense_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchFinger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchFinger) ProtoMessage() {}
+
+func (x *TouchFinger) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchFinger.ProtoReflect.Descriptor instead.
+func (*TouchFinger) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TouchFinger) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TouchFinger) GetNotTouching() bool {
+	if x != nil {
+		return x.NotTouching
+	}
+	return false
+}
+
+func (x *TouchFinger) GetFingerX() uint32 {
+	if x != nil {
+		return x.FingerX
+	}
+	return 0
+}
+
+func (x *TouchFinger) GetFingerY() uint32 {
+	if x != nil {
+		return x.FingerY
+	}
+	return 0
+}
+
+// InputState mirrors dualsense.USBGetStateData. Enum values use the same
+// names as their JSON encoding.
+type InputState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeftStickX               uint32       `protobuf:"varint,1,opt,name=left_stick_x,json=leftStickX,proto3" json:"left_stick_x,omitempty"`
+	LeftStickY               uint32       `protobuf:"varint,2,opt,name=left_stick_y,json=leftStickY,proto3" json:"left_stick_y,omitempty"`
+	RightStickX              uint32       `protobuf:"varint,3,opt,name=right_stick_x,json=rightStickX,proto3" json:"right_stick_x,omitempty"`
+	RightStickY              uint32       `protobuf:"varint,4,opt,name=right_stick_y,json=rightStickY,proto3" json:"right_stick_y,omitempty"`
+	TriggerLeft              uint32       `protobuf:"varint,5,opt,name=trigger_left,json=triggerLeft,proto3" json:"trigger_left,omitempty"`
+	TriggerRight             uint32       `protobuf:"varint,6,opt,name=trigger_right,json=triggerRight,proto3" json:"trigger_right,omitempty"`
+	SeqNo                    uint32       `protobuf:"varint,7,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
+	Dpad                     string       `protobuf:"bytes,8,opt,name=dpad,proto3" json:"dpad,omitempty"`
+	ButtonSquare             bool         `protobuf:"varint,9,opt,name=button_square,json=buttonSquare,proto3" json:"button_square,omitempty"`
+	ButtonCross              bool         `protobuf:"varint,10,opt,name=button_cross,json=buttonCross,proto3" json:"button_cross,omitempty"`
+	ButtonCircle             bool         `protobuf:"varint,11,opt,name=button_circle,json=buttonCircle,proto3" json:"button_circle,omitempty"`
+	ButtonTriangle           bool         `protobuf:"varint,12,opt,name=button_triangle,json=buttonTriangle,proto3" json:"button_triangle,omitempty"`
+	ButtonL1                 bool         `protobuf:"varint,13,opt,name=button_l1,json=buttonL1,proto3" json:"button_l1,omitempty"`
+	ButtonR1                 bool         `protobuf:"varint,14,opt,name=button_r1,json=buttonR1,proto3" json:"button_r1,omitempty"`
+	ButtonL2                 bool         `protobuf:"varint,15,opt,name=button_l2,json=buttonL2,proto3" json:"button_l2,omitempty"`
+	ButtonR2                 bool         `protobuf:"varint,16,opt,name=button_r2,json=buttonR2,proto3" json:"button_r2,omitempty"`
+	ButtonCreate             bool         `protobuf:"varint,17,opt,name=button_create,json=buttonCreate,proto3" json:"button_create,omitempty"`
+	ButtonOptions            bool         `protobuf:"varint,18,opt,name=button_options,json=buttonOptions,proto3" json:"button_options,omitempty"`
+	ButtonL3                 bool         `protobuf:"varint,19,opt,name=button_l3,json=buttonL3,proto3" json:"button_l3,omitempty"`
+	ButtonR3                 bool         `protobuf:"varint,20,opt,name=button_r3,json=buttonR3,proto3" json:"button_r3,omitempty"`
+	ButtonHome               bool         `protobuf:"varint,21,opt,name=button_home,json=buttonHome,proto3" json:"button_home,omitempty"`
+	ButtonPad                bool         `protobuf:"varint,22,opt,name=button_pad,json=buttonPad,proto3" json:"button_pad,omitempty"`
+	ButtonMute               bool         `protobuf:"varint,23,opt,name=button_mute,json=buttonMute,proto3" json:"button_mute,omitempty"`
+	ButtonLeftFunction       bool         `protobuf:"varint,24,opt,name=button_left_function,json=buttonLeftFunction,proto3" json:"button_left_function,omitempty"`
+	ButtonRightFunction      bool         `protobuf:"varint,25,opt,name=button_right_function,json=buttonRightFunction,proto3" json:"button_right_function,omitempty"`
+	ButtonLeftPaddle         bool         `protobuf:"varint,26,opt,name=button_left_paddle,json=buttonLeftPaddle,proto3" json:"button_left_paddle,omitempty"`
+	ButtonRightPaddle        bool         `protobuf:"varint,27,opt,name=button_right_paddle,json=buttonRightPaddle,proto3" json:"button_right_paddle,omitempty"`
+	AngularVelocityX         int32        `protobuf:"varint,28,opt,name=angular_velocity_x,json=angularVelocityX,proto3" json:"angular_velocity_x,omitempty"`
+	AngularVelocityZ         int32        `protobuf:"varint,29,opt,name=angular_velocity_z,json=angularVelocityZ,proto3" json:"angular_velocity_z,omitempty"`
+	AngularVelocityY         int32        `protobuf:"varint,30,opt,name=angular_velocity_y,json=angularVelocityY,proto3" json:"angular_velocity_y,omitempty"`
+	AccelerometerX           int32        `protobuf:"varint,31,opt,name=accelerometer_x,json=accelerometerX,proto3" json:"accelerometer_x,omitempty"`
+	AccelerometerY           int32        `protobuf:"varint,32,opt,name=accelerometer_y,json=accelerometerY,proto3" json:"accelerometer_y,omitempty"`
+	AccelerometerZ           int32        `protobuf:"varint,33,opt,name=accelerometer_z,json=accelerometerZ,proto3" json:"accelerometer_z,omitempty"`
+	SensorTimestamp          uint32       `protobuf:"varint,34,opt,name=sensor_timestamp,json=sensorTimestamp,proto3" json:"sensor_timestamp,omitempty"`
+	Temperature              int32        `protobuf:"varint,35,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	TouchFinger1             *TouchFinger `protobuf:"bytes,36,opt,name=touch_finger1,json=touchFinger1,proto3" json:"touch_finger1,omitempty"`
+	TouchFinger2             *TouchFinger `protobuf:"bytes,37,opt,name=touch_finger2,json=touchFinger2,proto3" json:"touch_finger2,omitempty"`
+	TouchTimestamp           uint32       `protobuf:"varint,38,opt,name=touch_timestamp,json=touchTimestamp,proto3" json:"touch_timestamp,omitempty"`
+	TriggerRightStopLocation uint32       `protobuf:"varint,39,opt,name=trigger_right_stop_location,json=triggerRightStopLocation,proto3" json:"trigger_right_stop_location,omitempty"`
+	TriggerRightStatus       uint32       `protobuf:"varint,40,opt,name=trigger_right_status,json=triggerRightStatus,proto3" json:"trigger_right_status,omitempty"`
+	TriggerLeftStopLocation  uint32       `protobuf:"varint,41,opt,name=trigger_left_stop_location,json=triggerLeftStopLocation,proto3" json:"trigger_left_stop_location,omitempty"`
+	TriggerLeftStatus        uint32       `protobuf:"varint,42,opt,name=trigger_left_status,json=triggerLeftStatus,proto3" json:"trigger_left_status,omitempty"`
+	HostTimestamp            uint32       `protobuf:"varint,43,opt,name=host_timestamp,json=hostTimestamp,proto3" json:"host_timestamp,omitempty"`
+	TriggerRightEffect       uint32       `protobuf:"varint,44,opt,name=trigger_right_effect,json=triggerRightEffect,proto3" json:"trigger_right_effect,omitempty"`
+	TriggerLeftEffect        uint32       `protobuf:"varint,45,opt,name=trigger_left_effect,json=triggerLeftEffect,proto3" json:"trigger_left_effect,omitempty"`
+	DeviceTimestamp          uint32       `protobuf:"varint,46,opt,name=device_timestamp,json=deviceTimestamp,proto3" json:"device_timestamp,omitempty"`
+	PowerPercent             uint32       `protobuf:"varint,47,opt,name=power_percent,json=powerPercent,proto3" json:"power_percent,omitempty"`
+	PowerState               string       `protobuf:"bytes,48,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`
+	PluggedHeadphones        bool         `protobuf:"varint,49,opt,name=plugged_headphones,json=pluggedHeadphones,proto3" json:"plugged_headphones,omitempty"`
+	PluggedMic               bool         `protobuf:"varint,50,opt,name=plugged_mic,json=pluggedMic,proto3" json:"plugged_mic,omitempty"`
+	MicMuted                 bool         `protobuf:"varint,51,opt,name=mic_muted,json=micMuted,proto3" json:"mic_muted,omitempty"`
+	PluggedUsbData           bool         `protobuf:"varint,52,opt,name=plugged_usb_data,json=pluggedUsbData,proto3" json:"plugged_usb_data,omitempty"`
+	PluggedUsbPower          bool         `protobuf:"varint,53,opt,name=plugged_usb_power,json=pluggedUsbPower,proto3" json:"plugged_usb_power,omitempty"`
+	PluggedExternalMic       bool         `protobuf:"varint,54,opt,name=plugged_external_mic,json=pluggedExternalMic,proto3" json:"plugged_external_mic,omitempty"`
+	HapticLowPassFilter      bool         `protobuf:"varint,55,opt,name=haptic_low_pass_filter,json=hapticLowPassFilter,proto3" json:"haptic_low_pass_filter,omitempty"`
+	AesCmac                  uint64       `protobuf:"varint,56,opt,name=aes_cmac,json=aesCmac,proto3" json:"aes_cmac,omitempty"`
+}
+
+func (x *InputState) Reset() {
+	*x = InputState{}
+	mi := &file_dualsense_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputState) ProtoMessage() {}
+
+func (x *InputState) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputState.ProtoReflect.Descriptor instead.
+func (*InputState) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InputState) GetLeftStickX() uint32 {
+	if x != nil {
+		return x.LeftStickX
+	}
+	return 0
+}
+
+func (x *InputState) GetLeftStickY() uint32 {
+	if x != nil {
+		return x.LeftStickY
+	}
+	return 0
+}
+
+func (x *InputState) GetRightStickX() uint32 {
+	if x != nil {
+		return x.RightStickX
+	}
+	return 0
+}
+
+func (x *InputState) GetRightStickY() uint32 {
+	if x != nil {
+		return x.RightStickY
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerLeft() uint32 {
+	if x != nil {
+		return x.TriggerLeft
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerRight() uint32 {
+	if x != nil {
+		return x.TriggerRight
+	}
+	return 0
+}
+
+func (x *InputState) GetSeqNo() uint32 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *InputState) GetDpad() string {
+	if x != nil {
+		return x.Dpad
+	}
+	return ""
+}
+
+func (x *InputState) GetButtonSquare() bool {
+	if x != nil {
+		return x.ButtonSquare
+	}
+	return false
+}
+
+func (x *InputState) GetButtonCross() bool {
+	if x != nil {
+		return x.ButtonCross
+	}
+	return false
+}
+
+func (x *InputState) GetButtonCircle() bool {
+	if x != nil {
+		return x.ButtonCircle
+	}
+	return false
+}
+
+func (x *InputState) GetButtonTriangle() bool {
+	if x != nil {
+		return x.ButtonTriangle
+	}
+	return false
+}
+
+func (x *InputState) GetButtonL1() bool {
+	if x != nil {
+		return x.ButtonL1
+	}
+	return false
+}
+
+func (x *InputState) GetButtonR1() bool {
+	if x != nil {
+		return x.ButtonR1
+	}
+	return false
+}
+
+func (x *InputState) GetButtonL2() bool {
+	if x != nil {
+		return x.ButtonL2
+	}
+	return false
+}
+
+func (x *InputState) GetButtonR2() bool {
+	if x != nil {
+		return x.ButtonR2
+	}
+	return false
+}
+
+func (x *InputState) GetButtonCreate() bool {
+	if x != nil {
+		return x.ButtonCreate
+	}
+	return false
+}
+
+func (x *InputState) GetButtonOptions() bool {
+	if x != nil {
+		return x.ButtonOptions
+	}
+	return false
+}
+
+func (x *InputState) GetButtonL3() bool {
+	if x != nil {
+		return x.ButtonL3
+	}
+	return false
+}
+
+func (x *InputState) GetButtonR3() bool {
+	if x != nil {
+		return x.ButtonR3
+	}
+	return false
+}
+
+func (x *InputState) GetButtonHome() bool {
+	if x != nil {
+		return x.ButtonHome
+	}
+	return false
+}
+
+func (x *InputState) GetButtonPad() bool {
+	if x != nil {
+		return x.ButtonPad
+	}
+	return false
+}
+
+func (x *InputState) GetButtonMute() bool {
+	if x != nil {
+		return x.ButtonMute
+	}
+	return false
+}
+
+func (x *InputState) GetButtonLeftFunction() bool {
+	if x != nil {
+		return x.ButtonLeftFunction
+	}
+	return false
+}
+
+func (x *InputState) GetButtonRightFunction() bool {
+	if x != nil {
+		return x.ButtonRightFunction
+	}
+	return false
+}
+
+func (x *InputState) GetButtonLeftPaddle() bool {
+	if x != nil {
+		return x.ButtonLeftPaddle
+	}
+	return false
+}
+
+func (x *InputState) GetButtonRightPaddle() bool {
+	if x != nil {
+		return x.ButtonRightPaddle
+	}
+	return false
+}
+
+func (x *InputState) GetAngularVelocityX() int32 {
+	if x != nil {
+		return x.AngularVelocityX
+	}
+	return 0
+}
+
+func (x *InputState) GetAngularVelocityZ() int32 {
+	if x != nil {
+		return x.AngularVelocityZ
+	}
+	return 0
+}
+
+func (x *InputState) GetAngularVelocityY() int32 {
+	if x != nil {
+		return x.AngularVelocityY
+	}
+	return 0
+}
+
+func (x *InputState) GetAccelerometerX() int32 {
+	if x != nil {
+		return x.AccelerometerX
+	}
+	return 0
+}
+
+func (x *InputState) GetAccelerometerY() int32 {
+	if x != nil {
+		return x.AccelerometerY
+	}
+	return 0
+}
+
+func (x *InputState) GetAccelerometerZ() int32 {
+	if x != nil {
+		return x.AccelerometerZ
+	}
+	return 0
+}
+
+func (x *InputState) GetSensorTimestamp() uint32 {
+	if x != nil {
+		return x.SensorTimestamp
+	}
+	return 0
+}
+
+func (x *InputState) GetTemperature() int32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *InputState) GetTouchFinger1() *TouchFinger {
+	if x != nil {
+		return x.TouchFinger1
+	}
+	return nil
+}
+
+func (x *InputState) GetTouchFinger2() *TouchFinger {
+	if x != nil {
+		return x.TouchFinger2
+	}
+	return nil
+}
+
+func (x *InputState) GetTouchTimestamp() uint32 {
+	if x != nil {
+		return x.TouchTimestamp
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerRightStopLocation() uint32 {
+	if x != nil {
+		return x.TriggerRightStopLocation
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerRightStatus() uint32 {
+	if x != nil {
+		return x.TriggerRightStatus
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerLeftStopLocation() uint32 {
+	if x != nil {
+		return x.TriggerLeftStopLocation
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerLeftStatus() uint32 {
+	if x != nil {
+		return x.TriggerLeftStatus
+	}
+	return 0
+}
+
+func (x *InputState) GetHostTimestamp() uint32 {
+	if x != nil {
+		return x.HostTimestamp
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerRightEffect() uint32 {
+	if x != nil {
+		return x.TriggerRightEffect
+	}
+	return 0
+}
+
+func (x *InputState) GetTriggerLeftEffect() uint32 {
+	if x != nil {
+		return x.TriggerLeftEffect
+	}
+	return 0
+}
+
+func (x *InputState) GetDeviceTimestamp() uint32 {
+	if x != nil {
+		return x.DeviceTimestamp
+	}
+	return 0
+}
+
+func (x *InputState) GetPowerPercent() uint32 {
+	if x != nil {
+		return x.PowerPercent
+	}
+	return 0
+}
+
+func (x *InputState) GetPowerState() string {
+	if x != nil {
+		return x.PowerState
+	}
+	return ""
+}
+
+func (x *InputState) GetPluggedHeadphones() bool {
+	if x != nil {
+		return x.PluggedHeadphones
+	}
+	return false
+}
+
+func (x *InputState) GetPluggedMic() bool {
+	if x != nil {
+		return x.PluggedMic
+	}
+	return false
+}
+
+func (x *InputState) GetMicMuted() bool {
+	if x != nil {
+		return x.MicMuted
+	}
+	return false
+}
+
+func (x *InputState) GetPluggedUsbData() bool {
+	if x != nil {
+		return x.PluggedUsbData
+	}
+	return false
+}
+
+func (x *InputState) GetPluggedUsbPower() bool {
+	if x != nil {
+		return x.PluggedUsbPower
+	}
+	return false
+}
+
+func (x *InputState) GetPluggedExternalMic() bool {
+	if x != nil {
+		return x.PluggedExternalMic
+	}
+	return false
+}
+
+func (x *InputState) GetHapticLowPassFilter() bool {
+	if x != nil {
+		return x.HapticLowPassFilter
+	}
+	return false
+}
+
+func (x *InputState) GetAesCmac() uint64 {
+	if x != nil {
+		return x.AesCmac
+	}
+	return 0
+}
+
+// OutputState mirrors dualsense.SetStateData. Enum values use the same names
+// as their JSON encoding and the trigger effects hold 11 bytes each. Every
+// field tracks presence, so SetOutputStateRequest can leave fields unset.
+type OutputState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EnableRumbleEmulation         *bool   `protobuf:"varint,1,opt,name=enable_rumble_emulation,json=enableRumbleEmulation,proto3,oneof" json:"enable_rumble_emulation,omitempty"`
+	UseRumbleNotHaptics           *bool   `protobuf:"varint,2,opt,name=use_rumble_not_haptics,json=useRumbleNotHaptics,proto3,oneof" json:"use_rumble_not_haptics,omitempty"`
+	AllowRightTriggerFfb          *bool   `protobuf:"varint,3,opt,name=allow_right_trigger_ffb,json=allowRightTriggerFfb,proto3,oneof" json:"allow_right_trigger_ffb,omitempty"`
+	AllowLeftTriggerFfb           *bool   `protobuf:"varint,4,opt,name=allow_left_trigger_ffb,json=allowLeftTriggerFfb,proto3,oneof" json:"allow_left_trigger_ffb,omitempty"`
+	AllowHeadphoneVolume          *bool   `protobuf:"varint,5,opt,name=allow_headphone_volume,json=allowHeadphoneVolume,proto3,oneof" json:"allow_headphone_volume,omitempty"`
+	AllowSpeakerVolume            *bool   `protobuf:"varint,6,opt,name=allow_speaker_volume,json=allowSpeakerVolume,proto3,oneof" json:"allow_speaker_volume,omitempty"`
+	AllowMicVolume                *bool   `protobuf:"varint,7,opt,name=allow_mic_volume,json=allowMicVolume,proto3,oneof" json:"allow_mic_volume,omitempty"`
+	AllowAudioControl             *bool   `protobuf:"varint,8,opt,name=allow_audio_control,json=allowAudioControl,proto3,oneof" json:"allow_audio_control,omitempty"`
+	AllowMuteLight                *bool   `protobuf:"varint,9,opt,name=allow_mute_light,json=allowMuteLight,proto3,oneof" json:"allow_mute_light,omitempty"`
+	AllowAudioMute                *bool   `protobuf:"varint,10,opt,name=allow_audio_mute,json=allowAudioMute,proto3,oneof" json:"allow_audio_mute,omitempty"`
+	AllowLedColor                 *bool   `protobuf:"varint,11,opt,name=allow_led_color,json=allowLedColor,proto3,oneof" json:"allow_led_color,omitempty"`
+	ResetLights                   *bool   `protobuf:"varint,12,opt,name=reset_lights,json=resetLights,proto3,oneof" json:"reset_lights,omitempty"`
+	AllowPlayerIndicators         *bool   `protobuf:"varint,13,opt,name=allow_player_indicators,json=allowPlayerIndicators,proto3,oneof" json:"allow_player_indicators,omitempty"`
+	AllowHapticLowPassFilter      *bool   `protobuf:"varint,14,opt,name=allow_haptic_low_pass_filter,json=allowHapticLowPassFilter,proto3,oneof" json:"allow_haptic_low_pass_filter,omitempty"`
+	AllowMotorPowerLevel          *bool   `protobuf:"varint,15,opt,name=allow_motor_power_level,json=allowMotorPowerLevel,proto3,oneof" json:"allow_motor_power_level,omitempty"`
+	AllowAudioControl2            *bool   `protobuf:"varint,16,opt,name=allow_audio_control2,json=allowAudioControl2,proto3,oneof" json:"allow_audio_control2,omitempty"`
+	RumbleEmulationRight          *uint32 `protobuf:"varint,17,opt,name=rumble_emulation_right,json=rumbleEmulationRight,proto3,oneof" json:"rumble_emulation_right,omitempty"`
+	RumbleEmulationLeft           *uint32 `protobuf:"varint,18,opt,name=rumble_emulation_left,json=rumbleEmulationLeft,proto3,oneof" json:"rumble_emulation_left,omitempty"`
+	VolumeHeadphones              *uint32 `protobuf:"varint,19,opt,name=volume_headphones,json=volumeHeadphones,proto3,oneof" json:"volume_headphones,omitempty"`
+	VolumeSpeaker                 *uint32 `protobuf:"varint,20,opt,name=volume_speaker,json=volumeSpeaker,proto3,oneof" json:"volume_speaker,omitempty"`
+	VolumeMic                     *uint32 `protobuf:"varint,21,opt,name=volume_mic,json=volumeMic,proto3,oneof" json:"volume_mic,omitempty"`
+	MicSelect                     *string `protobuf:"bytes,22,opt,name=mic_select,json=micSelect,proto3,oneof" json:"mic_select,omitempty"`
+	EchoCancelEnable              *bool   `protobuf:"varint,23,opt,name=echo_cancel_enable,json=echoCancelEnable,proto3,oneof" json:"echo_cancel_enable,omitempty"`
+	NoiseCancelEnable             *bool   `protobuf:"varint,24,opt,name=noise_cancel_enable,json=noiseCancelEnable,proto3,oneof" json:"noise_cancel_enable,omitempty"`
+	OutputPathSelect              *uint32 `protobuf:"varint,25,opt,name=output_path_select,json=outputPathSelect,proto3,oneof" json:"output_path_select,omitempty"`
+	InputPathSelect               *uint32 `protobuf:"varint,26,opt,name=input_path_select,json=inputPathSelect,proto3,oneof" json:"input_path_select,omitempty"`
+	MuteLight                     *string `protobuf:"bytes,27,opt,name=mute_light,json=muteLight,proto3,oneof" json:"mute_light,omitempty"`
+	TouchPowerSave                *bool   `protobuf:"varint,28,opt,name=touch_power_save,json=touchPowerSave,proto3,oneof" json:"touch_power_save,omitempty"`
+	MotionPowerSave               *bool   `protobuf:"varint,29,opt,name=motion_power_save,json=motionPowerSave,proto3,oneof" json:"motion_power_save,omitempty"`
+	HapticPowerSave               *bool   `protobuf:"varint,30,opt,name=haptic_power_save,json=hapticPowerSave,proto3,oneof" json:"haptic_power_save,omitempty"`
+	AudioPowerSave                *bool   `protobuf:"varint,31,opt,name=audio_power_save,json=audioPowerSave,proto3,oneof" json:"audio_power_save,omitempty"`
+	MicMute                       *bool   `protobuf:"varint,32,opt,name=mic_mute,json=micMute,proto3,oneof" json:"mic_mute,omitempty"`
+	SpeakerMute                   *bool   `protobuf:"varint,33,opt,name=speaker_mute,json=speakerMute,proto3,oneof" json:"speaker_mute,omitempty"`
+	HeadphoneMute                 *bool   `protobuf:"varint,34,opt,name=headphone_mute,json=headphoneMute,proto3,oneof" json:"headphone_mute,omitempty"`
+	HapticMute                    *bool   `protobuf:"varint,35,opt,name=haptic_mute,json=hapticMute,proto3,oneof" json:"haptic_mute,omitempty"`
+	RightTriggerFfb               []byte  `protobuf:"bytes,36,opt,name=right_trigger_ffb,json=rightTriggerFfb,proto3,oneof" json:"right_trigger_ffb,omitempty"`
+	LeftTriggerFfb                []byte  `protobuf:"bytes,37,opt,name=left_trigger_ffb,json=leftTriggerFfb,proto3,oneof" json:"left_trigger_ffb,omitempty"`
+	HostTimestamp                 *uint32 `protobuf:"varint,38,opt,name=host_timestamp,json=hostTimestamp,proto3,oneof" json:"host_timestamp,omitempty"`
+	TriggerMotorPowerReduction    *uint32 `protobuf:"varint,39,opt,name=trigger_motor_power_reduction,json=triggerMotorPowerReduction,proto3,oneof" json:"trigger_motor_power_reduction,omitempty"`
+	RumbleMotorPowerReduction     *uint32 `protobuf:"varint,40,opt,name=rumble_motor_power_reduction,json=rumbleMotorPowerReduction,proto3,oneof" json:"rumble_motor_power_reduction,omitempty"`
+	SpeakerCompPreGain            *uint32 `protobuf:"varint,41,opt,name=speaker_comp_pre_gain,json=speakerCompPreGain,proto3,oneof" json:"speaker_comp_pre_gain,omitempty"`
+	BeamformingEnable             *bool   `protobuf:"varint,42,opt,name=beamforming_enable,json=beamformingEnable,proto3,oneof" json:"beamforming_enable,omitempty"`
+	AllowLightBrightnessChange    *bool   `protobuf:"varint,43,opt,name=allow_light_brightness_change,json=allowLightBrightnessChange,proto3,oneof" json:"allow_light_brightness_change,omitempty"`
+	AllowColorLightFadeAnimation  *bool   `protobuf:"varint,44,opt,name=allow_color_light_fade_animation,json=allowColorLightFadeAnimation,proto3,oneof" json:"allow_color_light_fade_animation,omitempty"`
+	EnableImprovedRumbleEmulation *bool   `protobuf:"varint,45,opt,name=enable_improved_rumble_emulation,json=enableImprovedRumbleEmulation,proto3,oneof" json:"enable_improved_rumble_emulation,omitempty"`
+	HapticLowPassFilter           *bool   `protobuf:"varint,46,opt,name=haptic_low_pass_filter,json=hapticLowPassFilter,proto3,oneof" json:"haptic_low_pass_filter,omitempty"`
+	LightFadeAnimation            *string `protobuf:"bytes,47,opt,name=light_fade_animation,json=lightFadeAnimation,proto3,oneof" json:"light_fade_animation,omitempty"`
+	LightBrightness               *string `protobuf:"bytes,48,opt,name=light_brightness,json=lightBrightness,proto3,oneof" json:"light_brightness,omitempty"`
+	PlayerLight1                  *bool   `protobuf:"varint,49,opt,name=player_light1,json=playerLight1,proto3,oneof" json:"player_light1,omitempty"`
+	PlayerLight2                  *bool   `protobuf:"varint,50,opt,name=player_light2,json=playerLight2,proto3,oneof" json:"player_light2,omitempty"`
+	PlayerLight3                  *bool   `protobuf:"varint,51,opt,name=player_light3,json=playerLight3,proto3,oneof" json:"player_light3,omitempty"`
+	PlayerLight4                  *bool   `protobuf:"varint,52,opt,name=player_light4,json=playerLight4,proto3,oneof" json:"player_light4,omitempty"`
+	PlayerLight5                  *bool   `protobuf:"varint,53,opt,name=player_light5,json=playerLight5,proto3,oneof" json:"player_light5,omitempty"`
+	PlayerLightFade               *bool   `protobuf:"varint,54,opt,name=player_light_fade,json=playerLightFade,proto3,oneof" json:"player_light_fade,omitempty"`
+	LedRed                        *uint32 `protobuf:"varint,55,opt,name=led_red,json=ledRed,proto3,oneof" json:"led_red,omitempty"`
+	LedGreen                      *uint32 `protobuf:"varint,56,opt,name=led_green,json=ledGreen,proto3,oneof" json:"led_green,omitempty"`
+	LedBlue                       *uint32 `protobuf:"varint,57,opt,name=led_blue,json=ledBlue,proto3,oneof" json:"led_blue,omitempty"`
+}
+
+func (x *OutputState) Reset() {
+	*x = OutputState{}
+	mi := &file_dualsense_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputState) ProtoMessage() {}
+
+func (x *OutputState) ProtoReflect() protoreflect.Message {
+	mi := &file_dualsense_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputState.ProtoReflect.Descriptor instead.
+func (*OutputState) Descriptor() ([]byte, []int) {
+	return file_dualsense_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OutputState) GetEnableRumbleEmulation() bool {
+	if x != nil && x.EnableRumbleEmulation != nil {
+		return *x.EnableRumbleEmulation
+	}
+	return false
+}
+
+func (x *OutputState) GetUseRumbleNotHaptics() bool {
+	if x != nil && x.UseRumbleNotHaptics != nil {
+		return *x.UseRumbleNotHaptics
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowRightTriggerFfb() bool {
+	if x != nil && x.AllowRightTriggerFfb != nil {
+		return *x.AllowRightTriggerFfb
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowLeftTriggerFfb() bool {
+	if x != nil && x.AllowLeftTriggerFfb != nil {
+		return *x.AllowLeftTriggerFfb
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowHeadphoneVolume() bool {
+	if x != nil && x.AllowHeadphoneVolume != nil {
+		return *x.AllowHeadphoneVolume
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowSpeakerVolume() bool {
+	if x != nil && x.AllowSpeakerVolume != nil {
+		return *x.AllowSpeakerVolume
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowMicVolume() bool {
+	if x != nil && x.AllowMicVolume != nil {
+		return *x.AllowMicVolume
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowAudioControl() bool {
+	if x != nil && x.AllowAudioControl != nil {
+		return *x.AllowAudioControl
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowMuteLight() bool {
+	if x != nil && x.AllowMuteLight != nil {
+		return *x.AllowMuteLight
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowAudioMute() bool {
+	if x != nil && x.AllowAudioMute != nil {
+		return *x.AllowAudioMute
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowLedColor() bool {
+	if x != nil && x.AllowLedColor != nil {
+		return *x.AllowLedColor
+	}
+	return false
+}
+
+func (x *OutputState) GetResetLights() bool {
+	if x != nil && x.ResetLights != nil {
+		return *x.ResetLights
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowPlayerIndicators() bool {
+	if x != nil && x.AllowPlayerIndicators != nil {
+		return *x.AllowPlayerIndicators
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowHapticLowPassFilter() bool {
+	if x != nil && x.AllowHapticLowPassFilter != nil {
+		return *x.AllowHapticLowPassFilter
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowMotorPowerLevel() bool {
+	if x != nil && x.AllowMotorPowerLevel != nil {
+		return *x.AllowMotorPowerLevel
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowAudioControl2() bool {
+	if x != nil && x.AllowAudioControl2 != nil {
+		return *x.AllowAudioControl2
+	}
+	return false
+}
+
+func (x *OutputState) GetRumbleEmulationRight() uint32 {
+	if x != nil && x.RumbleEmulationRight != nil {
+		return *x.RumbleEmulationRight
+	}
+	return 0
+}
+
+func (x *OutputState) GetRumbleEmulationLeft() uint32 {
+	if x != nil && x.RumbleEmulationLeft != nil {
+		return *x.RumbleEmulationLeft
+	}
+	return 0
+}
+
+func (x *OutputState) GetVolumeHeadphones() uint32 {
+	if x != nil && x.VolumeHeadphones != nil {
+		return *x.VolumeHeadphones
+	}
+	return 0
+}
+
+func (x *OutputState) GetVolumeSpeaker() uint32 {
+	if x != nil && x.VolumeSpeaker != nil {
+		return *x.VolumeSpeaker
+	}
+	return 0
+}
+
+func (x *OutputState) GetVolumeMic() uint32 {
+	if x != nil && x.VolumeMic != nil {
+		return *x.VolumeMic
+	}
+	return 0
+}
+
+func (x *OutputState) GetMicSelect() string {
+	if x != nil && x.MicSelect != nil {
+		return *x.MicSelect
+	}
+	return ""
+}
+
+func (x *OutputState) GetEchoCancelEnable() bool {
+	if x != nil && x.EchoCancelEnable != nil {
+		return *x.EchoCancelEnable
+	}
+	return false
+}
+
+func (x *OutputState) GetNoiseCancelEnable() bool {
+	if x != nil && x.NoiseCancelEnable != nil {
+		return *x.NoiseCancelEnable
+	}
+	return false
+}
+
+func (x *OutputState) GetOutputPathSelect() uint32 {
+	if x != nil && x.OutputPathSelect != nil {
+		return *x.OutputPathSelect
+	}
+	return 0
+}
+
+func (x *OutputState) GetInputPathSelect() uint32 {
+	if x != nil && x.InputPathSelect != nil {
+		return *x.InputPathSelect
+	}
+	return 0
+}
+
+func (x *OutputState) GetMuteLight() string {
+	if x != nil && x.MuteLight != nil {
+		return *x.MuteLight
+	}
+	return ""
+}
+
+func (x *OutputState) GetTouchPowerSave() bool {
+	if x != nil && x.TouchPowerSave != nil {
+		return *x.TouchPowerSave
+	}
+	return false
+}
+
+func (x *OutputState) GetMotionPowerSave() bool {
+	if x != nil && x.MotionPowerSave != nil {
+		return *x.MotionPowerSave
+	}
+	return false
+}
+
+func (x *OutputState) GetHapticPowerSave() bool {
+	if x != nil && x.HapticPowerSave != nil {
+		return *x.HapticPowerSave
+	}
+	return false
+}
+
+func (x *OutputState) GetAudioPowerSave() bool {
+	if x != nil && x.AudioPowerSave != nil {
+		return *x.AudioPowerSave
+	}
+	return false
+}
+
+func (x *OutputState) GetMicMute() bool {
+	if x != nil && x.MicMute != nil {
+		return *x.MicMute
+	}
+	return false
+}
+
+func (x *OutputState) GetSpeakerMute() bool {
+	if x != nil && x.SpeakerMute != nil {
+		return *x.SpeakerMute
+	}
+	return false
+}
+
+func (x *OutputState) GetHeadphoneMute() bool {
+	if x != nil && x.HeadphoneMute != nil {
+		return *x.HeadphoneMute
+	}
+	return false
+}
+
+func (x *OutputState) GetHapticMute() bool {
+	if x != nil && x.HapticMute != nil {
+		return *x.HapticMute
+	}
+	return false
+}
+
+func (x *OutputState) GetRightTriggerFfb() []byte {
+	if x != nil {
+		return x.RightTriggerFfb
+	}
+	return nil
+}
+
+func (x *OutputState) GetLeftTriggerFfb() []byte {
+	if x != nil {
+		return x.LeftTriggerFfb
+	}
+	return nil
+}
+
+func (x *OutputState) GetHostTimestamp() uint32 {
+	if x != nil && x.HostTimestamp != nil {
+		return *x.HostTimestamp
+	}
+	return 0
+}
+
+func (x *OutputState) GetTriggerMotorPowerReduction() uint32 {
+	if x != nil && x.TriggerMotorPowerReduction != nil {
+		return *x.TriggerMotorPowerReduction
+	}
+	return 0
+}
+
+func (x *OutputState) GetRumbleMotorPowerReduction() uint32 {
+	if x != nil && x.RumbleMotorPowerReduction != nil {
+		return *x.RumbleMotorPowerReduction
+	}
+	return 0
+}
+
+func (x *OutputState) GetSpeakerCompPreGain() uint32 {
+	if x != nil && x.SpeakerCompPreGain != nil {
+		return *x.SpeakerCompPreGain
+	}
+	return 0
+}
+
+func (x *OutputState) GetBeamformingEnable() bool {
+	if x != nil && x.BeamformingEnable != nil {
+		return *x.BeamformingEnable
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowLightBrightnessChange() bool {
+	if x != nil && x.AllowLightBrightnessChange != nil {
+		return *x.AllowLightBrightnessChange
+	}
+	return false
+}
+
+func (x *OutputState) GetAllowColorLightFadeAnimation() bool {
+	if x != nil && x.AllowColorLightFadeAnimation != nil {
+		return *x.AllowColorLightFadeAnimation
+	}
+	return false
+}
+
+func (x *OutputState) GetEnableImprovedRumbleEmulation() bool {
+	if x != nil && x.EnableImprovedRumbleEmulation != nil {
+		return *x.EnableImprovedRumbleEmulation
+	}
+	return false
+}
+
+func (x *OutputState) GetHapticLowPassFilter() bool {
+	if x != nil && x.HapticLowPassFilter != nil {
+		return *x.HapticLowPassFilter
+	}
+	return false
+}
+
+func (x *OutputState) GetLightFadeAnimation() string {
+	if x != nil && x.LightFadeAnimation != nil {
+		return *x.LightFadeAnimation
+	}
+	return ""
+}
+
+func (x *OutputState) GetLightBrightness() string {
+	if x != nil && x.LightBrightness != nil {
+		return *x.LightBrightness
+	}
+	return ""
+}
+
+func (x *OutputState) GetPlayerLight1() bool {
+	if x != nil && x.PlayerLight1 != nil {
+		return *x.PlayerLight1
+	}
+	return false
+}
+
+func (x *OutputState) GetPlayerLight2() bool {
+	if x != nil && x.PlayerLight2 != nil {
+		return *x.PlayerLight2
+	}
+	return false
+}
+
+func (x *OutputState) GetPlayerLight3() bool {
+	if x != nil && x.PlayerLight3 != nil {
+		return *x.PlayerLight3
+	}
+	return false
+}
+
+func (x *OutputState) GetPlayerLight4() bool {
+	if x != nil && x.PlayerLight4 != nil {
+		return *x.PlayerLight4
+	}
+	return false
+}
+
+func (x *OutputState) GetPlayerLight5() bool {
+	if x != nil && x.PlayerLight5 != nil {
+		return *x.PlayerLight5
+	}
+	return false
+}
+
+func (x *OutputState) GetPlayerLightFade() bool {
+	if x != nil && x.PlayerLightFade != nil {
+		return *x.PlayerLightFade
+	}
+	return false
+}
+
+func (x *OutputState) GetLedRed() uint32 {
+	if x != nil && x.LedRed != nil {
+		return *x.LedRed
+	}
+	return 0
+}
+
+func (x *OutputState) GetLedGreen() uint32 {
+	if x != nil && x.LedGreen != nil {
+		return *x.LedGreen
+	}
+	return 0
+}
+
+func (x *OutputState) GetLedBlue() uint32 {
+	if x != nil && x.LedBlue != nil {
+		return *x.LedBlue
+	}
+	return 0
+}
+
+var File_dualsense_proto protoreflect.FileDescriptor
+
+var file_dualsense_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a, 0x06,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x42, 0x0a, 0x13,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65,
+	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x22, 0x33, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x49, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x22, 0xad, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x2e, 0x0a, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x75, 0x6e,
+	0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x55, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f,
+	0x22, 0x62, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73,
+	0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x22, 0x46, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x7c, 0x0a, 0x0b,
+	0x54, 0x6f, 0x75, 0x63, 0x68, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x5f, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x54, 0x6f, 0x75, 0x63,
+	0x68, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x58, 0x12,
+	0x19, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x59, 0x22, 0xd2, 0x11, 0x0a, 0x0a, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x65, 0x66,
+	0x74, 0x5f, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x69, 0x63, 0x6b, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x6c,
+	0x65, 0x66, 0x74, 0x5f, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0a, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x69, 0x63, 0x6b, 0x59, 0x12, 0x22, 0x0a,
+	0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x69, 0x63, 0x6b,
+	0x58, 0x12, 0x22, 0x0a, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x69, 0x63, 0x6b,
+	0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x53,
+	0x74, 0x69, 0x63, 0x6b, 0x59, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x72, 0x69,
+	0x67, 0x67, 0x65, 0x72, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x15, 0x0a,
+	0x06, 0x73, 0x65, 0x71, 0x5f, 0x6e, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73,
+	0x65, 0x71, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x70, 0x61, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x70, 0x61, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x5f, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x43, 0x72, 0x6f, 0x73, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x63, 0x69, 0x72, 0x63, 0x6c,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x43,
+	0x69, 0x72, 0x63, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f,
+	0x74, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x54, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x31, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x31, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x72, 0x31, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x52, 0x31, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x5f, 0x6c, 0x32, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x4c, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f,
+	0x72, 0x32, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e,
+	0x52, 0x32, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62, 0x75, 0x74, 0x74, 0x6f,
+	0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x74, 0x74, 0x6f,
+	0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x33, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x33, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x72, 0x33, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x52, 0x33, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x50, 0x61, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4d, 0x75, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c,
+	0x65, 0x66, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x15, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2c, 0x0a, 0x12, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x70,
+	0x61, 0x64, 0x64, 0x6c, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x4c, 0x65, 0x66, 0x74, 0x50, 0x61, 0x64, 0x64, 0x6c, 0x65, 0x12, 0x2e, 0x0a,
+	0x13, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x61,
+	0x64, 0x64, 0x6c, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x62, 0x75, 0x74, 0x74,
+	0x6f, 0x6e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x50, 0x61, 0x64, 0x64, 0x6c, 0x65, 0x12, 0x2c, 0x0a,
+	0x12, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74,
+	0x79, 0x5f, 0x78, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x6e, 0x67, 0x75, 0x6c,
+	0x61, 0x72, 0x56, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x58, 0x12, 0x2c, 0x0a, 0x12, 0x61,
+	0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f,
+	0x7a, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72,
+	0x56, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5a, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x6e, 0x67,
+	0x75, 0x6c, 0x61, 0x72, 0x5f, 0x76, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x79, 0x18,
+	0x1e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x56, 0x65,
+	0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x59, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x78, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x58,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x5f, 0x79, 0x18, 0x20, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x6c,
+	0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x59, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63,
+	0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x7a, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x5a, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a,
+	0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x23, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x3b, 0x0a, 0x0d, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x31,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e,
+	0x73, 0x65, 0x2e, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x0c,
+	0x74, 0x6f, 0x75, 0x63, 0x68, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x31, 0x12, 0x3b, 0x0a, 0x0d,
+	0x74, 0x6f, 0x75, 0x63, 0x68, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x32, 0x18, 0x25, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e,
+	0x54, 0x6f, 0x75, 0x63, 0x68, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x0c, 0x74, 0x6f, 0x75,
+	0x63, 0x68, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x32, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x6f, 0x75,
+	0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x26, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x3d, 0x0a, 0x1b, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x12, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x1a, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x6c,
+	0x65, 0x66, 0x74, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x4c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2e, 0x0a, 0x13, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x66, 0x74,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x74,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x4c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18,
+	0x2c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x69,
+	0x67, 0x68, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x72, 0x69,
+	0x67, 0x67, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x18, 0x2d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x4c,
+	0x65, 0x66, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x2e, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x30, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x6c,
+	0x75, 0x67, 0x67, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73,
+	0x18, 0x31, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x48,
+	0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x75,
+	0x67, 0x67, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x63, 0x18, 0x32, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x4d, 0x69, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69,
+	0x63, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x33, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6d,
+	0x69, 0x63, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x6c, 0x75, 0x67, 0x67,
+	0x65, 0x64, 0x5f, 0x75, 0x73, 0x62, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x34, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x55, 0x73, 0x62, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x62,
+	0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x35, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x6c,
+	0x75, 0x67, 0x67, 0x65, 0x64, 0x55, 0x73, 0x62, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x30, 0x0a,
+	0x14, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x5f, 0x6d, 0x69, 0x63, 0x18, 0x36, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70, 0x6c, 0x75,
+	0x67, 0x67, 0x65, 0x64, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x4d, 0x69, 0x63, 0x12,
+	0x33, 0x0a, 0x16, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61,
+	0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x37, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x13, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x4c, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x65, 0x73, 0x5f, 0x63, 0x6d, 0x61, 0x63,
+	0x18, 0x38, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x65, 0x73, 0x43, 0x6d, 0x61, 0x63, 0x22,
+	0xd5, 0x20, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x3b, 0x0a, 0x17, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65,
+	0x5f, 0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x75, 0x6d, 0x62, 0x6c, 0x65,
+	0x45, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x16,
+	0x75, 0x73, 0x65, 0x5f, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x68,
+	0x61, 0x70, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x13,
+	0x75, 0x73, 0x65, 0x52, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x48, 0x61, 0x70, 0x74,
+	0x69, 0x63, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
+	0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x66, 0x66,
+	0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x02, 0x52, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x46, 0x66, 0x62, 0x88,
+	0x01, 0x01, 0x12, 0x38, 0x0a, 0x16, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x65, 0x66, 0x74,
+	0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x66, 0x66, 0x62, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x03, 0x52, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x65, 0x66, 0x74, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x46, 0x66, 0x62, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x16,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x04, 0x52, 0x14,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x48, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5f, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x05, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x70,
+	0x65, 0x61, 0x6b, 0x65, 0x72, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2d,
+	0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x63, 0x5f, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x06, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x4d, 0x69, 0x63, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x33, 0x0a,
+	0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x48, 0x07, 0x52, 0x11, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x88,
+	0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x75, 0x74, 0x65,
+	0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x48, 0x08, 0x52, 0x0e,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x2d, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x6f,
+	0x5f, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x48, 0x09, 0x52, 0x0e, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x4d, 0x75, 0x74, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x2b, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x48, 0x0a, 0x52, 0x0d, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x4c, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a,
+	0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x0b, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x69, 0x67, 0x68,
+	0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3b, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x48, 0x0c, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x43, 0x0a, 0x1c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x68, 0x61, 0x70, 0x74,
+	0x69, 0x63, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x48, 0x0d, 0x52, 0x18, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x48, 0x61, 0x70, 0x74, 0x69, 0x63, 0x4c, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x48, 0x0e, 0x52, 0x14, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x4d, 0x6f, 0x74, 0x6f, 0x72, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64,
+	0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x32, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x0f, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x32, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x16, 0x72, 0x75,
+	0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x10, 0x52, 0x14, 0x72, 0x75,
+	0x6d, 0x62, 0x6c, 0x65, 0x45, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x15, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f,
+	0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x11, 0x52, 0x13, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x45, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x66, 0x74, 0x88, 0x01, 0x01, 0x12, 0x30,
+	0x0a, 0x11, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f,
+	0x6e, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x12, 0x52, 0x10, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x48, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x2a, 0x0a, 0x0e, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x73, 0x70, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x13, 0x52, 0x0d, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x63, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x14, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x69, 0x63, 0x88, 0x01, 0x01,
+	0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x69, 0x63, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x15, 0x52, 0x09, 0x6d, 0x69, 0x63, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x65, 0x63, 0x68, 0x6f, 0x5f, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x16, 0x52, 0x10, 0x65, 0x63, 0x68, 0x6f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x45, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x33, 0x0a, 0x13, 0x6e, 0x6f, 0x69, 0x73, 0x65,
+	0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x17, 0x52, 0x11, 0x6e, 0x6f, 0x69, 0x73, 0x65, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x18, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x2f, 0x0a, 0x11, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x19, 0x52, 0x0f, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x1b,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x1a, 0x52, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x67, 0x68,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x5f, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x48, 0x1b,
+	0x52, 0x0e, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x61, 0x76, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x48, 0x1c,
+	0x52, 0x0f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x61, 0x76,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x5f, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x1d, 0x52, 0x0f, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x61,
+	0x76, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x1e, 0x52, 0x0e, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x61, 0x76,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x69, 0x63, 0x5f, 0x6d, 0x75, 0x74, 0x65,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x08, 0x48, 0x1f, 0x52, 0x07, 0x6d, 0x69, 0x63, 0x4d, 0x75, 0x74,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x5f,
+	0x6d, 0x75, 0x74, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x48, 0x20, 0x52, 0x0b, 0x73, 0x70,
+	0x65, 0x61, 0x6b, 0x65, 0x72, 0x4d, 0x75, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e,
+	0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x22,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x21, 0x52, 0x0d, 0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e,
+	0x65, 0x4d, 0x75, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x68, 0x61, 0x70, 0x74,
+	0x69, 0x63, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x08, 0x48, 0x22, 0x52,
+	0x0a, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x4d, 0x75, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2f,
+	0x0a, 0x11, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f,
+	0x66, 0x66, 0x62, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x23, 0x52, 0x0f, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x46, 0x66, 0x62, 0x88, 0x01, 0x01, 0x12,
+	0x2d, 0x0a, 0x10, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f,
+	0x66, 0x66, 0x62, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x24, 0x52, 0x0e, 0x6c, 0x65, 0x66,
+	0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x46, 0x66, 0x62, 0x88, 0x01, 0x01, 0x12, 0x2a,
+	0x0a, 0x0e, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x26, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x25, 0x52, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x1d, 0x74, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x5f, 0x72, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x27, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x26, 0x52, 0x1a, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x4d, 0x6f, 0x74, 0x6f,
+	0x72, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x44, 0x0a, 0x1c, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x74,
+	0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x27, 0x52, 0x19, 0x72, 0x75, 0x6d, 0x62,
+	0x6c, 0x65, 0x4d, 0x6f, 0x74, 0x6f, 0x72, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x64, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x15, 0x73, 0x70, 0x65, 0x61,
+	0x6b, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x5f, 0x67, 0x61, 0x69,
+	0x6e, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x28, 0x52, 0x12, 0x73, 0x70, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x43, 0x6f, 0x6d, 0x70, 0x50, 0x72, 0x65, 0x47, 0x61, 0x69, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x32, 0x0a, 0x12, 0x62, 0x65, 0x61, 0x6d, 0x66, 0x6f, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x08, 0x48, 0x29, 0x52, 0x11,
+	0x62, 0x65, 0x61, 0x6d, 0x66, 0x6f, 0x72, 0x6d, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x46, 0x0a, 0x1d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x62, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x08, 0x48, 0x2a, 0x52, 0x1a, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e,
+	0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x4b, 0x0a, 0x20,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x66, 0x61, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x2c, 0x20, 0x01, 0x28, 0x08, 0x48, 0x2b, 0x52, 0x1c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x46, 0x61, 0x64, 0x65, 0x41, 0x6e, 0x69,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x20, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x69, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x75, 0x6d,
+	0x62, 0x6c, 0x65, 0x5f, 0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x2d, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x2c, 0x52, 0x1d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6d, 0x70,
+	0x72, 0x6f, 0x76, 0x65, 0x64, 0x52, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x45, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x16, 0x68, 0x61, 0x70, 0x74, 0x69,
+	0x63, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x08, 0x48, 0x2d, 0x52, 0x13, 0x68, 0x61, 0x70, 0x74, 0x69,
+	0x63, 0x4c, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x88, 0x01,
+	0x01, 0x12, 0x35, 0x0a, 0x14, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x66, 0x61, 0x64, 0x65, 0x5f,
+	0x61, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x2e, 0x52, 0x12, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x46, 0x61, 0x64, 0x65, 0x41, 0x6e, 0x69, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x62, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x30, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x2f, 0x52, 0x0f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x72, 0x69, 0x67, 0x68,
+	0x74, 0x6e, 0x65, 0x73, 0x73, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x31, 0x18, 0x31, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x30, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x31, 0x88,
+	0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x32, 0x18, 0x32, 0x20, 0x01, 0x28, 0x08, 0x48, 0x31, 0x52, 0x0c, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x32, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x33, 0x18, 0x33, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x32, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x69, 0x67,
+	0x68, 0x74, 0x33, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x34, 0x18, 0x34, 0x20, 0x01, 0x28, 0x08, 0x48, 0x33, 0x52,
+	0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x34, 0x88, 0x01, 0x01,
+	0x12, 0x28, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x35, 0x18, 0x35, 0x20, 0x01, 0x28, 0x08, 0x48, 0x34, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x35, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x66, 0x61, 0x64, 0x65, 0x18,
+	0x36, 0x20, 0x01, 0x28, 0x08, 0x48, 0x35, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c,
+	0x69, 0x67, 0x68, 0x74, 0x46, 0x61, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x6c,
+	0x65, 0x64, 0x5f, 0x72, 0x65, 0x64, 0x18, 0x37, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x36, 0x52, 0x06,
+	0x6c, 0x65, 0x64, 0x52, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x6c, 0x65, 0x64,
+	0x5f, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x38, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x37, 0x52, 0x08,
+	0x6c, 0x65, 0x64, 0x47, 0x72, 0x65, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x6c,
+	0x65, 0x64, 0x5f, 0x62, 0x6c, 0x75, 0x65, 0x18, 0x39, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x38, 0x52,
+	0x07, 0x6c, 0x65, 0x64, 0x42, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x42, 0x1a, 0x0a, 0x18, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x65, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x75, 0x73, 0x65, 0x5f,
+	0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x70, 0x74, 0x69,
+	0x63, 0x73, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x66, 0x66, 0x62, 0x42, 0x19,
+	0x0a, 0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x74, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x66, 0x66, 0x62, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73,
+	0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x42, 0x13, 0x0a,
+	0x11, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x63, 0x5f, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64,
+	0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f,
+	0x6d, 0x75, 0x74, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x65, 0x73,
+	0x65, 0x74, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
+	0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x75, 0x64,
+	0x69, 0x6f, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x32, 0x42, 0x19, 0x0a, 0x17, 0x5f,
+	0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x72, 0x69, 0x67, 0x68, 0x74, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x72, 0x75, 0x6d, 0x62, 0x6c,
+	0x65, 0x5f, 0x65, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x66, 0x74,
+	0x42, 0x14, 0x0a, 0x12, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x5f, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x63, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x69, 0x63,
+	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x65, 0x63, 0x68, 0x6f,
+	0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x16,
+	0x0a, 0x14, 0x5f, 0x6e, 0x6f, 0x69, 0x73, 0x65, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x42, 0x14, 0x0a,
+	0x12, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x5f, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6d, 0x6f, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x42, 0x14, 0x0a,
+	0x12, 0x5f, 0x68, 0x61, 0x70, 0x74, 0x69, 0x63, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73,
+	0x61, 0x76, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x69, 0x63,
+	0x5f, 0x6d, 0x75, 0x74, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65,
+	0x72, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x70,
+	0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x68, 0x61,
+	0x70, 0x74, 0x69, 0x63, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x66, 0x66, 0x62, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x5f, 0x66, 0x66, 0x62, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x20, 0x0a, 0x1e, 0x5f, 0x74, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x72, 0x75,
+	0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x5f, 0x72, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x73,
+	0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x5f,
+	0x67, 0x61, 0x69, 0x6e, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x62, 0x65, 0x61, 0x6d, 0x66, 0x6f, 0x72,
+	0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x20, 0x0a, 0x1e, 0x5f,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x62, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x23, 0x0a,
+	0x21, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x66, 0x61, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x23, 0x0a, 0x21, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x6d,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x72, 0x75, 0x6d, 0x62, 0x6c, 0x65, 0x5f, 0x65, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x68, 0x61, 0x70, 0x74,
+	0x69, 0x63, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x66, 0x61, 0x64,
+	0x65, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x13, 0x0a, 0x11, 0x5f,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x62, 0x72, 0x69, 0x67, 0x68, 0x74, 0x6e, 0x65, 0x73, 0x73,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x31, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x32, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x33, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x34, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x35, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x66, 0x61, 0x64, 0x65,
+	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x6c, 0x65, 0x64, 0x5f, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6c,
+	0x65, 0x64, 0x5f, 0x62, 0x6c, 0x75, 0x65, 0x32, 0xc0, 0x02, 0x0a, 0x09, 0x44, 0x75, 0x61, 0x6c,
+	0x53, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73,
+	0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x45, 0x0a, 0x0b,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x1d, 0x2e, 0x64, 0x75,
+	0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x75, 0x61,
+	0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73,
+	0x65, 0x2e, 0x53, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65,
+	0x6e, 0x73, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x6b, 0x61, 0x73, 0x68, 0x61,
+	0x6e, 0x30, 0x32, 0x2f, 0x64, 0x75, 0x61, 0x6c, 0x73, 0x65, 0x6e, 0x73, 0x65, 0x2d, 0x67, 0x6f,
+	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dualsense_proto_rawDescOnce sync.Once
+	file_dualsense_proto_rawDescData = file_dualsense_proto_rawDesc
+)
+
+func file_dualsense_proto_rawDescGZIP() []byte {
+	file_dualsense_proto_rawDescOnce.Do(func() {
+		file_dualsense_proto_rawDescData = protoimpl.X.CompressGZIP(file_dualsense_proto_rawDescData)
+	})
+	return file_dualsense_proto_rawDescData
+}
+
+var file_dualsense_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_dualsense_proto_goTypes = []any{
+	(*Device)(nil),                 // 0: dualsense.Device
+	(*ListDevicesRequest)(nil),     // 1: dualsense.ListDevicesRequest
+	(*ListDevicesResponse)(nil),    // 2: dualsense.ListDevicesResponse
+	(*GetInputStateRequest)(nil),   // 3: dualsense.GetInputStateRequest
+	(*StreamInputRequest)(nil),     // 4: dualsense.StreamInputRequest
+	(*InputEvent)(nil),             // 5: dualsense.InputEvent
+	(*SetOutputStateRequest)(nil),  // 6: dualsense.SetOutputStateRequest
+	(*SetOutputStateResponse)(nil), // 7: dualsense.SetOutputStateResponse
+	(*TouchFinger)(nil),            // 8: dualsense.TouchFinger
+	(*InputState)(nil),             // 9: dualsense.InputState
+	(*OutputState)(nil),            // 10: dualsense.OutputState
+}
+var file_dualsense_proto_depIdxs = []int32{
+	0,  // 0: dualsense.ListDevicesResponse.devices:type_name -> dualsense.Device
+	9,  // 1: dualsense.InputEvent.state:type_name -> dualsense.InputState
+	10, // 2: dualsense.SetOutputStateRequest.state:type_name -> dualsense.OutputState
+	10, // 3: dualsense.SetOutputStateResponse.state:type_name -> dualsense.OutputState
+	8,  // 4: dualsense.InputState.touch_finger1:type_name -> dualsense.TouchFinger
+	8,  // 5: dualsense.InputState.touch_finger2:type_name -> dualsense.TouchFinger
+	1,  // 6: dualsense.DualSense.ListDevices:input_type -> dualsense.ListDevicesRequest
+	3,  // 7: dualsense.DualSense.GetInputState:input_type -> dualsense.GetInputStateRequest
+	4,  // 8: dualsense.DualSense.StreamInput:input_type -> dualsense.StreamInputRequest
+	6,  // 9: dualsense.DualSense.SetOutputState:input_type -> dualsense.SetOutputStateRequest
+	2,  // 10: dualsense.DualSense.ListDevices:output_type -> dualsense.ListDevicesResponse
+	9,  // 11: dualsense.DualSense.GetInputState:output_type -> dualsense.InputState
+	5,  // 12: dualsense.DualSense.StreamInput:output_type -> dualsense.InputEvent
+	7,  // 13: dualsense.DualSense.SetOutputState:output_type -> dualsense.SetOutputStateResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_dualsense_proto_init() }
+func file_dualsense_proto_init() {
+	if File_dualsense_proto != nil {
+		return
+	}
+	file_dualsense_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dualsense_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dualsense_proto_goTypes,
+		DependencyIndexes: file_dualsense_proto_depIdxs,
+		MessageInfos:      file_dualsense_proto_msgTypes,
+	}.Build()
+	File_dualsense_proto = out.File
+	file_dualsense_proto_rawDesc = nil
+	file_dualsense_proto_goTypes = nil
+	file_dualsense_proto_depIdxs = nil
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: dualsense.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DualSense_ListDevices_FullMethodName    = "/dualsense.DualSense/ListDevices"
+	DualSense_GetInputState_FullMethodName  = "/dualsense.DualSense/GetInputState"
+	DualSense_StreamInput_FullMethodName    = "/dualsense.DualSense/StreamInput"
+	DualSense_SetOutputState_FullMethodName = "/dualsense.DualSense/SetOutputState"
+)
+
+// DualSenseClient is the client API for DualSense service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DualSense gives remote access to the controllers registered with a server.
+type DualSenseClient interface {
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	GetInputState(ctx context.Context, in *GetInputStateRequest, opts ...grpc.CallOption) (*InputState, error)
+	// StreamInput sends an InputEvent whenever the input state changes. Events
+	// are coalesced when the client reads slower than the controller reports.
+	StreamInput(ctx context.Context, in *StreamInputRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InputEvent], error)
+	SetOutputState(ctx context.Context, in *SetOutputStateRequest, opts ...grpc.CallOption) (*SetOutputStateResponse, error)
+}
+
+type dualSenseClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDualSenseClient(cc grpc.ClientConnInterface) DualSenseClient {
+	return &dualSenseClient{cc}
+}
+
+func (c *dualSenseClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, DualSense_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dualSenseClient) GetInputState(ctx context.Context, in *GetInputStateRequest, opts ...grpc.CallOption) (*InputState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InputState)
+	err := c.cc.Invoke(ctx, DualSense_GetInputState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dualSenseClient) StreamInput(ctx context.Context, in *StreamInputRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InputEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DualSense_ServiceDesc.Streams[0], DualSense_StreamInput_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamInputRequest, InputEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DualSense_StreamInputClient = grpc.ServerStreamingClient[InputEvent]
+
+func (c *dualSenseClient) SetOutputState(ctx context.Context, in *SetOutputStateRequest, opts ...grpc.CallOption) (*SetOutputStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOutputStateResponse)
+	err := c.cc.Invoke(ctx, DualSense_SetOutputState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DualSenseServer is the server API for DualSense service.
+// All implementations must embed UnimplementedDualSenseServer
+// for forward compatibility.
+//
+// DualSense gives remote access to the controllers registered with a server.
+type DualSenseServer interface {
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	GetInputState(context.Context, *GetInputStateRequest) (*InputState, error)
+	// StreamInput sends an InputEvent whenever the input state changes. Events
+	// are coalesced when the client reads slower than the controller reports.
+	StreamInput(*StreamInputRequest, grpc.ServerStreamingServer[InputEvent]) error
+	SetOutputState(context.Context, *SetOutputStateRequest) (*SetOutputStateResponse, error)
+	mustEmbedUnimplementedDualSenseServer()
+}
+
+// UnimplementedDualSenseServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDualSenseServer struct{}
+
+func (UnimplementedDualSenseServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDualSenseServer) GetInputState(context.Context, *GetInputStateRequest) (*InputState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInputState not implemented")
+}
+func (UnimplementedDualSenseServer) StreamInput(*StreamInputRequest, grpc.ServerStreamingServer[InputEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamInput not implemented")
+}
+func (UnimplementedDualSenseServer) SetOutputState(context.Context, *SetOutputStateRequest) (*SetOutputStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOutputState not implemented")
+}
+func (UnimplementedDualSenseServer) mustEmbedUnimplementedDualSenseServer() {}
+func (UnimplementedDualSenseServer) testEmbeddedByValue()                   {}
+
+// UnsafeDualSenseServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DualSenseServer will
+// result in compilation errors.
+type UnsafeDualSenseServer interface {
+	mustEmbedUnimplementedDualSenseServer()
+}
+
+func RegisterDualSenseServer(s grpc.ServiceRegistrar, srv DualSenseServer) {
+	// If the following call pancis, it indicates UnimplementedDualSenseServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DualSense_ServiceDesc, srv)
+}
+
+func _DualSense_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DualSenseServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DualSense_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DualSenseServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DualSense_GetInputState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInputStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DualSenseServer).GetInputState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DualSense_GetInputState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DualSenseServer).GetInputState(ctx, req.(*GetInputStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DualSense_StreamInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamInputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DualSenseServer).StreamInput(m, &grpc.GenericServerStream[StreamInputRequest, InputEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DualSense_StreamInputServer = grpc.ServerStreamingServer[InputEvent]
+
+func _DualSense_SetOutputState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOutputStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DualSenseServer).SetOutputState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DualSense_SetOutputState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DualSenseServer).SetOutputState(ctx, req.(*SetOutputStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DualSense_ServiceDesc is the grpc.ServiceDesc for DualSense service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DualSense_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dualsense.DualSense",
+	HandlerType: (*DualSenseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _DualSense_ListDevices_Handler,
+		},
+		{
+			MethodName: "GetInputState",
+			Handler:    _DualSense_GetInputState_Handler,
+		},
+		{
+			MethodName: "SetOutputState",
+			Handler:    _DualSense_SetOutputState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInput",
+			Handler:       _DualSense_StreamInput_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dualsense.proto",
+}
@@ -0,0 +1,5 @@
+package rpc
+
+// buf compiles dualsense.proto itself, so no protoc install is needed; the
+// compiler and plugin versions are pinned here and in buf.gen.yaml.
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.47.2 generate
@@ -0,0 +1,19 @@
+module github.com/nikashan02/dualsense-go/rpc
+
+go 1.22.3
+
+require (
+	github.com/nikashan02/dualsense-go v0.0.0
+	google.golang.org/grpc v1.67.1
+	google.golang.org/protobuf v1.35.2
+)
+
+require (
+	github.com/sstallion/go-hid v0.14.1 // indirect
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sys v0.24.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+)
+
+replace github.com/nikashan02/dualsense-go => ../
@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"encoding"
+	"fmt"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+)
+
+// outputStateDecoder collects the updates for the fields set in an
+// OutputState, keeping the first invalid one as err.
+type outputStateDecoder struct {
+	updates []func(*dualsense.SetStateData)
+	err     error
+}
+
+func (d *outputStateDecoder) fail(name string, err error) {
+	if d.err == nil {
+		d.err = fmt.Errorf("invalid OutputState %s: %w", name, err)
+	}
+}
+
+func decodeValue[T any](d *outputStateDecoder, value *T, field func(*dualsense.SetStateData) *T) {
+	if value == nil {
+		return
+	}
+	v := *value
+	d.updates = append(d.updates, func(s *dualsense.SetStateData) {
+		*field(s) = v
+	})
+}
+
+func decodeUint8[T ~uint8](d *outputStateDecoder, value *uint32, name string, field func(*dualsense.SetStateData) *T) {
+	if value == nil {
+		return
+	}
+	if *value > 0xFF {
+		d.fail(name, fmt.Errorf("%w: %d does not fit a byte", dualsense.ErrOutOfRange, *value))
+		return
+	}
+	v := T(*value)
+	decodeValue(d, &v, field)
+}
+
+func decodePowerReduction(d *outputStateDecoder, value *uint32, name string, field func(*dualsense.SetStateData) *dualsense.PowerReduction) {
+	if value != nil && *value <= 0xFF {
+		err := dualsense.PowerReduction(*value).Validate()
+		if err != nil {
+			d.fail(name, err)
+			return
+		}
+	}
+	decodeUint8(d, value, name, field)
+}
+
+func decodeEnum[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](d *outputStateDecoder, value *string, name string, field func(*dualsense.SetStateData) *T) {
+	if value == nil {
+		return
+	}
+	var v T
+	err := PT(&v).UnmarshalText([]byte(*value))
+	if err != nil {
+		d.fail(name, err)
+		return
+	}
+	decodeValue(d, &v, field)
+}
+
+func decodeTrigger(d *outputStateDecoder, value []byte, name string, field func(*dualsense.SetStateData) *[11]uint8) {
+	if value == nil {
+		return
+	}
+	var v [11]uint8
+	if len(value) != len(v) {
+		d.fail(name, fmt.Errorf("got %d bytes, want %d", len(value), len(v)))
+		return
+	}
+	copy(v[:], value)
+	decodeValue(d, &v, field)
+}
+
+// outputStateUpdates validates the fields set in state and returns updates
+// applying them to a SetStateData, so a request is decoded once and never
+// reaches the controller half applied.
+func outputStateUpdates(state *OutputState) ([]func(*dualsense.SetStateData), error) {
+	var d outputStateDecoder
+	if state == nil {
+		return nil, nil
+	}
+	decodeValue(&d, state.EnableRumbleEmulation, func(s *dualsense.SetStateData) *bool { return &s.EnableRumbleEmulation })
+	decodeValue(&d, state.UseRumbleNotHaptics, func(s *dualsense.SetStateData) *bool { return &s.UseRumbleNotHaptics })
+	decodeValue(&d, state.AllowRightTriggerFfb, func(s *dualsense.SetStateData) *bool { return &s.AllowRightTriggerFFB })
+	decodeValue(&d, state.AllowLeftTriggerFfb, func(s *dualsense.SetStateData) *bool { return &s.AllowLeftTriggerFFB })
+	decodeValue(&d, state.AllowHeadphoneVolume, func(s *dualsense.SetStateData) *bool { return &s.AllowHeadphoneVolume })
+	decodeValue(&d, state.AllowSpeakerVolume, func(s *dualsense.SetStateData) *bool { return &s.AllowSpeakerVolume })
+	decodeValue(&d, state.AllowMicVolume, func(s *dualsense.SetStateData) *bool { return &s.AllowMicVolume })
+	decodeValue(&d, state.AllowAudioControl, func(s *dualsense.SetStateData) *bool { return &s.AllowAudioControl })
+	decodeValue(&d, state.AllowMuteLight, func(s *dualsense.SetStateData) *bool { return &s.AllowMuteLight })
+	decodeValue(&d, state.AllowAudioMute, func(s *dualsense.SetStateData) *bool { return &s.AllowAudioMute })
+	decodeValue(&d, state.AllowLedColor, func(s *dualsense.SetStateData) *bool { return &s.AllowLedColor })
+	decodeValue(&d, state.ResetLights, func(s *dualsense.SetStateData) *bool { return &s.ResetLights })
+	decodeValue(&d, state.AllowPlayerIndicators, func(s *dualsense.SetStateData) *bool { return &s.AllowPlayerIndicators })
+	decodeValue(&d, state.AllowHapticLowPassFilter, func(s *dualsense.SetStateData) *bool { return &s.AllowHapticLowPassFilter })
+	decodeValue(&d, state.AllowMotorPowerLevel, func(s *dualsense.SetStateData) *bool { return &s.AllowMotorPowerLevel })
+	decodeValue(&d, state.AllowAudioControl2, func(s *dualsense.SetStateData) *bool { return &s.AllowAudioControl2 })
+	decodeUint8(&d, state.RumbleEmulationRight, "RumbleEmulationRight", func(s *dualsense.SetStateData) *uint8 { return &s.RumbleEmulationRight })
+	decodeUint8(&d, state.RumbleEmulationLeft, "RumbleEmulationLeft", func(s *dualsense.SetStateData) *uint8 { return &s.RumbleEmulationLeft })
+	decodeUint8(&d, state.VolumeHeadphones, "VolumeHeadphones", func(s *dualsense.SetStateData) *uint8 { return &s.VolumeHeadphones })
+	decodeUint8(&d, state.VolumeSpeaker, "VolumeSpeaker", func(s *dualsense.SetStateData) *uint8 { return &s.VolumeSpeaker })
+	decodeUint8(&d, state.VolumeMic, "VolumeMic", func(s *dualsense.SetStateData) *uint8 { return &s.VolumeMic })
+	decodeEnum(&d, state.MicSelect, "MicSelect", func(s *dualsense.SetStateData) *dualsense.MicSelectType { return &s.MicSelect })
+	decodeValue(&d, state.EchoCancelEnable, func(s *dualsense.SetStateData) *bool { return &s.EchoCancelEnable })
+	decodeValue(&d, state.NoiseCancelEnable, func(s *dualsense.SetStateData) *bool { return &s.NoiseCancelEnable })
+	decodeUint8(&d, state.OutputPathSelect, "OutputPathSelect", func(s *dualsense.SetStateData) *uint8 { return &s.OutputPathSelect })
+	decodeUint8(&d, state.InputPathSelect, "InputPathSelect", func(s *dualsense.SetStateData) *uint8 { return &s.InputPathSelect })
+	decodeEnum(&d, state.MuteLight, "MuteLight", func(s *dualsense.SetStateData) *dualsense.MuteLightMode { return &s.MuteLight })
+	decodeValue(&d, state.TouchPowerSave, func(s *dualsense.SetStateData) *bool { return &s.TouchPowerSave })
+	decodeValue(&d, state.MotionPowerSave, func(s *dualsense.SetStateData) *bool { return &s.MotionPowerSave })
+	decodeValue(&d, state.HapticPowerSave, func(s *dualsense.SetStateData) *bool { return &s.HapticPowerSave })
+	decodeValue(&d, state.AudioPowerSave, func(s *dualsense.SetStateData) *bool { return &s.AudioPowerSave })
+	decodeValue(&d, state.MicMute, func(s *dualsense.SetStateData) *bool { return &s.MicMute })
+	decodeValue(&d, state.SpeakerMute, func(s *dualsense.SetStateData) *bool { return &s.SpeakerMute })
+	decodeValue(&d, state.HeadphoneMute, func(s *dualsense.SetStateData) *bool { return &s.HeadphoneMute })
+	decodeValue(&d, state.HapticMute, func(s *dualsense.SetStateData) *bool { return &s.HapticMute })
+	decodeTrigger(&d, state.RightTriggerFfb, "RightTriggerFfb", func(s *dualsense.SetStateData) *[11]uint8 { return &s.RightTriggerFFB })
+	decodeTrigger(&d, state.LeftTriggerFfb, "LeftTriggerFfb", func(s *dualsense.SetStateData) *[11]uint8 { return &s.LeftTriggerFFB })
+	decodeValue(&d, state.HostTimestamp, func(s *dualsense.SetStateData) *uint32 { return &s.HostTimestamp })
+	decodePowerReduction(&d, state.TriggerMotorPowerReduction, "TriggerMotorPowerReduction", func(s *dualsense.SetStateData) *dualsense.PowerReduction { return &s.TriggerMotorPowerReduction })
+	decodePowerReduction(&d, state.RumbleMotorPowerReduction, "RumbleMotorPowerReduction", func(s *dualsense.SetStateData) *dualsense.PowerReduction { return &s.RumbleMotorPowerReduction })
+	decodeUint8(&d, state.SpeakerCompPreGain, "SpeakerCompPreGain", func(s *dualsense.SetStateData) *uint8 { return &s.SpeakerCompPreGain })
+	decodeValue(&d, state.BeamformingEnable, func(s *dualsense.SetStateData) *bool { return &s.BeamformingEnable })
+	decodeValue(&d, state.AllowLightBrightnessChange, func(s *dualsense.SetStateData) *bool { return &s.AllowLightBrightnessChange })
+	decodeValue(&d, state.AllowColorLightFadeAnimation, func(s *dualsense.SetStateData) *bool { return &s.AllowColorLightFadeAnimation })
+	decodeValue(&d, state.EnableImprovedRumbleEmulation, func(s *dualsense.SetStateData) *bool { return &s.EnableImprovedRumbleEmulation })
+	decodeValue(&d, state.HapticLowPassFilter, func(s *dualsense.SetStateData) *bool { return &s.HapticLowPassFilter })
+	decodeEnum(&d, state.LightFadeAnimation, "LightFadeAnimation", func(s *dualsense.SetStateData) *dualsense.LightFadeAnimation { return &s.LightFadeAnimation })
+	decodeEnum(&d, state.LightBrightness, "LightBrightness", func(s *dualsense.SetStateData) *dualsense.LightBrightness { return &s.LightBrightness })
+	decodeValue(&d, state.PlayerLight1, func(s *dualsense.SetStateData) *bool { return &s.PlayerLight1 })
+	decodeValue(&d, state.PlayerLight2, func(s *dualsense.SetStateData) *bool { return &s.PlayerLight2 })
+	decodeValue(&d, state.PlayerLight3, func(s *dualsense.SetStateData) *bool { return &s.PlayerLight3 })
+	decodeValue(&d, state.PlayerLight4, func(s *dualsense.SetStateData) *bool { return &s.PlayerLight4 })
+	decodeValue(&d, state.PlayerLight5, func(s *dualsense.SetStateData) *bool { return &s.PlayerLight5 })
+	decodeValue(&d, state.PlayerLightFade, func(s *dualsense.SetStateData) *bool { return &s.PlayerLightFade })
+	decodeUint8(&d, state.LedRed, "LedRed", func(s *dualsense.SetStateData) *uint8 { return &s.LedRed })
+	decodeUint8(&d, state.LedGreen, "LedGreen", func(s *dualsense.SetStateData) *uint8 { return &s.LedGreen })
+	decodeUint8(&d, state.LedBlue, "LedBlue", func(s *dualsense.SetStateData) *uint8 { return &s.LedBlue })
+	return d.updates, d.err
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// newOutputState converts s with every field set.
+func newOutputState(s dualsense.SetStateData) *OutputState {
+	return &OutputState{
+		EnableRumbleEmulation:         ptr(s.EnableRumbleEmulation),
+		UseRumbleNotHaptics:           ptr(s.UseRumbleNotHaptics),
+		AllowRightTriggerFfb:          ptr(s.AllowRightTriggerFFB),
+		AllowLeftTriggerFfb:           ptr(s.AllowLeftTriggerFFB),
+		AllowHeadphoneVolume:          ptr(s.AllowHeadphoneVolume),
+		AllowSpeakerVolume:            ptr(s.AllowSpeakerVolume),
+		AllowMicVolume:                ptr(s.AllowMicVolume),
+		AllowAudioControl:             ptr(s.AllowAudioControl),
+		AllowMuteLight:                ptr(s.AllowMuteLight),
+		AllowAudioMute:                ptr(s.AllowAudioMute),
+		AllowLedColor:                 ptr(s.AllowLedColor),
+		ResetLights:                   ptr(s.ResetLights),
+		AllowPlayerIndicators:         ptr(s.AllowPlayerIndicators),
+		AllowHapticLowPassFilter:      ptr(s.AllowHapticLowPassFilter),
+		AllowMotorPowerLevel:          ptr(s.AllowMotorPowerLevel),
+		AllowAudioControl2:            ptr(s.AllowAudioControl2),
+		RumbleEmulationRight:          ptr(uint32(s.RumbleEmulationRight)),
+		RumbleEmulationLeft:           ptr(uint32(s.RumbleEmulationLeft)),
+		VolumeHeadphones:              ptr(uint32(s.VolumeHeadphones)),
+		VolumeSpeaker:                 ptr(uint32(s.VolumeSpeaker)),
+		VolumeMic:                     ptr(uint32(s.VolumeMic)),
+		MicSelect:                     ptr(s.MicSelect.String()),
+		EchoCancelEnable:              ptr(s.EchoCancelEnable),
+		NoiseCancelEnable:             ptr(s.NoiseCancelEnable),
+		OutputPathSelect:              ptr(uint32(s.OutputPathSelect)),
+		InputPathSelect:               ptr(uint32(s.InputPathSelect)),
+		MuteLight:                     ptr(s.MuteLight.String()),
+		TouchPowerSave:                ptr(s.TouchPowerSave),
+		MotionPowerSave:               ptr(s.MotionPowerSave),
+		HapticPowerSave:               ptr(s.HapticPowerSave),
+		AudioPowerSave:                ptr(s.AudioPowerSave),
+		MicMute:                       ptr(s.MicMute),
+		SpeakerMute:                   ptr(s.SpeakerMute),
+		HeadphoneMute:                 ptr(s.HeadphoneMute),
+		HapticMute:                    ptr(s.HapticMute),
+		RightTriggerFfb:               s.RightTriggerFFB[:],
+		LeftTriggerFfb:                s.LeftTriggerFFB[:],
+		HostTimestamp:                 ptr(s.HostTimestamp),
+		TriggerMotorPowerReduction:    ptr(uint32(s.TriggerMotorPowerReduction)),
+		RumbleMotorPowerReduction:     ptr(uint32(s.RumbleMotorPowerReduction)),
+		SpeakerCompPreGain:            ptr(uint32(s.SpeakerCompPreGain)),
+		BeamformingEnable:             ptr(s.BeamformingEnable),
+		AllowLightBrightnessChange:    ptr(s.AllowLightBrightnessChange),
+		AllowColorLightFadeAnimation:  ptr(s.AllowColorLightFadeAnimation),
+		EnableImprovedRumbleEmulation: ptr(s.EnableImprovedRumbleEmulation),
+		HapticLowPassFilter:           ptr(s.HapticLowPassFilter),
+		LightFadeAnimation:            ptr(s.LightFadeAnimation.String()),
+		LightBrightness:               ptr(s.LightBrightness.String()),
+		PlayerLight1:                  ptr(s.PlayerLight1),
+		PlayerLight2:                  ptr(s.PlayerLight2),
+		PlayerLight3:                  ptr(s.PlayerLight3),
+		PlayerLight4:                  ptr(s.PlayerLight4),
+		PlayerLight5:                  ptr(s.PlayerLight5),
+		PlayerLightFade:               ptr(s.PlayerLightFade),
+		LedRed:                        ptr(uint32(s.LedRed)),
+		LedGreen:                      ptr(uint32(s.LedGreen)),
+		LedBlue:                       ptr(uint32(s.LedBlue)),
+	}
+}
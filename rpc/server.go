@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the DualSense gRPC service for a set of registered
+// controllers.
+type Server struct {
+	UnimplementedDualSenseServer
+	devicesMu sync.RWMutex
+	devices   map[string]dualsense.Controller
+}
+
+func NewServer() *Server {
+	return &Server{devices: map[string]dualsense.Controller{}}
+}
+
+// Register makes controller available to clients under id. Input is only
+// subscribed to while a StreamInput call is open.
+func (s *Server) Register(id string, controller dualsense.Controller) error {
+	s.devicesMu.Lock()
+	defer s.devicesMu.Unlock()
+	if _, ok := s.devices[id]; ok {
+		return fmt.Errorf("error registering DualSense controller: %q is already registered", id)
+	}
+	s.devices[id] = controller
+	return nil
+}
+
+// Unregister removes the controller registered under id. Open streams of it
+// keep running until their clients cancel them.
+func (s *Server) Unregister(id string) {
+	s.devicesMu.Lock()
+	defer s.devicesMu.Unlock()
+	delete(s.devices, id)
+}
+
+func (s *Server) device(id string) (dualsense.Controller, error) {
+	s.devicesMu.RLock()
+	defer s.devicesMu.RUnlock()
+	controller, ok := s.devices[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no DualSense controller registered as %q", id)
+	}
+	return controller, nil
+}
+
+func (s *Server) ListDevices(ctx context.Context, request *ListDevicesRequest) (*ListDevicesResponse, error) {
+	s.devicesMu.RLock()
+	defer s.devicesMu.RUnlock()
+	response := &ListDevicesResponse{}
+	for id := range s.devices {
+		response.Devices = append(response.Devices, &Device{Id: id})
+	}
+	slices.SortFunc(response.Devices, func(a, b *Device) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return response, nil
+}
+
+func (s *Server) GetInputState(ctx context.Context, request *GetInputStateRequest) (*InputState, error) {
+	controller, err := s.device(request.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+	return newInputState(controller.GetInStateData()), nil
+}
+
+func (s *Server) StreamInput(request *StreamInputRequest, stream grpc.ServerStreamingServer[InputEvent]) error {
+	controller, err := s.device(request.DeviceId)
+	if err != nil {
+		return err
+	}
+	fields := dualsense.Fields()
+	if len(request.Fields) > 0 {
+		fields = nil
+		for _, name := range request.Fields {
+			field, err := dualsense.ParseField(name)
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			fields = append(fields, field)
+		}
+	}
+	// Callbacks only wake the stream without blocking; it reads the latest
+	// state itself, so missed wake-ups only coalesce events.
+	changed := make(chan struct{}, 1)
+	for _, field := range fields {
+		cancel, err := controller.OnFieldChange(field, func(dualsense.FieldChange) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		defer cancel()
+	}
+
+	last := controller.GetInStateData()
+	err = stream.Send(&InputEvent{DeviceId: request.DeviceId, State: newInputState(last), TimestampUnixNano: time.Now().UnixNano()})
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+		current := controller.GetInStateData()
+		var changedFields []string
+		for _, change := range dualsense.StateDiff(last, current) {
+			if slices.Contains(fields, change.Field) {
+				changedFields = append(changedFields, change.Field.String())
+			}
+		}
+		if len(changedFields) == 0 {
+			continue
+		}
+		last = current
+		err := stream.Send(&InputEvent{DeviceId: request.DeviceId, ChangedFields: changedFields, State: newInputState(current), TimestampUnixNano: time.Now().UnixNano()})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) SetOutputState(ctx context.Context, request *SetOutputStateRequest) (*SetOutputStateResponse, error) {
+	controller, err := s.device(request.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := outputStateUpdates(request.State)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = controller.UpdateState(func(setStateData *dualsense.SetStateData) {
+		for _, update := range updates {
+			update(setStateData)
+		}
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "error updating DualSense state: %v", err)
+	}
+	return &SetOutputStateResponse{State: newOutputState(controller.GetOutStateData())}, nil
+}
+
+func newTouchFinger(finger dualsense.TouchFinger) *TouchFinger {
+	return &TouchFinger{
+		Index:       uint32(finger.Index),
+		NotTouching: finger.NotTouching,
+		FingerX:     uint32(finger.FingerX),
+		FingerY:     uint32(finger.FingerY),
+	}
+}
+
+func newInputState(state dualsense.USBGetStateData) *InputState {
+	return &InputState{
+		LeftStickX:               uint32(state.LeftStickX),
+		LeftStickY:               uint32(state.LeftStickY),
+		RightStickX:              uint32(state.RightStickX),
+		RightStickY:              uint32(state.RightStickY),
+		TriggerLeft:              uint32(state.TriggerLeft),
+		TriggerRight:             uint32(state.TriggerRight),
+		SeqNo:                    uint32(state.SeqNo),
+		Dpad:                     state.DPad.String(),
+		ButtonSquare:             state.ButtonSquare,
+		ButtonCross:              state.ButtonCross,
+		ButtonCircle:             state.ButtonCircle,
+		ButtonTriangle:           state.ButtonTriangle,
+		ButtonL1:                 state.ButtonL1,
+		ButtonR1:                 state.ButtonR1,
+		ButtonL2:                 state.ButtonL2,
+		ButtonR2:                 state.ButtonR2,
+		ButtonCreate:             state.ButtonCreate,
+		ButtonOptions:            state.ButtonOptions,
+		ButtonL3:                 state.ButtonL3,
+		ButtonR3:                 state.ButtonR3,
+		ButtonHome:               state.ButtonHome,
+		ButtonPad:                state.ButtonPad,
+		ButtonMute:               state.ButtonMute,
+		ButtonLeftFunction:       state.ButtonLeftFunction,
+		ButtonRightFunction:      state.ButtonRightFunction,
+		ButtonLeftPaddle:         state.ButtonLeftPaddle,
+		ButtonRightPaddle:        state.ButtonRightPaddle,
+		AngularVelocityX:         int32(state.AngularVelocityX),
+		AngularVelocityZ:         int32(state.AngularVelocityZ),
+		AngularVelocityY:         int32(state.AngularVelocityY),
+		AccelerometerX:           int32(state.AccelerometerX),
+		AccelerometerY:           int32(state.AccelerometerY),
+		AccelerometerZ:           int32(state.AccelerometerZ),
+		SensorTimestamp:          state.SensorTimestamp,
+		Temperature:              int32(state.Temperature),
+		TouchFinger1:             newTouchFinger(state.TouchData.TouchFinger1),
+		TouchFinger2:             newTouchFinger(state.TouchData.TouchFinger2),
+		TouchTimestamp:           uint32(state.TouchData.Timestamp),
+		TriggerRightStopLocation: uint32(state.TriggerRightStopLocation),
+		TriggerRightStatus:       uint32(state.TriggerRightStatus),
+		TriggerLeftStopLocation:  uint32(state.TriggerLeftStopLocation),
+		TriggerLeftStatus:        uint32(state.TriggerLeftStatus),
+		HostTimestamp:            state.HostTimestamp,
+		TriggerRightEffect:       uint32(state.TriggerRightEffect),
+		TriggerLeftEffect:        uint32(state.TriggerLeftEffect),
+		DeviceTimestamp:          state.DeviceTimestamp,
+		PowerPercent:             uint32(state.PowerPercent),
+		PowerState:               state.PowerState.String(),
+		PluggedHeadphones:        state.PluggedHeadphones,
+		PluggedMic:               state.PluggedMic,
+		MicMuted:                 state.MicMuted,
+		PluggedUsbData:           state.PluggedUsbData,
+		PluggedUsbPower:          state.PluggedUsbPower,
+		PluggedExternalMic:       state.PluggedExternalMic,
+		HapticLowPassFilter:      state.HapticLowPassFilter,
+		AesCmac:                  state.AesCmac,
+	}
+}
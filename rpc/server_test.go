@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	dualsense "github.com/nikashan02/dualsense-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) (DualSenseClient, *Server, *dualsense.MockDualSense) {
+	controller := dualsense.NewMockDualSense()
+	if err := controller.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(controller.Close)
+	server := NewServer()
+	if err := server.Register("pad0", controller); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1 << 16)
+	grpcServer := grpc.NewServer()
+	RegisterDualSenseServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewDualSenseClient(conn), server, controller
+}
+
+func TestServer(t *testing.T) {
+	client, _, mock := newTestClient(t)
+	ctx := context.Background()
+
+	devices, err := client.ListDevices(ctx, &ListDevicesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices.Devices) != 1 || devices.Devices[0].Id != "pad0" {
+		t.Fatalf("got devices %v, want [pad0]", devices.Devices)
+	}
+
+	stream, err := client.StreamInput(ctx, &StreamInputRequest{DeviceId: "pad0", Fields: []string{"ButtonCross"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initial.State.ButtonCross {
+		t.Fatal("initial state has ButtonCross pressed")
+	}
+	mock.UpdateInState(func(state *dualsense.USBGetStateData) {
+		state.ButtonCross = true
+		state.DPad = dualsense.DirectionWest
+	})
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(event.ChangedFields) != 1 || event.ChangedFields[0] != "ButtonCross" || event.State.Dpad != "West" {
+		t.Fatalf("got event %v", event)
+	}
+
+	state, err := client.GetInputState(ctx, &GetInputStateRequest{DeviceId: "pad0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.ButtonCross {
+		t.Fatal("GetInputState: ButtonCross not pressed")
+	}
+
+	response, err := client.SetOutputState(ctx, &SetOutputStateRequest{DeviceId: "pad0", State: &OutputState{LedRed: ptr(uint32(200))}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := mock.GetOutStateData(); out.LedRed != 200 || out.LedGreen != 255 {
+		t.Fatalf("got output state %+v", out)
+	}
+	if response.State.GetLedRed() != 200 || response.State.GetLedGreen() != 255 {
+		t.Fatalf("got response state %v", response.State)
+	}
+	_, err = client.SetOutputState(ctx, &SetOutputStateRequest{DeviceId: "pad0", State: &OutputState{MuteLight: ptr("Blinking")}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument for an invalid mute light", err)
+	}
+	_, err = client.SetOutputState(ctx, &SetOutputStateRequest{DeviceId: "pad0", State: &OutputState{RightTriggerFfb: []byte{1, 2}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument for a short trigger effect", err)
+	}
+	_, err = client.GetInputState(ctx, &GetInputStateRequest{DeviceId: "pad1"})
+	if err == nil {
+		t.Fatal("expected error for unknown device")
+	}
+}
+
+func TestStreamInputUnsubscribes(t *testing.T) {
+	client, _, controller := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.StreamInput(ctx, &StreamInputRequest{DeviceId: "pad0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := controller.FieldSubscriptions(), len(dualsense.Fields()); got != want {
+		t.Fatalf("got %d subscriptions, want %d", got, want)
+	}
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for controller.FieldSubscriptions() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d subscriptions left after the stream ended", controller.FieldSubscriptions())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	client, server, _ := newTestClient(t)
+	server.Unregister("pad0")
+	_, err := client.GetInputState(context.Background(), &GetInputStateRequest{DeviceId: "pad0"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v, want NotFound after Unregister", err)
+	}
+	if err := server.Register("pad0", dualsense.NewMockDualSense()); err != nil {
+		t.Fatalf("Register after Unregister: %v", err)
+	}
+}
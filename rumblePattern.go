@@ -0,0 +1,84 @@
+package dualsense
+
+import (
+	"fmt"
+	"time"
+)
+
+// RumbleFrame holds the rumble motors at Left and Right for Duration. Left is
+// the heavier, low frequency motor.
+type RumbleFrame struct {
+	Left     uint8
+	Right    uint8
+	Duration time.Duration
+}
+
+// RumblePattern is a sequence of RumbleFrames played in order, see
+// PlayRumblePattern.
+type RumblePattern []RumbleFrame
+
+// Duration returns the total length of the pattern.
+func (p RumblePattern) Duration() time.Duration {
+	var duration time.Duration
+	for _, frame := range p {
+		duration += frame.Duration
+	}
+	return duration
+}
+
+// PlayRumblePattern plays pattern on the rumble motors and then restores the
+// previous rumble, unless it was changed while the pattern played. Frames are
+// timed from the start of the pattern, so slow writes do not stretch it. It
+// blocks for the whole pattern, so call it from its own goroutine rather than
+// from an input callback.
+func (d *DualSense) PlayRumblePattern(pattern RumblePattern) error {
+	return d.playRumblePattern(pattern, nil)
+}
+
+// playRumblePattern plays pattern until it ends or stop is closed.
+func (d *DualSense) playRumblePattern(pattern RumblePattern, stop <-chan struct{}) error {
+	var saved, last hapticRumble
+	start := time.Now()
+	var elapsed time.Duration
+	for i, frame := range pattern {
+		last = hapticRumble{enableRumbleEmulation: true, useRumbleNotHaptics: true, left: frame.Left, right: frame.Right}
+		err := d.UpdateState(func(setStateData *SetStateData) {
+			if i == 0 {
+				saved = hapticRumbleOf(setStateData)
+			}
+			last.apply(setStateData)
+		})
+		if err != nil {
+			return fmt.Errorf("error playing rumble pattern frame %d: %w", i, err)
+		}
+		elapsed += frame.Duration
+		if !sleepUnless(time.Until(start.Add(elapsed)), stop) {
+			break
+		}
+	}
+	if len(pattern) == 0 {
+		return nil
+	}
+	err := d.UpdateState(func(setStateData *SetStateData) {
+		if hapticRumbleOf(setStateData) == last {
+			saved.apply(setStateData)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("error restoring rumble after pattern: %w", err)
+	}
+	return nil
+}
+
+// sleepUnless sleeps for duration unless stop is closed first, and reports
+// whether it slept the whole duration.
+func sleepUnless(duration time.Duration, stop <-chan struct{}) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
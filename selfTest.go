@@ -0,0 +1,184 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// SELF_TEST_STEP_DURATION is how long RunSelfTest keeps each output
+	// active once the controller confirmed it, so it can be seen or felt.
+	SELF_TEST_STEP_DURATION = 400 * time.Millisecond
+	// SELF_TEST_ECHO_TIMEOUT is how long RunSelfTest waits for the controller
+	// to echo each output before failing the step.
+	SELF_TEST_ECHO_TIMEOUT = time.Second
+)
+
+// SelfTestStep is the outcome of one output exercised by RunSelfTest.
+type SelfTestStep struct {
+	Name    string        `json:"name"`
+	Passed  bool          `json:"passed"`
+	Latency time.Duration `json:"latency,omitempty"` // From the write to its echo
+	Error   string        `json:"error,omitempty"`
+}
+
+// SelfTestResult lists the steps of RunSelfTest in the order they ran.
+type SelfTestResult struct {
+	Passed bool           `json:"passed"`
+	Steps  []SelfTestStep `json:"steps"`
+}
+
+type selfTestStep struct {
+	name   string
+	update func(*SetStateData)
+	// check verifies the input report echoing the step, beyond the echo
+	// itself.
+	check func(OutputApplied) error
+}
+
+var selfTestSteps = []selfTestStep{
+	{name: "left rumble motor", update: func(setStateData *SetStateData) {
+		setStateData.EnableRumbleEmulation = true
+		setStateData.UseRumbleNotHaptics = true
+		setStateData.RumbleEmulationLeft = 0xFF
+		setStateData.RumbleEmulationRight = 0x00
+	}},
+	{name: "right rumble motor", update: func(setStateData *SetStateData) {
+		setStateData.EnableRumbleEmulation = true
+		setStateData.UseRumbleNotHaptics = true
+		setStateData.RumbleEmulationLeft = 0x00
+		setStateData.RumbleEmulationRight = 0xFF
+	}},
+	{name: "left adaptive trigger", update: func(setStateData *SetStateData) {
+		setStateData.AllowLeftTriggerFFB = true
+		setStateData.LeftTriggerFFB = GenerateTriggerFFBParams(EffectTypeFeedback, 0x00, 0xFF, 0xFF)
+	}, check: func(applied OutputApplied) error {
+		if applied.TriggerLeftEffect == 0 {
+			return errors.New("left trigger reports no active effect")
+		}
+		return nil
+	}},
+	{name: "right adaptive trigger", update: func(setStateData *SetStateData) {
+		setStateData.AllowRightTriggerFFB = true
+		setStateData.RightTriggerFFB = GenerateTriggerFFBParams(EffectTypeFeedback, 0x00, 0xFF, 0xFF)
+	}, check: func(applied OutputApplied) error {
+		if applied.TriggerRightEffect == 0 {
+			return errors.New("right trigger reports no active effect")
+		}
+		return nil
+	}},
+	{name: "lightbar", update: LedColor{Red: 0xFF, Green: 0xFF, Blue: 0xFF}.apply},
+	{name: "player LEDs", update: func(setStateData *SetStateData) {
+		setStateData.AllowPlayerIndicators = true
+		setStateData.PlayerLight1 = true
+		setStateData.PlayerLight2 = true
+		setStateData.PlayerLight3 = true
+		setStateData.PlayerLight4 = true
+		setStateData.PlayerLight5 = true
+	}},
+	{name: "mute light", update: func(setStateData *SetStateData) {
+		setStateData.AllowMuteLight = true
+		setStateData.MuteLight = MuteLightModeOn
+	}},
+}
+
+// notifySelfTest hands a confirmation to a running RunSelfTest, dropping it if
+// the self-test is not waiting for one.
+func (t *outputAckTracker) notifySelfTest(applied OutputApplied) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	select {
+	case t.selfTest <- applied:
+	default:
+	}
+}
+
+// RunSelfTest exercises the rumble motors, the adaptive triggers, the
+// lightbar, the player LEDs and the mute light one after the other, each on
+// top of the current output state, and checks that the controller echoes every
+// write in its input reports. A step fails when no echo arrives within
+// SELF_TEST_ECHO_TIMEOUT, or when a trigger reports no active effect. The
+// output state is restored afterwards.
+//
+// RunSelfTest blocks for a few seconds and needs input reports, so a
+// controller created WithManualPump must be polled from another goroutine
+// meanwhile. Other output changes made during the test fail its steps. The
+// error is only set when the test could not run.
+func (d *DualSense) RunSelfTest() (SelfTestResult, error) {
+	if state := d.LifecycleState(); state != LifecycleStarted {
+		return SelfTestResult{}, fmt.Errorf("RunSelfTest: DualSense controller is %s: %w", state, ErrInvalidLifecycleState)
+	}
+	echoes := make(chan OutputApplied, latencyPendingWrites)
+	d.outputAck.mu.Lock()
+	if d.outputAck.selfTest != nil {
+		d.outputAck.mu.Unlock()
+		return SelfTestResult{}, errors.New("RunSelfTest: a self-test is already running")
+	}
+	d.outputAck.selfTest = echoes
+	d.outputAck.mu.Unlock()
+	defer func() {
+		d.outputAck.mu.Lock()
+		d.outputAck.selfTest = nil
+		d.outputAck.mu.Unlock()
+	}()
+
+	original := d.GetOutStateData()
+	result := SelfTestResult{Passed: true}
+	for _, step := range selfTestSteps {
+		outcome, err := d.runSelfTestStep(step, original, echoes)
+		if err != nil {
+			d.SetStateData(original)
+			return result, fmt.Errorf("RunSelfTest: error testing %s: %w", step.name, err)
+		}
+		result.Passed = result.Passed && outcome.Passed
+		result.Steps = append(result.Steps, outcome)
+	}
+	err := d.SetStateData(original)
+	if err != nil {
+		return result, fmt.Errorf("RunSelfTest: error restoring output state: %w", err)
+	}
+	return result, nil
+}
+
+// runSelfTestStep writes step on top of original and waits for its echo. Only
+// a failed write is returned as an error.
+func (d *DualSense) runSelfTestStep(step selfTestStep, original SetStateData, echoes <-chan OutputApplied) (SelfTestStep, error) {
+	outcome := SelfTestStep{Name: step.name}
+	setStateData := original
+	step.update(&setStateData)
+	// The step is written even when it matches the current output state, so
+	// there is something to echo. The echo carries the report as sent, with
+	// the lightbar scaled.
+	d.setStateDataMu.Lock()
+	expected := setStateData
+	d.transformOutput(&expected)
+	err := d.applySetStateData(setStateData)
+	d.setStateDataMu.Unlock()
+	if err != nil {
+		return outcome, err
+	}
+	timeout := time.After(d.selfTestEchoTimeout)
+	for {
+		select {
+		case applied := <-echoes:
+			expected.HostTimestamp = applied.SetStateData.HostTimestamp
+			if applied.SetStateData != expected {
+				continue
+			}
+			outcome.Latency = applied.Latency
+			if step.check != nil {
+				if err := step.check(applied); err != nil {
+					outcome.Error = err.Error()
+					return outcome, nil
+				}
+			}
+			outcome.Passed = true
+			time.Sleep(d.selfTestStepDuration)
+			return outcome, nil
+		case <-timeout:
+			outcome.Error = fmt.Sprintf("no echo within %s", d.selfTestEchoTimeout)
+			return outcome, nil
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package dualsense
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunSelfTest(t *testing.T) {
+	d := NewMockDualSense()
+	d.selfTestStepDuration = 0
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	original := d.GetOutStateData()
+
+	type selfTestOutcome struct {
+		result SelfTestResult
+		err    error
+	}
+	done := make(chan selfTestOutcome)
+	go func() {
+		result, err := d.RunSelfTest()
+		done <- selfTestOutcome{result, err}
+	}()
+	// Echo every write, with both trigger effects active, until the
+	// self-test is over.
+	var outcome selfTestOutcome
+	echoed := 1
+	for running := true; running; {
+		select {
+		case outcome = <-done:
+			running = false
+		default:
+			outStates := d.OutStates()
+			for ; echoed < len(outStates); echoed++ {
+				d.UpdateInState(func(state *USBGetStateData) {
+					state.HostTimestamp = outStates[echoed].HostTimestamp
+					state.TriggerLeftEffect = 2
+					state.TriggerRightEffect = 2
+				})
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if outcome.err != nil {
+		t.Fatal(outcome.err)
+	}
+	if !outcome.result.Passed || len(outcome.result.Steps) != len(selfTestSteps) {
+		t.Fatalf("got %+v, want every step passed", outcome.result)
+	}
+	outStates := d.OutStates()
+	if len(outStates) != 1+len(selfTestSteps)+1 {
+		t.Errorf("got %d output reports, want one per step and the restore", len(outStates))
+	}
+	if last := outStates[len(outStates)-1]; last.MuteLight != original.MuteLight || last.RumbleEmulationLeft != original.RumbleEmulationLeft {
+		t.Errorf("output state not restored: %+v", last)
+	}
+	if d.GetOutStateData() != original {
+		t.Errorf("got output state %+v, want %+v", d.GetOutStateData(), original)
+	}
+}
+
+func TestRunSelfTestWithoutEcho(t *testing.T) {
+	d := NewMockDualSense()
+	d.selfTestStepDuration = 0
+	d.selfTestEchoTimeout = time.Millisecond
+	if _, err := d.RunSelfTest(); !errors.Is(err, ErrInvalidLifecycleState) {
+		t.Errorf("RunSelfTest before Start: got %v, want ErrInvalidLifecycleState", err)
+	}
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	result, err := d.RunSelfTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed {
+		t.Error("self-test passed without any echo")
+	}
+	for _, step := range result.Steps {
+		if step.Passed || step.Error == "" {
+			t.Errorf("step %q: got %+v, want a failure", step.Name, step)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package dualsense
+
+import (
+	"sync"
+	"time"
+)
+
+// REPORT_RATE_WINDOW is roughly how many recent report intervals the
+// ReportRate and ReportJitter moving averages cover.
+const REPORT_RATE_WINDOW = 64
+
+// REPORT_RATE_MAX_INTERVAL is the longest interval between input reports
+// counted towards the report rate. Longer pauses, such as while the transport
+// reconnects, are skipped.
+const REPORT_RATE_MAX_INTERVAL = time.Second
+
+// Stats holds counters describing the input report stream.
+type Stats struct {
+	ReportsReceived   uint64
+	ReportsDropped    uint64
+	ReportsOutOfOrder uint64
+	StatesCoalesced   uint64 // Input states skipped by WithAsyncCallbacks because a subscription's queue was full
+	// ReportRate is the moving average of the input report rate in Hz,
+	// nominally 250 over USB.
+	ReportRate float64
+	// ReportJitter is the moving average of how far report intervals deviate
+	// from the average interval.
+	ReportJitter time.Duration
+}
+
+// ReportGap describes a discontinuity in the SeqNo of consecutive input reports.
+// Dropped is the number of reports missing between the expected and received
+// SeqNo, and is zero for out of order reports.
+type ReportGap struct {
+	ExpectedSeqNo uint8
+	ReceivedSeqNo uint8
+	Dropped       uint8
+	OutOfOrder    bool
+}
+
+type reportStats struct {
+	mu        sync.Mutex
+	stats     Stats
+	hasSeqNo  bool
+	lastSeqNo uint8
+	// meanInterval and jitter are in seconds.
+	lastArrival  time.Time
+	meanInterval float64
+	jitter       float64
+}
+
+// recordSeqNo counts a received report and returns the gap to the previous
+// report, if any. SeqNo wraps at 256, so a backwards jump of less than half the
+// range is treated as an out of order report rather than a large loss.
+func (s *reportStats) recordSeqNo(seqNo uint8) (ReportGap, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.ReportsReceived++
+	if !s.hasSeqNo {
+		s.hasSeqNo = true
+		s.lastSeqNo = seqNo
+		return ReportGap{}, false
+	}
+	expectedSeqNo := s.lastSeqNo + 1
+	if seqNo == expectedSeqNo {
+		s.lastSeqNo = seqNo
+		return ReportGap{}, false
+	}
+	gap := ReportGap{
+		ExpectedSeqNo: expectedSeqNo,
+		ReceivedSeqNo: seqNo,
+	}
+	if distance := seqNo - expectedSeqNo; distance < 128 {
+		gap.Dropped = distance
+		s.stats.ReportsDropped += uint64(distance)
+		s.lastSeqNo = seqNo
+	} else {
+		gap.OutOfOrder = true
+		s.stats.ReportsOutOfOrder++
+	}
+	return gap, true
+}
+
+// recordArrival updates the report rate and jitter with a report received at
+// now.
+func (s *reportStats) recordArrival(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.lastArrival
+	s.lastArrival = now
+	interval := now.Sub(previous)
+	if previous.IsZero() || interval <= 0 || interval > REPORT_RATE_MAX_INTERVAL {
+		return
+	}
+	seconds := interval.Seconds()
+	if s.meanInterval == 0 {
+		s.meanInterval = seconds
+	} else {
+		s.meanInterval += (seconds - s.meanInterval) / REPORT_RATE_WINDOW
+	}
+	deviation := seconds - s.meanInterval
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	s.jitter += (deviation - s.jitter) / REPORT_RATE_WINDOW
+	s.stats.ReportRate = 1 / s.meanInterval
+	s.stats.ReportJitter = time.Duration(s.jitter * float64(time.Second))
+}
+
+func (s *reportStats) recordCoalescedState() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.StatesCoalesced++
+}
+
+func (s *reportStats) snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+func (d *DualSense) Stats() Stats {
+	return d.stats.snapshot()
+}
@@ -0,0 +1,75 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportStatsRecordSeqNo(t *testing.T) {
+	var s reportStats
+	for _, seqNo := range []uint8{254, 255, 0, 3, 2, 4} {
+		s.recordSeqNo(seqNo)
+	}
+	got := s.snapshot()
+	want := Stats{ReportsReceived: 6, ReportsDropped: 2, ReportsOutOfOrder: 1}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReportStatsGap(t *testing.T) {
+	var s reportStats
+	s.recordSeqNo(10)
+	gap, ok := s.recordSeqNo(13)
+	if !ok {
+		t.Fatal("expected a gap")
+	}
+	want := ReportGap{ExpectedSeqNo: 11, ReceivedSeqNo: 13, Dropped: 2}
+	if gap != want {
+		t.Fatalf("got %+v, want %+v", gap, want)
+	}
+	if _, ok := s.recordSeqNo(14); ok {
+		t.Fatal("unexpected gap for consecutive SeqNo")
+	}
+}
+
+func TestLatencyTrackerRecordEcho(t *testing.T) {
+	var l latencyTracker
+	l.enable(0)
+	sentAt := time.Now()
+	l.recordSent(100, sentAt)
+	l.recordSent(200, sentAt.Add(time.Millisecond))
+	l.recordEcho(100, sentAt.Add(3*time.Millisecond))
+	l.recordEcho(100, sentAt.Add(9*time.Millisecond))
+	l.recordEcho(200, sentAt.Add(6*time.Millisecond))
+	stats := l.stats()
+	if stats.Samples != 2 {
+		t.Fatalf("got %d samples, want 2", stats.Samples)
+	}
+	if stats.Min != 3*time.Millisecond || stats.Max != 5*time.Millisecond || stats.Avg != 4*time.Millisecond {
+		t.Fatalf("unexpected min/avg/max: %+v", stats)
+	}
+	if stats.Histogram[2].Count != 1 || stats.Histogram[3].Count != 1 {
+		t.Fatalf("unexpected histogram: %+v", stats.Histogram)
+	}
+}
+
+func TestReportStatsRecordArrival(t *testing.T) {
+	var s reportStats
+	now := time.Now()
+	s.recordArrival(now)
+	// Alternate 3ms and 5ms intervals, 250 Hz with 1ms jitter.
+	for i := 0; i < 20*REPORT_RATE_WINDOW; i++ {
+		now = now.Add(time.Duration(3+2*(i%2)) * time.Millisecond)
+		s.recordArrival(now)
+	}
+	// A pause is not counted.
+	s.recordArrival(now.Add(5 * time.Second))
+	stats := s.snapshot()
+	if stats.ReportRate < 245 || stats.ReportRate > 255 {
+		t.Fatalf("got report rate %v, want 250", stats.ReportRate)
+	}
+	if stats.ReportJitter < 900*time.Microsecond || stats.ReportJitter > 1100*time.Microsecond {
+		t.Fatalf("got report jitter %v, want 1ms", stats.ReportJitter)
+	}
+}
@@ -0,0 +1,95 @@
+package dualsense
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	// STICK_DPAD_PRESS_THRESHOLD is how far, as a fraction of full
+	// deflection, the left stick must be pushed to press the synthetic D-pad.
+	STICK_DPAD_PRESS_THRESHOLD = 0.5
+	// STICK_DPAD_RELEASE_THRESHOLD is the deflection below which the
+	// synthetic D-pad is released again. It is lower than the press threshold
+	// so a stick held near it does not repeat presses.
+	STICK_DPAD_RELEASE_THRESHOLD = 0.35
+	// STICK_DPAD_ANGLE_HYSTERESIS is how far, in radians, the stick may turn
+	// past the edge of the held direction's zone before the direction changes.
+	STICK_DPAD_ANGLE_HYSTERESIS = math.Pi / 18
+)
+
+// StickDPadMode selects how the left stick drives the D-pad, see
+// SetStickDPadMode.
+type StickDPadMode uint8
+
+const (
+	StickDPadOff StickDPadMode = iota
+	// StickDPad4Way maps the stick to North, East, South and West.
+	StickDPad4Way
+	// StickDPad8Way adds the diagonals.
+	StickDPad8Way
+)
+
+type stickDPad struct {
+	mu        sync.Mutex
+	mode      StickDPadMode
+	direction Direction
+}
+
+// SetStickDPadMode makes the left stick act as a D-pad, e.g. for menus or
+// games that only read the D-pad: pushing it past STICK_DPAD_PRESS_THRESHOLD
+// presses the direction it points in until it returns below
+// STICK_DPAD_RELEASE_THRESHOLD. It is combined with the physical D-pad as in
+// SetTouchpadDPadMode. The stick itself is still reported.
+func (d *DualSense) SetStickDPadMode(mode StickDPadMode) {
+	d.stickDPad.mu.Lock()
+	defer d.stickDPad.mu.Unlock()
+	d.stickDPad.mode = mode
+	d.stickDPad.direction = DirectionNone
+}
+
+// StickDPadMode returns the mode set with SetStickDPadMode.
+func (d *DualSense) StickDPadMode() StickDPadMode {
+	d.stickDPad.mu.Lock()
+	defer d.stickDPad.mu.Unlock()
+	return d.stickDPad.mode
+}
+
+// stickDPadHat returns the hat bits held through the left stick.
+func (d *DualSense) stickDPadHat(state *USBGetStateData) uint8 {
+	d.stickDPad.mu.Lock()
+	defer d.stickDPad.mu.Unlock()
+	if d.stickDPad.mode == StickDPadOff {
+		return 0
+	}
+	x, y, magnitude := stickDeflection(state.LeftStickX, state.LeftStickY)
+	d.stickDPad.direction = stickDPadDirection(d.stickDPad.direction, x, y, magnitude, d.stickDPad.mode)
+	return dPadHats[d.stickDPad.direction]
+}
+
+// stickDPadDirection returns the direction held after the stick moved to
+// (x, y) while held was.
+func stickDPadDirection(held Direction, x, y, magnitude float64, mode StickDPadMode) Direction {
+	if held == DirectionNone {
+		if magnitude < STICK_DPAD_PRESS_THRESHOLD {
+			return DirectionNone
+		}
+	} else if magnitude < STICK_DPAD_RELEASE_THRESHOLD {
+		return DirectionNone
+	}
+	zones := directionZones4Way
+	if mode == StickDPad8Way {
+		zones = directionZones8Way
+	}
+	for i, zone := range zones {
+		if zone != held {
+			continue
+		}
+		center := 2 * math.Pi * float64(i) / float64(len(zones))
+		offset := math.Remainder(math.Atan2(y, x)-center, 2*math.Pi)
+		if math.Abs(offset) <= math.Pi/float64(len(zones))+STICK_DPAD_ANGLE_HYSTERESIS {
+			return held
+		}
+	}
+	return directionAt(x, y, zones)
+}
@@ -0,0 +1,68 @@
+package dualsense
+
+import (
+	"math"
+	"testing"
+)
+
+// stickAt returns raw stick values for a deflection of magnitude towards
+// degrees counterclockwise from East.
+func stickAt(degrees, magnitude float64) (uint8, uint8) {
+	angle := degrees * math.Pi / 180
+	return uint8(math.Round(127.5 + 127.5*magnitude*math.Cos(angle))), uint8(math.Round(127.5 - 127.5*magnitude*math.Sin(angle)))
+}
+
+func TestStickDPadDirection(t *testing.T) {
+	tests := []struct {
+		name      string
+		held      Direction
+		degrees   float64
+		magnitude float64
+		mode      StickDPadMode
+		want      Direction
+	}{
+		{"below press", DirectionNone, 0, 0.4, StickDPad4Way, DirectionNone},
+		{"press", DirectionNone, 0, 0.6, StickDPad4Way, DirectionEast},
+		{"held above release", DirectionEast, 0, 0.4, StickDPad4Way, DirectionEast},
+		{"release", DirectionEast, 0, 0.3, StickDPad4Way, DirectionNone},
+		{"held past zone edge", DirectionEast, 50, 0.9, StickDPad4Way, DirectionEast},
+		{"turned", DirectionEast, 60, 0.9, StickDPad4Way, DirectionNorth},
+		{"diagonal", DirectionNone, 225, 0.9, StickDPad8Way, DirectionSouthWest},
+		{"held across zero", DirectionEast, -40, 0.9, StickDPad4Way, DirectionEast},
+	}
+	for _, test := range tests {
+		x, y, magnitude := stickDeflection(stickAt(test.degrees, test.magnitude))
+		if got := stickDPadDirection(test.held, x, y, magnitude, test.mode); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestStickDPadMode(t *testing.T) {
+	d := NewMockDualSense()
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionNone
+		state.LeftStickX, state.LeftStickY = 128, 128
+	})
+	var got []Direction
+	d.OnFieldChange(FieldDPad, func(change FieldChange) {
+		got = append(got, change.New.(Direction))
+	})
+	d.SetStickDPadMode(StickDPad4Way)
+
+	for _, deflection := range [][2]float64{{90, 0.9}, {90, 0.4}, {90, 0.2}, {0, 0.6}, {0, 0}} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.DPad = DirectionNone
+			state.LeftStickX, state.LeftStickY = stickAt(deflection[0], deflection[1])
+		})
+	}
+	want := []Direction{DirectionNorth, DirectionNone, DirectionEast, DirectionNone}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package dualsense
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// STICK_FLICK_THRESHOLD is how far, as a fraction of full deflection, a
+	// stick must be pushed to count as a flick.
+	STICK_FLICK_THRESHOLD = 0.8
+	// STICK_REST_THRESHOLD is the deflection below which a stick counts as
+	// back at rest.
+	STICK_REST_THRESHOLD = 0.25
+	// STICK_FLICK_MAX_DURATION is the longest a flick may take from leaving
+	// rest to returning to it. Slower movements are ordinary stick input.
+	STICK_FLICK_MAX_DURATION = 250 * time.Millisecond
+	// STICK_CLICK_THRESHOLD is how far a stick must be deflected for a click
+	// of it to be reported as a StickClick.
+	STICK_CLICK_THRESHOLD = 0.5
+)
+
+type Stick uint8
+
+const (
+	StickLeft Stick = iota
+	StickRight
+)
+
+// StickFlick is a stick pushed quickly past STICK_FLICK_THRESHOLD and let go.
+// Direction is where it was pushed furthest, in 8 directions, and Magnitude how
+// far, from 0 to 1.
+type StickFlick struct {
+	Stick     Stick
+	Direction Direction
+	Magnitude float64
+	Duration  time.Duration
+}
+
+// StickClick is a stick clicked in, L3 or R3, while deflected past
+// STICK_CLICK_THRESHOLD.
+type StickClick struct {
+	Stick     Stick
+	Direction Direction
+	Magnitude float64
+}
+
+// stickFlickTracker follows one stick between leaving rest and returning to
+// it. It is only used while processing input reports.
+type stickFlickTracker struct {
+	deflected bool
+	start     time.Time
+	peakX     float64
+	peakY     float64
+	peak      float64
+}
+
+// stickDeflection returns the normalized deflection of a stick with y growing
+// upwards, and its magnitude capped at 1.
+func stickDeflection(rawX, rawY uint8) (float64, float64, float64) {
+	x, y := normalizeStickAxis(rawX), -normalizeStickAxis(rawY)
+	return x, y, min(math.Hypot(x, y), 1)
+}
+
+// OnStickFlick registers a callback for quick stick flicks, e.g. for menu
+// navigation or dodges. It fires when the stick returns to rest.
+func (d *DualSense) OnStickFlick(callback func(StickFlick)) {
+	d.callbacks.OnStickFlick = append(d.callbacks.OnStickFlick, callback)
+}
+
+// OnStickClick registers a callback for L3 and R3 presses made while their
+// stick is deflected. The button presses are still reported through their own
+// change callbacks.
+func (d *DualSense) OnStickClick(callback func(StickClick)) {
+	d.callbacks.OnStickClick = append(d.callbacks.OnStickClick, callback)
+}
+
+func (d *DualSense) processStickGestures(current, previous *USBGetStateData, now time.Time) {
+	if len(d.callbacks.OnStickFlick) == 0 && len(d.callbacks.OnStickClick) == 0 {
+		return
+	}
+	sticks := [2]struct {
+		rawX, rawY       uint8
+		clicked, wasDown bool
+	}{
+		{current.LeftStickX, current.LeftStickY, current.ButtonL3, previous.ButtonL3},
+		{current.RightStickX, current.RightStickY, current.ButtonR3, previous.ButtonR3},
+	}
+	for i, s := range sticks {
+		stick := Stick(i)
+		x, y, magnitude := stickDeflection(s.rawX, s.rawY)
+		if s.clicked && !s.wasDown && magnitude > STICK_CLICK_THRESHOLD {
+			click := StickClick{Stick: stick, Direction: directionAt(x, y, directionZones8Way), Magnitude: magnitude}
+			for _, callback := range d.callbacks.OnStickClick {
+				callback(click)
+			}
+		}
+		if flick, ok := d.stickFlicks[i].record(x, y, magnitude, now); ok {
+			flick.Stick = stick
+			for _, callback := range d.callbacks.OnStickFlick {
+				callback(flick)
+			}
+		}
+	}
+}
+
+// record follows the stick and returns the flick completed by its return to
+// rest, if any.
+func (t *stickFlickTracker) record(x, y, magnitude float64, now time.Time) (StickFlick, bool) {
+	if !t.deflected {
+		if magnitude > STICK_REST_THRESHOLD {
+			*t = stickFlickTracker{deflected: true, start: now, peakX: x, peakY: y, peak: magnitude}
+		}
+		return StickFlick{}, false
+	}
+	if magnitude > t.peak {
+		t.peakX, t.peakY, t.peak = x, y, magnitude
+	}
+	if magnitude > STICK_REST_THRESHOLD {
+		return StickFlick{}, false
+	}
+	t.deflected = false
+	duration := now.Sub(t.start)
+	if t.peak < STICK_FLICK_THRESHOLD || duration > STICK_FLICK_MAX_DURATION {
+		return StickFlick{}, false
+	}
+	return StickFlick{Direction: directionAt(t.peakX, t.peakY, directionZones8Way), Magnitude: t.peak, Duration: duration}, true
+}
@@ -0,0 +1,84 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStickFlick(t *testing.T) {
+	tracker := stickFlickTracker{}
+	start := time.Now()
+	samples := []struct {
+		rawX, rawY uint8
+		at         time.Duration
+	}{
+		{128, 128, 0},
+		{200, 60, 10 * time.Millisecond},
+		{255, 0, 20 * time.Millisecond},
+		{140, 120, 60 * time.Millisecond},
+	}
+	var flicks []StickFlick
+	for _, sample := range samples {
+		x, y, magnitude := stickDeflection(sample.rawX, sample.rawY)
+		if flick, ok := tracker.record(x, y, magnitude, start.Add(sample.at)); ok {
+			flicks = append(flicks, flick)
+		}
+	}
+	if len(flicks) != 1 {
+		t.Fatalf("got %d flicks, want 1", len(flicks))
+	}
+	if flick := flicks[0]; flick.Direction != DirectionNorthEast || flick.Magnitude != 1 || flick.Duration != 50*time.Millisecond {
+		t.Errorf("got %+v", flick)
+	}
+
+	// Too slow.
+	for _, sample := range []struct {
+		rawX uint8
+		at   time.Duration
+	}{{128, 0}, {0, 100 * time.Millisecond}, {128, 500 * time.Millisecond}} {
+		x, y, magnitude := stickDeflection(sample.rawX, 128)
+		if flick, ok := tracker.record(x, y, magnitude, start.Add(time.Second+sample.at)); ok {
+			t.Errorf("slow movement reported as flick %+v", flick)
+		}
+	}
+	// Not far enough.
+	for i, rawY := range []uint8{128, 70, 128} {
+		x, y, magnitude := stickDeflection(128, rawY)
+		if flick, ok := tracker.record(x, y, magnitude, start.Add(2*time.Second+time.Duration(i)*time.Millisecond)); ok {
+			t.Errorf("small movement reported as flick %+v", flick)
+		}
+	}
+}
+
+func TestStickFlickAndClickCallbacks(t *testing.T) {
+	d := NewMockDualSense()
+	centered := func(state *USBGetStateData) {
+		state.LeftStickX, state.LeftStickY, state.RightStickX, state.RightStickY = 128, 128, 128, 128
+	}
+	d.UpdateInState(centered)
+	var flicks []StickFlick
+	var clicks []StickClick
+	d.OnStickFlick(func(flick StickFlick) {
+		flicks = append(flicks, flick)
+	})
+	d.OnStickClick(func(click StickClick) {
+		clicks = append(clicks, click)
+	})
+
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.RightStickX = 0
+	})
+	d.UpdateInState(centered)
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.LeftStickY = 255
+	})
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.ButtonL3 = true
+	})
+	if len(flicks) != 1 || flicks[0].Stick != StickRight || flicks[0].Direction != DirectionWest {
+		t.Errorf("got flicks %+v, want one West flick of the right stick", flicks)
+	}
+	if len(clicks) != 1 || clicks[0].Stick != StickLeft || clicks[0].Direction != DirectionSouth {
+		t.Errorf("got clicks %+v, want one L3 click deflected South", clicks)
+	}
+}
@@ -0,0 +1,201 @@
+package dualsense
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type subscription struct {
+	field       Field
+	priority    int
+	everyReport bool
+	call        func(current, previous *USBGetStateData)
+	cancelled   atomic.Bool
+	// stopped is closed on cancelling, ending the goroutine of a
+	// subscription created WithAsyncCallbacks.
+	stopped chan struct{}
+	// throttle holds the delivery timer of a subscription created
+	// WithThrottle.
+	throttle *throttledCall
+}
+
+type subscriptionOptions struct {
+	priority int
+	throttle time.Duration
+	// everyReport calls the callback for every processed input report,
+	// changed or not, inline with report processing.
+	everyReport bool
+}
+
+// SubscriptionOption configures a callback registered with OnFieldChange or one
+// of the typed OnXChange methods.
+type SubscriptionOption func(*subscriptionOptions)
+
+// WithPriority makes the callback run before every callback of a lower
+// priority within the same report, e.g. so a remapping layer sees changes
+// before application handlers. Callbacks default to priority 0.
+func WithPriority(priority int) SubscriptionOption {
+	return func(options *subscriptionOptions) {
+		options.priority = priority
+	}
+}
+
+// WithThrottle invokes the callback at most once per interval. Changes arriving
+// within the interval are held back and the latest value is delivered once the
+// interval has passed, from a timer goroutine.
+func WithThrottle(interval time.Duration) SubscriptionOption {
+	return func(options *subscriptionOptions) {
+		options.throttle = interval
+	}
+}
+
+// subscribeReports registers call for every processed input report, returning
+// a func that removes it again.
+func (d *DualSense) subscribeReports(call func(current, previous *USBGetStateData)) func() {
+	return d.subscribe(0, call, []SubscriptionOption{func(options *subscriptionOptions) {
+		options.everyReport = true
+	}})
+}
+
+// subscribe inserts call after every subscription of the same or higher
+// priority, keeping subscriptions ordered for triggerCallbacks, and returns a
+// func that removes it again. The slice is copied on every change, so
+// triggerCallbacks can run callbacks without holding subscriptionsMu.
+func (d *DualSense) subscribe(field Field, call func(current, previous *USBGetStateData), options []SubscriptionOption) func() {
+	var subscriptionOptions subscriptionOptions
+	for _, option := range options {
+		option(&subscriptionOptions)
+	}
+	s := &subscription{
+		field:       field,
+		priority:    subscriptionOptions.priority,
+		everyReport: subscriptionOptions.everyReport,
+		stopped:     make(chan struct{}),
+	}
+	s.call = call
+	if subscriptionOptions.throttle > 0 {
+		s.throttle = &throttledCall{
+			field:    field,
+			interval: subscriptionOptions.throttle,
+			call:     call,
+		}
+		s.call = s.throttle.handle
+	}
+	if d.callbackQueueSize > 0 && !s.everyReport {
+		s.call = d.queueCall(field, s.call, s.stopped)
+	}
+	d.callbacks.subscriptionsMu.Lock()
+	defer d.callbacks.subscriptionsMu.Unlock()
+	index := len(d.callbacks.subscriptions)
+	for i, subscription := range d.callbacks.subscriptions {
+		if subscription.priority < s.priority {
+			index = i
+			break
+		}
+	}
+	d.callbacks.subscriptions = slices.Insert(slices.Clip(d.callbacks.subscriptions), index, s)
+	return func() {
+		if s.cancelled.Swap(true) {
+			return
+		}
+		close(s.stopped)
+		if s.throttle != nil {
+			s.throttle.stop()
+		}
+		d.callbacks.subscriptionsMu.Lock()
+		defer d.callbacks.subscriptionsMu.Unlock()
+		d.callbacks.subscriptions = slices.DeleteFunc(slices.Clone(d.callbacks.subscriptions), func(subscription *subscription) bool {
+			return subscription == s
+		})
+	}
+}
+
+type throttledCall struct {
+	mu        sync.Mutex
+	field     Field
+	interval  time.Duration
+	call      func(current, previous *USBGetStateData)
+	lastCall  time.Time
+	delivered USBGetStateData
+	latest    USBGetStateData
+	// timer is armed while a change is held back, and stopped is set once
+	// the subscription is cancelled or the DualSense closed.
+	timer   *time.Timer
+	stopped bool
+}
+
+func (t *throttledCall) handle(current, previous *USBGetStateData) {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	if t.lastCall.IsZero() {
+		t.delivered = *previous
+	}
+	t.latest = *current
+	if t.timer != nil {
+		t.mu.Unlock()
+		return
+	}
+	if wait := t.interval - time.Since(t.lastCall); wait > 0 {
+		t.timer = time.AfterFunc(wait, t.fire)
+		t.mu.Unlock()
+		return
+	}
+	t.deliver()
+}
+
+// fire delivers the change held back once the interval has passed.
+func (t *throttledCall) fire() {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	t.timer = nil
+	t.deliver()
+}
+
+// stop drops the change held back, if any, and ignores later ones.
+func (t *throttledCall) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// stopThrottles stops the timers of every throttled subscription, so none
+// delivers a change after Close.
+func (d *DualSense) stopThrottles() {
+	d.callbacks.subscriptionsMu.Lock()
+	subscriptions := d.callbacks.subscriptions
+	d.callbacks.subscriptionsMu.Unlock()
+	for _, subscription := range subscriptions {
+		if subscription.throttle != nil {
+			subscription.throttle.stop()
+		}
+	}
+}
+
+// deliver must be called with mu held, which it releases before running the
+// callback, so the callback may cancel its subscription or close the
+// DualSense. Values that returned to the last delivered one while throttled
+// are not reported.
+func (t *throttledCall) deliver() {
+	if !inputFields[t.field].changed(&t.latest, &t.delivered) {
+		t.mu.Unlock()
+		return
+	}
+	t.lastCall = time.Now()
+	previous := t.delivered
+	t.delivered = t.latest
+	current := t.delivered
+	t.mu.Unlock()
+	t.call(&current, &previous)
+}
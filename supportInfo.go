@@ -0,0 +1,156 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const modulePath = "github.com/nikashan02/dualsense-go"
+
+// ErrorCounters counts the I/O errors seen since the controller was opened.
+// Read timeouts are not errors.
+type ErrorCounters struct {
+	ReadErrors    uint64    `json:"readErrors"`
+	WriteErrors   uint64    `json:"writeErrors"`
+	LastError     string    `json:"lastError,omitempty"`
+	LastErrorTime time.Time `json:"lastErrorTime,omitempty"`
+}
+
+type errorCounter struct {
+	mu       sync.Mutex
+	counters ErrorCounters
+}
+
+func (c *errorCounter) record(err error, write bool) {
+	if err == nil || errors.Is(err, ErrTimeout) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if write {
+		c.counters.WriteErrors++
+	} else {
+		c.counters.ReadErrors++
+	}
+	c.counters.LastError = err.Error()
+	c.counters.LastErrorTime = time.Now()
+}
+
+// SupportConfig is the library configuration of a DualSense, as set through
+// options and setters.
+type SupportConfig struct {
+	ManualPump          bool             `json:"manualPump"`
+	AsyncCallbacks      bool             `json:"asyncCallbacks"`
+	AsyncOutput         bool             `json:"asyncOutput"`
+	PollingRate         time.Duration    `json:"pollingRate"`
+	OutputFlushInterval time.Duration    `json:"outputFlushInterval"`
+	MinOutputInterval   time.Duration    `json:"minOutputInterval"`
+	KeepAliveInterval   time.Duration    `json:"keepAliveInterval"`
+	WriteRetryPolicy    WriteRetryPolicy `json:"writeRetryPolicy"`
+	ClampAudioGains     bool             `json:"clampAudioGains"`
+	SuppressedFields    []string         `json:"suppressedFields,omitempty"`
+	LatencyMeasurement  bool             `json:"latencyMeasurement"`
+}
+
+// SupportInfo gathers what is useful to attach to a bug report in a single
+// JSON serializable struct.
+type SupportInfo struct {
+	Time           time.Time `json:"time"`
+	LibraryVersion string    `json:"libraryVersion"`
+	GoVersion      string    `json:"goVersion"`
+	Platform       string    `json:"platform"`
+	Label          string    `json:"label,omitempty"`
+	// Transport is the Go type of the transport, e.g. "*dualsense.HIDRawTransport".
+	Transport    string        `json:"transport"`
+	Wireless     bool          `json:"wireless"`
+	ReportLayout ReportLayout  `json:"reportLayout"`
+	Hardware     *HardwareInfo `json:"hardware,omitempty"`
+	// HardwareError explains why Hardware is missing.
+	HardwareError    string        `json:"hardwareError,omitempty"`
+	Config           SupportConfig `json:"config"`
+	Stats            Stats         `json:"stats"`
+	Latency          LatencyStats  `json:"latency"`
+	AnomalousReports uint64        `json:"anomalousReports"`
+	MalformedReports uint64        `json:"malformedReports"`
+	Errors           ErrorCounters `json:"errors"`
+}
+
+// libraryVersion returns the version of this module the program was built
+// with, "(devel)" when built from its own source tree.
+func libraryVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if buildInfo.Main.Path == modulePath {
+		return buildInfo.Main.Version
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Version + " => " + dep.Replace.Path + " " + dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+// DumpSupportInfo collects the library and controller details useful in a bug
+// report. It reads the hardware info feature reports, so it talks to the
+// controller; a failure there is recorded in HardwareError rather than
+// returned.
+func (d *DualSense) DumpSupportInfo() SupportInfo {
+	info := SupportInfo{
+		Time:           time.Now(),
+		LibraryVersion: libraryVersion(),
+		GoVersion:      runtime.Version(),
+		Platform:       runtime.GOOS + "/" + runtime.GOARCH,
+		Label:          d.Label(),
+		Transport:      fmt.Sprintf("%T", d.currentTransport()),
+		ReportLayout:   d.ReportLayout(),
+		Stats:          d.Stats(),
+		Latency:        d.Latency(),
+	}
+	if wireless, ok := d.currentTransport().(WirelessTransport); ok {
+		info.Wireless = wireless.Wireless()
+	}
+	hardware, err := d.HardwareInfo()
+	if err != nil {
+		info.HardwareError = err.Error()
+	} else {
+		info.Hardware = &hardware
+	}
+
+	d.setStateDataMu.Lock()
+	info.Config = SupportConfig{
+		ManualPump:          d.manualPump,
+		AsyncCallbacks:      d.callbackQueueSize > 0,
+		AsyncOutput:         d.asyncOutput,
+		PollingRate:         d.pollingRate,
+		OutputFlushInterval: d.outputFlushInterval,
+		MinOutputInterval:   d.minOutputInterval,
+		KeepAliveInterval:   d.keepAliveInterval,
+		WriteRetryPolicy:    d.writeRetryPolicy,
+		ClampAudioGains:     d.clampAudioGains,
+		LatencyMeasurement:  d.latency.isEnabled(),
+	}
+	d.setStateDataMu.Unlock()
+	for field, suppressed := range d.callbacks.suppressed {
+		if suppressed {
+			info.Config.SuppressedFields = append(info.Config.SuppressedFields, Field(field).String())
+		}
+	}
+
+	diagnostics := d.Diagnostics()
+	info.AnomalousReports = diagnostics.AnomalousReports
+	info.MalformedReports = diagnostics.MalformedReports
+	d.ioErrors.mu.Lock()
+	info.Errors = d.ioErrors.counters
+	d.ioErrors.mu.Unlock()
+	return info
+}
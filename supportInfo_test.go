@@ -0,0 +1,31 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDumpSupportInfo(t *testing.T) {
+	d := NewMockDualSense(WithSuppressedFields(FieldTemperature))
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	d.transport.Close()
+	if err := d.SetLedRed(0x10); err == nil {
+		t.Fatal("expected a write error on a closed transport")
+	}
+
+	info := d.DumpSupportInfo()
+	if info.Transport != "*dualsense.mockTransport" || info.Hardware != nil || info.HardwareError == "" {
+		t.Errorf("got transport %q, hardware %+v, hardware error %q", info.Transport, info.Hardware, info.HardwareError)
+	}
+	if !info.Config.ManualPump || len(info.Config.SuppressedFields) != 1 || info.Config.SuppressedFields[0] != "Temperature" {
+		t.Errorf("got config %+v", info.Config)
+	}
+	if info.Errors.WriteErrors != 1 || info.Errors.LastError == "" {
+		t.Errorf("got errors %+v", info.Errors)
+	}
+	if _, err := json.Marshal(info); err != nil {
+		t.Fatal(err)
+	}
+}
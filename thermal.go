@@ -0,0 +1,92 @@
+package dualsense
+
+import (
+	"sync"
+)
+
+// THERMAL_HYSTERESIS is how many degrees below ThermalPolicy.Threshold the
+// controller must cool before throttling is released.
+const THERMAL_HYSTERESIS = 2
+
+// ThermalPolicy raises RumbleMotorPowerReduction while the controller runs
+// hot, from 1 at Threshold up to MAX_MOTOR_POWER_REDUCTION at Critical.
+// Temperatures are in degrees Celsius as reported in Temperature; a Threshold
+// of 0 disables throttling.
+type ThermalPolicy struct {
+	Threshold int8
+	Critical  int8
+}
+
+// ThermalThrottle describes a change in thermal throttling.
+type ThermalThrottle struct {
+	Engaged     bool
+	Temperature int8
+	// Reduction is the RumbleMotorPowerReduction applied by throttling, 0
+	// once released.
+	Reduction PowerReduction
+}
+
+type thermalThrottler struct {
+	mu        sync.Mutex
+	policy    ThermalPolicy
+	reduction PowerReduction
+}
+
+// reductionFor returns the throttling reduction for temperature given the
+// current one, applying the hysteresis on release.
+func (p ThermalPolicy) reductionFor(temperature int8, current PowerReduction) PowerReduction {
+	if p.Threshold == 0 {
+		return 0
+	}
+	if temperature < p.Threshold {
+		if current > 0 && int(temperature) > int(p.Threshold)-THERMAL_HYSTERESIS {
+			return 1
+		}
+		return 0
+	}
+	if p.Critical <= p.Threshold || temperature >= p.Critical {
+		return PowerReductionMax
+	}
+	span := int(p.Critical) - int(p.Threshold)
+	return PowerReduction(1 + (int(temperature)-int(p.Threshold))*(MAX_MOTOR_POWER_REDUCTION-1)/span)
+}
+
+// SetThermalPolicy configures thermal rumble throttling, see ThermalPolicy.
+func (d *DualSense) SetThermalPolicy(policy ThermalPolicy) {
+	d.thermal.mu.Lock()
+	defer d.thermal.mu.Unlock()
+	d.thermal.policy = policy
+}
+
+// ThermalThrottled reports whether rumble is currently throttled.
+func (d *DualSense) ThermalThrottled() bool {
+	d.thermal.mu.Lock()
+	defer d.thermal.mu.Unlock()
+	return d.thermal.reduction > 0
+}
+
+// OnThermalThrottle registers a callback for when thermal throttling engages,
+// changes level or is released.
+func (d *DualSense) OnThermalThrottle(callback func(ThermalThrottle)) {
+	d.callbacks.OnThermalThrottle = append(d.callbacks.OnThermalThrottle, callback)
+}
+
+// processThermal applies the thermal policy for the latest report. A
+// RumbleMotorPowerReduction set while throttled is kept on release.
+func (d *DualSense) processThermal(state *USBGetStateData) {
+	d.thermal.mu.Lock()
+	previous := d.thermal.reduction
+	reduction := d.thermal.policy.reductionFor(state.Temperature, previous)
+	d.thermal.reduction = reduction
+	d.thermal.mu.Unlock()
+	if reduction == previous {
+		return
+	}
+	d.limitMotorPower(func(l *motorPowerLimiter) {
+		l.thermal = reduction
+	})
+	throttle := ThermalThrottle{Engaged: reduction > 0, Temperature: state.Temperature, Reduction: reduction}
+	for _, callback := range d.callbacks.OnThermalThrottle {
+		callback(throttle)
+	}
+}
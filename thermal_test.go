@@ -0,0 +1,67 @@
+package dualsense
+
+import "testing"
+
+func TestThermalPolicyReduction(t *testing.T) {
+	policy := ThermalPolicy{Threshold: 40, Critical: 46}
+	for _, test := range []struct {
+		temperature int8
+		current     PowerReduction
+		want        PowerReduction
+	}{
+		{35, 0, 0},
+		{40, 0, 1},
+		{43, 1, 4},
+		{46, 4, MAX_MOTOR_POWER_REDUCTION},
+		{50, 0, MAX_MOTOR_POWER_REDUCTION},
+		{39, 0, 0},
+		{39, 3, 1},
+		{38, 1, 0},
+	} {
+		if got := policy.reductionFor(test.temperature, test.current); got != test.want {
+			t.Errorf("reductionFor(%d, %d) = %d, want %d", test.temperature, test.current, got, test.want)
+		}
+	}
+	if got := (ThermalPolicy{}).reductionFor(100, 0); got != 0 {
+		t.Errorf("disabled policy throttled to %d", got)
+	}
+}
+
+func TestThermalThrottle(t *testing.T) {
+	mock := NewMockDualSense()
+	mock.SetRumbleMotorPowerReduction(2)
+	mock.SetThermalPolicy(ThermalPolicy{Threshold: 40, Critical: 46})
+	var throttles []ThermalThrottle
+	mock.OnThermalThrottle(func(throttle ThermalThrottle) { throttles = append(throttles, throttle) })
+
+	setTemperature := func(temperature int8) {
+		mock.UpdateInState(func(state *USBGetStateData) { state.Temperature = temperature })
+	}
+	setTemperature(30)
+	setTemperature(40)
+	// The existing reduction is higher than the first level.
+	if got := mock.GetOutStateData().RumbleMotorPowerReduction; got != 2 {
+		t.Fatalf("got reduction %d at threshold, want 2", got)
+	}
+	setTemperature(46)
+	if got := mock.GetOutStateData().RumbleMotorPowerReduction; got != MAX_MOTOR_POWER_REDUCTION || !mock.ThermalThrottled() {
+		t.Fatalf("got reduction %d at critical, want %d", got, MAX_MOTOR_POWER_REDUCTION)
+	}
+	setTemperature(30)
+	if got := mock.GetOutStateData().RumbleMotorPowerReduction; got != 2 || mock.ThermalThrottled() {
+		t.Fatalf("got reduction %d after cooling, want 2", got)
+	}
+	want := []ThermalThrottle{
+		{Engaged: true, Temperature: 40, Reduction: 1},
+		{Engaged: true, Temperature: 46, Reduction: MAX_MOTOR_POWER_REDUCTION},
+		{Engaged: false, Temperature: 30, Reduction: 0},
+	}
+	if len(throttles) != len(want) {
+		t.Fatalf("got %+v, want %+v", throttles, want)
+	}
+	for i := range want {
+		if throttles[i] != want[i] {
+			t.Fatalf("got %+v, want %+v", throttles, want)
+		}
+	}
+}
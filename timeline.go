@@ -0,0 +1,227 @@
+package dualsense
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// LightbarKeyframe sets the lightbar to Color At a time from the start of a
+// Timeline.
+type LightbarKeyframe struct {
+	At    time.Duration `json:"at"`
+	Color LedColor      `json:"color"`
+}
+
+// RumbleKeyframe sets the rumble motors to Left and Right At a time from the
+// start of a Timeline.
+type RumbleKeyframe struct {
+	At    time.Duration `json:"at"`
+	Left  uint8         `json:"left"`
+	Right uint8         `json:"right"`
+}
+
+// TriggerEffect is a trigger effect as passed to GenerateTriggerFFBParams.
+type TriggerEffect struct {
+	Type     EffectType `json:"type"`
+	Start    uint8      `json:"start"`
+	End      uint8      `json:"end"`
+	Strength uint8      `json:"strength"`
+}
+
+func (e TriggerEffect) params() [11]uint8 {
+	return GenerateTriggerFFBParams(e.Type, e.Start, e.End, e.Strength)
+}
+
+// TriggerCue changes the trigger effects At a time from the start of a
+// Timeline. A nil effect leaves that trigger as it is.
+type TriggerCue struct {
+	At    time.Duration  `json:"at"`
+	Left  *TriggerEffect `json:"left,omitempty"`
+	Right *TriggerEffect `json:"right,omitempty"`
+}
+
+// Timeline coordinates lightbar, rumble and trigger effect changes on one
+// clock. The lightbar and rumble fade linearly between keyframes and hold the
+// last one until the Timeline ends; trigger cues switch at their time. Tracks
+// are left alone until their first keyframe or cue.
+type Timeline struct {
+	Lightbar []LightbarKeyframe `json:"lightbar,omitempty"`
+	Rumble   []RumbleKeyframe   `json:"rumble,omitempty"`
+	Triggers []TriggerCue       `json:"triggers,omitempty"`
+}
+
+// Duration returns the time of the last keyframe or cue.
+func (t Timeline) Duration() time.Duration {
+	var duration time.Duration
+	for _, keyframe := range t.Lightbar {
+		duration = max(duration, keyframe.At)
+	}
+	for _, keyframe := range t.Rumble {
+		duration = max(duration, keyframe.At)
+	}
+	for _, cue := range t.Triggers {
+		duration = max(duration, cue.At)
+	}
+	return duration
+}
+
+// sorted checks the times of t and returns a copy with its keyframes and cues
+// in time order.
+func (t Timeline) sorted() (Timeline, error) {
+	for _, keyframe := range t.Lightbar {
+		if keyframe.At < 0 {
+			return Timeline{}, fmt.Errorf("invalid lightbar keyframe time %v", keyframe.At)
+		}
+	}
+	for _, keyframe := range t.Rumble {
+		if keyframe.At < 0 {
+			return Timeline{}, fmt.Errorf("invalid rumble keyframe time %v", keyframe.At)
+		}
+	}
+	for _, cue := range t.Triggers {
+		if cue.At < 0 {
+			return Timeline{}, fmt.Errorf("invalid trigger cue time %v", cue.At)
+		}
+	}
+	sorted := Timeline{
+		Lightbar: slices.Clone(t.Lightbar),
+		Rumble:   slices.Clone(t.Rumble),
+		Triggers: slices.Clone(t.Triggers),
+	}
+	slices.SortStableFunc(sorted.Lightbar, func(a, b LightbarKeyframe) int { return cmp.Compare(a.At, b.At) })
+	slices.SortStableFunc(sorted.Rumble, func(a, b RumbleKeyframe) int { return cmp.Compare(a.At, b.At) })
+	slices.SortStableFunc(sorted.Triggers, func(a, b TriggerCue) int { return cmp.Compare(a.At, b.At) })
+	return sorted, nil
+}
+
+// keyframeAt returns the index of the last of n keyframes at or before t, and
+// how far t is towards the next one, from 0 to 1. The index is -1 before the
+// first keyframe.
+func keyframeAt(n int, at func(int) time.Duration, t time.Duration) (int, float64) {
+	i := -1
+	for i+1 < n && at(i+1) <= t {
+		i++
+	}
+	if i < 0 || i+1 == n {
+		return i, 0
+	}
+	return i, float64(t-at(i)) / float64(at(i+1)-at(i))
+}
+
+// timelineTracks is what a Timeline has set so far.
+type timelineTracks struct {
+	lightbar, rumble, left, right bool
+}
+
+// apply sets the state of the sorted timeline at t on setStateData and
+// returns the tracks it set.
+func (t Timeline) apply(at time.Duration, setStateData *SetStateData) timelineTracks {
+	var tracks timelineTracks
+	if i, amount := keyframeAt(len(t.Lightbar), func(i int) time.Duration { return t.Lightbar[i].At }, at); i >= 0 {
+		color := t.Lightbar[i].Color
+		if amount > 0 {
+			color = blendLedColor(color, t.Lightbar[i+1].Color, amount)
+		}
+		color.apply(setStateData)
+		tracks.lightbar = true
+	}
+	if i, amount := keyframeAt(len(t.Rumble), func(i int) time.Duration { return t.Rumble[i].At }, at); i >= 0 {
+		from := t.Rumble[i]
+		to := from
+		if amount > 0 {
+			to = t.Rumble[i+1]
+		}
+		blend := func(from, to uint8) uint8 {
+			return uint8(math.Round(float64(from) + amount*(float64(to)-float64(from))))
+		}
+		setStateData.EnableRumbleEmulation = true
+		setStateData.UseRumbleNotHaptics = true
+		setStateData.RumbleEmulationLeft = blend(from.Left, to.Left)
+		setStateData.RumbleEmulationRight = blend(from.Right, to.Right)
+		tracks.rumble = true
+	}
+	for _, cue := range t.Triggers {
+		if cue.At > at {
+			break
+		}
+		if cue.Left != nil {
+			setStateData.AllowLeftTriggerFFB = true
+			setStateData.LeftTriggerFFB = cue.Left.params()
+			tracks.left = true
+		}
+		if cue.Right != nil {
+			setStateData.AllowRightTriggerFFB = true
+			setStateData.RightTriggerFFB = cue.Right.params()
+			tracks.right = true
+		}
+	}
+	return tracks
+}
+
+// restore puts back the tracks of saved that still hold what the timeline
+// last wrote.
+func (tracks timelineTracks) restore(setStateData, saved, last *SetStateData) {
+	if tracks.lightbar && ledColorOf(setStateData) == ledColorOf(last) && setStateData.AllowLedColor == last.AllowLedColor {
+		ledColorOf(saved).apply(setStateData)
+		setStateData.AllowLedColor = saved.AllowLedColor
+	}
+	if tracks.rumble && hapticRumbleOf(setStateData) == hapticRumbleOf(last) {
+		hapticRumbleOf(saved).apply(setStateData)
+	}
+	if tracks.left && setStateData.LeftTriggerFFB == last.LeftTriggerFFB {
+		setStateData.AllowLeftTriggerFFB = saved.AllowLeftTriggerFFB
+		setStateData.LeftTriggerFFB = saved.LeftTriggerFFB
+	}
+	if tracks.right && setStateData.RightTriggerFFB == last.RightTriggerFFB {
+		setStateData.AllowRightTriggerFFB = saved.AllowRightTriggerFFB
+		setStateData.RightTriggerFFB = saved.RightTriggerFFB
+	}
+}
+
+// timelineEffect plays a sorted Timeline as an Effect.
+type timelineEffect struct {
+	timeline    Timeline
+	duration    time.Duration
+	saved, last SetStateData
+	tracks      timelineTracks
+}
+
+// Effect returns an Effect playing the timeline, to run with other effects
+// through RunEffects. The outputs it set are restored as in PlayTimeline.
+func (t Timeline) Effect() (Effect, error) {
+	sorted, err := t.sorted()
+	if err != nil {
+		return nil, err
+	}
+	return &timelineEffect{timeline: sorted, duration: sorted.Duration()}, nil
+}
+
+func (e *timelineEffect) Start(setStateData *SetStateData) {
+	e.saved = *setStateData
+}
+
+func (e *timelineEffect) Tick(elapsed time.Duration, setStateData *SetStateData) bool {
+	e.tracks = e.timeline.apply(min(elapsed, e.duration), setStateData)
+	e.last = *setStateData
+	return elapsed < e.duration
+}
+
+func (e *timelineEffect) Stop(setStateData *SetStateData) {
+	e.tracks.restore(setStateData, &e.saved, &e.last)
+}
+
+// PlayTimeline plays timeline, writing the lightbar and rumble every
+// EFFECT_TICK_INTERVAL. Closing stop ends it early. Either way the lightbar,
+// rumble and trigger effects it set are then restored, unless they were
+// changed while it played. It blocks until the timeline ends, so call it from
+// its own goroutine rather than from an input callback.
+func (d *DualSense) PlayTimeline(timeline Timeline, stop <-chan struct{}) error {
+	effect, err := timeline.Effect()
+	if err != nil {
+		return err
+	}
+	return d.RunEffects(stop, effect)
+}
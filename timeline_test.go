@@ -0,0 +1,97 @@
+package dualsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineApply(t *testing.T) {
+	timeline, err := Timeline{
+		Lightbar: []LightbarKeyframe{
+			{At: 100 * time.Millisecond, Color: LedColor{Red: 200}},
+			{At: 0, Color: LedColor{Blue: 200}},
+		},
+		Rumble: []RumbleKeyframe{{At: 50 * time.Millisecond, Left: 100}, {At: 150 * time.Millisecond, Left: 200, Right: 50}},
+		Triggers: []TriggerCue{
+			{At: 80 * time.Millisecond, Left: &TriggerEffect{Type: EffectTypeFeedback, End: 0xFF, Strength: 0x80}},
+		},
+	}.sorted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := timeline.Duration(); got != 150*time.Millisecond {
+		t.Errorf("Duration() = %v, want 150ms", got)
+	}
+	tests := []struct {
+		at     time.Duration
+		color  LedColor
+		rumble [2]uint8
+		tracks timelineTracks
+	}{
+		{0, LedColor{Blue: 200}, [2]uint8{}, timelineTracks{lightbar: true}},
+		{50 * time.Millisecond, LedColor{Red: 100, Blue: 100}, [2]uint8{100, 0}, timelineTracks{lightbar: true, rumble: true}},
+		{100 * time.Millisecond, LedColor{Red: 200}, [2]uint8{150, 25}, timelineTracks{true, true, true, false}},
+		{200 * time.Millisecond, LedColor{Red: 200}, [2]uint8{200, 50}, timelineTracks{true, true, true, false}},
+	}
+	for _, test := range tests {
+		var setStateData SetStateData
+		tracks := timeline.apply(test.at, &setStateData)
+		if tracks != test.tracks {
+			t.Errorf("at %v: got tracks %+v, want %+v", test.at, tracks, test.tracks)
+		}
+		if got := ledColorOf(&setStateData); got != test.color {
+			t.Errorf("at %v: got color %+v, want %+v", test.at, got, test.color)
+		}
+		if got := [2]uint8{setStateData.RumbleEmulationLeft, setStateData.RumbleEmulationRight}; got != test.rumble {
+			t.Errorf("at %v: got rumble %v, want %v", test.at, got, test.rumble)
+		}
+		if tracks.left && setStateData.LeftTriggerFFB != GenerateTriggerFFBParams(EffectTypeFeedback, 0, 0xFF, 0x80) {
+			t.Errorf("at %v: got left trigger %v", test.at, setStateData.LeftTriggerFFB)
+		}
+	}
+
+	if _, err := (Timeline{Rumble: []RumbleKeyframe{{At: -time.Millisecond}}}).sorted(); err == nil {
+		t.Error("negative keyframe time: got no error")
+	}
+}
+
+func TestPlayTimeline(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	before := d.GetOutStateData()
+
+	timeline := Timeline{
+		Lightbar: []LightbarKeyframe{{Color: LedColor{Red: 255}}, {At: 30 * time.Millisecond, Color: LedColor{Green: 255}}},
+		Rumble:   []RumbleKeyframe{{Left: 255}},
+	}
+	if err := d.PlayTimeline(timeline, nil); err != nil {
+		t.Fatal(err)
+	}
+	outStates := d.OutStates()
+	if len(outStates) < 3 {
+		t.Fatalf("got %d output reports, want at least 3", len(outStates))
+	}
+	if got := ledColorOf(&outStates[len(outStates)-2]); got != (LedColor{Green: 255}) {
+		t.Errorf("last frame: got %+v, want green", got)
+	}
+	if got := d.GetOutStateData(); got != before {
+		t.Errorf("not restored: got %+v, want %+v", got, before)
+	}
+
+	// Stopping ends the timeline early and restores too.
+	stop := make(chan struct{})
+	close(stop)
+	started := time.Now()
+	if err := d.PlayTimeline(Timeline{Rumble: []RumbleKeyframe{{Left: 10}, {At: time.Hour}}}, stop); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(started) > time.Second {
+		t.Error("stopped timeline kept playing")
+	}
+	if got := d.GetOutStateData(); got != before {
+		t.Errorf("not restored after stop: got %+v, want %+v", got, before)
+	}
+}
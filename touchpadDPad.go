@@ -0,0 +1,92 @@
+package dualsense
+
+import (
+	"math"
+	"sync"
+)
+
+// TOUCHPAD_DPAD_DEAD_ZONE is the radius around the center of the touchpad,
+// as a fraction of its half width and height, where a touch points in no
+// direction.
+const TOUCHPAD_DPAD_DEAD_ZONE = 0.25
+
+// TouchpadDPadMode selects how the touchpad drives the D-pad, see
+// SetTouchpadDPadMode.
+type TouchpadDPadMode uint8
+
+const (
+	TouchpadDPadOff TouchpadDPadMode = iota
+	// TouchpadDPad4Way divides the touchpad into North, East, South and West
+	// zones.
+	TouchpadDPad4Way
+	// TouchpadDPad8Way adds the diagonals.
+	TouchpadDPad8Way
+)
+
+// Direction zones counterclockwise from East, see directionAt.
+var (
+	directionZones4Way = []Direction{DirectionEast, DirectionNorth, DirectionWest, DirectionSouth}
+	directionZones8Way = []Direction{
+		DirectionEast, DirectionNorthEast, DirectionNorth, DirectionNorthWest,
+		DirectionWest, DirectionSouthWest, DirectionSouth, DirectionSouthEast,
+	}
+)
+
+type touchpadDPad struct {
+	mu   sync.Mutex
+	mode TouchpadDPadMode
+}
+
+// SetTouchpadDPadMode makes the touchpad act as a D-pad, e.g. for emulator
+// front-ends: while the first finger touches it outside its center, DPad
+// reports the direction of the touch from the center, and FieldDPad callbacks
+// and events fire as for the physical D-pad. Both are combined, with opposing
+// directions resolved by the DPadPolicy. The touch itself is still reported.
+func (d *DualSense) SetTouchpadDPadMode(mode TouchpadDPadMode) {
+	d.touchpadDPad.mu.Lock()
+	defer d.touchpadDPad.mu.Unlock()
+	d.touchpadDPad.mode = mode
+}
+
+// TouchpadDPadMode returns the mode set with SetTouchpadDPadMode.
+func (d *DualSense) TouchpadDPadMode() TouchpadDPadMode {
+	d.touchpadDPad.mu.Lock()
+	defer d.touchpadDPad.mu.Unlock()
+	return d.touchpadDPad.mode
+}
+
+// touchpadDPadHat returns the hat bits held through the touchpad.
+func (d *DualSense) touchpadDPadHat(state *USBGetStateData) uint8 {
+	mode := d.TouchpadDPadMode()
+	if mode == TouchpadDPadOff {
+		return 0
+	}
+	return dPadHats[touchpadDirection(state.TouchData.TouchFinger1, mode)]
+}
+
+// touchpadDirection returns the zone of the touchpad finger is in.
+func touchpadDirection(finger TouchFinger, mode TouchpadDPadMode) Direction {
+	if finger.NotTouching {
+		return DirectionNone
+	}
+	x := (float64(finger.FingerX) - TOUCHPAD_WIDTH/2) / (TOUCHPAD_WIDTH / 2)
+	y := (TOUCHPAD_HEIGHT/2 - float64(finger.FingerY)) / (TOUCHPAD_HEIGHT / 2)
+	if math.Hypot(x, y) < TOUCHPAD_DPAD_DEAD_ZONE {
+		return DirectionNone
+	}
+	zones := directionZones4Way
+	if mode == TouchpadDPad8Way {
+		zones = directionZones8Way
+	}
+	return directionAt(x, y, zones)
+}
+
+// directionAt returns the zone the vector (x, y) points to, with y growing
+// upwards and zones laid out counterclockwise from East.
+func directionAt(x, y float64, zones []Direction) Direction {
+	zone := int(math.Round(math.Atan2(y, x)/(2*math.Pi)*float64(len(zones)))) % len(zones)
+	if zone < 0 {
+		zone += len(zones)
+	}
+	return zones[zone]
+}
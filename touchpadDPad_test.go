@@ -0,0 +1,70 @@
+package dualsense
+
+import "testing"
+
+func TestTouchpadDirection(t *testing.T) {
+	tests := []struct {
+		x, y uint16
+		mode TouchpadDPadMode
+		want Direction
+	}{
+		{960, 540, TouchpadDPad8Way, DirectionNone},
+		{1800, 540, TouchpadDPad4Way, DirectionEast},
+		{960, 50, TouchpadDPad4Way, DirectionNorth},
+		{100, 600, TouchpadDPad4Way, DirectionWest},
+		{960, 1000, TouchpadDPad4Way, DirectionSouth},
+		{1800, 1000, TouchpadDPad4Way, DirectionEast},
+		{1800, 1000, TouchpadDPad8Way, DirectionSouthEast},
+		{100, 50, TouchpadDPad8Way, DirectionNorthWest},
+		{1800, 560, TouchpadDPad8Way, DirectionEast},
+	}
+	for _, test := range tests {
+		got := touchpadDirection(TouchFinger{FingerX: test.x, FingerY: test.y}, test.mode)
+		if got != test.want {
+			t.Errorf("(%d, %d) in mode %d: got %s, want %s", test.x, test.y, test.mode, got, test.want)
+		}
+	}
+	if got := touchpadDirection(TouchFinger{NotTouching: true, FingerX: 1800, FingerY: 540}, TouchpadDPad8Way); got != DirectionNone {
+		t.Errorf("lifted finger: got %s, want None", got)
+	}
+}
+
+func TestTouchpadDPadMode(t *testing.T) {
+	d := NewMockDualSense()
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionNone
+		state.TouchData.TouchFinger1 = TouchFinger{NotTouching: true}
+		state.TouchData.TouchFinger2 = TouchFinger{NotTouching: true}
+	})
+	var got []Direction
+	d.OnFieldChange(FieldDPad, func(change FieldChange) {
+		got = append(got, change.New.(Direction))
+	})
+	d.SetTouchpadDPadMode(TouchpadDPad4Way)
+
+	// Reports carry the physical D-pad, released.
+	touch := func(x, y uint16) {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.DPad = DirectionNone
+			state.TouchData.TouchFinger1 = TouchFinger{FingerX: x, FingerY: y}
+		})
+	}
+	touch(1800, 540)
+	touch(960, 50)
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionSouth
+	})
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.DPad = DirectionNone
+		state.TouchData.TouchFinger1.NotTouching = true
+	})
+	want := []Direction{DirectionEast, DirectionNorth, DirectionSouth, DirectionNone}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
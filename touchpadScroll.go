@@ -0,0 +1,54 @@
+package dualsense
+
+// TouchpadScroll is how far two fingers moved together across the touchpad
+// between two input reports, in touchpad units. DY grows downwards, as
+// FingerY does.
+type TouchpadScroll struct {
+	DX int
+	DY int
+}
+
+// TwoFingerScroll returns the movement of the midpoint of two fingers that
+// touched the touchpad in both previous and current and moved in the same
+// direction. Fingers moving apart or towards each other, as in a pinch, do not
+// scroll.
+func TwoFingerScroll(previous, current TouchData) (TouchpadScroll, bool) {
+	fingers := [2][2]TouchFinger{
+		{previous.TouchFinger1, current.TouchFinger1},
+		{previous.TouchFinger2, current.TouchFinger2},
+	}
+	var deltas [2][2]int
+	for i, finger := range fingers {
+		before, after := finger[0], finger[1]
+		if before.NotTouching || after.NotTouching || before.Index != after.Index {
+			return TouchpadScroll{}, false
+		}
+		deltas[i] = [2]int{int(after.FingerX) - int(before.FingerX), int(after.FingerY) - int(before.FingerY)}
+	}
+	if deltas[0][0]*deltas[1][0]+deltas[0][1]*deltas[1][1] <= 0 {
+		return TouchpadScroll{}, false
+	}
+	return TouchpadScroll{
+		DX: (deltas[0][0] + deltas[1][0]) / 2,
+		DY: (deltas[0][1] + deltas[1][1]) / 2,
+	}, true
+}
+
+// OnTouchpadScroll registers a callback for every input report in which two
+// fingers moved together on the touchpad, see TwoFingerScroll.
+func (d *DualSense) OnTouchpadScroll(callback func(TouchpadScroll)) {
+	d.callbacks.OnTouchpadScroll = append(d.callbacks.OnTouchpadScroll, callback)
+}
+
+func (d *DualSense) processTouchpadScroll(current, previous *USBGetStateData) {
+	if len(d.callbacks.OnTouchpadScroll) == 0 {
+		return
+	}
+	scroll, ok := TwoFingerScroll(previous.TouchData, current.TouchData)
+	if !ok || scroll == (TouchpadScroll{}) {
+		return
+	}
+	for _, callback := range d.callbacks.OnTouchpadScroll {
+		callback(scroll)
+	}
+}
@@ -0,0 +1,58 @@
+package dualsense
+
+import "testing"
+
+func twoFingers(x1, y1, x2, y2 uint16) TouchData {
+	return TouchData{
+		TouchFinger1: TouchFinger{Index: 1, FingerX: x1, FingerY: y1},
+		TouchFinger2: TouchFinger{Index: 2, FingerX: x2, FingerY: y2},
+	}
+}
+
+func TestTwoFingerScroll(t *testing.T) {
+	oneFinger := twoFingers(500, 500, 0, 0)
+	oneFinger.TouchFinger2.NotTouching = true
+	tests := []struct {
+		name              string
+		previous, current TouchData
+		want              TouchpadScroll
+		ok                bool
+	}{
+		{"down", twoFingers(500, 500, 700, 500), twoFingers(500, 540, 700, 560), TouchpadScroll{DY: 50}, true},
+		{"left", twoFingers(500, 500, 700, 500), twoFingers(470, 502, 670, 498), TouchpadScroll{DX: -30}, true},
+		{"pinch", twoFingers(500, 500, 700, 500), twoFingers(520, 500, 680, 500), TouchpadScroll{}, false},
+		{"one finger", oneFinger, oneFinger, TouchpadScroll{}, false},
+		{"new touch", twoFingers(500, 500, 700, 500), TouchData{
+			TouchFinger1: TouchFinger{Index: 3, FingerX: 500, FingerY: 540},
+			TouchFinger2: TouchFinger{Index: 2, FingerX: 700, FingerY: 540},
+		}, TouchpadScroll{}, false},
+	}
+	for _, test := range tests {
+		got, ok := TwoFingerScroll(test.previous, test.current)
+		if got != test.want || ok != test.ok {
+			t.Errorf("%s: got %+v, %v, want %+v, %v", test.name, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestOnTouchpadScroll(t *testing.T) {
+	d := NewMockDualSense()
+	var got []TouchpadScroll
+	d.OnTouchpadScroll(func(scroll TouchpadScroll) {
+		got = append(got, scroll)
+	})
+	for _, touch := range []TouchData{
+		twoFingers(500, 500, 700, 500),
+		twoFingers(500, 520, 700, 520),
+		twoFingers(500, 520, 700, 520),
+		twoFingers(510, 520, 710, 520),
+	} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.TouchData = touch
+		})
+	}
+	want := []TouchpadScroll{{DY: 20}, {DX: 10}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+package dualsense
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// SHAPE_MIN_SIZE is the smallest extent, in touchpad units, of a stroke
+	// recognized as a shape. Shorter strokes are taps or swipes.
+	SHAPE_MIN_SIZE = 300
+	// SHAPE_MIN_POINTS is how many distinct touch positions a stroke needs
+	// before it is recognized as a shape.
+	SHAPE_MIN_POINTS = 6
+)
+
+// Shape is a figure drawn on the touchpad with a single finger.
+type Shape uint8
+
+const (
+	// ShapeCircle is a closed loop, drawn in either direction.
+	ShapeCircle Shape = iota
+	// ShapeZ is drawn from the top left: right, down to the bottom left,
+	// then right again.
+	ShapeZ
+	// ShapeCheckmark is a short stroke down and to the right followed by a
+	// longer one up and to the right.
+	ShapeCheckmark
+)
+
+// ShapeGesture is a Shape recognized once the finger drawing it is lifted.
+type ShapeGesture struct {
+	Shape    Shape
+	Duration time.Duration
+}
+
+type touchPoint struct {
+	x, y float64
+}
+
+// shapeStroke collects the single-finger stroke in progress. It is only used
+// while processing input reports.
+type shapeStroke struct {
+	active bool
+	// discarded is set from a second finger touching until the first one is
+	// lifted.
+	discarded bool
+	index     uint8
+	start     time.Time
+	points    []touchPoint
+}
+
+// OnShapeGesture registers a callback for shapes drawn on the touchpad with a
+// single finger, e.g. as shortcuts. A stroke is recognized when the finger is
+// lifted; strokes touched by a second finger are discarded.
+func (d *DualSense) OnShapeGesture(callback func(ShapeGesture)) {
+	d.callbacks.OnShapeGesture = append(d.callbacks.OnShapeGesture, callback)
+}
+
+func (d *DualSense) processShapeGestures(current *USBGetStateData, now time.Time) {
+	if len(d.callbacks.OnShapeGesture) == 0 {
+		return
+	}
+	stroke := &d.shapeStroke
+	finger1, finger2 := current.TouchData.TouchFinger1, current.TouchData.TouchFinger2
+	if !finger2.NotTouching {
+		stroke.active = false
+		stroke.discarded = !finger1.NotTouching
+		return
+	}
+	if finger1.NotTouching {
+		stroke.discarded = false
+	}
+	if stroke.discarded {
+		return
+	}
+	if finger1.NotTouching || (stroke.active && finger1.Index != stroke.index) {
+		if stroke.active {
+			stroke.active = false
+			shape, ok := recognizeShape(stroke.points)
+			if ok {
+				gesture := ShapeGesture{Shape: shape, Duration: now.Sub(stroke.start)}
+				for _, callback := range d.callbacks.OnShapeGesture {
+					callback(gesture)
+				}
+			}
+		}
+		if finger1.NotTouching {
+			return
+		}
+	}
+	if !stroke.active {
+		stroke.active = true
+		stroke.index = finger1.Index
+		stroke.start = now
+		stroke.points = stroke.points[:0]
+	}
+	point := touchPoint{x: float64(finger1.FingerX), y: float64(finger1.FingerY)}
+	if n := len(stroke.points); n == 0 || stroke.points[n-1] != point {
+		stroke.points = append(stroke.points, point)
+	}
+}
+
+// recognizeShape classifies a stroke in touchpad coordinates, where y grows
+// downwards.
+func recognizeShape(points []touchPoint) (Shape, bool) {
+	if len(points) < SHAPE_MIN_POINTS {
+		return 0, false
+	}
+	minX, minY, maxX, maxY := points[0].x, points[0].y, points[0].x, points[0].y
+	for _, p := range points[1:] {
+		minX, maxX = min(minX, p.x), max(maxX, p.x)
+		minY, maxY = min(minY, p.y), max(maxY, p.y)
+	}
+	width, height := maxX-minX, maxY-minY
+	if max(width, height) < SHAPE_MIN_SIZE {
+		return 0, false
+	}
+	first, last := points[0], points[len(points)-1]
+	closed := math.Hypot(last.x-first.x, last.y-first.y) < 0.25*math.Hypot(width, height)
+
+	if closed && isCircle(points, width, height) {
+		return ShapeCircle, true
+	}
+	if !closed && isZ(points, minX, minY, width, height) {
+		return ShapeZ, true
+	}
+	if !closed && isCheckmark(points) {
+		return ShapeCheckmark, true
+	}
+	return 0, false
+}
+
+// isCircle checks that a closed stroke is about as wide as it is high and
+// keeps a steady distance from its center.
+func isCircle(points []touchPoint, width, height float64) bool {
+	if width < height/2 || height < width/2 {
+		return false
+	}
+	var center touchPoint
+	for _, p := range points {
+		center.x += p.x
+		center.y += p.y
+	}
+	center.x /= float64(len(points))
+	center.y /= float64(len(points))
+	var sum, sumSquares float64
+	for _, p := range points {
+		r := math.Hypot(p.x-center.x, p.y-center.y)
+		sum += r
+		sumSquares += r * r
+	}
+	mean := sum / float64(len(points))
+	deviation := math.Sqrt(max(sumSquares/float64(len(points))-mean*mean, 0))
+	return deviation < 0.25*mean
+}
+
+// isZ looks for the top right and bottom left corners of a Z, in that order,
+// between a start at the top left and an end at the bottom right.
+func isZ(points []touchPoint, minX, minY, width, height float64) bool {
+	if height < width/3 || width < height/3 {
+		return false
+	}
+	normalized := make([]touchPoint, len(points))
+	for i, p := range points {
+		normalized[i] = touchPoint{x: (p.x - minX) / width, y: (p.y - minY) / height}
+	}
+	topRight, bottomLeft := 0, 0
+	for i, p := range normalized {
+		if p.x-p.y > normalized[topRight].x-normalized[topRight].y {
+			topRight = i
+		}
+		if p.y-p.x > normalized[bottomLeft].y-normalized[bottomLeft].x {
+			bottomLeft = i
+		}
+	}
+	start, end := normalized[0], normalized[len(normalized)-1]
+	a, b := normalized[topRight], normalized[bottomLeft]
+	return topRight < bottomLeft &&
+		start.x < 0.4 && start.y < 0.3 &&
+		a.x > 0.6 && a.y < 0.3 &&
+		b.x < 0.4 && b.y > 0.7 &&
+		end.x > 0.6 && end.y > 0.7
+}
+
+// isCheckmark splits the stroke at its lowest point into a descending and a
+// longer rising arm, both moving right.
+func isCheckmark(points []touchPoint) bool {
+	lowest := 0
+	for i, p := range points {
+		if p.y > points[lowest].y {
+			lowest = i
+		}
+	}
+	first, bottom, last := points[0], points[lowest], points[len(points)-1]
+	down := touchPoint{x: bottom.x - first.x, y: bottom.y - first.y}
+	up := touchPoint{x: last.x - bottom.x, y: last.y - bottom.y}
+	return lowest > 0 && lowest < len(points)-1 &&
+		down.x > 0 && down.y > 0 &&
+		up.x > 0 && up.y < 0 &&
+		math.Hypot(up.x, up.y) > math.Hypot(down.x, down.y)
+}
@@ -0,0 +1,89 @@
+package dualsense
+
+import (
+	"math"
+	"testing"
+)
+
+// drawStroke touches the touchpad with finger index at every point in turn and
+// lifts it.
+func drawStroke(d *MockDualSense, index uint8, points [][2]uint16) {
+	for _, p := range points {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.TouchData.TouchFinger1 = TouchFinger{Index: index, FingerX: p[0], FingerY: p[1]}
+			state.TouchData.TouchFinger2 = TouchFinger{NotTouching: true}
+		})
+	}
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.TouchData.TouchFinger1.NotTouching = true
+	})
+}
+
+// polyline interpolates steps points along every segment between corners.
+func polyline(steps int, corners ...[2]uint16) [][2]uint16 {
+	points := [][2]uint16{corners[0]}
+	for i := 1; i < len(corners); i++ {
+		from, to := corners[i-1], corners[i]
+		for step := 1; step <= steps; step++ {
+			t := float64(step) / float64(steps)
+			points = append(points, [2]uint16{
+				uint16(float64(from[0]) + t*(float64(to[0])-float64(from[0]))),
+				uint16(float64(from[1]) + t*(float64(to[1])-float64(from[1]))),
+			})
+		}
+	}
+	return points
+}
+
+func TestShapeGestures(t *testing.T) {
+	var circle [][2]uint16
+	for i := range 25 {
+		angle := 2 * math.Pi * float64(i) / 24
+		circle = append(circle, [2]uint16{uint16(960 + 300*math.Cos(angle)), uint16(540 + 300*math.Sin(angle))})
+	}
+	tests := []struct {
+		name   string
+		points [][2]uint16
+		want   []Shape
+	}{
+		{"circle", circle, []Shape{ShapeCircle}},
+		{"Z", polyline(5, [2]uint16{400, 200}, [2]uint16{1400, 200}, [2]uint16{400, 900}, [2]uint16{1400, 900}), []Shape{ShapeZ}},
+		{"checkmark", polyline(5, [2]uint16{500, 500}, [2]uint16{700, 800}, [2]uint16{1300, 100}), []Shape{ShapeCheckmark}},
+		{"swipe", polyline(5, [2]uint16{200, 500}, [2]uint16{1600, 500}), nil},
+		{"tap", [][2]uint16{{960, 540}}, nil},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewMockDualSense()
+			var got []Shape
+			d.OnShapeGesture(func(gesture ShapeGesture) {
+				got = append(got, gesture.Shape)
+			})
+			drawStroke(d, uint8(i), test.points)
+			if len(got) != len(test.want) || (len(got) == 1 && got[0] != test.want[0]) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestShapeGestureDiscardedBySecondFinger(t *testing.T) {
+	d := NewMockDualSense()
+	var got []Shape
+	d.OnShapeGesture(func(gesture ShapeGesture) {
+		got = append(got, gesture.Shape)
+	})
+	points := polyline(5, [2]uint16{500, 500}, [2]uint16{700, 800}, [2]uint16{1300, 100})
+	for i, p := range points {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.TouchData.TouchFinger1 = TouchFinger{FingerX: p[0], FingerY: p[1]}
+			state.TouchData.TouchFinger2 = TouchFinger{Index: 1, NotTouching: i != 3}
+		})
+	}
+	d.UpdateInState(func(state *USBGetStateData) {
+		state.TouchData.TouchFinger1.NotTouching = true
+	})
+	if len(got) != 0 {
+		t.Errorf("got %v, want no shape", got)
+	}
+}
@@ -0,0 +1,28 @@
+package dualsense
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned by Transport.Read when no report arrives before the
+// timeout expires.
+var ErrTimeout = errors.New("timeout")
+
+// ErrDisconnected is returned by Transport.Read once the controller is gone,
+// e.g. unplugged. DualSense then moves to LifecycleDisconnected. HIDTransport,
+// HIDRawTransport and USBTransport report it, as does DualShock4Transport over
+// any of them. Transports that cannot tell a disconnect from other failures
+// return their own errors.
+var ErrDisconnected = errors.New("device disconnected")
+
+// Transport moves raw reports between DualSense and a controller. Every report
+// starts with its report ID. Implementations must be safe for a concurrent Read
+// and Write.
+type Transport interface {
+	Read(p []byte, timeout time.Duration) (int, error)
+	Write(p []byte) (int, error)
+	SendFeature(p []byte) (int, error)
+	GetFeature(p []byte) (int, error)
+	Close() error
+}
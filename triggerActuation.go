@@ -0,0 +1,42 @@
+package dualsense
+
+import "sync"
+
+// TriggerActuation sets the analog value, from 1 to 255, at which L2 and R2
+// count as pressed. 0 keeps the controller's own digital threshold.
+type TriggerActuation struct {
+	Left  uint8
+	Right uint8
+}
+
+type triggerActuation struct {
+	mu        sync.Mutex
+	actuation TriggerActuation
+}
+
+// SetTriggerActuation makes ButtonL2 and ButtonR2 follow the analog triggers,
+// pressed while they are pulled to at least the actuation point, e.g. a low
+// one for quicker response in shooters. The actuation point applies to how
+// far the trigger is physically pulled, before any AxisInversion.
+func (d *DualSense) SetTriggerActuation(actuation TriggerActuation) {
+	d.actuation.mu.Lock()
+	defer d.actuation.mu.Unlock()
+	d.actuation.actuation = actuation
+}
+
+// TriggerActuation returns the actuation points set with SetTriggerActuation.
+func (d *DualSense) TriggerActuation() TriggerActuation {
+	d.actuation.mu.Lock()
+	defer d.actuation.mu.Unlock()
+	return d.actuation.actuation
+}
+
+func (d *DualSense) processTriggerActuation(state *USBGetStateData) {
+	actuation := d.TriggerActuation()
+	if actuation.Left != 0 {
+		state.ButtonL2 = state.TriggerLeft >= actuation.Left
+	}
+	if actuation.Right != 0 {
+		state.ButtonR2 = state.TriggerRight >= actuation.Right
+	}
+}
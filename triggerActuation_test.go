@@ -0,0 +1,29 @@
+package dualsense
+
+import "testing"
+
+func TestTriggerActuation(t *testing.T) {
+	d := NewMockDualSense()
+	d.SetTriggerActuation(TriggerActuation{Left: 40})
+	var got []bool
+	d.OnButtonL2Change(func(pressed bool) {
+		got = append(got, pressed)
+	})
+
+	for _, value := range []uint8{30, 40, 200, 39} {
+		d.UpdateInState(func(state *USBGetStateData) {
+			state.TriggerLeft = value
+			// The hardware threshold is only crossed at 200.
+			state.ButtonL2 = value >= 200
+			state.TriggerRight = value
+			state.ButtonR2 = value >= 200
+		})
+	}
+	want := []bool{true, false}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ButtonL2 changes: got %v, want %v", got, want)
+	}
+	if d.GetInStateData().ButtonR2 {
+		t.Error("ButtonR2 pressed without an actuation point below the hardware threshold")
+	}
+}
@@ -0,0 +1,310 @@
+package dualsense
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// usbfs structures and ioctl requests from linux/usbdevice_fs.h.
+type usbfsCtrlTransfer struct {
+	RequestType uint8
+	Request     uint8
+	Value       uint16
+	Index       uint16
+	Length      uint16
+	Timeout     uint32
+	Data        unsafe.Pointer
+}
+
+type usbfsBulkTransfer struct {
+	Endpoint uint32
+	Length   uint32
+	Timeout  uint32
+	Data     unsafe.Pointer
+}
+
+type usbfsIoctl struct {
+	Interface int32
+	IoctlCode int32
+	Data      unsafe.Pointer
+}
+
+func ioctlRequest(direction, kind, nr, size uintptr) uintptr {
+	return direction<<30 | size<<16 | kind<<8 | nr
+}
+
+var (
+	usbfsControl          = ioctlRequest(3, 'U', 0, unsafe.Sizeof(usbfsCtrlTransfer{}))
+	usbfsBulk             = ioctlRequest(3, 'U', 2, unsafe.Sizeof(usbfsBulkTransfer{}))
+	usbfsClaimInterface   = ioctlRequest(2, 'U', 15, unsafe.Sizeof(uint32(0)))
+	usbfsReleaseInterface = ioctlRequest(2, 'U', 16, unsafe.Sizeof(uint32(0)))
+	usbfsIoctlRequest     = ioctlRequest(3, 'U', 18, unsafe.Sizeof(usbfsIoctl{}))
+	usbfsDisconnect       = ioctlRequest(0, 'U', 22, 0)
+	usbfsConnect          = ioctlRequest(0, 'U', 23, 0)
+)
+
+const (
+	usbClassHID             = 0x03
+	hidGetReport            = 0x01
+	hidSetReport            = 0x09
+	hidReportTypeOutput     = 0x02
+	hidReportTypeFeature    = 0x03
+	usbDescriptorInterface  = 0x04
+	usbDescriptorEndpoint   = 0x05
+	usbGetDescriptor        = 0x06
+	hidDescriptorTypeReport = 0x22
+)
+
+// USBTransport talks to the HID interface of a controller directly through
+// Linux usbfs, without hidapi or cgo, so it works in statically linked
+// binaries. It detaches the kernel HID driver while open.
+type USBTransport struct {
+	file        *os.File
+	iface       uint32
+	endpointIn  uint8
+	endpointOut uint8
+}
+
+// OpenUSBTransport opens the first DualSense or DualSense Edge controller
+// found in sysfs.
+func OpenUSBTransport() (*USBTransport, error) {
+	transport, err := openUSBTransport(dualSenseProductIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to open DualSense controller: %w", err)
+	}
+	return transport, nil
+}
+
+// OpenDualShock4USBTransport opens the first DualShock 4 controller found in
+// sysfs. Wrap it in a DualShock4Transport to use it with DualSense.
+func OpenDualShock4USBTransport() (*USBTransport, error) {
+	transport, err := openUSBTransport(dualShock4ProductIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to open DualShock 4 controller: %w", err)
+	}
+	return transport, nil
+}
+
+func openUSBTransport(productIDs ...uint16) (*USBTransport, error) {
+	devicePaths, err := filepath.Glob("/sys/bus/usb/devices/*")
+	if err != nil {
+		return nil, fmt.Errorf("filepath.Glob: error trying to list USB devices: %w", err)
+	}
+	for _, devicePath := range devicePaths {
+		if readSysfsHex(devicePath, "idVendor") != DUALSENSE_VENDOR_ID || !slices.Contains(productIDs, uint16(readSysfsHex(devicePath, "idProduct"))) {
+			continue
+		}
+		busNum, errBus := readSysfsInt(devicePath, "busnum")
+		devNum, errDev := readSysfsInt(devicePath, "devnum")
+		if errBus != nil || errDev != nil {
+			continue
+		}
+		return OpenUSBTransportPath(fmt.Sprintf("/dev/bus/usb/%03d/%03d", busNum, devNum))
+	}
+	return nil, errors.New("no matching device found on the USB bus")
+}
+
+// OpenUSBTransportPath opens the usbfs device node at path, e.g.
+// /dev/bus/usb/001/004.
+func OpenUSBTransportPath(path string) (*USBTransport, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: error trying to open DualSense controller: %w", err)
+	}
+	descriptors := make([]byte, 4096)
+	n, err := file.Read(descriptors)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error trying to read DualSense controller descriptors: %w", err)
+	}
+	iface, endpointIn, endpointOut, err := findHIDInterface(descriptors[:n])
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	t := &USBTransport{
+		file:        file,
+		iface:       uint32(iface),
+		endpointIn:  endpointIn,
+		endpointOut: endpointOut,
+	}
+	// Detaching fails when no kernel driver is bound, which is fine.
+	t.driverIoctl(usbfsDisconnect)
+	err = t.ioctl(usbfsClaimInterface, unsafe.Pointer(&t.iface))
+	if err != nil {
+		t.driverIoctl(usbfsConnect)
+		file.Close()
+		return nil, fmt.Errorf("error trying to claim DualSense controller HID interface: %w", err)
+	}
+	return t, nil
+}
+
+// findHIDInterface walks the device and configuration descriptors for the
+// first HID interface and its interrupt endpoints. endpointOut is 0 when the
+// interface has no OUT endpoint.
+func findHIDInterface(descriptors []byte) (iface, endpointIn, endpointOut uint8, err error) {
+	inHID := false
+	found := false
+	for offset := 0; offset+2 <= len(descriptors); {
+		length := int(descriptors[offset])
+		if length < 2 || offset+length > len(descriptors) {
+			break
+		}
+		descriptor := descriptors[offset : offset+length]
+		switch descriptor[1] {
+		case usbDescriptorInterface:
+			if found {
+				return iface, endpointIn, endpointOut, nil
+			}
+			inHID = length >= 6 && descriptor[5] == usbClassHID
+			if inHID {
+				iface = descriptor[2]
+				found = true
+			}
+		case usbDescriptorEndpoint:
+			if inHID && length >= 3 {
+				if descriptor[2]&0x80 != 0 {
+					endpointIn = descriptor[2]
+				} else {
+					endpointOut = descriptor[2]
+				}
+			}
+		}
+		offset += length
+	}
+	if !found || endpointIn == 0 {
+		return 0, 0, 0, errors.New("error trying to find DualSense controller HID interface in USB descriptors")
+	}
+	return iface, endpointIn, endpointOut, nil
+}
+
+func (t *USBTransport) ioctl(request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.file.Fd(), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func (t *USBTransport) driverIoctl(request uintptr) error {
+	command := usbfsIoctl{Interface: int32(t.iface), IoctlCode: int32(request)}
+	return t.ioctl(usbfsIoctlRequest, unsafe.Pointer(&command))
+}
+
+func (t *USBTransport) interruptTransfer(endpoint uint8, p []byte, timeout time.Duration) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// A usbfs timeout of 0 waits forever, so the shortest poll is 1ms.
+	transfer := usbfsBulkTransfer{
+		Endpoint: uint32(endpoint),
+		Length:   uint32(len(p)),
+		Timeout:  uint32(max(timeout, time.Millisecond) / time.Millisecond),
+		Data:     unsafe.Pointer(&p[0]),
+	}
+	n, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.file.Fd(), usbfsBulk, uintptr(unsafe.Pointer(&transfer)))
+	if errno == syscall.ETIMEDOUT {
+		return 0, ErrTimeout
+	}
+	if errno == syscall.ENODEV {
+		return 0, ErrDisconnected
+	}
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func (t *USBTransport) controlTransfer(requestType, request uint8, value uint16, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, errors.New("missing report ID")
+	}
+	transfer := usbfsCtrlTransfer{
+		RequestType: requestType,
+		Request:     request,
+		Value:       value,
+		Index:       uint16(t.iface),
+		Length:      uint16(len(p)),
+		Timeout:     uint32(DEFAULT_READ_TIMEOUT / time.Millisecond),
+		Data:        unsafe.Pointer(&p[0]),
+	}
+	n, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.file.Fd(), usbfsControl, uintptr(unsafe.Pointer(&transfer)))
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
+
+func (t *USBTransport) Read(p []byte, timeout time.Duration) (int, error) {
+	return t.interruptTransfer(t.endpointIn, p, timeout)
+}
+
+func (t *USBTransport) Write(p []byte) (int, error) {
+	if t.endpointOut == 0 {
+		if len(p) == 0 {
+			return 0, errors.New("missing report ID")
+		}
+		return t.controlTransfer(0x21, hidSetReport, hidReportTypeOutput<<8|uint16(p[0]), p)
+	}
+	return t.interruptTransfer(t.endpointOut, p, DEFAULT_READ_TIMEOUT)
+}
+
+func (t *USBTransport) SendFeature(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, errors.New("missing report ID")
+	}
+	return t.controlTransfer(0x21, hidSetReport, hidReportTypeFeature<<8|uint16(p[0]), p)
+}
+
+func (t *USBTransport) GetFeature(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, errors.New("missing report ID")
+	}
+	return t.controlTransfer(0xA1, hidGetReport, hidReportTypeFeature<<8|uint16(p[0]), p)
+}
+
+// ReportDescriptor requests the report descriptor of the HID interface with a
+// standard GET_DESCRIPTOR request.
+func (t *USBTransport) ReportDescriptor() ([]byte, error) {
+	descriptor := make([]byte, MAX_REPORT_DESCRIPTOR_SIZE)
+	n, err := t.controlTransfer(0x81, usbGetDescriptor, hidDescriptorTypeReport<<8, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return descriptor[:n], nil
+}
+
+// Close releases the interface and hands it back to the kernel HID driver.
+func (t *USBTransport) Close() error {
+	t.ioctl(usbfsReleaseInterface, unsafe.Pointer(&t.iface))
+	t.driverIoctl(usbfsConnect)
+	return t.file.Close()
+}
+
+func readSysfsHex(devicePath, name string) uint64 {
+	data, err := os.ReadFile(filepath.Join(devicePath, name))
+	if err != nil {
+		return 0
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 16, 16)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func readSysfsInt(devicePath, name string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(devicePath, name))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
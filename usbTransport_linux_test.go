@@ -0,0 +1,30 @@
+package dualsense
+
+import "testing"
+
+func TestFindHIDInterface(t *testing.T) {
+	descriptors := []byte{
+		// Device and configuration descriptors.
+		18, 0x01, 0x00, 0x02, 0, 0, 0, 64, 0x4C, 0x05, 0xE6, 0x0C, 0x00, 0x01, 1, 2, 0, 1,
+		9, 0x02, 0xE3, 0x00, 4, 1, 0, 0xC0, 250,
+		// Audio control interface with an unrelated endpoint.
+		9, 0x04, 0, 0, 1, 0x01, 0x01, 0, 0,
+		7, 0x05, 0x81, 0x03, 0x10, 0x00, 4,
+		// HID interface with its interrupt endpoints.
+		9, 0x04, 3, 0, 2, 0x03, 0, 0, 0,
+		9, 0x21, 0x11, 0x01, 0, 1, 0x22, 0x11, 0x01,
+		7, 0x05, 0x84, 0x03, 0x40, 0x00, 6,
+		7, 0x05, 0x03, 0x03, 0x40, 0x00, 6,
+	}
+	iface, endpointIn, endpointOut, err := findHIDInterface(descriptors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iface != 3 || endpointIn != 0x84 || endpointOut != 0x03 {
+		t.Errorf("findHIDInterface() = %d, %#x, %#x, want 3, 0x84, 0x03", iface, endpointIn, endpointOut)
+	}
+	_, _, _, err = findHIDInterface(descriptors[:45])
+	if err == nil {
+		t.Error("findHIDInterface() without HID interface: expected error")
+	}
+}
@@ -0,0 +1,207 @@
+package dualsense
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// WAV_RUMBLE_CROSSOVER is the frequency, in Hz, that splits audio between the
+// low frequency left motor and the high frequency right motor.
+const WAV_RUMBLE_CROSSOVER = 150
+
+// WAV_MAX_DATA_SIZE caps the audio RumblePatternFromWAV reads, about six
+// minutes of 48 kHz 16-bit stereo.
+const WAV_MAX_DATA_SIZE = 64 << 20
+
+// wavFormatSize is the part of the fmt chunk read, up to the subformat of
+// WAVE_FORMAT_EXTENSIBLE.
+const wavFormatSize = 26
+
+// WAV format tags of the fmt chunk.
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavAudio is decoded WAV audio mixed down to one channel, in [-1, 1].
+type wavAudio struct {
+	sampleRate int
+	samples    []float64
+}
+
+// RumblePatternFromWAV analyzes the amplitude envelope of a WAV file in frames
+// of frameDuration and returns it as a RumblePattern, approximating the audio
+// driven haptics of the PS5 over the HID rumble emulation: content below
+// WAV_RUMBLE_CROSSOVER drives the left motor and content above it the right.
+// Both are scaled together so the loudest frame plays at full strength.
+// Integer PCM of 8 to 32 bits and 32-bit float audio are supported.
+func RumblePatternFromWAV(r io.Reader, frameDuration time.Duration) (RumblePattern, error) {
+	if frameDuration <= 0 {
+		return nil, fmt.Errorf("invalid rumble frame duration %v", frameDuration)
+	}
+	audio, err := readWAV(r)
+	if err != nil {
+		return nil, err
+	}
+	frameSamples := max(int(frameDuration.Seconds()*float64(audio.sampleRate)), 1)
+	dt := 1 / float64(audio.sampleRate)
+	rc := 1 / (2 * math.Pi * WAV_RUMBLE_CROSSOVER)
+	alpha := dt / (rc + dt)
+
+	var envelopes [][2]float64
+	// Two cascaded one-pole filters per band, low-pass for the left motor and
+	// high-pass for the right, keep each band mostly out of the other motor.
+	var low1, low2, highLow, peak float64
+	for start := 0; start < len(audio.samples); start += frameSamples {
+		end := min(start+frameSamples, len(audio.samples))
+		var lowSum, highSum float64
+		for _, sample := range audio.samples[start:end] {
+			low1 += alpha * (sample - low1)
+			low2 += alpha * (low1 - low2)
+			high1 := sample - low1
+			highLow += alpha * (high1 - highLow)
+			high := high1 - highLow
+			lowSum += low2 * low2
+			highSum += high * high
+		}
+		n := float64(end - start)
+		envelope := [2]float64{math.Sqrt(lowSum / n), math.Sqrt(highSum / n)}
+		peak = max(peak, envelope[0], envelope[1])
+		envelopes = append(envelopes, envelope)
+	}
+
+	pattern := make(RumblePattern, len(envelopes))
+	for i, envelope := range envelopes {
+		pattern[i].Duration = frameDuration
+		if peak > 0 {
+			pattern[i].Left = uint8(math.Round(255 * envelope[0] / peak))
+			pattern[i].Right = uint8(math.Round(255 * envelope[1] / peak))
+		}
+	}
+	if len(pattern) > 0 {
+		last := len(audio.samples) - (len(pattern)-1)*frameSamples
+		pattern[len(pattern)-1].Duration = time.Duration(float64(last) * dt * float64(time.Second))
+	}
+	return pattern, nil
+}
+
+// readWAV decodes the fmt and data chunks of a RIFF WAVE file.
+func readWAV(r io.Reader) (wavAudio, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return wavAudio{}, fmt.Errorf("error reading WAV header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return wavAudio{}, errors.New("not a RIFF WAVE file")
+	}
+	var format, channels, bitsPerSample uint16
+	var sampleRate uint32
+	haveFormat := false
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err != nil {
+			return wavAudio{}, fmt.Errorf("error reading WAV chunk: %w", err)
+		}
+		id := string(chunkHeader[0:4])
+		size := binary.LittleEndian.Uint32(chunkHeader[4:8])
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return wavAudio{}, fmt.Errorf("invalid WAV fmt chunk size %d", size)
+			}
+			var chunk [wavFormatSize]byte
+			if _, err := io.ReadFull(r, chunk[:min(size, wavFormatSize)]); err != nil {
+				return wavAudio{}, fmt.Errorf("error reading WAV fmt chunk: %w", err)
+			}
+			if _, err := io.CopyN(io.Discard, r, int64(size+size%2)-int64(min(size, wavFormatSize))); err != nil {
+				return wavAudio{}, fmt.Errorf("error reading WAV fmt chunk: %w", err)
+			}
+			format = binary.LittleEndian.Uint16(chunk[0:2])
+			channels = binary.LittleEndian.Uint16(chunk[2:4])
+			sampleRate = binary.LittleEndian.Uint32(chunk[4:8])
+			bitsPerSample = binary.LittleEndian.Uint16(chunk[14:16])
+			if format == wavFormatExtensible && size >= 26 {
+				format = binary.LittleEndian.Uint16(chunk[24:26])
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return wavAudio{}, errors.New("WAV data chunk before fmt chunk")
+			}
+			data, err := readWAVData(r, size)
+			if err != nil {
+				return wavAudio{}, err
+			}
+			return decodeWAVSamples(data, format, channels, bitsPerSample, sampleRate)
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(size+size%2)); err != nil {
+				return wavAudio{}, fmt.Errorf("error skipping WAV %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+// readWAVData reads a data chunk of size bytes, without trusting size for the
+// allocation. Streamed WAV files, which do not know their length up front,
+// give a size of 0 or 0xFFFFFFFF and are read until the end instead.
+func readWAVData(r io.Reader, size uint32) ([]byte, error) {
+	streamed := size == 0 || size == math.MaxUint32
+	if !streamed && size > WAV_MAX_DATA_SIZE {
+		return nil, fmt.Errorf("WAV data chunk of %d bytes exceeds %d", size, WAV_MAX_DATA_SIZE)
+	}
+	limit := int64(size)
+	if streamed {
+		limit = WAV_MAX_DATA_SIZE + 1
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit))
+	if err != nil {
+		return nil, fmt.Errorf("error reading WAV data chunk: %w", err)
+	}
+	if streamed && len(data) > WAV_MAX_DATA_SIZE {
+		return nil, fmt.Errorf("streamed WAV data exceeds %d bytes", WAV_MAX_DATA_SIZE)
+	}
+	if !streamed && len(data) < int(size) {
+		return nil, fmt.Errorf("error reading WAV data chunk: %w", io.ErrUnexpectedEOF)
+	}
+	return data, nil
+}
+
+func decodeWAVSamples(data []byte, format, channels, bitsPerSample uint16, sampleRate uint32) (wavAudio, error) {
+	if channels == 0 || sampleRate == 0 {
+		return wavAudio{}, fmt.Errorf("invalid WAV format: %d channels at %d Hz", channels, sampleRate)
+	}
+	var decode func([]byte) float64
+	switch {
+	case format == wavFormatPCM && bitsPerSample == 8:
+		decode = func(b []byte) float64 { return (float64(b[0]) - 128) / 128 }
+	case format == wavFormatPCM && bitsPerSample == 16:
+		decode = func(b []byte) float64 { return float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15) }
+	case format == wavFormatPCM && bitsPerSample == 24:
+		decode = func(b []byte) float64 {
+			return float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / (1 << 23)
+		}
+	case format == wavFormatPCM && bitsPerSample == 32:
+		decode = func(b []byte) float64 { return float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31) }
+	case format == wavFormatFloat && bitsPerSample == 32:
+		decode = func(b []byte) float64 { return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) }
+	default:
+		return wavAudio{}, fmt.Errorf("unsupported WAV format 0x%04x with %d bits per sample", format, bitsPerSample)
+	}
+	sampleSize := int(bitsPerSample / 8)
+	frameSize := sampleSize * int(channels)
+	audio := wavAudio{sampleRate: int(sampleRate), samples: make([]float64, len(data)/frameSize)}
+	for i := range audio.samples {
+		frame := data[i*frameSize:]
+		var sum float64
+		for channel := range int(channels) {
+			sum += decode(frame[channel*sampleSize:])
+		}
+		audio.samples[i] = sum / float64(channels)
+	}
+	return audio, nil
+}
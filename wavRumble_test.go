@@ -0,0 +1,146 @@
+package dualsense
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// encodeWAV writes 16-bit stereo PCM at sampleRate with both channels
+// carrying samples.
+func encodeWAV(sampleRate int, samples []float64) []byte {
+	var data bytes.Buffer
+	for _, sample := range samples {
+		value := int16(sample * math.MaxInt16)
+		binary.Write(&data, binary.LittleEndian, [2]int16{value, value})
+	}
+	var wav bytes.Buffer
+	wav.WriteString("RIFF")
+	binary.Write(&wav, binary.LittleEndian, uint32(4+8+16+8+3+1+8+data.Len()))
+	wav.WriteString("WAVEfmt ")
+	binary.Write(&wav, binary.LittleEndian, struct {
+		Size                   uint32
+		Format, Channels       uint16
+		SampleRate, ByteRate   uint32
+		BlockAlign, SampleBits uint16
+	}{16, wavFormatPCM, 2, uint32(sampleRate), uint32(sampleRate * 4), 4, 16})
+	// An odd sized chunk to skip, with its padding byte.
+	wav.WriteString("LIST")
+	binary.Write(&wav, binary.LittleEndian, uint32(3))
+	wav.Write([]byte{1, 2, 3, 0})
+	wav.WriteString("data")
+	binary.Write(&wav, binary.LittleEndian, uint32(data.Len()))
+	wav.Write(data.Bytes())
+	return wav.Bytes()
+}
+
+func TestRumblePatternFromWAV(t *testing.T) {
+	const sampleRate = 8000
+	var samples []float64
+	// Half a second of 40 Hz, a quarter of silence and a quarter of 2 kHz.
+	for i := range sampleRate {
+		seconds := float64(i) / sampleRate
+		switch {
+		case seconds < 0.5:
+			samples = append(samples, 0.8*math.Sin(2*math.Pi*40*seconds))
+		case seconds < 0.75:
+			samples = append(samples, 0)
+		default:
+			samples = append(samples, 0.8*math.Sin(2*math.Pi*2000*seconds))
+		}
+	}
+	pattern, err := RumblePatternFromWAV(bytes.NewReader(encodeWAV(sampleRate, samples)), 125*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pattern) != 8 || pattern.Duration() != time.Second {
+		t.Fatalf("got %d frames over %v, want 8 over 1s", len(pattern), pattern.Duration())
+	}
+	for i, frame := range pattern {
+		switch {
+		case i >= 1 && i < 4:
+			if frame.Left < 100 || frame.Right > frame.Left/4 {
+				t.Errorf("frame %d of 40 Hz: got %+v", i, frame)
+			}
+		case i == 5:
+			if frame.Left > 5 || frame.Right > 5 {
+				t.Errorf("frame %d of silence: got %+v", i, frame)
+			}
+		case i >= 7:
+			if frame.Right < 100 || frame.Left > frame.Right/4 {
+				t.Errorf("frame %d of 2 kHz: got %+v", i, frame)
+			}
+		}
+	}
+}
+
+func TestRumblePatternFromWAVErrors(t *testing.T) {
+	valid := encodeWAV(8000, make([]float64, 100))
+	unsupported := bytes.Clone(valid)
+	binary.LittleEndian.PutUint16(unsupported[20:], 2)
+	// The data chunk size follows the 4 byte "data" ID, 400 bytes of
+	// samples before the end.
+	dataSizeOffset := len(valid) - 400 - 4
+	oversized := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(oversized[dataSizeOffset:], WAV_MAX_DATA_SIZE+1)
+	formatSize := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(formatSize[16:], math.MaxUint32)
+	tests := map[string][]byte{
+		"not RIFF":       []byte("RIFX0000WAVE"),
+		"truncated":      valid[:len(valid)-300],
+		"unsupported":    unsupported,
+		"oversized data": oversized,
+		"oversized fmt":  formatSize,
+	}
+	for name, wav := range tests {
+		if _, err := RumblePatternFromWAV(bytes.NewReader(wav), 10*time.Millisecond); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+	if _, err := RumblePatternFromWAV(strings.NewReader(""), 0); err == nil {
+		t.Error("zero frame duration: got no error")
+	}
+}
+
+func TestRumblePatternFromStreamedWAV(t *testing.T) {
+	streamed := encodeWAV(8000, make([]float64, 100))
+	binary.LittleEndian.PutUint32(streamed[len(streamed)-400-4:], math.MaxUint32)
+	pattern, err := RumblePatternFromWAV(bytes.NewReader(streamed), 5*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 100 * time.Second / 8000; pattern.Duration() != want {
+		t.Fatalf("got %v of audio, want %v", pattern.Duration(), want)
+	}
+}
+
+func TestPlayRumblePattern(t *testing.T) {
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	before := d.GetOutStateData()
+
+	pattern := RumblePattern{{Left: 200, Duration: time.Millisecond}, {Right: 100, Duration: time.Millisecond}}
+	if err := d.PlayRumblePattern(pattern); err != nil {
+		t.Fatal(err)
+	}
+	var got [][2]uint8
+	for _, outState := range d.OutStates() {
+		got = append(got, [2]uint8{outState.RumbleEmulationLeft, outState.RumbleEmulationRight})
+	}
+	want := [][2]uint8{{200, 0}, {0, 100}, {before.RumbleEmulationLeft, before.RumbleEmulationRight}}
+	if len(got) < len(want) {
+		t.Fatalf("got %v, want to end with %v", got, want)
+	}
+	got = got[len(got)-len(want):]
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want to end with %v", got, want)
+		}
+	}
+}
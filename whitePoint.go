@@ -0,0 +1,164 @@
+package dualsense
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// WhitePoint scales the lightbar channels of one controller, from 0 to 1, so
+// that units whose LEDs render the same colors with different tints match. A
+// unit with a blue cast might use {1, 1, 0.85}.
+type WhitePoint struct {
+	Red   float64 `json:"red"`
+	Green float64 `json:"green"`
+	Blue  float64 `json:"blue"`
+}
+
+// neutralWhitePoint leaves colors as set.
+var neutralWhitePoint = WhitePoint{Red: 1, Green: 1, Blue: 1}
+
+// Validate checks every scale is within [0, 1].
+func (w WhitePoint) Validate() error {
+	for _, scale := range []float64{w.Red, w.Green, w.Blue} {
+		if math.IsNaN(scale) || scale < 0 || scale > 1 {
+			return fmt.Errorf("%w: white point %+v outside [0, 1]", ErrOutOfRange, w)
+		}
+	}
+	return nil
+}
+
+// WhitePoints maps controller serial numbers, see SerialNumber, to their
+// white point calibration.
+type WhitePoints map[string]WhitePoint
+
+// DefaultWhitePointsPath returns where dualsensectl keeps white point
+// calibrations, whitepoints.json next to the controller labels.
+func DefaultWhitePointsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("os.UserConfigDir: error trying to locate white points: %w", err)
+	}
+	return filepath.Join(dir, "dualsense-go", "whitepoints.json"), nil
+}
+
+// LoadWhitePoints reads white points saved by SaveWhitePoints. A missing file
+// holds no calibrations.
+func LoadWhitePoints(path string) (WhitePoints, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return WhitePoints{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: error trying to load white points: %w", err)
+	}
+	whitePoints := WhitePoints{}
+	err = json.Unmarshal(data, &whitePoints)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: error trying to decode white points %s: %w", path, err)
+	}
+	for serial, whitePoint := range whitePoints {
+		err = whitePoint.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("error in white point of %s in %s: %w", serial, path, err)
+		}
+	}
+	return whitePoints, nil
+}
+
+// SaveWhitePoints writes white points to path, creating its directory if
+// needed.
+func SaveWhitePoints(path string, whitePoints WhitePoints) error {
+	data, err := json.MarshalIndent(whitePoints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: error trying to encode white points: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: error trying to save white points: %w", err)
+	}
+	err = os.WriteFile(path, append(data, '\n'), 0o644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: error trying to save white points: %w", err)
+	}
+	return nil
+}
+
+// SetWhitePoint calibrates the lightbar. Like SetLightbarBrightness, colors
+// are scaled as each output report is written, so GetOutStateData keeps
+// returning the colors as set.
+func (d *DualSense) SetWhitePoint(whitePoint WhitePoint) error {
+	err := whitePoint.Validate()
+	if err != nil {
+		return err
+	}
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	d.whitePoint = whitePoint
+	// Written even if unchanged, as the calibrated colors are not part of the
+	// state.
+	err = d.applySetStateData(d.setStateData)
+	if err != nil {
+		return fmt.Errorf("error updating white point: %w", err)
+	}
+	return nil
+}
+
+// WhitePoint returns the calibration set with SetWhitePoint, scaling every
+// channel by 1 unless changed.
+func (d *DualSense) WhitePoint() WhitePoint {
+	d.setStateDataMu.Lock()
+	defer d.setStateDataMu.Unlock()
+	return d.whitePoint
+}
+
+// ApplyWhitePoints calibrates the controller with the entry of whitePoints
+// for its serial number, if any.
+func (d *DualSense) ApplyWhitePoints(whitePoints WhitePoints) error {
+	serial, err := d.SerialNumber()
+	if err != nil {
+		return err
+	}
+	whitePoint, ok := whitePoints[serial]
+	if !ok {
+		return nil
+	}
+	return d.SetWhitePoint(whitePoint)
+}
+
+// applyWhitePoints looks up the white point set WithWhitePoints once the
+// transport is open or reopened, to be written with the next output report.
+// Failing to read the serial number leaves the white point unchanged.
+func (d *DualSense) applyWhitePoints() {
+	if d.whitePoints == nil {
+		return
+	}
+	serial, err := d.SerialNumber()
+	if err != nil {
+		d.logger.Debug("could not look up DualSense white point", "error", err)
+		return
+	}
+	whitePoint, ok := d.whitePoints[serial]
+	if !ok || whitePoint.Validate() != nil {
+		// A reconnected pad must not keep the white point of another one.
+		whitePoint = neutralWhitePoint
+	}
+	d.setStateDataMu.Lock()
+	d.whitePoint = whitePoint
+	d.setStateDataMu.Unlock()
+}
+
+// calibrateLightbar applies the white point to an outgoing output report. It
+// must be called with setStateDataMu held.
+func (d *DualSense) calibrateLightbar(setStateData *SetStateData) {
+	if d.whitePoint == neutralWhitePoint {
+		return
+	}
+	setStateData.LedRed = uint8(math.Round(float64(setStateData.LedRed) * d.whitePoint.Red))
+	setStateData.LedGreen = uint8(math.Round(float64(setStateData.LedGreen) * d.whitePoint.Green))
+	setStateData.LedBlue = uint8(math.Round(float64(setStateData.LedBlue) * d.whitePoint.Blue))
+}
@@ -0,0 +1,57 @@
+package dualsense
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWhitePoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "whitepoints.json")
+	whitePoints, err := LoadWhitePoints(path)
+	if err != nil || len(whitePoints) != 0 {
+		t.Fatalf("got %v, %v for a missing file, want no white points", whitePoints, err)
+	}
+	whitePoints["a0:b1:c2:d3:e4:f5"] = WhitePoint{Red: 1, Green: 0.9, Blue: 0.5}
+	if err := SaveWhitePoints(path, whitePoints); err != nil {
+		t.Fatal(err)
+	}
+	whitePoints, err = LoadWhitePoints(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewMockDualSense()
+	if err := d.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	pairingInfo := make([]byte, PAIRING_INFO_REPORT_SIZE)
+	pairingInfo[0] = PAIRING_INFO_REPORT_ID
+	copy(pairingInfo[1:], []byte{0xF5, 0xE4, 0xD3, 0xC2, 0xB1, 0xA0})
+	d.SetFeatureReport(pairingInfo)
+	if err := d.ApplyWhitePoints(whitePoints); err != nil {
+		t.Fatal(err)
+	}
+	set := LedColor{Red: 200, Green: 200, Blue: 200}
+	if err := d.SetLedColor(set); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lastLedColor(d), (LedColor{Red: 200, Green: 180, Blue: 100}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := d.LedColor(); got != set {
+		t.Errorf("LedColor() = %+v, want the color as set %+v", got, set)
+	}
+
+	if err := d.SetWhitePoint(WhitePoint{Red: 1.5, Green: 1, Blue: 1}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("out of range white point: got %v, want ErrOutOfRange", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"x": {"red": -1, "green": 1, "blue": 1}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWhitePoints(path); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("loading an out of range white point: got %v, want ErrOutOfRange", err)
+	}
+}